@@ -0,0 +1,104 @@
+// Bazel Remote Execution API gRPC
+// Shared constants and helpers for translating between Bazel and Scoot identifiers
+package bazel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+)
+
+const (
+	// Prefix used to distinguish Scoot snapshots, tasks and blobs that originate from the Bazel API
+	SnapshotIDPrefix = "bz"
+
+	// JobType used for jobs that are submitted through the Execution API
+	ExecutionJobType = "BazelExecution"
+
+	// Prefix and extension of the bundlestore entries holding CAS blobs
+	StorePrefix = SnapshotIDPrefix + "-"
+	StoreSuffix = ".bin"
+
+	// Extension of the bundlestore entries holding ActionCache results, which share the blob prefix
+	ActionResultStoreSuffix = ".actionresult"
+
+	// Extension of the bundlestore entries holding the ID of the Scoot snapshot an input root was ingested as
+	InputRootStoreSuffix = ".snapshot"
+
+	// Extension of the bundlestore entries holding the ActionResult of the run of an Execute job, which is
+	// stored once the job finishes so polling its Operation doesn't read back its outputs every time
+	JobResultStoreSuffix = ".jobresult"
+
+	// SHA-256 of zero-length data. Bazel uses this digest for empty blobs and empty input roots.
+	EmptySha  = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	EmptySize = int64(0)
+
+	// Length of a hex encoded SHA-256 hash
+	shaLength = 64
+)
+
+// Returns true if the hash and size describe a well-formed SHA-256 digest
+func IsValidDigest(hash string, size int64) bool {
+	if len(hash) != shaLength || size < 0 {
+		return false
+	}
+	for _, c := range hash {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the digest refers to zero-length data
+func IsEmptyDigest(digest *remoteexecution.Digest) bool {
+	return digest == nil || (digest.GetHash() == EmptySha && digest.GetSizeBytes() == EmptySize)
+}
+
+// Formats a digest as "<hash>-<size>", which is used as a component of Scoot identifiers
+func DigestToStr(digest *remoteexecution.Digest) string {
+	return fmt.Sprintf("%s-%d", digest.GetHash(), digest.GetSizeBytes())
+}
+
+// Parses a digest from the "<hash>-<size>" format generated by DigestToStr
+func DigestFromStr(s string) (*remoteexecution.Digest, error) {
+	idx := strings.LastIndex(s, "-")
+	if idx < 0 {
+		return nil, fmt.Errorf("Invalid digest string %q, expected <hash>-<size>", s)
+	}
+	hash := s[:idx]
+	size, err := strconv.ParseInt(s[idx+1:], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid size in digest string %q: %v", s, err)
+	}
+	if !IsValidDigest(hash, size) {
+		return nil, fmt.Errorf("Invalid digest in string %q", s)
+	}
+	return &remoteexecution.Digest{Hash: hash, SizeBytes: size}, nil
+}
+
+// Returns the name of the bundlestore entry that holds the blob for the given digest
+func DigestStoreName(digest *remoteexecution.Digest) string {
+	return StorePrefix + DigestToStr(digest) + StoreSuffix
+}
+
+// Returns the name of the bundlestore entry that holds the ActionResult for the given Action digest
+func ActionResultStoreName(actionDigest *remoteexecution.Digest) string {
+	return StorePrefix + DigestToStr(actionDigest) + ActionResultStoreSuffix
+}
+
+// Returns the name of the bundlestore entry that holds the Scoot SnapshotID of the given input root digest
+func InputRootStoreName(inputRootDigest *remoteexecution.Digest) string {
+	return StorePrefix + DigestToStr(inputRootDigest) + InputRootStoreSuffix
+}
+
+// Returns the name of the bundlestore entry that holds the ActionResult of the run of the given job.
+// Job IDs aren't digests, so the entry is named by the digest of the job ID.
+func JobResultStoreName(jobID string) string {
+	sum := sha256.Sum256([]byte(jobID))
+	return StorePrefix + DigestToStr(&remoteexecution.Digest{Hash: hex.EncodeToString(sum[:]), SizeBytes: int64(len(jobID))}) + JobResultStoreSuffix
+}
@@ -0,0 +1,104 @@
+package cas
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+)
+
+// ActionCache APIs
+// ActionResults are serialized protobufs stored in bundlestore alongside CAS blobs, named by their Action digest.
+
+// Returns the cached ActionResult for an Action digest, or NotFound if there is none
+func (s *casServer) GetActionResult(
+	ctx context.Context,
+	req *remoteexecution.GetActionResultRequest) (*remoteexecution.ActionResult, error) {
+	s.stat.Counter(stats.BazelActionCacheGetCounter).Inc(1)
+	log.Debugf("Received GetActionResult request: %s", req)
+
+	d := req.GetActionDigest()
+	if d == nil || !bazel.IsValidDigest(d.GetHash(), d.GetSizeBytes()) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid ActionDigest %s", d))
+	}
+	result, err := ReadActionResult(s.store, d)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if result == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ActionResult for %s not found", bazel.DigestToStr(d)))
+	}
+	s.stat.Counter(stats.BazelActionCacheHitCounter).Inc(1)
+	return result, nil
+}
+
+// Stores the ActionResult for an Action digest, replacing any previous result, and returns it
+func (s *casServer) UpdateActionResult(
+	ctx context.Context,
+	req *remoteexecution.UpdateActionResultRequest) (*remoteexecution.ActionResult, error) {
+	s.stat.Counter(stats.BazelActionCacheUpdateCounter).Inc(1)
+	log.Debugf("Received UpdateActionResult request: %s", req)
+
+	d := req.GetActionDigest()
+	if d == nil || !bazel.IsValidDigest(d.GetHash(), d.GetSizeBytes()) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid ActionDigest %s", d))
+	}
+	if req.GetActionResult() == nil {
+		return nil, status.Error(codes.InvalidArgument, "UpdateActionResultRequest must include an ActionResult")
+	}
+	if err := WriteActionResult(s.store, d, req.GetActionResult()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return req.GetActionResult(), nil
+}
+
+// Reads the ActionResult for an Action digest from the store, returning nil if it doesn't exist
+func ReadActionResult(store bundlestore.Store, actionDigest *remoteexecution.Digest) (*remoteexecution.ActionResult, error) {
+	name := bazel.ActionResultStoreName(actionDigest)
+	exists, err := store.Exists(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to check for ActionResult %s: %v", name, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	r, err := store.OpenForRead(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open ActionResult %s: %v", name, err)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read ActionResult %s: %v", name, err)
+	}
+
+	result := &remoteexecution.ActionResult{}
+	if err := proto.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal ActionResult %s: %v", name, err)
+	}
+	return result, nil
+}
+
+// Writes the ActionResult for an Action digest to the store
+func WriteActionResult(store bundlestore.Store, actionDigest *remoteexecution.Digest, result *remoteexecution.ActionResult) error {
+	name := bazel.ActionResultStoreName(actionDigest)
+	data, err := proto.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal ActionResult %s: %v", name, err)
+	}
+	if err := store.Write(name, bytes.NewReader(data), nil); err != nil {
+		return fmt.Errorf("Failed to write ActionResult %s: %v", name, err)
+	}
+	return nil
+}
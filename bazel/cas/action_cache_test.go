@@ -0,0 +1,39 @@
+package cas
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+	"google.golang.org/grpc/codes"
+)
+
+func TestActionResult(t *testing.T) {
+	s := makeTestServer(t)
+	ctx := context.Background()
+	actionDigest := makeDigest([]byte("action"))
+
+	_, err := s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{ActionDigest: actionDigest})
+	expectCode(t, err, codes.NotFound)
+
+	result := &remoteexecution.ActionResult{ExitCode: 3, StdoutRaw: []byte("out")}
+	if _, err := s.UpdateActionResult(ctx,
+		&remoteexecution.UpdateActionResultRequest{ActionDigest: actionDigest, ActionResult: result}); err != nil {
+		t.Fatalf("Non-nil error from UpdateActionResult: %v", err)
+	}
+
+	got, err := s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{ActionDigest: actionDigest})
+	if err != nil {
+		t.Fatalf("Non-nil error from GetActionResult: %v", err)
+	}
+	if got.GetExitCode() != 3 || string(got.GetStdoutRaw()) != "out" {
+		t.Errorf("Expected cached ActionResult %v, got: %v", result, got)
+	}
+
+	_, err = s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{
+		ActionDigest: &remoteexecution.Digest{Hash: "bad", SizeBytes: 1}})
+	expectCode(t, err, codes.InvalidArgument)
+
+	_, err = s.UpdateActionResult(ctx, &remoteexecution.UpdateActionResultRequest{ActionDigest: actionDigest})
+	expectCode(t, err, codes.InvalidArgument)
+}
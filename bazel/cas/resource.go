@@ -0,0 +1,72 @@
+package cas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+
+	"github.com/twitter/scoot/bazel"
+)
+
+// Resource name path components used by the ByteStream API
+const (
+	ResourceTypeBlobs   = "blobs"
+	ResourceTypeUploads = "uploads"
+)
+
+// A parsed ByteStream resource name, identifying a CAS blob
+type Resource struct {
+	Instance string
+	UUID     string
+	Digest   *remoteexecution.Digest
+}
+
+// Parses a ByteStream resource name for reading, in the format "[{instance}/]blobs/{hash}/{size}[/...]"
+func ParseReadResource(name string) (*Resource, error) {
+	return parseResource(name, false)
+}
+
+// Parses a ByteStream resource name for writing, in the format "[{instance}/]uploads/{uuid}/blobs/{hash}/{size}[/...]"
+func ParseWriteResource(name string) (*Resource, error) {
+	return parseResource(name, true)
+}
+
+func parseResource(name string, write bool) (*Resource, error) {
+	elems := strings.Split(name, "/")
+
+	// Find the first "blobs" element that is preceded by the expected components.
+	// Instance names may themselves contain slashes, so everything before is the instance.
+	for i := 0; i+2 < len(elems); i++ {
+		if elems[i] != ResourceTypeBlobs {
+			continue
+		}
+		res := &Resource{}
+		instanceEnd := i
+		if write {
+			if i < 2 || elems[i-2] != ResourceTypeUploads {
+				continue
+			}
+			res.UUID = elems[i-1]
+			instanceEnd = i - 2
+		}
+		res.Instance = strings.Join(elems[:instanceEnd], "/")
+
+		hash := elems[i+1]
+		size, err := strconv.ParseInt(elems[i+2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid size in resource name %q: %v", name, err)
+		}
+		if !bazel.IsValidDigest(hash, size) {
+			return nil, fmt.Errorf("Invalid digest in resource name %q", name)
+		}
+		res.Digest = &remoteexecution.Digest{Hash: hash, SizeBytes: size}
+		return res, nil
+	}
+
+	if write {
+		return nil, fmt.Errorf("Invalid resource name %q, expected [{instance}/]uploads/{uuid}/blobs/{hash}/{size}", name)
+	}
+	return nil, fmt.Errorf("Invalid resource name %q, expected [{instance}/]blobs/{hash}/{size}", name)
+}
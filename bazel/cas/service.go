@@ -1,10 +1,21 @@
 // Bazel Remote Execution API gRPC server
-// Contains limited implementation of the ContentAddressableStore API interface
+// Contains implementation of the ContentAddressableStore API interface backed by bundlestore
 package cas
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
 	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	googlebytestream "google.golang.org/genproto/googleapis/bytestream"
@@ -12,19 +23,75 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+)
+
+const (
+	// Max number of bytes sent in a single ByteStream ReadResponse
+	DefaultReadChunkSize = 1024 * 1024
+
+	// Number of Directories returned per GetTree page if the client does not specify a page size
+	DefaultTreePageSize = 1000
+
+	// Partial ByteStream writes of blobs larger than this are spilled to a temp file instead of held in memory
+	DefaultMaxBufferedWriteSize = 4 * 1024 * 1024
+
+	// Partial ByteStream writes that receive no data for this long are discarded, and must be restarted by the client
+	DefaultPendingWriteExpiry = 10 * time.Minute
 )
 
-// Implements GRPCServer and remoteexecution.ContentAddressableStoreServer interfaces
+// Implements GRPCServer, remoteexecution.ContentAddressableStoreServer,
+// remoteexecution.ActionCacheServer and googlebytestream.ByteStreamServer interfaces
 type casServer struct {
 	listener net.Listener
 	server   *grpc.Server
+	store    bundlestore.Store
+	stat     stats.StatsReceiver
+
+	// Partially committed ByteStream writes, keyed by resource name, which can be resumed by clients.
+	// Large writes are spilled to files in tmp, and writes that are abandoned are discarded after writeExpiry.
+	writes        map[string]*pendingWrite
+	writesMu      sync.Mutex
+	tmp           *temp.TempDir
+	maxBufferSize int64
+	writeExpiry   time.Duration
 }
 
-// Creates a new GRPCServer (CASServer, ByteStreamServer) based on a listener, and preregisters the service
-func NewCASServer(l net.Listener) *casServer {
-	g := casServer{listener: l, server: grpc.NewServer()}
-	remoteexecution.RegisterContentAddressableStorageServer(g.server, &casServer{})
-	googlebytestream.RegisterByteStreamServer(g.server, &casServer{})
+// Data received so far for a ByteStream write that has not been finished.
+// Streams resuming the same write may race, so all fields are guarded by mu.
+type pendingWrite struct {
+	mu        sync.Mutex
+	digest    *remoteexecution.Digest
+	hash      hash.Hash
+	size      int64
+	buf       *bytes.Buffer
+	file      *os.File
+	lastWrite time.Time
+	// Set once the write is finished or discarded, after which no more data can be added
+	done bool
+}
+
+// Creates a new GRPCServer (CASServer, ActionCacheServer, ByteStreamServer) based on a listener, and preregisters the services.
+// Blobs and ActionResults are stored in and served from the given bundlestore.Store, named by their digest.
+// Large partial writes are kept in files under tmp until they are finished.
+func NewCASServer(l net.Listener, store bundlestore.Store, tmp *temp.TempDir, stat stats.StatsReceiver) *casServer {
+	g := casServer{
+		listener:      l,
+		server:        grpc.NewServer(),
+		store:         store,
+		stat:          stat,
+		writes:        make(map[string]*pendingWrite),
+		tmp:           tmp,
+		maxBufferSize: DefaultMaxBufferedWriteSize,
+		writeExpiry:   DefaultPendingWriteExpiry,
+	}
+	remoteexecution.RegisterContentAddressableStorageServer(g.server, &g)
+	remoteexecution.RegisterActionCacheServer(g.server, &g)
+	googlebytestream.RegisterByteStreamServer(g.server, &g)
 	return &g
 }
 
@@ -39,53 +106,461 @@ func (s *casServer) Serve() error {
 func (s *casServer) FindMissingBlobs(
 	ctx context.Context,
 	req *remoteexecution.FindMissingBlobsRequest) (*remoteexecution.FindMissingBlobsResponse, error) {
-	log.Infof("Received CAS FindMissingBlobs request: %s", req)
+	s.stat.Counter(stats.BazelCasFindMissingBlobsCounter).Inc(1)
+	log.Debugf("Received CAS FindMissingBlobs request: %s", req)
+
 	res := remoteexecution.FindMissingBlobsResponse{}
-	// Return all requested blobs as missing
-	res.MissingBlobDigests = make([]*remoteexecution.Digest, len(req.BlobDigests), len(req.BlobDigests))
-	copy(res.MissingBlobDigests, req.BlobDigests)
+	for _, d := range req.BlobDigests {
+		if !bazel.IsValidDigest(d.GetHash(), d.GetSizeBytes()) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid digest %s", d))
+		}
+		// The empty blob is always available
+		if bazel.IsEmptyDigest(d) {
+			continue
+		}
+		exists, err := s.store.Exists(bazel.DigestStoreName(d))
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to check for blob %s: %v", d, err))
+		}
+		if !exists {
+			res.MissingBlobDigests = append(res.MissingBlobDigests, d)
+		}
+	}
 	return &res, nil
 }
 
-// BatchUpdate not supported in Scoot for V1
+// BatchUpdateBlobs writes each blob in the request to the store after validating its digest.
+// Failures are reported per blob in the response rather than failing the whole request.
 func (s *casServer) BatchUpdateBlobs(
 	ctx context.Context,
 	req *remoteexecution.BatchUpdateBlobsRequest) (*remoteexecution.BatchUpdateBlobsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Currently unsupported in Scoot - update blobs independently")
+	s.stat.Counter(stats.BazelCasBatchUpdateBlobsCounter).Inc(1)
+	log.Debugf("Received CAS BatchUpdateBlobs request for %d blobs", len(req.Requests))
+
+	res := remoteexecution.BatchUpdateBlobsResponse{}
+	for _, r := range req.Requests {
+		st := status.New(codes.OK, "")
+		if err := s.writeBlob(r.ContentDigest, r.Data); err != nil {
+			st, _ = status.FromError(err)
+		}
+		res.Responses = append(res.Responses, &remoteexecution.BatchUpdateBlobsResponse_Response{
+			BlobDigest: r.ContentDigest,
+			Status:     st.Proto(),
+		})
+	}
+	return &res, nil
 }
 
-// DEPRECATED - Included for protobuf generated code compatability/compilation
+// GetTree returns all Directories reachable from the root Directory, in breadth-first order.
+// Results are paginated, with the page token being the offset of the next Directory to return.
 func (s *casServer) GetTree(
 	ctx context.Context,
 	req *remoteexecution.GetTreeRequest) (*remoteexecution.GetTreeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "This API is marked as deprecated in the Bazel API definition")
+	s.stat.Counter(stats.BazelCasGetTreeCounter).Inc(1)
+	log.Debugf("Received CAS GetTree request: %s", req)
+
+	root := req.GetRootDigest()
+	if root == nil || !bazel.IsValidDigest(root.GetHash(), root.GetSizeBytes()) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid root digest %s", root))
+	}
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(req.PageToken); err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid page token %q", req.PageToken))
+		}
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = DefaultTreePageSize
+	}
+
+	dirs, err := s.getTree(root)
+	if err != nil {
+		return nil, err
+	}
+	if offset > len(dirs) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Page token %q is out of range", req.PageToken))
+	}
+
+	res := remoteexecution.GetTreeResponse{}
+	end := offset + pageSize
+	if end < len(dirs) {
+		res.NextPageToken = strconv.Itoa(end)
+	} else {
+		end = len(dirs)
+	}
+	res.Directories = dirs[offset:end]
+	return &res, nil
+}
+
+// Reads the Directory tree rooted at the given digest from the store, in breadth-first order
+func (s *casServer) getTree(root *remoteexecution.Digest) ([]*remoteexecution.Directory, error) {
+	dirs := []*remoteexecution.Directory{}
+	queue := []*remoteexecution.Digest{root}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+
+		data, err := s.readBlob(d)
+		if err != nil {
+			return nil, err
+		}
+		dir := &remoteexecution.Directory{}
+		if err := proto.Unmarshal(data, dir); err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Failed to unmarshal Directory %s: %v", d, err))
+		}
+		dirs = append(dirs, dir)
+		for _, child := range dir.Directories {
+			queue = append(queue, child.Digest)
+		}
+	}
+	return dirs, nil
 }
 
 // CAS - ByteStream APIs
 
-// Serves content in the bundlestore to a client via grpc streaming.
+// Serves content in the bundlestore to a client via grpc streaming, in chunks of at most DefaultReadChunkSize.
 // Implements googleapis bytestream Read
 func (s *casServer) Read(req *googlebytestream.ReadRequest, ser googlebytestream.ByteStream_ReadServer) error {
-	log.Infof("Received CAS Read request: %s", req)
-	// Real implementation: fetch resource from backend and call Send(Data []byte) until finished
+	defer s.stat.Latency(stats.BazelCasReadLatency_ms).Time().Stop()
+	s.stat.Counter(stats.BazelCasReadCounter).Inc(1)
+	log.Debugf("Received CAS Read request: %s", req)
+
+	res, err := ParseReadResource(req.ResourceName)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	size := res.Digest.GetSizeBytes()
+	if req.ReadOffset < 0 || req.ReadOffset > size {
+		return status.Error(codes.OutOfRange, fmt.Sprintf("Invalid read offset %d for blob of size %d", req.ReadOffset, size))
+	}
+	if req.ReadLimit < 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid read limit %d", req.ReadLimit))
+	}
+
+	// The empty blob is never stored, and there is no data to send
+	if bazel.IsEmptyDigest(res.Digest) {
+		return nil
+	}
+
+	r, err := s.openBlob(res.Digest)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if _, err := io.CopyN(ioutil.Discard, r, req.ReadOffset); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("Failed to seek to offset %d: %v", req.ReadOffset, err))
+	}
+	remaining := size - req.ReadOffset
+	if req.ReadLimit > 0 && req.ReadLimit < remaining {
+		remaining = req.ReadLimit
+	}
+
+	buf := make([]byte, DefaultReadChunkSize)
+	for remaining > 0 {
+		chunk := buf
+		if remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := io.ReadFull(r, chunk)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("Failed to read blob %s: %v", res.Digest, err))
+		}
+		if err := ser.Send(&googlebytestream.ReadResponse{Data: chunk[:n]}); err != nil {
+			return err
+		}
+		remaining -= int64(n)
+	}
 	return nil
 }
 
-// Writes data into bundlestore from a client via grpc streaming. Clients can use this API
-// to determine the progress of data being committed and to resume writes after interruptions.
+// Writes data into bundlestore from a client via grpc streaming. Data is kept until the client
+// finishes the write, at which point the digest is validated and the blob is committed to the store.
+// If the stream is interrupted, clients can use QueryWriteStatus to find the committed size and
+// resume the write from that offset, as long as they do so before the pending write expires.
 // Implements googleapis bytestream Write
-func (s *casServer) Write(googlebytestream.ByteStream_WriteServer) error {
-	log.Info("Received CAS Write request")
-	// Real implementation: stream from client with Recv(), finish with SendAndClose
-	return nil
+func (s *casServer) Write(ser googlebytestream.ByteStream_WriteServer) error {
+	defer s.stat.Latency(stats.BazelCasWriteLatency_ms).Time().Stop()
+	s.stat.Counter(stats.BazelCasWriteCounter).Inc(1)
+
+	var name string
+	var pw *pendingWrite
+	for {
+		req, err := ser.Recv()
+		if err == io.EOF {
+			return status.Error(codes.InvalidArgument, "Write stream closed before write was finished")
+		}
+		if err != nil {
+			return err
+		}
+
+		if pw == nil {
+			name = req.ResourceName
+			log.Debugf("Received CAS Write request for %s", name)
+			res, err := ParseWriteResource(name)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+
+			// If the blob is already present there is no need to receive any more data
+			exists := bazel.IsEmptyDigest(res.Digest)
+			if !exists {
+				exists, err = s.store.Exists(bazel.DigestStoreName(res.Digest))
+				if err != nil {
+					return status.Error(codes.Internal, fmt.Sprintf("Failed to check for blob %s: %v", res.Digest, err))
+				}
+			}
+			if exists {
+				s.removePendingWrite(name, nil)
+				return ser.SendAndClose(&googlebytestream.WriteResponse{CommittedSize: res.Digest.GetSizeBytes()})
+			}
+			if pw, err = s.getPendingWrite(name, res.Digest); err != nil {
+				return err
+			}
+		} else if req.ResourceName != "" && req.ResourceName != name {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Resource name changed from %q to %q", name, req.ResourceName))
+		}
+
+		if discarded, err := pw.write(req.WriteOffset, req.Data); err != nil {
+			if discarded {
+				s.removePendingWrite(name, pw)
+			}
+			return err
+		}
+
+		if req.FinishWrite {
+			s.removePendingWrite(name, pw)
+			return s.finishWrite(pw, ser)
+		}
+	}
+}
+
+// Validates and stores a pending write that has been removed from s.writes, and responds to the client
+func (s *casServer) finishWrite(pw *pendingWrite, ser googlebytestream.ByteStream_WriteServer) error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	defer pw.discardLocked()
+	if pw.done {
+		return status.Error(codes.Aborted, fmt.Sprintf("Write of %s was finished or expired", pw.digest))
+	}
+	pw.done = true
+
+	if pw.size != pw.digest.GetSizeBytes() {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("Data length %d does not match digest size %d", pw.size, pw.digest.GetSizeBytes()))
+	}
+	if sha := fmt.Sprintf("%x", pw.hash.Sum(nil)); sha != pw.digest.GetHash() {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Data hash %s does not match digest hash %s", sha, pw.digest.GetHash()))
+	}
+	var r io.Reader
+	if pw.file != nil {
+		if _, err := pw.file.Seek(0, io.SeekStart); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("Failed to read pending write of %s: %v", pw.digest, err))
+		}
+		r = pw.file
+	} else {
+		r = bytes.NewReader(pw.buf.Bytes())
+	}
+	if err := s.store.Write(bazel.DigestStoreName(pw.digest), r, nil); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("Failed to write blob %s: %v", pw.digest, err))
+	}
+	return ser.SendAndClose(&googlebytestream.WriteResponse{CommittedSize: pw.size})
 }
 
 // QueryWriteStatus gives status information about a Write operation in progress
 func (s *casServer) QueryWriteStatus(
 	ctx context.Context,
 	req *googlebytestream.QueryWriteStatusRequest) (*googlebytestream.QueryWriteStatusResponse, error) {
-	log.Infof("Received CAS QueryWriteStatus request: %s", req)
-	// Placeholder - response indicates size 0 resource in completed state
-	res := googlebytestream.QueryWriteStatusResponse{CommittedSize: 0, Complete: true}
-	return &res, nil
+	s.stat.Counter(stats.BazelCasQueryWriteStatusCounter).Inc(1)
+	log.Debugf("Received CAS QueryWriteStatus request: %s", req)
+
+	res, err := ParseWriteResource(req.ResourceName)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.writesMu.Lock()
+	pw, ok := s.writes[req.ResourceName]
+	s.writesMu.Unlock()
+	if ok {
+		return &googlebytestream.QueryWriteStatusResponse{CommittedSize: pw.committed(), Complete: false}, nil
+	}
+
+	exists := bazel.IsEmptyDigest(res.Digest)
+	if !exists {
+		exists, err = s.store.Exists(bazel.DigestStoreName(res.Digest))
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to check for blob %s: %v", res.Digest, err))
+		}
+	}
+	if exists {
+		return &googlebytestream.QueryWriteStatusResponse{CommittedSize: res.Digest.GetSizeBytes(), Complete: true}, nil
+	}
+	return nil, status.Error(codes.NotFound, fmt.Sprintf("No write in progress for %s", req.ResourceName))
+}
+
+// Returns the pending write for the resource, creating one if no write has been started.
+// Writes of blobs larger than maxBufferSize get a temp file for their data.
+// Expired pending writes are discarded first, so abandoned writes don't accumulate.
+func (s *casServer) getPendingWrite(name string, digest *remoteexecution.Digest) (*pendingWrite, error) {
+	s.writesMu.Lock()
+	defer s.writesMu.Unlock()
+	now := time.Now()
+	for n, pw := range s.writes {
+		if pw.expired(now, s.writeExpiry) {
+			log.Infof("Discarding expired pending write %s", n)
+			delete(s.writes, n)
+			pw.discard()
+		}
+	}
+
+	pw, ok := s.writes[name]
+	if !ok {
+		pw = &pendingWrite{digest: digest, hash: sha256.New(), lastWrite: now}
+		if digest.GetSizeBytes() > s.maxBufferSize {
+			f, err := s.tmp.TempFile("cas-write-")
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to create temp file for %s: %v", digest, err))
+			}
+			pw.file = f
+		} else {
+			pw.buf = &bytes.Buffer{}
+		}
+		s.writes[name] = pw
+	}
+	return pw, nil
+}
+
+// Removes the pending write for the resource. If pw is nil, whichever write is pending is removed and its
+// data discarded, otherwise pw is only removed if it hasn't been replaced, and the caller discards its data.
+func (s *casServer) removePendingWrite(name string, pw *pendingWrite) {
+	s.writesMu.Lock()
+	cur, ok := s.writes[name]
+	if ok && (pw == nil || cur == pw) {
+		delete(s.writes, name)
+	}
+	s.writesMu.Unlock()
+	if ok && pw == nil {
+		cur.discard()
+	}
+}
+
+// Appends data to the write, which must be at the committed offset. If the write can't be
+// resumed after an error, its data is discarded and discarded is returned as true.
+func (pw *pendingWrite) write(offset int64, data []byte) (discarded bool, err error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	if pw.done {
+		return true, status.Error(codes.Aborted, fmt.Sprintf("Write of %s was finished or expired", pw.digest))
+	}
+	// The client can query the committed size and resume from there
+	if offset != pw.size {
+		return false, status.Error(codes.InvalidArgument, fmt.Sprintf("Write offset %d does not match committed size %d", offset, pw.size))
+	}
+	if pw.size+int64(len(data)) > pw.digest.GetSizeBytes() {
+		err = status.Error(codes.InvalidArgument, fmt.Sprintf("Write exceeds expected size %d", pw.digest.GetSizeBytes()))
+	} else if pw.file != nil {
+		if _, werr := pw.file.Write(data); werr != nil {
+			err = status.Error(codes.Internal, fmt.Sprintf("Failed to write pending write of %s: %v", pw.digest, werr))
+		}
+	} else {
+		pw.buf.Write(data)
+	}
+	if err != nil {
+		pw.done = true
+		pw.discardLocked()
+		return true, err
+	}
+	pw.hash.Write(data)
+	pw.size += int64(len(data))
+	pw.lastWrite = time.Now()
+	return false, nil
+}
+
+// Returns the number of bytes written so far
+func (pw *pendingWrite) committed() int64 {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	return pw.size
+}
+
+// Returns true if the write hasn't received data for longer than expiry
+func (pw *pendingWrite) expired(now time.Time, expiry time.Duration) bool {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	return now.Sub(pw.lastWrite) > expiry
+}
+
+// Marks the write done and releases its data
+func (pw *pendingWrite) discard() {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	pw.done = true
+	pw.discardLocked()
+}
+
+func (pw *pendingWrite) discardLocked() {
+	if pw.file != nil {
+		pw.file.Close()
+		os.Remove(pw.file.Name())
+		pw.file = nil
+	}
+	pw.buf = nil
+}
+
+// Validates that the data matches the digest, and writes it to the store
+func (s *casServer) writeBlob(digest *remoteexecution.Digest, data []byte) error {
+	if digest == nil || !bazel.IsValidDigest(digest.GetHash(), digest.GetSizeBytes()) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid digest %s", digest))
+	}
+	if int64(len(data)) != digest.GetSizeBytes() {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("Data length %d does not match digest size %d", len(data), digest.GetSizeBytes()))
+	}
+	if sha := fmt.Sprintf("%x", sha256.Sum256(data)); sha != digest.GetHash() {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Data hash %s does not match digest hash %s", sha, digest.GetHash()))
+	}
+	if bazel.IsEmptyDigest(digest) {
+		return nil
+	}
+	if err := s.store.Write(bazel.DigestStoreName(digest), bytes.NewReader(data), nil); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("Failed to write blob %s: %v", digest, err))
+	}
+	return nil
+}
+
+// Opens a blob in the store for reading, returning a NotFound error if it doesn't exist
+func (s *casServer) openBlob(digest *remoteexecution.Digest) (io.ReadCloser, error) {
+	name := bazel.DigestStoreName(digest)
+	exists, err := s.store.Exists(name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to check for blob %s: %v", digest, err))
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Blob %s not found", digest))
+	}
+	r, err := s.store.OpenForRead(name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to open blob %s: %v", digest, err))
+	}
+	return r, nil
+}
+
+// Reads the full contents of a blob from the store
+func (s *casServer) readBlob(digest *remoteexecution.Digest) ([]byte, error) {
+	if bazel.IsEmptyDigest(digest) {
+		return []byte{}, nil
+	}
+	r, err := s.openBlob(digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to read blob %s: %v", digest, err))
+	}
+	return data, nil
 }
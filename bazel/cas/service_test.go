@@ -1,116 +1,356 @@
 package cas
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 	googlebytestream "google.golang.org/genproto/googleapis/bytestream"
 	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-)
 
-func TestFindMissingBlobsStub(t *testing.T) {
-	s := casServer{}
-	ctx := context.Background()
-	digests := []*remoteexecution.Digest{&remoteexecution.Digest{Hash: "abc123", SizeBytes: 1}}
-	req := remoteexecution.FindMissingBlobsRequest{BlobDigests: digests}
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+)
 
-	res, err := s.FindMissingBlobs(ctx, &req)
+func makeTestServer(t *testing.T) *casServer {
+	tmp, err := temp.NewTempDir("", "cas_test")
 	if err != nil {
-		t.Errorf("Error response from FindMissingBlobs: %v", err)
+		t.Fatalf("Failed to create temp dir: %v", err)
 	}
-
-	if len(digests) != len(res.MissingBlobDigests) {
-		t.Errorf("Length of missing blobs mismatch, expected %d got %d", len(digests), len(res.MissingBlobDigests))
+	store, err := bundlestore.MakeFileStoreInTemp(tmp)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
 	}
-	for i, d := range res.MissingBlobDigests {
-		if digests[i] != d {
-			t.Errorf("Non match iterating through missing digests, expected %s got: %s", digests[i], d)
-		}
+	return &casServer{
+		store:         store,
+		stat:          stats.NilStatsReceiver(),
+		writes:        make(map[string]*pendingWrite),
+		tmp:           tmp,
+		maxBufferSize: DefaultMaxBufferedWriteSize,
+		writeExpiry:   DefaultPendingWriteExpiry,
 	}
 }
 
-func TestBatchUpdateBlobsStub(t *testing.T) {
-	s := casServer{}
-	ctx := context.Background()
-	req := remoteexecution.BatchUpdateBlobsRequest{}
+func makeDigest(data []byte) *remoteexecution.Digest {
+	return &remoteexecution.Digest{Hash: fmt.Sprintf("%x", sha256.Sum256(data)), SizeBytes: int64(len(data))}
+}
 
-	_, err := s.BatchUpdateBlobs(ctx, &req)
+func expectCode(t *testing.T, err error, code codes.Code) {
 	if err == nil {
-		t.Errorf("Non-error response from BatchUpdateBlobs")
+		t.Fatalf("Expected error with status code %d, got nil", code)
 	}
 	st, ok := status.FromError(err)
 	if !ok {
-		t.Errorf("Not ok reading grpc status from error")
+		t.Fatalf("Not ok reading grpc status from error")
 	}
-	if st.Code() != codes.Unimplemented {
-		t.Errorf("Expected status code %d, got: %d", codes.Unimplemented, st.Code())
+	if st.Code() != code {
+		t.Errorf("Expected status code %d, got: %d", code, st.Code())
 	}
 }
 
-func TestGetTreeStub(t *testing.T) {
-	s := casServer{}
+func TestFindMissingBlobs(t *testing.T) {
+	s := makeTestServer(t)
 	ctx := context.Background()
-	req := remoteexecution.GetTreeRequest{}
+	present := []byte("present")
+	missing := makeDigest([]byte("missing"))
+	if err := s.writeBlob(makeDigest(present), present); err != nil {
+		t.Fatalf("Failed to write blob: %v", err)
+	}
 
-	_, err := s.GetTree(ctx, &req)
-	if err == nil {
-		t.Errorf("Non-error response from GetTree")
+	digests := []*remoteexecution.Digest{
+		makeDigest(present),
+		missing,
+		&remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: bazel.EmptySize},
 	}
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Errorf("Not ok reading grpc status from error")
+	req := remoteexecution.FindMissingBlobsRequest{BlobDigests: digests}
+
+	res, err := s.FindMissingBlobs(ctx, &req)
+	if err != nil {
+		t.Fatalf("Error response from FindMissingBlobs: %v", err)
 	}
-	if st.Code() != codes.Unimplemented {
-		t.Errorf("Expected status code %d, got: %d", codes.Unimplemented, st.Code())
+	if len(res.MissingBlobDigests) != 1 || res.MissingBlobDigests[0] != missing {
+		t.Errorf("Expected only %s to be missing, got: %v", missing, res.MissingBlobDigests)
 	}
+
+	req = remoteexecution.FindMissingBlobsRequest{BlobDigests: []*remoteexecution.Digest{{Hash: "abc123", SizeBytes: 1}}}
+	_, err = s.FindMissingBlobs(ctx, &req)
+	expectCode(t, err, codes.InvalidArgument)
 }
 
-func TestReadStub(t *testing.T) {
-	s := casServer{}
-	req := googlebytestream.ReadRequest{}
-	n := nilReadServer{}
+func TestBatchUpdateBlobs(t *testing.T) {
+	s := makeTestServer(t)
+	ctx := context.Background()
+	good := []byte("good")
+	bad := []byte("bad")
+	req := remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.UpdateBlobRequest{
+			{ContentDigest: makeDigest(good), Data: good},
+			{ContentDigest: makeDigest(good), Data: bad},
+		},
+	}
 
-	err := s.Read(&req, &n)
+	res, err := s.BatchUpdateBlobs(ctx, &req)
 	if err != nil {
-		t.Errorf("Error response from Read: %v", err)
+		t.Fatalf("Error response from BatchUpdateBlobs: %v", err)
+	}
+	if len(res.Responses) != 2 {
+		t.Fatalf("Expected 2 responses, got %d", len(res.Responses))
+	}
+	if codes.Code(res.Responses[0].Status.Code) != codes.OK {
+		t.Errorf("Expected OK for valid blob, got: %v", res.Responses[0].Status)
+	}
+	if codes.Code(res.Responses[1].Status.Code) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for mismatched blob, got: %v", res.Responses[1].Status)
+	}
+
+	data, err := s.readBlob(makeDigest(good))
+	if err != nil || !bytes.Equal(data, good) {
+		t.Errorf("Expected to read back %q, got %q, %v", good, data, err)
 	}
 }
 
-func TestWriteStub(t *testing.T) {
-	s := casServer{}
-	n := nilWriteServer{}
+func TestGetTree(t *testing.T) {
+	s := makeTestServer(t)
+	ctx := context.Background()
 
-	err := s.Write(&n)
+	writeDir := func(dir *remoteexecution.Directory) *remoteexecution.Digest {
+		data, err := proto.Marshal(dir)
+		if err != nil {
+			t.Fatalf("Failed to marshal directory: %v", err)
+		}
+		d := makeDigest(data)
+		if err := s.writeBlob(d, data); err != nil {
+			t.Fatalf("Failed to write directory: %v", err)
+		}
+		return d
+	}
+	leaf := writeDir(&remoteexecution.Directory{
+		Files: []*remoteexecution.FileNode{{Name: "file", Digest: makeDigest([]byte("file"))}},
+	})
+	mid := writeDir(&remoteexecution.Directory{
+		Directories: []*remoteexecution.DirectoryNode{{Name: "leaf", Digest: leaf}},
+	})
+	root := writeDir(&remoteexecution.Directory{
+		Directories: []*remoteexecution.DirectoryNode{{Name: "mid", Digest: mid}, {Name: "leaf", Digest: leaf}},
+	})
+
+	res, err := s.GetTree(ctx, &remoteexecution.GetTreeRequest{RootDigest: root, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(res.Directories) != 2 || res.NextPageToken == "" {
+		t.Fatalf("Expected first page of 2 directories with a next page token, got: %s", res)
+	}
+	res, err = s.GetTree(ctx, &remoteexecution.GetTreeRequest{RootDigest: root, PageSize: 2, PageToken: res.NextPageToken})
 	if err != nil {
-		t.Errorf("Error response from Write: %v", err)
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(res.Directories) != 2 || res.NextPageToken != "" {
+		t.Fatalf("Expected last page of 2 directories, got: %s", res)
 	}
+
+	_, err = s.GetTree(ctx, &remoteexecution.GetTreeRequest{RootDigest: makeDigest([]byte("missing"))})
+	expectCode(t, err, codes.NotFound)
 }
 
-func TestQueryWriteStatusStub(t *testing.T) {
-	s := casServer{}
-	ctx := context.Background()
-	req := googlebytestream.QueryWriteStatusRequest{}
+func TestWriteAndRead(t *testing.T) {
+	s := makeTestServer(t)
+	data := []byte("some data to store in the cas")
+	d := makeDigest(data)
+	writeName := fmt.Sprintf("instance/uploads/%s/blobs/%s/%d", "uuid", d.Hash, d.SizeBytes)
+	readName := fmt.Sprintf("instance/blobs/%s/%d", d.Hash, d.SizeBytes)
+
+	// Write the first part of the data, then drop the stream
+	w := &fakeWriteServer{reqs: []*googlebytestream.WriteRequest{
+		{ResourceName: writeName, WriteOffset: 0, Data: data[:10]},
+	}}
+	expectCode(t, s.Write(w), codes.InvalidArgument)
+
+	qres, err := s.QueryWriteStatus(context.Background(), &googlebytestream.QueryWriteStatusRequest{ResourceName: writeName})
+	if err != nil {
+		t.Fatalf("Error response from QueryWriteStatus: %v", err)
+	}
+	if qres.Complete || qres.CommittedSize != 10 {
+		t.Fatalf("Expected incomplete write with 10 bytes committed, got: %s", qres)
+	}
+
+	// Resume the write from the committed offset
+	w = &fakeWriteServer{reqs: []*googlebytestream.WriteRequest{
+		{ResourceName: writeName, WriteOffset: 10, Data: data[10:20]},
+		{WriteOffset: 20, Data: data[20:], FinishWrite: true},
+	}}
+	if err := s.Write(w); err != nil {
+		t.Fatalf("Error response from Write: %v", err)
+	}
+	if w.res == nil || w.res.CommittedSize != d.SizeBytes {
+		t.Fatalf("Expected committed size %d, got: %v", d.SizeBytes, w.res)
+	}
+
+	qres, err = s.QueryWriteStatus(context.Background(), &googlebytestream.QueryWriteStatusRequest{ResourceName: writeName})
+	if err != nil {
+		t.Fatalf("Error response from QueryWriteStatus: %v", err)
+	}
+	if !qres.Complete || qres.CommittedSize != d.SizeBytes {
+		t.Errorf("Expected complete write, got: %s", qres)
+	}
+
+	// Read back a range of the data
+	r := &fakeReadServer{}
+	if err := s.Read(&googlebytestream.ReadRequest{ResourceName: readName, ReadOffset: 5, ReadLimit: 10}, r); err != nil {
+		t.Fatalf("Error response from Read: %v", err)
+	}
+	if !bytes.Equal(r.data.Bytes(), data[5:15]) {
+		t.Errorf("Expected to read %q, got %q", data[5:15], r.data.Bytes())
+	}
+
+	r = &fakeReadServer{}
+	if err := s.Read(&googlebytestream.ReadRequest{ResourceName: readName}, r); err != nil {
+		t.Fatalf("Error response from Read: %v", err)
+	}
+	if !bytes.Equal(r.data.Bytes(), data) {
+		t.Errorf("Expected to read %q, got %q", data, r.data.Bytes())
+	}
+}
+
+func TestWriteSpillAndExpiry(t *testing.T) {
+	s := makeTestServer(t)
+	s.maxBufferSize = 8
+	data := []byte("data larger than the buffer size")
+	d := makeDigest(data)
+	name := func(uuid string) string {
+		return fmt.Sprintf("uploads/%s/blobs/%s/%d", uuid, d.Hash, d.SizeBytes)
+	}
+
+	// A large write is kept in a temp file until it's finished
+	w := &fakeWriteServer{reqs: []*googlebytestream.WriteRequest{{ResourceName: name("a"), Data: data[:10]}}}
+	expectCode(t, s.Write(w), codes.InvalidArgument)
+	files, _ := ioutil.ReadDir(s.tmp.Dir)
+	var spilled []os.FileInfo
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), "cas-write-") {
+			spilled = append(spilled, f)
+		}
+	}
+	if len(spilled) != 1 || spilled[0].Size() != 10 {
+		t.Fatalf("Expected one temp file with 10 bytes, got: %v", spilled)
+	}
+
+	// Writes that haven't received data within the expiry are discarded when another write starts
+	s.writes[name("a")].lastWrite = time.Now().Add(-2 * s.writeExpiry)
+	w = &fakeWriteServer{reqs: []*googlebytestream.WriteRequest{{ResourceName: name("b"), Data: data[:5]}}}
+	expectCode(t, s.Write(w), codes.InvalidArgument)
+	_, err := s.QueryWriteStatus(context.Background(), &googlebytestream.QueryWriteStatusRequest{ResourceName: name("a")})
+	expectCode(t, err, codes.NotFound)
+	if _, err := os.Stat(filepath.Join(s.tmp.Dir, spilled[0].Name())); !os.IsNotExist(err) {
+		t.Fatalf("Expected the expired write's temp file to be removed, got: %v", err)
+	}
+
+	w = &fakeWriteServer{reqs: []*googlebytestream.WriteRequest{{ResourceName: name("b"), WriteOffset: 5, Data: data[5:], FinishWrite: true}}}
+	if err := s.Write(w); err != nil {
+		t.Fatalf("Error response from Write: %v", err)
+	}
+	stored, err := s.readBlob(d)
+	if err != nil || !bytes.Equal(stored, data) {
+		t.Fatalf("Expected to read %q, got %q %v", data, stored, err)
+	}
+	if len(s.writes) != 0 {
+		t.Fatalf("Expected no pending writes, got: %v", s.writes)
+	}
+}
+
+func TestWriteInvalidDigest(t *testing.T) {
+	s := makeTestServer(t)
+	d := makeDigest([]byte("expected"))
+	w := &fakeWriteServer{reqs: []*googlebytestream.WriteRequest{
+		{
+			ResourceName: fmt.Sprintf("uploads/uuid/blobs/%s/%d", d.Hash, d.SizeBytes),
+			Data:         []byte("mismatch"),
+			FinishWrite:  true,
+		},
+	}}
+	expectCode(t, s.Write(w), codes.InvalidArgument)
+
+	exists, err := s.store.Exists(bazel.DigestStoreName(d))
+	if err != nil || exists {
+		t.Errorf("Expected mismatched blob to not be stored, got: %t, %v", exists, err)
+	}
+}
+
+func TestReadMissing(t *testing.T) {
+	s := makeTestServer(t)
+	d := makeDigest([]byte("missing"))
+	req := googlebytestream.ReadRequest{ResourceName: fmt.Sprintf("blobs/%s/%d", d.Hash, d.SizeBytes)}
+	expectCode(t, s.Read(&req, &fakeReadServer{}), codes.NotFound)
+
+	req = googlebytestream.ReadRequest{ResourceName: "not/a/resource"}
+	expectCode(t, s.Read(&req, &fakeReadServer{}), codes.InvalidArgument)
+}
+
+func TestParseResource(t *testing.T) {
+	d := makeDigest([]byte("data"))
+	res, err := ParseWriteResource(fmt.Sprintf("a/b/uploads/id/blobs/%s/%d/extra", d.Hash, d.SizeBytes))
+	if err != nil {
+		t.Fatalf("Failed to parse write resource: %v", err)
+	}
+	if res.Instance != "a/b" || res.UUID != "id" || res.Digest.Hash != d.Hash || res.Digest.SizeBytes != d.SizeBytes {
+		t.Errorf("Unexpected resource: %+v", res)
+	}
 
-	res, err := s.QueryWriteStatus(ctx, &req)
+	res, err = ParseReadResource(fmt.Sprintf("blobs/%s/%d", d.Hash, d.SizeBytes))
 	if err != nil {
-		t.Errorf("Error response from QueryWriteStatus: %v", err)
+		t.Fatalf("Failed to parse read resource: %v", err)
+	}
+	if res.Instance != "" || res.Digest.Hash != d.Hash {
+		t.Errorf("Unexpected resource: %+v", res)
 	}
 
-	if !res.Complete {
-		t.Errorf("Unexpected false returned from Complete")
+	if _, err := ParseWriteResource(fmt.Sprintf("blobs/%s/%d", d.Hash, d.SizeBytes)); err == nil {
+		t.Errorf("Expected error parsing read resource name as write resource")
 	}
 }
 
 // Implements googlebytestream.ByteStream_ReadServer interface
-type nilReadServer struct{ grpc.ServerStream }
+type fakeReadServer struct {
+	grpc.ServerStream
+	data bytes.Buffer
+}
 
-func (s *nilReadServer) Send(*googlebytestream.ReadResponse) error { return nil }
+func (s *fakeReadServer) Send(res *googlebytestream.ReadResponse) error {
+	s.data.Write(res.Data)
+	return nil
+}
 
 // Implements googlebytestream.ByteStream_WriteServer interface
-type nilWriteServer struct{ grpc.ServerStream }
+type fakeWriteServer struct {
+	grpc.ServerStream
+	reqs []*googlebytestream.WriteRequest
+	res  *googlebytestream.WriteResponse
+}
+
+func (s *fakeWriteServer) SendAndClose(res *googlebytestream.WriteResponse) error {
+	s.res = res
+	return nil
+}
 
-func (s *nilWriteServer) SendAndClose(*googlebytestream.WriteResponse) error { return nil }
-func (s *nilWriteServer) Recv() (*googlebytestream.WriteRequest, error)      { return nil, nil }
+func (s *fakeWriteServer) Recv() (*googlebytestream.WriteRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
@@ -0,0 +1,141 @@
+package execution
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	googlelongrunning "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/saga"
+)
+
+const (
+	// How long WaitOperation blocks if the client does not specify a timeout
+	DefaultWaitTimeout = time.Minute
+
+	// How often WaitOperation checks the saga state while waiting for an Operation to complete
+	DefaultWaitPollInterval = 500 * time.Millisecond
+)
+
+// Longrunning Operations APIs
+// Operation names are the Scoot job IDs returned by Execute, and status is derived from the job's saga.
+// Operations answered from the ActionCache are named by their Action digest instead, and are served from it.
+
+// Returns the latest state of the Operation corresponding to a job created by Execute
+func (s *executionServer) GetOperation(
+	ctx context.Context,
+	req *googlelongrunning.GetOperationRequest) (*googlelongrunning.Operation, error) {
+	defer s.stat.Latency(stats.BazelGetOperationLatency_ms).Time().Stop()
+	s.stat.Counter(stats.BazelGetOperationCounter).Inc(1)
+	log.Debugf("Received GetOperation request: %s", req)
+
+	return s.getOperation(req.GetName())
+}
+
+// Cancels the job corresponding to the Operation. Cancelling an Operation that is already done has no effect.
+func (s *executionServer) CancelOperation(
+	ctx context.Context,
+	req *googlelongrunning.CancelOperationRequest) (*empty.Empty, error) {
+	s.stat.Counter(stats.BazelCancelOperationCounter).Inc(1)
+	log.Infof("Received CancelOperation request: %s", req)
+
+	op, err := s.getOperation(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if op.GetDone() {
+		return &empty.Empty{}, nil
+	}
+	if err := s.scheduler.KillJob(op.GetName()); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to cancel operation %s: %v", op.GetName(), err))
+	}
+	return &empty.Empty{}, nil
+}
+
+// Blocks until the Operation is done or the timeout expires, and returns its latest state
+func (s *executionServer) WaitOperation(
+	ctx context.Context,
+	req *googlelongrunning.WaitOperationRequest) (*googlelongrunning.Operation, error) {
+	s.stat.Counter(stats.BazelWaitOperationCounter).Inc(1)
+	log.Debugf("Received WaitOperation request: %s", req)
+
+	timeout := DefaultWaitTimeout
+	if req.GetTimeout() != nil {
+		t, err := ptypes.Duration(req.GetTimeout())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid timeout: %v", err))
+		}
+		timeout = t
+	}
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(DefaultWaitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		op, err := s.getOperation(req.GetName())
+		if err != nil || op.GetDone() {
+			return op, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		case <-deadline:
+			return op, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Not supported in Scoot - use the Scoot API to list jobs
+func (s *executionServer) ListOperations(
+	ctx context.Context,
+	req *googlelongrunning.ListOperationsRequest) (*googlelongrunning.ListOperationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Currently unsupported in Scoot")
+}
+
+// Not supported in Scoot - job history is retained by the saga log
+func (s *executionServer) DeleteOperation(
+	ctx context.Context,
+	req *googlelongrunning.DeleteOperationRequest) (*empty.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "Currently unsupported in Scoot")
+}
+
+// Reads the saga for the job named by the Operation, and converts it to an Operation.
+// Operations named by an Action digest are read from the ActionCache.
+func (s *executionServer) getOperation(name string) (*googlelongrunning.Operation, error) {
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Operation name must be specified")
+	}
+	if actionDigest, err := bazel.DigestFromStr(name); err == nil {
+		op, err := s.getCachedOperation(actionDigest)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if op == nil {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("Operation %s not found", name))
+		}
+		return op, nil
+	}
+
+	state, err := s.sagaCoord.GetSagaState(name)
+	if err != nil {
+		switch err.(type) {
+		case saga.InvalidRequestError:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if state == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Operation %s not found", name))
+	}
+	return s.operationFromSagaState(name, state)
+}
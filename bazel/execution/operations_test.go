@@ -0,0 +1,131 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+	googlelongrunning "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+)
+
+// Creates a saga for a single-task job as Execute would, returning the saga and task ID
+func makeTestSaga(t *testing.T, s *executionServer, jobID string) (*saga.Saga, string) {
+	taskID := bazel.DigestToStr(&remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 10})
+	job := sched.Job{
+		Id: jobID,
+		Def: sched.JobDefinition{
+			JobType: bazel.ExecutionJobType,
+			Tasks: []sched.TaskDefinition{
+				sched.TaskDefinition{Command: runner.Command{Argv: []string{"/bin/true"}}},
+			},
+		},
+	}
+	job.Def.Tasks[0].TaskID = taskID
+	jobData, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("Failed to serialize job: %v", err)
+	}
+	sg, err := s.sagaCoord.MakeSaga(jobID, jobData)
+	if err != nil {
+		t.Fatalf("Failed to make saga: %v", err)
+	}
+	return sg, taskID
+}
+
+func expectStatusCode(t *testing.T, err error, code codes.Code) {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		t.Fatalf("Expected grpc status error, got: %v", err)
+	}
+	if st.Code() != code {
+		t.Errorf("Expected status code %d, got: %d", code, st.Code())
+	}
+}
+
+func TestGetOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := makeTestServer(t, scheduler.NewMockScheduler(mockCtrl))
+	ctx := context.Background()
+	sg, taskID := makeTestSaga(t, s, "job1")
+	sg.StartTask(taskID, nil)
+
+	op, err := s.GetOperation(ctx, &googlelongrunning.GetOperationRequest{Name: "job1"})
+	if err != nil {
+		t.Fatalf("Non-nil error from GetOperation: %v", err)
+	}
+	metadata := remoteexecution.ExecuteOperationMetadata{}
+	if err := ptypes.UnmarshalAny(op.GetMetadata(), &metadata); err != nil {
+		t.Fatalf("Failed to unmarshal metadata from any: %v", err)
+	}
+	if op.GetName() != "job1" || metadata.GetStage() != remoteexecution.ExecuteOperationMetadata_EXECUTING {
+		t.Errorf("Expected executing operation job1, got: %s %s", op.GetName(), metadata.GetStage())
+	}
+
+	_, err = s.GetOperation(ctx, &googlelongrunning.GetOperationRequest{Name: "unknown"})
+	expectStatusCode(t, err, codes.NotFound)
+}
+
+func TestCancelOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	s := makeTestServer(t, sc)
+	ctx := context.Background()
+	makeTestSaga(t, s, "job1")
+
+	sc.EXPECT().KillJob("job1").Return(nil)
+	if _, err := s.CancelOperation(ctx, &googlelongrunning.CancelOperationRequest{Name: "job1"}); err != nil {
+		t.Errorf("Non-nil error from CancelOperation: %v", err)
+	}
+
+	sc.EXPECT().KillJob("job1").Return(errors.New("already killed"))
+	_, err := s.CancelOperation(ctx, &googlelongrunning.CancelOperationRequest{Name: "job1"})
+	expectStatusCode(t, err, codes.FailedPrecondition)
+}
+
+func TestWaitOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := makeTestServer(t, scheduler.NewMockScheduler(mockCtrl))
+	ctx := context.Background()
+	sg, taskID := makeTestSaga(t, s, "job1")
+
+	// Operation does not complete before the timeout
+	req := &googlelongrunning.WaitOperationRequest{Name: "job1", Timeout: ptypes.DurationProto(10 * time.Millisecond)}
+	op, err := s.WaitOperation(ctx, req)
+	if err != nil {
+		t.Fatalf("Non-nil error from WaitOperation: %v", err)
+	}
+	if op.GetDone() {
+		t.Errorf("Expected operation to not be done")
+	}
+
+	// Operation completes while waiting
+	sg.StartTask(taskID, nil)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		sg.EndTask(taskID, nil)
+		sg.EndSaga()
+	}()
+	req.Timeout = ptypes.DurationProto(10 * time.Second)
+	op, err = s.WaitOperation(ctx, req)
+	if err != nil {
+		t.Fatalf("Non-nil error from WaitOperation: %v", err)
+	}
+	if !op.GetDone() {
+		t.Errorf("Expected operation to be done")
+	}
+}
@@ -0,0 +1,150 @@
+package execution
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	log "github.com/sirupsen/logrus"
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+	googlelongrunning "google.golang.org/genproto/googleapis/longrunning"
+
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+)
+
+// The ActionResult of a job's run is built once, when its task finishes, and stored under the job's name
+// in the bundlestore. Operations of completed jobs are served from the stored result. Successful results
+// are also stored in the ActionCache under the Action's digest, unless the Action sets DoNotCache.
+
+// A result that is being built. Callers asking for the same job's result wait for it instead of building it again.
+type pendingResult struct {
+	done   chan struct{}
+	result *remoteexecution.ActionResult
+	err    error
+}
+
+// Waits for the job to finish, then builds and stores its ActionResult. The job is watched in the
+// scheduler, and its saga is checked whenever the scheduler isn't tracking it, as before it's added or
+// once it's done. Returns once the job's Operation is done, or if the job can't be found.
+func (s *executionServer) watchJob(jobID string, actionDigest *remoteexecution.Digest, doNotCache bool) {
+	version := int64(0)
+	for {
+		update, err := s.scheduler.WatchJob(jobID, version, DefaultWaitTimeout)
+		if err == nil && update != nil && update.Status != sched.Completed {
+			version = update.Version
+			continue
+		}
+		op, err := s.getOperation(jobID)
+		if err != nil {
+			log.Errorf("Failed to get the result of job %s: %v", jobID, err)
+			return
+		}
+		if op.GetDone() {
+			if !doNotCache {
+				s.cacheActionResult(op, actionDigest)
+			}
+			return
+		}
+		if update == nil {
+			time.Sleep(DefaultWaitPollInterval)
+		}
+	}
+}
+
+// Stores the ActionResult of a done Operation in the ActionCache if the command succeeded
+func (s *executionServer) cacheActionResult(op *googlelongrunning.Operation, actionDigest *remoteexecution.Digest) {
+	if op.GetResponse() == nil {
+		return
+	}
+	res := remoteexecution.ExecuteResponse{}
+	if err := ptypes.UnmarshalAny(op.GetResponse(), &res); err != nil {
+		log.Errorf("Failed to unmarshal response of job %s: %v", op.GetName(), err)
+		return
+	}
+	if res.GetResult().GetExitCode() != 0 {
+		return
+	}
+	if err := cas.WriteActionResult(s.store, actionDigest, res.GetResult()); err != nil {
+		log.Errorf("Failed to store result of job %s in the ActionCache: %v", op.GetName(), err)
+	}
+}
+
+// Returns a done Operation with the ActionResult cached for the Action, or nil if there is none.
+// The Operation is named by the Action digest, so it can be served from the ActionCache again.
+func (s *executionServer) getCachedOperation(actionDigest *remoteexecution.Digest) (*googlelongrunning.Operation, error) {
+	ar, err := cas.ReadActionResult(s.store, actionDigest)
+	if err != nil || ar == nil {
+		return nil, err
+	}
+	eom := remoteexecution.ExecuteOperationMetadata{
+		Stage:        remoteexecution.ExecuteOperationMetadata_COMPLETED,
+		ActionDigest: actionDigest,
+	}
+	return makeResponseOperation(bazel.DigestToStr(actionDigest), &eom, &remoteexecution.ExecuteResponse{Result: ar, CachedResult: true})
+}
+
+// Returns the ActionResult of the job's completed run. It's read from the store if it was already
+// built, otherwise it's built from the run's result snapshot, see getActionResult, and stored.
+func (s *executionServer) getJobActionResult(
+	jobID string, rs runner.RunStatus, outputs []string) (*remoteexecution.ActionResult, error) {
+	s.mu.Lock()
+	p, ok := s.results[jobID]
+	if !ok {
+		p = &pendingResult{done: make(chan struct{})}
+		s.results[jobID] = p
+	}
+	s.mu.Unlock()
+	if ok {
+		<-p.done
+		return p.result, p.err
+	}
+	defer func() {
+		close(p.done)
+		s.mu.Lock()
+		delete(s.results, jobID)
+		s.mu.Unlock()
+	}()
+
+	name := bazel.JobResultStoreName(jobID)
+	if p.result, p.err = s.readJobActionResult(name); p.result != nil || p.err != nil {
+		return p.result, p.err
+	}
+	if p.result, p.err = s.getActionResult(rs, outputs); p.err != nil {
+		return nil, p.err
+	}
+	if data, err := proto.Marshal(p.result); err != nil {
+		log.Errorf("Failed to marshal result %s of job %s: %v", name, jobID, err)
+	} else if err := s.store.Write(name, bytes.NewReader(data), nil); err != nil {
+		log.Errorf("Failed to store result %s of job %s: %v", name, jobID, err)
+	}
+	return p.result, nil
+}
+
+// Reads a stored ActionResult from the store, returning nil if it doesn't exist
+func (s *executionServer) readJobActionResult(name string) (*remoteexecution.ActionResult, error) {
+	if exists, err := s.store.Exists(name); err != nil {
+		return nil, fmt.Errorf("Failed to check for result %s: %v", name, err)
+	} else if !exists {
+		return nil, nil
+	}
+	r, err := s.store.OpenForRead(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open result %s: %v", name, err)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read result %s: %v", name, err)
+	}
+	result := &remoteexecution.ActionResult{}
+	if err := proto.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal result %s: %v", name, err)
+	}
+	return result, nil
+}
@@ -1,32 +1,75 @@
 // Bazel Remote Execution API gRPC server
-// Contains limited implementation of the Execution API interface
+// Contains implementation of the Execution API interface on top of the Scoot scheduler
 package execution
 
 import (
 	"fmt"
 	"net"
+	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
-	uuid "github.com/nu7hatch/gouuid"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
 	googlelongrunning "google.golang.org/genproto/googleapis/longrunning"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/twitter/scoot/bazel"
 	scootproto "github.com/twitter/scoot/common/proto"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+	"github.com/twitter/scoot/workerapi"
 )
 
-// Implements GRPCServer and remoteexecution.ExecutionServer interfaces
+// Implements GRPCServer, remoteexecution.ExecutionServer and googlelongrunning.OperationsServer interfaces
 type executionServer struct {
-	listener net.Listener
-	server   *grpc.Server
+	listener  net.Listener
+	server    *grpc.Server
+	scheduler scheduler.Scheduler
+	sagaCoord saga.SagaCoordinator
+	store     bundlestore.Store
+	db        snap.DB
+	tmp       *temp.TempDir
+	stat      stats.StatsReceiver
+
+	mu      sync.Mutex
+	results map[string]*pendingResult // Results being built, by job ID.
 }
 
-// Creates a new GRPCServer (executionServer) based on a listener, and preregisters the service
-func NewExecutionServer(l net.Listener) *executionServer {
-	g := executionServer{listener: l, server: grpc.NewServer()}
-	remoteexecution.RegisterExecutionServer(g.server, &executionServer{})
+// Creates a new GRPCServer (ExecutionServer, OperationsServer) based on a listener, and preregisters the services.
+// Jobs are submitted to the scheduler, and Commands referenced by Actions are read from the store.
+// Input roots are ingested into the snapshot db so workers can check them out, and results are read back
+// from it, using tmp to stage files.
+func NewExecutionServer(
+	l net.Listener,
+	s scheduler.Scheduler,
+	sc saga.SagaCoordinator,
+	store bundlestore.Store,
+	db snap.DB,
+	tmp *temp.TempDir,
+	stat stats.StatsReceiver) *executionServer {
+	g := executionServer{
+		listener:  l,
+		server:    grpc.NewServer(),
+		scheduler: s,
+		sagaCoord: sc,
+		store:     store,
+		db:        db,
+		tmp:       tmp,
+		stat:      stat,
+		results:   make(map[string]*pendingResult),
+	}
+	remoteexecution.RegisterExecutionServer(g.server, &g)
+	googlelongrunning.RegisterOperationsServer(g.server, &g)
 	return &g
 }
 
@@ -37,49 +80,271 @@ func (s *executionServer) Serve() error {
 
 // Execution APIs
 
-// Stub of Execute API - most fields omitted, but returns a valid hardcoded response.
-// Takes an ExecuteRequest and forms an ExecuteResponse that is returned as part of a
-// google LongRunning Operation message.
+// Takes an ExecuteRequest, translates the Action and its Command into a single-task Scoot job,
+// and schedules it. Returns a google LongRunning Operation named by the Scoot job ID, whose
+// ExecuteOperationMetadata reports the QUEUED stage. Subsequent stages can be retrieved with
+// the Operations API, and are derived from the job's saga state via OperationFromSagaState.
+// The job is watched until it finishes, so its ActionResult is ready before it's asked for, and
+// successful results are stored in the ActionCache unless the Action sets DoNotCache.
+// Unless the request sets SkipCacheLookup, an Action with a cached result isn't run, and a done
+// Operation with the cached result is returned instead.
 func (s *executionServer) Execute(
 	ctx context.Context,
 	req *remoteexecution.ExecuteRequest) (*googlelongrunning.Operation, error) {
-	// Get digest of request Action from wire format only, for inclusion in response metadata.
+	defer s.stat.Latency(stats.BazelExecuteRequestLatency_ms).Time().Stop()
+	s.stat.Counter(stats.BazelExecuteRequestCounter).Inc(1)
+	log.Infof("Received Execute request: %s", req)
+
+	op, err := s.execute(req)
+	if err != nil {
+		s.stat.Counter(stats.BazelExecuteFailureCounter).Inc(1)
+		log.Errorf("Failed to handle Execute request: %v", err)
+		return nil, err
+	}
+	return op, nil
+}
+
+func (s *executionServer) execute(req *remoteexecution.ExecuteRequest) (*googlelongrunning.Operation, error) {
+	if err := validateExecuteRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Get digest of request Action from wire format only, for use as the task ID and in response metadata.
 	actionSha, actionLen, err := scootproto.GetSha256(req.Action)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	actionDigest := &remoteexecution.Digest{Hash: actionSha, SizeBytes: actionLen}
+
+	if !req.GetSkipCacheLookup() {
+		if op, err := s.getCachedOperation(actionDigest); err != nil {
+			log.Errorf("Failed to look up action %s in the ActionCache, running it: %v", bazel.DigestToStr(actionDigest), err)
+		} else if op != nil {
+			log.Infof("Answered Execute request for action %s from the ActionCache", bazel.DigestToStr(actionDigest))
+			return op, nil
+		}
+	}
+
+	cmd, err := s.getCommand(req.Action.CommandDigest)
 	if err != nil {
 		return nil, err
 	}
 
-	op := googlelongrunning.Operation{}
+	snapshotID, err := s.getInputRootSnapshot(req.Action.InputRootDigest)
+	if err != nil {
+		return nil, err
+	}
 
-	// Generate a UUID as a stub job identifier
-	id, _ := uuid.NewV4()
-	op.Name = fmt.Sprintf("operations/%s", id.String())
-	op.Done = true
+	jobDef, err := execReqToScoot(req, actionDigest, cmd, snapshotID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id, err := s.scheduler.ScheduleJob(jobDef)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("Failed to schedule job: %v", err))
+	}
+	log.Infof("Scheduled Execute request as job %s, action %s", id, bazel.DigestToStr(actionDigest))
+	go s.watchJob(id, actionDigest, req.Action.GetDoNotCache())
+
+	eom := remoteexecution.ExecuteOperationMetadata{
+		Stage:        remoteexecution.ExecuteOperationMetadata_QUEUED,
+		ActionDigest: actionDigest,
+	}
+	return makeOperation(id, &eom, nil)
+}
+
+// Reads the Command referenced by the Action from the store. The Command must have been
+// uploaded by the client beforehand, and a missing Command is reported as a failed precondition.
+func (s *executionServer) getCommand(digest *remoteexecution.Digest) (*remoteexecution.Command, error) {
+	data, err := s.readBlob(digest, "Command")
+	if err != nil {
+		return nil, err
+	}
+	cmd := &remoteexecution.Command{}
+	if err := proto.Unmarshal(data, cmd); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Failed to unmarshal Command %s: %v", bazel.DigestToStr(digest), err))
+	}
+	return cmd, nil
+}
+
+// Validate an ExecuteRequest, returning an error describing the first problem found
+func validateExecuteRequest(req *remoteexecution.ExecuteRequest) error {
+	if req == nil || req.Action == nil {
+		return fmt.Errorf("ExecuteRequest must include an Action")
+	}
+	d := req.Action.CommandDigest
+	if d == nil {
+		return fmt.Errorf("Action must include a CommandDigest")
+	}
+	if !bazel.IsValidDigest(d.GetHash(), d.GetSizeBytes()) {
+		return fmt.Errorf("Invalid CommandDigest %s", d)
+	}
+	if d := req.Action.InputRootDigest; d != nil && !bazel.IsValidDigest(d.GetHash(), d.GetSizeBytes()) {
+		return fmt.Errorf("Invalid InputRootDigest %s", d)
+	}
+	return nil
+}
+
+// Translates a Bazel Action and Command into a single-task Scoot job definition, run against the
+// snapshot its input root was ingested as. The task ID is the Action digest, so the Action can be
+// recovered from the job's saga, and the Action's output files and directories are the task's outputs.
+func execReqToScoot(
+	req *remoteexecution.ExecuteRequest,
+	actionDigest *remoteexecution.Digest,
+	cmd *remoteexecution.Command,
+	snapshotID string) (result sched.JobDefinition, err error) {
+	if len(cmd.GetArguments()) == 0 {
+		return result, fmt.Errorf("Command must have at least one argument")
+	}
+
+	var task sched.TaskDefinition
+	task.TaskID = bazel.DigestToStr(actionDigest)
+	task.Command.Argv = cmd.GetArguments()
+	task.Command.EnvVars = make(map[string]string)
+	for _, env := range cmd.GetEnvironmentVariables() {
+		task.Command.EnvVars[env.GetName()] = env.GetValue()
+	}
+	if req.Action.Timeout != nil {
+		timeout, err := ptypes.Duration(req.Action.Timeout)
+		if err != nil {
+			return result, fmt.Errorf("Invalid Action Timeout: %v", err)
+		}
+		task.Command.Timeout = timeout
+	}
+	task.Command.SnapshotID = snapshotID
+	task.Command.Outputs = append(append([]string{}, req.Action.GetOutputFiles()...), req.Action.GetOutputDirectories()...)
+	task.SkipCacheLookup = req.GetSkipCacheLookup()
+	task.DoNotCache = req.Action.GetDoNotCache()
+
+	result.JobType = bazel.ExecutionJobType
+	result.Tag = req.GetInstanceName()
+	result.Tasks = []sched.TaskDefinition{task}
+	return result, nil
+}
+
+// Creates a LongRunning Operation representing the current state of a job created by Execute.
+// The ExecuteOperationMetadata stage progresses from QUEUED to EXECUTING to COMPLETED as the saga advances.
+// Once completed, the Operation contains either an ExecuteResponse or an error describing why
+// the Action could not be run to completion. The ExecuteResponse's ActionResult is built from the
+// run's result snapshot once, see getJobActionResult.
+func (s *executionServer) operationFromSagaState(jobID string, state *saga.SagaState) (*googlelongrunning.Operation, error) {
+	eom := remoteexecution.ExecuteOperationMetadata{Stage: remoteexecution.ExecuteOperationMetadata_QUEUED}
+
+	// No logged Saga messages, the job has not been accepted yet
+	if state == nil {
+		return makeOperation(jobID, &eom, nil)
+	}
+
+	task, err := getTask(state)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	taskID := task.TaskID
+	if actionDigest, err := bazel.DigestFromStr(taskID); err == nil {
+		eom.ActionDigest = actionDigest
+	}
+
+	if state.IsSagaAborted() {
+		eom.Stage = remoteexecution.ExecuteOperationMetadata_COMPLETED
+		return makeOperation(jobID, &eom, status.New(codes.Canceled, "Execution was aborted"))
+	}
+	if !state.IsTaskStarted(taskID) {
+		return makeOperation(jobID, &eom, nil)
+	}
+	if !state.IsTaskCompleted(taskID) {
+		eom.Stage = remoteexecution.ExecuteOperationMetadata_EXECUTING
+		return makeOperation(jobID, &eom, nil)
+	}
 
-	eom := remoteexecution.ExecuteOperationMetadata{}
 	eom.Stage = remoteexecution.ExecuteOperationMetadata_COMPLETED
-	eom.ActionDigest = &remoteexecution.Digest{Hash: actionSha, SizeBytes: actionLen}
+	rs, err := workerapi.DeserializeProcessStatus(state.GetEndTaskData(taskID))
+	if err != nil {
+		return makeOperation(jobID, &eom, status.New(codes.Internal, fmt.Sprintf("Failed to read task result: %v", err)))
+	}
+	if st := runStatusToGRPCStatus(rs); st != nil {
+		return makeOperation(jobID, &eom, st)
+	}
+
+	ar, err := s.getJobActionResult(jobID, rs, task.Command.Outputs)
+	if err != nil {
+		return makeOperation(jobID, &eom, status.New(codes.Internal, fmt.Sprintf("Failed to read task result: %v", err)))
+	}
+	res := remoteexecution.ExecuteResponse{
+		Result:       ar,
+		CachedResult: false,
+	}
+	return makeResponseOperation(jobID, &eom, &res)
+}
+
+// Returns the single task in a job created by Execute
+func getTask(state *saga.SagaState) (sched.TaskDefinition, error) {
+	job, err := sched.DeserializeJob(state.Job())
+	if err != nil {
+		return sched.TaskDefinition{}, fmt.Errorf("Failed to deserialize job %s: %v", state.SagaId(), err)
+	}
+	if len(job.Def.Tasks) != 1 {
+		return sched.TaskDefinition{}, fmt.Errorf("Expected job %s to have 1 task, got %d", state.SagaId(), len(job.Def.Tasks))
+	}
+	return job.Def.Tasks[0], nil
+}
+
+// Returns a status describing why a completed run did not produce an ActionResult,
+// or nil if the command ran to completion (regardless of its exit code).
+func runStatusToGRPCStatus(rs runner.RunStatus) *status.Status {
+	switch rs.State {
+	case runner.COMPLETE:
+		return nil
+	case runner.TIMEDOUT:
+		return status.New(codes.DeadlineExceeded, "Execution timed out")
+	case runner.ABORTED:
+		return status.New(codes.Canceled, "Execution was aborted")
+	case runner.BADREQUEST:
+		return status.New(codes.InvalidArgument, fmt.Sprintf("Bad request: %s", rs.Error))
+	default:
+		return status.New(codes.Internal, fmt.Sprintf("Execution failed in state %s: %s", rs.State, rs.Error))
+	}
+}
+
+// Creates an Operation with the given metadata. Operations with a COMPLETED stage are done,
+// and include the error status if one is provided.
+func makeOperation(
+	jobID string,
+	eom *remoteexecution.ExecuteOperationMetadata,
+	st *status.Status) (*googlelongrunning.Operation, error) {
+	op := googlelongrunning.Operation{Name: jobID}
 
 	// Marshal ExecuteActionMetadata to protobuf.Any format
-	eomAsPBAny, err := ptypes.MarshalAny(&eom)
+	eomAsPBAny, err := ptypes.MarshalAny(eom)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to marshal ExecuteOperationMetadata as ptypes/any.Any: %v", err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to marshal ExecuteOperationMetadata as ptypes/any.Any: %v", err))
 	}
 	op.Metadata = eomAsPBAny
 
-	res := remoteexecution.ExecuteResponse{}
-	ar := remoteexecution.ActionResult{}
-	ar.ExitCode = 0
-	res.Result = &ar
-	res.CachedResult = false
+	op.Done = eom.Stage == remoteexecution.ExecuteOperationMetadata_COMPLETED
+	if op.Done && st != nil {
+		op.Result = &googlelongrunning.Operation_Error{Error: st.Proto()}
+	}
+	return &op, nil
+}
+
+// Creates a done Operation with the given metadata and ExecuteResponse
+func makeResponseOperation(
+	name string,
+	eom *remoteexecution.ExecuteOperationMetadata,
+	res *remoteexecution.ExecuteResponse) (*googlelongrunning.Operation, error) {
+	op, err := makeOperation(name, eom, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	// Marshal ExecuteResponse to protobuf.Any format
-	resAsPBAny, err := ptypes.MarshalAny(&res)
+	resAsPBAny, err := ptypes.MarshalAny(res)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to marshal ExecuteResponse as ptypes/any.Any: %v", err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to marshal ExecuteResponse as ptypes/any.Any: %v", err))
 	}
 
 	// Include the response message in the longrunning operation message
 	op.Result = &googlelongrunning.Operation_Response{Response: resAsPBAny}
-	return &op, nil
+	return op, nil
 }
@@ -1,69 +1,556 @@
 package execution
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"golang.org/x/net/context"
 	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+	googlelongrunning "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
+	"github.com/twitter/scoot/common/log/tags"
 	scootproto "github.com/twitter/scoot/common/proto"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+	"github.com/twitter/scoot/snapshot/git/gitdb"
+	"github.com/twitter/scoot/snapshot/git/repo"
+	"github.com/twitter/scoot/workerapi"
 )
 
-// Determine that Execute can accept a well-formed request and returns a well-formed response
-func TestExecuteStub(t *testing.T) {
-	s := executionServer{}
-	ctx := context.Background()
+func makeTestServer(t *testing.T, s scheduler.Scheduler) *executionServer {
+	tmp, err := temp.NewTempDir("", "execution_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	store, err := bundlestore.MakeFileStoreInTemp(tmp)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	dataRepo, err := repo.InitRepo(filepath.Join(tmp.Dir, "repo"))
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+	// Bundles of ingested snapshots are made from commits, which need an author
+	for _, kv := range [][]string{{"user.name", "Scoot Test"}, {"user.email", "scoottest@twitter.github.io"}} {
+		if _, err := dataRepo.Run("config", kv[0], kv[1]); err != nil {
+			t.Fatalf("Failed to configure repo: %v", err)
+		}
+	}
+	db := gitdb.MakeDBFromRepo(dataRepo, nil, tmp, nil, nil,
+		&gitdb.BundlestoreConfig{Store: store}, gitdb.AutoUploadBundlestore, stats.NilStatsReceiver())
+	return &executionServer{
+		scheduler: s,
+		sagaCoord: sagalogs.MakeInMemorySagaCoordinator(),
+		store:     store,
+		db:        db,
+		tmp:       tmp,
+		stat:      stats.NilStatsReceiver(),
+		results:   make(map[string]*pendingResult),
+	}
+}
 
-	cmd := remoteexecution.Command{Arguments: []string{"/bin/true"}}
-	cmdSha, cmdLen, err := scootproto.GetSha256(&cmd)
+// Writes data to the server's store and returns its digest
+func writeTestBlob(t *testing.T, s *executionServer, data []byte) *remoteexecution.Digest {
+	sum := sha256.Sum256(data)
+	digest := &remoteexecution.Digest{Hash: hex.EncodeToString(sum[:]), SizeBytes: int64(len(data))}
+	if err := s.store.Write(bazel.DigestStoreName(digest), bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("Failed to write blob: %v", err)
+	}
+	return digest
+}
+
+func writeTestMessage(t *testing.T, s *executionServer, m proto.Message) *remoteexecution.Digest {
+	data, err := proto.Marshal(m)
 	if err != nil {
-		t.Errorf("Failed to get sha: %v", err)
+		t.Fatalf("Failed to marshal %v: %v", m, err)
 	}
-	dir := remoteexecution.Directory{}
-	dirSha, dirLen, err := scootproto.GetSha256(&dir)
+	return writeTestBlob(t, s, data)
+}
+
+func readTestBlob(t *testing.T, s *executionServer, digest *remoteexecution.Digest) []byte {
+	data, err := s.readBlob(digest, "test")
 	if err != nil {
-		t.Errorf("Failed to get sha: %v", err)
+		t.Fatalf("Failed to read blob %s: %v", digest, err)
+	}
+	return data
+}
+
+// Writes the Command to the server's store and returns a request for an Action referencing it
+func makeTestRequest(t *testing.T, s *executionServer, cmd *remoteexecution.Command) *remoteexecution.ExecuteRequest {
+	cmdSha, cmdLen, err := scootproto.GetSha256(cmd)
+	if err != nil {
+		t.Fatalf("Failed to get sha: %v", err)
+	}
+	cmdDigest := &remoteexecution.Digest{Hash: cmdSha, SizeBytes: cmdLen}
+	data, err := proto.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Failed to marshal command: %v", err)
+	}
+	if err := s.store.Write(bazel.DigestStoreName(cmdDigest), bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("Failed to write command: %v", err)
 	}
 
 	a := remoteexecution.Action{
-		CommandDigest:   &remoteexecution.Digest{Hash: cmdSha, SizeBytes: cmdLen},
-		InputRootDigest: &remoteexecution.Digest{Hash: dirSha, SizeBytes: dirLen},
+		CommandDigest:   cmdDigest,
+		InputRootDigest: &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: bazel.EmptySize},
+		Timeout:         ptypes.DurationProto(10 * time.Second),
 	}
-	req := remoteexecution.ExecuteRequest{
+	return &remoteexecution.ExecuteRequest{
 		Action:              &a,
 		InstanceName:        "test",
 		SkipCacheLookup:     true,
 		TotalInputFileCount: 0,
 		TotalInputFileBytes: 0,
 	}
+}
+
+// Determine that Execute translates a well-formed request into a job and returns a queued operation
+func TestExecute(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	s := makeTestServer(t, sc)
+
+	cmd := remoteexecution.Command{
+		Arguments:            []string{"/bin/echo", "hello"},
+		EnvironmentVariables: []*remoteexecution.Command_EnvironmentVariable{{Name: "FOO", Value: "bar"}},
+	}
+	req := makeTestRequest(t, s, &cmd)
+
+	var jobDef sched.JobDefinition
+	sc.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		jobDef = def
+	}).Return("job1", nil)
+	sc.EXPECT().WatchJob("job1", gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	res, err := s.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Non-nil error from Execute: %v", err)
+	}
+	if res.GetName() != "job1" {
+		t.Errorf("Expected operation name job1, got %s", res.GetName())
+	}
+	if res.GetDone() {
+		t.Error("Expected response to not be done")
+	}
+	metadata := remoteexecution.ExecuteOperationMetadata{}
+	if err := ptypes.UnmarshalAny(res.GetMetadata(), &metadata); err != nil {
+		t.Fatalf("Failed to unmarshal metadata from any: %v", err)
+	}
+	if metadata.GetStage() != remoteexecution.ExecuteOperationMetadata_QUEUED {
+		t.Errorf("Expected stage QUEUED, got %s", metadata.GetStage())
+	}
 
-	res, err := s.Execute(ctx, &req)
+	if len(jobDef.Tasks) != 1 {
+		t.Fatalf("Expected 1 task, got %d", len(jobDef.Tasks))
+	}
+	task := jobDef.Tasks[0]
+	if task.TaskID != bazel.DigestToStr(metadata.GetActionDigest()) {
+		t.Errorf("Expected TaskID to be action digest %s, got %s", metadata.GetActionDigest(), task.TaskID)
+	}
+	if len(task.Argv) != 2 || task.Argv[0] != "/bin/echo" || task.Argv[1] != "hello" {
+		t.Errorf("Unexpected argv: %v", task.Argv)
+	}
+	if task.EnvVars["FOO"] != "bar" {
+		t.Errorf("Unexpected env vars: %v", task.EnvVars)
+	}
+	if task.Timeout.Seconds() != 10 {
+		t.Errorf("Unexpected timeout: %v", task.Timeout)
+	}
+	if task.SnapshotID != "" {
+		t.Errorf("Expected empty SnapshotID for empty input root, got %s", task.SnapshotID)
+	}
+	if !task.SkipCacheLookup || task.DoNotCache {
+		t.Errorf("Expected the request's cache flags, got SkipCacheLookup=%t DoNotCache=%t", task.SkipCacheLookup, task.DoNotCache)
+	}
+}
+
+// Determine that Execute ingests the input root from CAS into a snapshot the task runs against
+func TestExecuteInputRoot(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	s := makeTestServer(t, sc)
+	defer os.RemoveAll(s.tmp.Dir)
+
+	bin := &remoteexecution.Directory{
+		Files: []*remoteexecution.FileNode{{Name: "run.sh", Digest: writeTestBlob(t, s, []byte("echo hi")), IsExecutable: true}},
+	}
+	root := &remoteexecution.Directory{
+		Files:       []*remoteexecution.FileNode{{Name: "a.txt", Digest: writeTestBlob(t, s, []byte("hello"))}},
+		Directories: []*remoteexecution.DirectoryNode{{Name: "bin", Digest: writeTestMessage(t, s, bin)}},
+	}
+	req := makeTestRequest(t, s, &remoteexecution.Command{Arguments: []string{"bin/run.sh"}})
+	req.Action.InputRootDigest = writeTestMessage(t, s, root)
+	req.Action.OutputFiles = []string{"out.txt"}
+	req.Action.OutputDirectories = []string{"out"}
+
+	var jobDefs []sched.JobDefinition
+	sc.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		jobDefs = append(jobDefs, def)
+	}).Return("job1", nil).Times(2)
+	sc.EXPECT().WatchJob("job1", gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	for i := 0; i < 2; i++ {
+		if _, err := s.Execute(context.Background(), req); err != nil {
+			t.Fatalf("Non-nil error from Execute: %v", err)
+		}
+	}
+
+	task := jobDefs[0].Tasks[0]
+	if task.SnapshotID == "" || jobDefs[1].Tasks[0].SnapshotID != task.SnapshotID {
+		t.Fatalf("Expected both tasks to use the input root's snapshot, got %q and %q",
+			task.SnapshotID, jobDefs[1].Tasks[0].SnapshotID)
+	}
+	if len(task.Outputs) != 2 || task.Outputs[0] != "out.txt" || task.Outputs[1] != "out" {
+		t.Errorf("Expected the Action's outputs, got %v", task.Outputs)
+	}
+	path, err := s.db.Checkout(snap.ID(task.SnapshotID))
 	if err != nil {
-		t.Errorf("Non-nil error from Execute: %v", err)
+		t.Fatalf("Failed to checkout input root snapshot: %v", err)
+	}
+	defer s.db.ReleaseCheckout(path)
+	if data, err := ioutil.ReadFile(filepath.Join(path, "a.txt")); err != nil || string(data) != "hello" {
+		t.Errorf("Expected a.txt in the input root, got %q %v", data, err)
+	}
+	if fi, err := os.Stat(filepath.Join(path, "bin", "run.sh")); err != nil || fi.Mode()&0100 == 0 {
+		t.Errorf("Expected an executable bin/run.sh in the input root, got %v %v", fi, err)
+	}
+
+	// Input roots referencing blobs that weren't uploaded are rejected
+	req.Action.InputRootDigest = writeTestMessage(t, s, &remoteexecution.Directory{
+		Files: []*remoteexecution.FileNode{{Name: "missing", Digest: &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 1}}},
+	})
+	if _, err := s.Execute(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected a missing input to fail the precondition, got %v", err)
+	}
+}
+
+// Determine that Execute rejects an Action whose Command was not uploaded
+func TestExecuteMissingCommand(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := makeTestServer(t, scheduler.NewMockScheduler(mockCtrl))
+
+	req := makeTestRequest(t, s, &remoteexecution.Command{Arguments: []string{"/bin/true"}})
+	req.Action.CommandDigest = &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 1}
+
+	_, err := s.Execute(context.Background(), req)
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		t.Fatalf("Expected grpc status error, got: %v", err)
 	}
+	if st.Code() != codes.FailedPrecondition {
+		t.Errorf("Expected status code %d, got: %d", codes.FailedPrecondition, st.Code())
+	}
+}
 
-	done := res.GetDone()
-	if !done {
-		t.Error("Expected response to be done")
+// Determine that the operation stage follows the saga from QUEUED to EXECUTING to COMPLETED
+func TestOperationFromSagaState(t *testing.T) {
+	server := makeTestServer(t, nil)
+	defer os.RemoveAll(server.tmp.Dir)
+	sc := server.sagaCoord
+	actionDigest := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 10}
+	taskID := bazel.DigestToStr(actionDigest)
+	job := sched.Job{
+		Id: "job1",
+		Def: sched.JobDefinition{
+			JobType: bazel.ExecutionJobType,
+			Tasks: []sched.TaskDefinition{
+				sched.TaskDefinition{Command: runner.Command{Argv: []string{"/bin/true"}}},
+			},
+		},
 	}
-	metadataAny := res.GetMetadata()
-	if metadataAny == nil {
-		t.Errorf("Nil metadata from operation: %s", res)
+	job.Def.Tasks[0].TaskID = taskID
+	jobData, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("Failed to serialize job: %v", err)
 	}
-	execResAny := res.GetResponse()
-	if execResAny == nil {
-		t.Errorf("Nil response from operation: %s", res)
+	s, err := sc.MakeSaga(job.Id, jobData)
+	if err != nil {
+		t.Fatalf("Failed to make saga: %v", err)
 	}
 
-	metadata := remoteexecution.ExecuteOperationMetadata{}
+	checkStage := func(expected remoteexecution.ExecuteOperationMetadata_Stage, done bool) {
+		op, err := server.operationFromSagaState(job.Id, s.GetState())
+		if err != nil {
+			t.Fatalf("Non-nil error from operationFromSagaState: %v", err)
+		}
+		metadata := remoteexecution.ExecuteOperationMetadata{}
+		if err := ptypes.UnmarshalAny(op.GetMetadata(), &metadata); err != nil {
+			t.Fatalf("Failed to unmarshal metadata from any: %v", err)
+		}
+		if metadata.GetStage() != expected {
+			t.Errorf("Expected stage %s, got %s", expected, metadata.GetStage())
+		}
+		if bazel.DigestToStr(metadata.GetActionDigest()) != taskID {
+			t.Errorf("Expected action digest %s, got %s", taskID, metadata.GetActionDigest())
+		}
+		if op.GetDone() != done {
+			t.Errorf("Expected done to be %t, got %t", done, op.GetDone())
+		}
+	}
+
+	checkStage(remoteexecution.ExecuteOperationMetadata_QUEUED, false)
+
+	s.StartTask(taskID, nil)
+	checkStage(remoteexecution.ExecuteOperationMetadata_EXECUTING, false)
+
+	statusData, err := workerapi.SerializeProcessStatus(runner.CompleteStatus(runner.RunID("run1"), "", 3, tags.LogTags{JobID: job.Id, TaskID: taskID}))
+	if err != nil {
+		t.Fatalf("Failed to serialize status: %v", err)
+	}
+	s.EndTask(taskID, statusData)
+	s.EndSaga()
+	checkStage(remoteexecution.ExecuteOperationMetadata_COMPLETED, true)
+
+	op, _ := server.operationFromSagaState(job.Id, s.GetState())
+	execRes := remoteexecution.ExecuteResponse{}
+	if err := ptypes.UnmarshalAny(op.GetResponse(), &execRes); err != nil {
+		t.Fatalf("Failed to unmarshal response from any: %v", err)
+	}
+	if execRes.GetResult().GetExitCode() != 3 {
+		t.Errorf("Expected exit code 3, got %d", execRes.GetResult().GetExitCode())
+	}
+}
+
+// Determine that a completed operation's ActionResult refers to the stdout, stderr and outputs in CAS
+func TestActionResult(t *testing.T) {
+	s := makeTestServer(t, nil)
+	defer os.RemoveAll(s.tmp.Dir)
+
+	// A result snapshot like the one a worker ingests
+	resultDir := filepath.Join(s.tmp.Dir, "result")
+	for path, data := range map[string]string{
+		"STDOUT":        "out",
+		"STDERR":        "",
+		"out.txt":       "file",
+		"out/a":         "a",
+		"out/sub/b":     "b",
+		"not-an-output": "x",
+	} {
+		os.MkdirAll(filepath.Dir(filepath.Join(resultDir, path)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(resultDir, path), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snapshotID, err := s.db.IngestDir(resultDir)
+	if err != nil {
+		t.Fatalf("Failed to ingest result: %v", err)
+	}
+
+	rs := runner.CompleteStatus(runner.RunID("run1"), string(snapshotID), 0, tags.LogTags{})
+	res, err := s.getActionResult(rs, []string{"out.txt", "out", "missing"})
+	if err != nil {
+		t.Fatalf("Failed to get ActionResult: %v", err)
+	}
+	if data := readTestBlob(t, s, res.GetStdoutDigest()); string(data) != "out" {
+		t.Errorf("Expected stdout %q, got %q", "out", data)
+	}
+	if !bazel.IsEmptyDigest(res.GetStderrDigest()) {
+		t.Errorf("Expected empty stderr, got %s", res.GetStderrDigest())
+	}
+	if len(res.GetOutputFiles()) != 1 || res.GetOutputFiles()[0].GetPath() != "out.txt" ||
+		string(readTestBlob(t, s, res.GetOutputFiles()[0].GetDigest())) != "file" {
+		t.Fatalf("Expected output file out.txt, got %v", res.GetOutputFiles())
+	}
+	if len(res.GetOutputDirectories()) != 1 || res.GetOutputDirectories()[0].GetPath() != "out" {
+		t.Fatalf("Expected output directory out, got %v", res.GetOutputDirectories())
+	}
+	tree := &remoteexecution.Tree{}
+	if err := proto.Unmarshal(readTestBlob(t, s, res.GetOutputDirectories()[0].GetTreeDigest()), tree); err != nil {
+		t.Fatalf("Failed to unmarshal Tree: %v", err)
+	}
+	root := tree.GetRoot()
+	if len(root.GetFiles()) != 1 || root.GetFiles()[0].GetName() != "a" ||
+		len(root.GetDirectories()) != 1 || root.GetDirectories()[0].GetName() != "sub" || len(tree.GetChildren()) != 1 {
+		t.Fatalf("Unexpected Tree for output directory: %v", tree)
+	}
+	if sub := tree.GetChildren()[0]; len(sub.GetFiles()) != 1 || string(readTestBlob(t, s, sub.GetFiles()[0].GetDigest())) != "b" {
+		t.Fatalf("Unexpected subdirectory in Tree: %v", sub)
+	}
+}
+
+// Logs a saga for a job created by Execute, whose task ran to completion with the given result snapshot
+func makeCompletedTestSaga(t *testing.T, s *executionServer, jobID, snapshotID string) {
+	actionDigest := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 10}
+	taskID := bazel.DigestToStr(actionDigest)
+	job := sched.Job{
+		Id: jobID,
+		Def: sched.JobDefinition{
+			JobType: bazel.ExecutionJobType,
+			Tasks: []sched.TaskDefinition{
+				sched.TaskDefinition{Command: runner.Command{Argv: []string{"/bin/true"}, Outputs: []string{"out.txt"}}},
+			},
+		},
+	}
+	job.Def.Tasks[0].TaskID = taskID
+	jobData, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("Failed to serialize job: %v", err)
+	}
+	sg, err := s.sagaCoord.MakeSaga(jobID, jobData)
+	if err != nil {
+		t.Fatalf("Failed to make saga: %v", err)
+	}
+	statusData, err := workerapi.SerializeProcessStatus(runner.CompleteStatus(runner.RunID("run1"), snapshotID, 0, tags.LogTags{JobID: jobID, TaskID: taskID}))
+	if err != nil {
+		t.Fatalf("Failed to serialize status: %v", err)
+	}
+	sg.StartTask(taskID, nil)
+	sg.EndTask(taskID, statusData)
+	sg.EndSaga()
+}
+
+// Returns the ActionResult in the response of the job's Operation
+func getTestActionResult(t *testing.T, s *executionServer, jobID string) *remoteexecution.ActionResult {
+	op, err := s.getOperation(jobID)
+	if err != nil {
+		t.Fatalf("Non-nil error from getOperation: %v", err)
+	}
 	execRes := remoteexecution.ExecuteResponse{}
-	err = ptypes.UnmarshalAny(metadataAny, &metadata)
+	if err := ptypes.UnmarshalAny(op.GetResponse(), &execRes); err != nil {
+		t.Fatalf("Failed to unmarshal response from any: %v, got %v", err, op)
+	}
+	return execRes.GetResult()
+}
+
+// Determine that a completed job's ActionResult is built once and stored, and later polls return the stored result
+func TestJobActionResultStored(t *testing.T) {
+	s := makeTestServer(t, nil)
+	defer os.RemoveAll(s.tmp.Dir)
+
+	resultDir := filepath.Join(s.tmp.Dir, "result")
+	os.MkdirAll(resultDir, 0755)
+	for name, data := range map[string]string{"STDOUT": "out", "STDERR": "", "out.txt": "file"} {
+		if err := ioutil.WriteFile(filepath.Join(resultDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snapshotID, err := s.db.IngestDir(resultDir)
 	if err != nil {
-		t.Errorf("Failed to unmarshal metadata from any: %v", err)
+		t.Fatalf("Failed to ingest result: %v", err)
+	}
+	makeCompletedTestSaga(t, s, "job1", string(snapshotID))
+
+	res := getTestActionResult(t, s, "job1")
+	if len(res.GetOutputFiles()) != 1 || string(readTestBlob(t, s, res.GetOutputFiles()[0].GetDigest())) != "file" {
+		t.Fatalf("Expected output file out.txt, got %v", res.GetOutputFiles())
+	}
+	stored, err := s.readJobActionResult(bazel.JobResultStoreName("job1"))
+	if err != nil || !proto.Equal(stored, res) {
+		t.Fatalf("Expected the ActionResult to be stored, got %v %v", stored, err)
 	}
-	err = ptypes.UnmarshalAny(execResAny, &execRes)
+
+	// Replace the stored result, polls should return it rather than build the result again
+	data, _ := proto.Marshal(&remoteexecution.ActionResult{ExitCode: 42})
+	if err := s.store.Write(bazel.JobResultStoreName("job1"), bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+	if res := getTestActionResult(t, s, "job1"); res.GetExitCode() != 42 {
+		t.Errorf("Expected the stored result with exit code 42, got %v", res)
+	}
+}
+
+// Determine that a job is watched until it finishes and its ActionResult is stored without being polled,
+// and also stored in the ActionCache unless the Action sets DoNotCache
+func TestWatchJob(t *testing.T) {
+	for _, doNotCache := range []bool{false, true} {
+		mockCtrl := gomock.NewController(t)
+		sc := scheduler.NewMockScheduler(mockCtrl)
+		s := makeTestServer(t, sc)
+		actionDigest := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 10}
+
+		gomock.InOrder(
+			sc.EXPECT().WatchJob("job1", int64(0), DefaultWaitTimeout).Return(&scheduler.JobUpdate{JobId: "job1", Version: 5, Status: sched.InProgress}, nil),
+			sc.EXPECT().WatchJob("job1", int64(5), DefaultWaitTimeout).Do(func(string, int64, time.Duration) {
+				makeCompletedTestSaga(t, s, "job1", "")
+			}).Return(&scheduler.JobUpdate{JobId: "job1", Version: 6, Status: sched.Completed}, nil),
+		)
+		s.watchJob("job1", actionDigest, doNotCache)
+
+		if res, err := s.readJobActionResult(bazel.JobResultStoreName("job1")); err != nil || res == nil {
+			t.Fatalf("Expected the ActionResult to be stored, got %v %v", res, err)
+		}
+		if res, err := cas.ReadActionResult(s.store, actionDigest); err != nil || (res == nil) != doNotCache {
+			t.Errorf("Expected the ActionResult to be cached unless DoNotCache=%t, got %v %v", doNotCache, res, err)
+		}
+		mockCtrl.Finish()
+		os.RemoveAll(s.tmp.Dir)
+	}
+}
+
+// Determine that Execute answers an Action from the ActionCache without running it, unless SkipCacheLookup is set
+func TestExecuteCacheLookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	s := makeTestServer(t, sc)
+	defer os.RemoveAll(s.tmp.Dir)
+
+	req := makeTestRequest(t, s, &remoteexecution.Command{Arguments: []string{"/bin/true"}})
+	req.SkipCacheLookup = false
+	actionSha, actionLen, err := scootproto.GetSha256(req.Action)
+	if err != nil {
+		t.Fatalf("Failed to get sha: %v", err)
+	}
+	actionDigest := &remoteexecution.Digest{Hash: actionSha, SizeBytes: actionLen}
+	if err := cas.WriteActionResult(s.store, actionDigest, &remoteexecution.ActionResult{ExitCode: 0, StdoutDigest: actionDigest}); err != nil {
+		t.Fatalf("Failed to write ActionResult: %v", err)
+	}
+
+	// No job is scheduled for a cached Action, and its Operation can be read back
+	op, err := s.Execute(context.Background(), req)
 	if err != nil {
-		t.Errorf("Failed to unmarshal response from any: %v", err)
+		t.Fatalf("Non-nil error from Execute: %v", err)
+	}
+	if !op.GetDone() || op.GetName() != bazel.DigestToStr(actionDigest) {
+		t.Fatalf("Expected a done operation named by the action digest, got %v", op)
+	}
+	for _, o := range []*googlelongrunning.Operation{op, nil} {
+		if o == nil {
+			if o, err = s.GetOperation(context.Background(), &googlelongrunning.GetOperationRequest{Name: op.GetName()}); err != nil {
+				t.Fatalf("Non-nil error from GetOperation: %v", err)
+			}
+		}
+		execRes := remoteexecution.ExecuteResponse{}
+		if err := ptypes.UnmarshalAny(o.GetResponse(), &execRes); err != nil {
+			t.Fatalf("Failed to unmarshal response from any: %v", err)
+		}
+		if !execRes.GetCachedResult() || !proto.Equal(execRes.GetResult().GetStdoutDigest(), actionDigest) {
+			t.Errorf("Expected the cached result, got %v", execRes)
+		}
+	}
+
+	// The Action is run when the cache lookup is skipped, and the scheduler is told to skip its own cache
+	req.SkipCacheLookup = true
+	var jobDef sched.JobDefinition
+	sc.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		jobDef = def
+	}).Return("job1", nil)
+	sc.EXPECT().WatchJob("job1", gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	if op, err := s.Execute(context.Background(), req); err != nil || op.GetDone() {
+		t.Fatalf("Expected the Action to be scheduled, got %v %v", op, err)
+	}
+	if !jobDef.Tasks[0].SkipCacheLookup {
+		t.Errorf("Expected the task to skip the scheduler's cache lookup, got %+v", jobDef.Tasks[0])
 	}
 }
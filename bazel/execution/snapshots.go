@@ -0,0 +1,311 @@
+package execution
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	remoteexecution "google.golang.org/genproto/googleapis/devtools/remoteexecution/v1test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
+	scootproto "github.com/twitter/scoot/common/proto"
+	"github.com/twitter/scoot/runner"
+	snap "github.com/twitter/scoot/snapshot"
+)
+
+// Input roots and results are translated between the CAS and Scoot snapshots here, in the scheduler,
+// so workers only deal with snapshots they already know how to check out and ingest.
+
+// Returns the ID of a Scoot snapshot with the contents of the input root. The input root's Directories
+// and files are read from the CAS and ingested the first time it's used, and the snapshot ID is stored
+// next to them for later Actions with the same input root. Empty input roots have no snapshot.
+func (s *executionServer) getInputRootSnapshot(digest *remoteexecution.Digest) (string, error) {
+	if bazel.IsEmptyDigest(digest) {
+		return "", nil
+	}
+	name := bazel.InputRootStoreName(digest)
+	if exists, err := s.store.Exists(name); err != nil {
+		return "", status.Error(codes.Internal, fmt.Sprintf("Failed to check for input root %s: %v", name, err))
+	} else if exists {
+		if r, err := s.store.OpenForRead(name); err == nil {
+			defer r.Close()
+			if id, err := ioutil.ReadAll(r); err == nil && len(id) > 0 {
+				return string(id), nil
+			}
+		}
+		log.Infof("Failed to read input root %s, ingesting it again", name)
+	}
+
+	dir, err := s.tmp.TempDir("input-root-")
+	if err != nil {
+		return "", status.Error(codes.Internal, fmt.Sprintf("Failed to create input root dir: %v", err))
+	}
+	defer os.RemoveAll(dir.Dir)
+	if err := s.writeDirectory(digest, dir.Dir); err != nil {
+		return "", err
+	}
+	id, err := s.db.IngestDir(dir.Dir)
+	if err != nil {
+		return "", status.Error(codes.Internal, fmt.Sprintf("Failed to ingest input root %s: %v", bazel.DigestToStr(digest), err))
+	}
+	if err := s.store.Write(name, strings.NewReader(string(id)), nil); err != nil {
+		log.Errorf("Failed to store snapshot %s of input root %s: %v", id, name, err)
+	}
+	return string(id), nil
+}
+
+// Writes the Directory with the given digest, and the files and Directories it contains, into dir.
+// Git doesn't keep empty directories, so they are left out of the snapshot.
+func (s *executionServer) writeDirectory(digest *remoteexecution.Digest, dir string) error {
+	data, err := s.readBlob(digest, "Directory")
+	if err != nil {
+		return err
+	}
+	d := &remoteexecution.Directory{}
+	if err := proto.Unmarshal(data, d); err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Failed to unmarshal Directory %s: %v", bazel.DigestToStr(digest), err))
+	}
+
+	for _, f := range d.GetFiles() {
+		if err := validateName(f.GetName()); err != nil {
+			return err
+		}
+		mode := os.FileMode(0644)
+		if f.GetIsExecutable() {
+			mode = 0755
+		}
+		if err := s.writeFile(f.GetDigest(), filepath.Join(dir, f.GetName()), mode); err != nil {
+			return err
+		}
+	}
+	for _, c := range d.GetDirectories() {
+		if err := validateName(c.GetName()); err != nil {
+			return err
+		}
+		path := filepath.Join(dir, c.GetName())
+		if err := os.Mkdir(path, 0755); err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Failed to create directory %s: %v", c.GetName(), err))
+		}
+		if err := s.writeDirectory(c.GetDigest(), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes the file with the given digest to path
+func (s *executionServer) writeFile(digest *remoteexecution.Digest, path string, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Failed to create file %s: %v", filepath.Base(path), err))
+	}
+	defer f.Close()
+	if bazel.IsEmptyDigest(digest) {
+		return nil
+	}
+	r, err := s.openBlob(digest, "File")
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if n, err := io.Copy(f, r); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("Failed to read File %s: %v", bazel.DigestToStr(digest), err))
+	} else if n != digest.GetSizeBytes() {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("File %s has %d bytes in CAS", bazel.DigestToStr(digest), n))
+	}
+	return nil
+}
+
+// Directory entries must be a single path component
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsRune(name, '/') {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid name %q in input root", name))
+	}
+	return nil
+}
+
+// Opens the blob with the given digest in the store. The blob must have been uploaded by the client
+// beforehand, and a missing blob is reported as a failed precondition. Kind describes the blob in errors.
+func (s *executionServer) openBlob(digest *remoteexecution.Digest, kind string) (io.ReadCloser, error) {
+	if digest == nil || !bazel.IsValidDigest(digest.GetHash(), digest.GetSizeBytes()) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid %s digest %s", kind, digest))
+	}
+	name := bazel.DigestStoreName(digest)
+	exists, err := s.store.Exists(name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to check for %s %s: %v", kind, name, err))
+	}
+	if !exists {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("%s %s not found in CAS", kind, name))
+	}
+	r, err := s.store.OpenForRead(name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to open %s %s: %v", kind, name, err))
+	}
+	return r, nil
+}
+
+// Reads the blob with the given digest from the store, see openBlob. Empty blobs aren't stored.
+func (s *executionServer) readBlob(digest *remoteexecution.Digest, kind string) ([]byte, error) {
+	if bazel.IsEmptyDigest(digest) {
+		return nil, nil
+	}
+	r, err := s.openBlob(digest, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to read %s %s: %v", kind, bazel.DigestToStr(digest), err))
+	}
+	return data, nil
+}
+
+// Returns the ActionResult of a run that completed. Its stdout, stderr and the outputs the Action
+// declared are read from the run's result snapshot and uploaded to the CAS, so Bazel can fetch them
+// by digest. Declared outputs the run didn't create are left out, Bazel reports them as missing.
+func (s *executionServer) getActionResult(rs runner.RunStatus, outputs []string) (*remoteexecution.ActionResult, error) {
+	res := &remoteexecution.ActionResult{ExitCode: int32(rs.ExitCode)}
+	if rs.SnapshotID == "" {
+		return res, nil
+	}
+	path, err := s.db.Checkout(snap.ID(rs.SnapshotID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to checkout result snapshot %s: %v", rs.SnapshotID, err)
+	}
+	defer s.db.ReleaseCheckout(path)
+
+	if res.StdoutDigest, err = s.uploadFile(filepath.Join(path, runner.StdoutName)); err != nil {
+		return nil, err
+	}
+	if res.StderrDigest, err = s.uploadFile(filepath.Join(path, runner.StderrName)); err != nil {
+		return nil, err
+	}
+	for _, o := range outputs {
+		fi, err := os.Lstat(filepath.Join(path, o))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		switch {
+		case fi.IsDir():
+			tree := &remoteexecution.Tree{}
+			if tree.Root, err = s.uploadDirectory(filepath.Join(path, o), tree); err != nil {
+				return nil, err
+			}
+			d, err := s.uploadMessage(tree)
+			if err != nil {
+				return nil, err
+			}
+			res.OutputDirectories = append(res.OutputDirectories, &remoteexecution.OutputDirectory{Path: o, TreeDigest: d})
+		case fi.Mode().IsRegular():
+			d, err := s.uploadFile(filepath.Join(path, o))
+			if err != nil {
+				return nil, err
+			}
+			res.OutputFiles = append(res.OutputFiles,
+				&remoteexecution.OutputFile{Path: o, Digest: d, IsExecutable: fi.Mode()&0111 != 0})
+		default:
+			log.Infof("Skipping output %s of %s, it isn't a file or directory", o, rs.SnapshotID)
+		}
+	}
+	return res, nil
+}
+
+// Uploads the files in dir and returns the Directory describing it, adding the Directories
+// of its subdirectories to tree. Entries that aren't files or directories are skipped.
+func (s *executionServer) uploadDirectory(dir string, tree *remoteexecution.Tree) (*remoteexecution.Directory, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	d := &remoteexecution.Directory{}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		switch {
+		case e.IsDir():
+			child, err := s.uploadDirectory(path, tree)
+			if err != nil {
+				return nil, err
+			}
+			sha, size, err := scootproto.GetSha256(child)
+			if err != nil {
+				return nil, err
+			}
+			tree.Children = append(tree.Children, child)
+			d.Directories = append(d.Directories, &remoteexecution.DirectoryNode{
+				Name:   e.Name(),
+				Digest: &remoteexecution.Digest{Hash: sha, SizeBytes: size},
+			})
+		case e.Mode().IsRegular():
+			digest, err := s.uploadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			d.Files = append(d.Files, &remoteexecution.FileNode{
+				Name:         e.Name(),
+				Digest:       digest,
+				IsExecutable: e.Mode()&0111 != 0,
+			})
+		}
+	}
+	return d, nil
+}
+
+// Uploads the file at path to the CAS, unless it's already there, and returns its digest
+func (s *executionServer) uploadFile(path string) (*remoteexecution.Digest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	digest := &remoteexecution.Digest{Hash: hex.EncodeToString(h.Sum(nil)), SizeBytes: size}
+	if bazel.IsEmptyDigest(digest) {
+		return digest, nil
+	}
+	name := bazel.DigestStoreName(digest)
+	if exists, err := s.store.Exists(name); err != nil || exists {
+		return digest, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := s.store.Write(name, f, nil); err != nil {
+		return nil, fmt.Errorf("Failed to upload %s: %v", name, err)
+	}
+	return digest, nil
+}
+
+// Uploads the wire format of a message to the CAS and returns its digest
+func (s *executionServer) uploadMessage(m proto.Message) (*remoteexecution.Digest, error) {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	digest := &remoteexecution.Digest{Hash: hex.EncodeToString(sum[:]), SizeBytes: int64(len(data))}
+	if bazel.IsEmptyDigest(digest) {
+		return digest, nil
+	}
+	if err := s.store.Write(bazel.DigestStoreName(digest), bytes.NewReader(data), nil); err != nil {
+		return nil, fmt.Errorf("Failed to upload %s: %v", bazel.DigestStoreName(digest), err)
+	}
+	return digest, nil
+}
@@ -9,6 +9,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/bazel/cas"
+	bazel "github.com/twitter/scoot/bazel/server"
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/cloud/cluster/local"
 	"github.com/twitter/scoot/common/endpoints"
@@ -94,6 +96,9 @@ func main() {
 		func() (net.Listener, error) {
 			return net.Listen("tcp", *grpcAddr)
 		},
+		func(l net.Listener, store bundlestore.Store, tmp *temp.TempDir, stat stats.StatsReceiver) bazel.GRPCServer {
+			return cas.NewCASServer(l, store, tmp, stat)
+		},
 	)
 	bundlestore.RunServer(bag, schema, configText)
 }
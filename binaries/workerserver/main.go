@@ -6,7 +6,9 @@ package main
 import (
 	"flag"
 	"math/rand"
+	"net"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -15,13 +17,17 @@ import (
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/twitter/scoot/binaries/workerserver/config"
 	"github.com/twitter/scoot/cloud/cluster/local"
+	"github.com/twitter/scoot/common/dialer"
 	"github.com/twitter/scoot/common/endpoints"
 	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/config/jsonconfig"
 	"github.com/twitter/scoot/ice"
 	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/oci"
+	osexec "github.com/twitter/scoot/runner/execer/os"
 	"github.com/twitter/scoot/runner/runners"
 	"github.com/twitter/scoot/scootapi"
 	"github.com/twitter/scoot/snapshot/bundlestore"
@@ -37,9 +43,25 @@ func main() {
 	httpAddr := flag.String("http_addr", scootapi.DefaultWorker_HTTP, "addr to serve http on")
 	configFlag := flag.String("config", "local.local", "Worker Server Config (either a filename like local.local or JSON text")
 	memCapFlag := flag.Uint64("mem_cap", 0, "Kill runs that exceed this amount of memory, in bytes. Zero means no limit.")
+	cgroupFlag := flag.String("cgroup", "", "Run each task in its own cgroup under this cgroup v2, ex: /sys/fs/cgroup/scoot, enforcing mem_cap and the cgroup limits and killing all of a task's processes when it ends. Empty means no cgroups (linux only).")
+	cgroupCPUsFlag := flag.Float64("cgroup_cpus", 0, "Limit each task's cpu time to this many cpus, ex: 1.5, with -cgroup. Zero means no limit.")
+	cgroupPidsFlag := flag.Int("cgroup_pids", 0, "Limit each task to this many processes and threads, with -cgroup. Zero means no limit.")
+	sandboxFlag := flag.Bool("sandbox", false, "Run every task in a sandbox, not only those that ask for one, with no network access and only able to write to its checkout (linux only).")
+	sandboxNetworkFlag := flag.Bool("sandbox_network", false, "Let sandboxed tasks use this worker's network.")
+	sandboxTmpfsFlag := flag.Uint64("sandbox_tmpfs", 0, "Mount a tmpfs of this many bytes on /tmp for each sandboxed task. Zero leaves /tmp read-only.")
+	imageDirFlag := flag.String("image_dir", "", "Abs dir path of the OCI image layouts and tarballs tasks can run in, unpacked into a cache in the worker's temp dir. Empty means tasks can't run in images (linux only).")
+	maxOutputFlag := flag.Int64("max_output_bytes", int64(runners.DefaultOutputLimit), "Fail runs whose declared outputs are more than this many bytes. Zero means no limit.")
+	slotsFlag := flag.Int("slots", 1, "Number of runs to execute concurrently, each with its own checkout and output.")
+	inheritEnvFlag := flag.String("inherit_env", "", "Comma separated names of this worker's environment variables tasks inherit, ex: PATH,HOME. Empty inherits all of them.")
+	defaultEnvFlag := flag.String("default_env", "", "Comma separated key=value environment variables set for every task, ex: JAVA_HOME=/usr/lib/jvm/default. Task variables override these.")
+	cleanEnvFlag := flag.Bool("clean_env", false, "Run tasks with only their own and the default environment variables, inheriting none from this worker.")
+	labelsFlag := flag.String("labels", "", "Comma separated key=value labels describing this worker, ex: os=linux-x86,has=docker.")
 	repoDir := flag.String("repo", "", "Abs dir path to a git repo to run against (don't use important repos yet!).")
 	storeHandle := flag.String("bundlestore", "", "Abs file path or an http 'host:port' to store/get bundles.")
 	logLevelFlag := flag.String("log_level", "info", "Log everything at this level and above (error|info|debug)")
+	schedulerAddr := flag.String("scheduler_addr", "", "Scheduler thrift 'host:port' to register with by sending heartbeats. Empty means don't register.")
+	heartbeatInterval := flag.Duration("heartbeat_interval", 2*time.Second, "How often to send heartbeats to the scheduler.")
+	advertiseAddr := flag.String("advertise_addr", "", "Thrift 'host:port' the scheduler should use to reach this worker, defaults to thrift_addr.")
 	flag.Parse()
 
 	level, err := log.ParseLevel(*logLevelFlag)
@@ -85,6 +107,53 @@ func main() {
 		func() execer.Memory {
 			return execer.Memory(*memCapFlag)
 		},
+		func() osexec.CgroupConfig {
+			return osexec.CgroupConfig{Parent: *cgroupFlag, CPUs: *cgroupCPUsFlag, Pids: *cgroupPidsFlag}
+		},
+		func() osexec.SandboxConfig {
+			return osexec.SandboxConfig{All: *sandboxFlag, Network: *sandboxNetworkFlag, TmpfsSize: execer.Memory(*sandboxTmpfsFlag)}
+		},
+		func(tmpDir *temp.TempDir) (*oci.Store, error) {
+			if *imageDirFlag == "" {
+				return nil, nil
+			}
+			cacheDir, err := tmpDir.FixedDir("images")
+			if err != nil {
+				return nil, err
+			}
+			return oci.NewStore(*imageDirFlag, cacheDir.Dir, oci.DefaultMaxCachedImages)
+		},
+		func() (execer.EnvPolicy, error) {
+			return execer.ParseEnvPolicy(*inheritEnvFlag, *defaultEnvFlag, *cleanEnvFlag)
+		},
+		func() runners.Slots {
+			return runners.Slots(*slotsFlag)
+		},
+		func() runners.OutputLimit {
+			return runners.OutputLimit(*maxOutputFlag)
+		},
+		func() (server.Labels, error) {
+			return server.ParseLabels(*labelsFlag)
+		},
+		func(
+			stat stats.StatsReceiver,
+			r runner.Service,
+			labels server.Labels,
+			tf thrift.TTransportFactory,
+			pf thrift.TProtocolFactory) (*server.Heartbeater, error) {
+			if *schedulerAddr == "" {
+				return nil, nil
+			}
+			nodeId, err := getAdvertiseAddr(*advertiseAddr, *thriftAddr)
+			if err != nil {
+				return nil, err
+			}
+			// A heartbeat that takes longer than the interval is stale, so use the interval as the timeout.
+			di := dialer.NewSimpleDialer(tf, pf, *heartbeatInterval)
+			client := scootapi.NewCloudScootClient(scootapi.CloudScootClientConfig{Addr: *schedulerAddr, Dialer: di})
+			log.Infof("Registering with scheduler %s as %s", *schedulerAddr, nodeId)
+			return server.NewHeartbeater(nodeId, client, r, labels, *heartbeatInterval, stat), nil
+		},
 		// Use storeHandle if provided, else try Fetching, then GetScootApiAddr(), then fallback to tmp file store.
 		func(tmp *temp.TempDir) (bundlestore.Store, error) {
 			if *storeHandle != "" {
@@ -115,3 +184,20 @@ func main() {
 	log.Info("Serving thrift on", *thriftAddr) //It's hard to access the thriftAddr value downstream, print it here.
 	server.RunServer(bag, schema, configText)
 }
+
+// Returns advertiseAddr if set, else thriftAddr with this machine's hostname filled in if its host is empty.
+func getAdvertiseAddr(advertiseAddr, thriftAddr string) (string, error) {
+	if advertiseAddr != "" {
+		return advertiseAddr, nil
+	}
+	host, port, err := net.SplitHostPort(thriftAddr)
+	if err != nil {
+		return "", err
+	}
+	if host == "" {
+		if host, err = os.Hostname(); err != nil {
+			return "", err
+		}
+	}
+	return net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,38 @@
+// Package dns provides a cluster Fetcher implementation for
+// obtaining nodes from DNS SRV records.
+package dns
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// Looks up the SRV records for _service._proto.name, ex: ("scoot-worker", "tcp", "example.com"),
+// and returns a node for each record's 'target:port'. If service and proto are empty, name is looked up directly.
+func MakeFetcher(service, proto, name string) cluster.Fetcher {
+	return &dnsFetcher{service, proto, name, net.LookupSRV}
+}
+
+type dnsFetcher struct {
+	service   string
+	proto     string
+	name      string
+	lookupSRV func(service, proto, name string) (string, []*net.SRV, error)
+}
+
+// Implements cluster.Fetcher interface for nodes in DNS SRV records
+func (f *dnsFetcher) Fetch() ([]cluster.Node, error) {
+	_, addrs, err := f.lookupSRV(f.service, f.proto, f.name)
+	if err != nil {
+		return nil, err
+	}
+	list := cluster.NodeList{}
+	for _, addr := range addrs {
+		host := strings.TrimSuffix(addr.Target, ".")
+		list.Nodes = append(list.Nodes, cluster.NodeId(net.JoinHostPort(host, strconv.Itoa(int(addr.Port)))))
+	}
+	return list.ToNodes(), nil
+}
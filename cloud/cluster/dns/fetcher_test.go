@@ -0,0 +1,42 @@
+package dns
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+func TestFetcher(t *testing.T) {
+	f := MakeFetcher("scoot-worker", "tcp", "example.com").(*dnsFetcher)
+	f.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		if service != "scoot-worker" || proto != "tcp" || name != "example.com" {
+			t.Fatalf("Unexpected lookup: %v %v %v", service, proto, name)
+		}
+		return "_scoot-worker._tcp.example.com.", []*net.SRV{
+			&net.SRV{Target: "host1.example.com.", Port: 9091},
+			&net.SRV{Target: "host2.example.com.", Port: 9092},
+		}, nil
+	}
+	expected := []cluster.Node{
+		cluster.NewIdNode("host1.example.com:9091"),
+		cluster.NewIdNode("host2.example.com:9092"),
+	}
+
+	nodes, err := f.Fetch()
+	if err != nil {
+		t.Fatalf("error fetching: %v", err)
+	}
+	if !reflect.DeepEqual(expected, nodes) {
+		t.Fatalf("Fetched wrong: %v %v", expected, nodes)
+	}
+
+	f.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		return "", nil, errors.New("no such host")
+	}
+	if _, err := f.Fetch(); err == nil {
+		t.Fatalf("Expected lookup error")
+	}
+}
@@ -0,0 +1,42 @@
+// Package endpoint provides a cluster Fetcher implementation for
+// obtaining nodes from an http endpoint.
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// Issues a GET to url and expects a json cluster.NodeList in the response body.
+// If client is nil, http.DefaultClient is used.
+func MakeFetcher(url string, client *http.Client) cluster.Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &endpointFetcher{url, client}
+}
+
+type endpointFetcher struct {
+	url    string
+	client *http.Client
+}
+
+// Implements cluster.Fetcher interface for nodes served by an http endpoint
+func (f *endpointFetcher) Fetch() ([]cluster.Node, error) {
+	resp, err := f.client.Get(f.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Fetching nodes from %s failed with status: %s", f.url, resp.Status)
+	}
+	var list cluster.NodeList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("Could not parse node list from %s: %v", f.url, err)
+	}
+	return list.ToNodes(), nil
+}
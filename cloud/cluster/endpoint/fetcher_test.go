@@ -0,0 +1,38 @@
+package endpoint
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+func TestFetcher(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"nodes": ["host1:9091", "host2:9091"]}`)
+	}))
+	defer server.Close()
+	expected := []cluster.Node{
+		cluster.NewIdNode("host1:9091"),
+		cluster.NewIdNode("host2:9091"),
+	}
+
+	f := MakeFetcher(server.URL, nil)
+	nodes, err := f.Fetch()
+	if err != nil {
+		t.Fatalf("error fetching: %v", err)
+	}
+	if !reflect.DeepEqual(expected, nodes) {
+		t.Fatalf("Fetched wrong: %v %v", expected, nodes)
+	}
+
+	status = http.StatusInternalServerError
+	if _, err := f.Fetch(); err == nil {
+		t.Fatalf("Expected error for a failed request")
+	}
+}
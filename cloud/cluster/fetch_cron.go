@@ -2,6 +2,8 @@ package cluster
 
 import (
 	"time"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type fetchCron struct {
@@ -33,6 +35,7 @@ func (c *fetchCron) loop() {
 		nodes, err := c.f.Fetch()
 		if err != nil {
 			// TODO(rcouto): Correctly handle as many errors as possible
+			log.Errorf("Error fetching cluster nodes, keeping the previous set: %v", err)
 			continue
 		}
 		c.outCh <- nodes
@@ -0,0 +1,66 @@
+// Package file provides a cluster Fetcher implementation for
+// obtaining the nodes listed in a static json or yaml file.
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// Reads cluster.NodeList from the file at path, which is parsed as yaml if it has a .yaml or .yml extension
+// and as json otherwise. The file is watched by re-reading it whenever its modification time changes,
+// so it can be edited in place (or atomically replaced) to add and remove nodes.
+func MakeFetcher(path string) cluster.Fetcher {
+	return &fileFetcher{path: path}
+}
+
+type fileFetcher struct {
+	path    string
+	modTime time.Time
+	nodes   []cluster.Node
+}
+
+// Implements cluster.Fetcher interface for nodes listed in a file
+func (f *fileFetcher) Fetch() ([]cluster.Node, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, err
+	}
+	if f.nodes != nil && info.ModTime().Equal(f.modTime) {
+		return f.nodes, nil
+	}
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := parseData(data, f.path)
+	if err != nil {
+		return nil, err
+	}
+	f.modTime, f.nodes = info.ModTime(), nodes
+	return nodes, nil
+}
+
+func parseData(data []byte, path string) ([]cluster.Node, error) {
+	var list cluster.NodeList
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &list)
+	default:
+		err = json.Unmarshal(data, &list)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse node list from %s: %v", path, err)
+	}
+	return list.ToNodes(), nil
+}
@@ -0,0 +1,69 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+func TestParse(t *testing.T) {
+	expected := []cluster.Node{
+		cluster.NewIdNode("host1:9091"),
+		cluster.NewIdNode("host2:9091"),
+	}
+	for path, data := range map[string]string{
+		"nodes.json": `{"nodes": ["host1:9091", "host2:9091", "host1:9091"]}`,
+		"nodes.yaml": "nodes:\n- host1:9091\n- host2:9091\n",
+		"nodes.yml":  "nodes: [host1:9091, host2:9091]",
+	} {
+		nodes, err := parseData([]byte(data), path)
+		if err != nil {
+			t.Fatalf("error parsing %s: %v", path, err)
+		}
+		if !reflect.DeepEqual(expected, nodes) {
+			t.Fatalf("Parsed %s wrong: %v %v", path, expected, nodes)
+		}
+	}
+	if _, err := parseData([]byte("nodes: [host1:9091]"), "nodes.json"); err == nil {
+		t.Fatalf("Expected error parsing yaml as json")
+	}
+}
+
+func TestFetchReloadsOnChange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-fetcher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nodes.json")
+
+	f := MakeFetcher(path)
+	if _, err := f.Fetch(); err == nil {
+		t.Fatalf("Expected error fetching from a missing file")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"nodes": ["host1:9091"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := f.Fetch()
+	if err != nil || len(nodes) != 1 {
+		t.Fatalf("Expected one node, got %v, %v", nodes, err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"nodes": ["host1:9091", "host2:9091"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err = f.Fetch()
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("Expected two nodes, got %v, %v", nodes, err)
+	}
+}
@@ -38,6 +38,41 @@ func (n *idNode) Id() NodeId {
 	return n.id
 }
 
+// Implemented by Nodes that advertise what they can run when they join the cluster, and again whenever
+// that changes, ex: workers that register with heartbeats.
+type CapacityNode interface {
+	Node
+	// The number of tasks the node can run concurrently, or zero if unknown.
+	Slots() int
+	// Labels that task node selectors are matched against.
+	Labels() map[string]string
+	// Snapshots the node recently checked out, most recent first.
+	SnapshotIDs() []string
+}
+
+type capacityNode struct {
+	idNode
+	slots       int
+	labels      map[string]string
+	snapshotIDs []string
+}
+
+func NewCapacityNode(id string, slots int, labels map[string]string, snapshotIDs []string) CapacityNode {
+	return &capacityNode{idNode: idNode{id: NodeId(id)}, slots: slots, labels: labels, snapshotIDs: snapshotIDs}
+}
+
+func (n *capacityNode) Slots() int {
+	return n.slots
+}
+
+func (n *capacityNode) Labels() map[string]string {
+	return n.labels
+}
+
+func (n *capacityNode) SnapshotIDs() []string {
+	return n.snapshotIDs
+}
+
 type NodeSorter []Node
 
 func (n NodeSorter) Len() int           { return len(n) }
@@ -49,15 +84,17 @@ type NodeUpdateType int
 const (
 	NodeAdded NodeUpdateType = iota
 	NodeRemoved
+	NodeChanged // A node already in the cluster advertises a new capacity, see CapacityNode.
 )
 
 var _ Node = (*idNode)(nil)
+var _ CapacityNode = (*capacityNode)(nil)
 
 // NodeUpdate represents a change to the cluster
 type NodeUpdate struct {
 	UpdateType NodeUpdateType
 	Id         NodeId
-	Node       Node // Only set for adds
+	Node       Node // Only set for adds and changes
 }
 
 func (u *NodeUpdate) String() string {
@@ -80,3 +117,32 @@ func NewRemove(id NodeId) NodeUpdate {
 		Id:         id,
 	}
 }
+
+func NewChange(node Node) NodeUpdate {
+	return NodeUpdate{
+		NodeChanged,
+		node.Id(),
+		node,
+	}
+}
+
+// A list of node ids as served by a file or endpoint, for fetchers that read cluster membership from one.
+// Ex json: {"nodes": ["host1:9091", "host2:9091"]}
+// Ex yaml: nodes: [host1:9091, host2:9091]
+type NodeList struct {
+	Nodes []NodeId `json:"nodes" yaml:"nodes"`
+}
+
+// Converts the listed ids to Nodes, skipping empty and duplicate ids.
+func (l NodeList) ToNodes() []Node {
+	nodes := []Node{}
+	seen := map[NodeId]bool{}
+	for _, id := range l.Nodes {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		nodes = append(nodes, NewIdNode(string(id)))
+	}
+	return nodes
+}
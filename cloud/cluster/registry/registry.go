@@ -0,0 +1,181 @@
+// Package registry provides cluster membership driven by worker heartbeats,
+// as an alternative to polling a cluster Fetcher.
+package registry
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+)
+
+// The latest state reported by a worker.
+type Heartbeat struct {
+	NodeId      cluster.NodeId
+	Version     string
+	Slots       int
+	NumRunning  int
+	Labels      map[string]string
+	SnapshotIDs []string
+}
+
+type heartbeatsByNode []Heartbeat
+
+func (h heartbeatsByNode) Len() int           { return len(h) }
+func (h heartbeatsByNode) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h heartbeatsByNode) Less(i, j int) bool { return h[i].NodeId < h[j].NodeId }
+
+type worker struct {
+	heartbeat Heartbeat
+	lastSeen  time.Time
+}
+
+// Registry tracks workers by their heartbeats and sends cluster updates as they come, change and go.
+// A worker is added to the cluster on its first heartbeat, changed when a heartbeat reports different slots,
+// labels or snapshots, and removed once timeout passes without one.
+type Registry struct {
+	timeout  time.Duration
+	updateCh chan cluster.ClusterUpdate
+	now      func() time.Time
+	stat     stats.StatsReceiver
+
+	// Guards workers, and is held while sending on updateCh so updates for a node are sent in order.
+	mu      sync.Mutex
+	workers map[cluster.NodeId]*worker
+}
+
+// Creates a Registry which removes workers that miss heartbeats for longer than timeout,
+// checking for them and reporting stats on the registered workers on each tick of tickCh.
+func NewRegistry(timeout time.Duration, tickCh <-chan time.Time, stat stats.StatsReceiver) *Registry {
+	r := &Registry{
+		timeout:  timeout,
+		updateCh: make(chan cluster.ClusterUpdate),
+		now:      time.Now,
+		stat:     stat,
+		workers:  map[cluster.NodeId]*worker{},
+	}
+	go func() {
+		for range tickCh {
+			r.expire()
+			r.reportStats()
+		}
+	}()
+	return r
+}
+
+// The channel on which []cluster.NodeUpdate are sent, to be passed to cluster.NewCluster().
+func (r *Registry) Updates() chan cluster.ClusterUpdate {
+	return r.updateCh
+}
+
+// Records a heartbeat, adding its worker to the cluster if it's new or was previously removed,
+// or sending a change if the worker now reports different slots, labels or snapshots.
+func (r *Registry) Heartbeat(hb Heartbeat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	node := cluster.NewCapacityNode(string(hb.NodeId), hb.Slots, hb.Labels, hb.SnapshotIDs)
+	w, ok := r.workers[hb.NodeId]
+	if !ok {
+		log.WithFields(
+			log.Fields{
+				"node":    hb.NodeId,
+				"version": hb.Version,
+				"slots":   hb.Slots,
+				"labels":  hb.Labels,
+			}).Info("Registering worker")
+		w = &worker{}
+		r.workers[hb.NodeId] = w
+		r.updateCh <- []cluster.NodeUpdate{cluster.NewAdd(node)}
+	} else {
+		prev := w.heartbeat
+		if prev.Version != hb.Version {
+			log.WithFields(
+				log.Fields{
+					"node":       hb.NodeId,
+					"version":    hb.Version,
+					"oldVersion": prev.Version,
+				}).Info("Worker changed version")
+		}
+		if prev.Slots != hb.Slots || !equalLabels(prev.Labels, hb.Labels) ||
+			!equalSnapshots(prev.SnapshotIDs, hb.SnapshotIDs) {
+			r.updateCh <- []cluster.NodeUpdate{cluster.NewChange(node)}
+		}
+	}
+	w.heartbeat = hb
+	w.lastSeen = r.now()
+}
+
+// Returns the latest heartbeat of each registered worker, ordered by node id.
+func (r *Registry) Workers() []Heartbeat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hbs := []Heartbeat{}
+	for _, w := range r.workers {
+		hbs = append(hbs, w.heartbeat)
+	}
+	sort.Sort(heartbeatsByNode(hbs))
+	return hbs
+}
+
+// Reports the number of registered workers, the tasks they're running and the versions they run.
+func (r *Registry) reportStats() {
+	hbs := r.Workers()
+	running := 0
+	versions := map[string]bool{}
+	for _, hb := range hbs {
+		running += hb.NumRunning
+		versions[hb.Version] = true
+	}
+	r.stat.Gauge(stats.ClusterRegisteredWorkers).Update(int64(len(hbs)))
+	r.stat.Gauge(stats.ClusterRegisteredRunningTasks).Update(int64(running))
+	r.stat.Gauge(stats.ClusterRegisteredVersions).Update(int64(len(versions)))
+}
+
+// Removes workers whose last heartbeat is older than the timeout.
+func (r *Registry) expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updates := []cluster.NodeUpdate{}
+	for id, w := range r.workers {
+		if r.now().Sub(w.lastSeen) > r.timeout {
+			log.WithFields(
+				log.Fields{
+					"node":     id,
+					"lastSeen": w.lastSeen,
+				}).Info("Worker missed heartbeats, removing")
+			delete(r.workers, id)
+			updates = append(updates, cluster.NewRemove(id))
+		}
+	}
+	if len(updates) > 0 {
+		r.updateCh <- updates
+	}
+}
+
+func equalLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func equalSnapshots(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
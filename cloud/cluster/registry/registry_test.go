@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+)
+
+func TestRegistry(t *testing.T) {
+	tickCh := make(chan time.Time)
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	stat, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	r := NewRegistry(10*time.Second, tickCh, stat)
+	now := time.Unix(0, 0)
+	r.now = func() time.Time { return now }
+	cl := cluster.NewCluster(nil, r.Updates())
+	sub := cl.Subscribe()
+	defer sub.Closer.Close()
+
+	expectUpdates := func(expected ...cluster.NodeUpdate) {
+		select {
+		case updates := <-sub.Updates:
+			if !reflect.DeepEqual(expected, updates) {
+				t.Fatalf("Expected updates %v, got %v", expected, updates)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for updates %v", expected)
+		}
+	}
+
+	// New workers are added, repeated heartbeats only refresh them.
+	r.Heartbeat(Heartbeat{NodeId: "node1", Slots: 1})
+	expectUpdates(cluster.NewAdd(cluster.NewCapacityNode("node1", 1, nil, nil)))
+	r.Heartbeat(Heartbeat{NodeId: "node2", Version: "v1", Slots: 2, Labels: map[string]string{"os": "linux"}})
+	expectUpdates(cluster.NewAdd(cluster.NewCapacityNode("node2", 2, map[string]string{"os": "linux"}, nil)))
+	r.Heartbeat(Heartbeat{NodeId: "node1", Slots: 1, NumRunning: 1})
+	if hbs := r.Workers(); len(hbs) != 2 || hbs[0].NumRunning != 1 || hbs[1].Slots != 2 {
+		t.Fatalf("Expected latest heartbeats for node1 and node2, got %v", hbs)
+	}
+
+	// node1 checks out a snapshot and gets more slots, which changes the node in the cluster.
+	r.Heartbeat(Heartbeat{NodeId: "node1", Slots: 2, NumRunning: 1, SnapshotIDs: []string{"snapA"}})
+	expectUpdates(cluster.NewChange(cluster.NewCapacityNode("node1", 2, nil, []string{"snapA"})))
+	if members := cl.Members(); len(members) != 2 || members[0].(cluster.CapacityNode).Slots() != 2 {
+		t.Fatalf("Expected node1 to be changed in the cluster, got %v", members)
+	}
+
+	// Ticks report stats on the registered workers, the second tick waits for the first to be reported.
+	tickCh <- now
+	tickCh <- now
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.ClusterRegisteredWorkers:      {Checker: stats.Int64EqTest, Value: 2},
+			stats.ClusterRegisteredRunningTasks: {Checker: stats.Int64EqTest, Value: 1},
+			stats.ClusterRegisteredVersions:     {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+
+	// node2 misses its heartbeats and is removed, node1 keeps sending them.
+	now = now.Add(6 * time.Second)
+	r.Heartbeat(Heartbeat{NodeId: "node1", Slots: 2, SnapshotIDs: []string{"snapA"}})
+	now = now.Add(6 * time.Second)
+	tickCh <- now
+	expectUpdates(cluster.NewRemove("node2"))
+	if members := cl.Members(); len(members) != 1 || members[0].Id() != "node1" {
+		t.Fatalf("Expected only node1 in the cluster, got %v", members)
+	}
+
+	// node2 comes back.
+	r.Heartbeat(Heartbeat{NodeId: "node2", Slots: 3})
+	expectUpdates(cluster.NewAdd(cluster.NewCapacityNode("node2", 3, nil, nil)))
+}
@@ -60,7 +60,7 @@ func (s *state) setAndDiff(newState []Node) []NodeUpdate {
 // FilterAndUpdate takes node updates as an argument and applies them
 // to the state to create a new state. It also filters out updates that
 // are not applicable, i.e. adding a node that is already in the state
-// or removing one that isn't present.
+// or removing or changing one that isn't present.
 func (s *state) filterAndUpdate(newUpdates []NodeUpdate) []NodeUpdate {
 	unused := []NodeUpdate{}
 	filtered := []NodeUpdate{}
@@ -87,6 +87,16 @@ func (s *state) filterAndUpdate(newUpdates []NodeUpdate) []NodeUpdate {
 				unused = append(unused, update)
 				continue
 			}
+		case update.UpdateType == NodeChanged:
+			if ok {
+				// replace node in state with its new capacity
+				s.nodes[update.Id] = update.Node
+				filtered = append(filtered, update)
+			} else {
+				// node wasn't previously in state
+				unused = append(unused, update)
+				continue
+			}
 		}
 	}
 	if len(unused) == 0 || len(unused) == len(newUpdates) {
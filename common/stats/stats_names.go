@@ -5,6 +5,98 @@ This file defines all the metrics being collected.   As new metrics are added pl
 */
 
 const (
+	/************************* Bazel Execution API metrics **************************/
+	/*
+		the number of Execute requests the gRPC execution server received
+	*/
+	BazelExecuteRequestCounter = "bzExecuteRequestCounter"
+
+	/*
+		the amount of time it took to translate and schedule an Execute request
+	*/
+	BazelExecuteRequestLatency_ms = "bzExecuteRequestLatency_ms"
+
+	/*
+		the number of Execute requests that were rejected or could not be scheduled
+	*/
+	BazelExecuteFailureCounter = "bzExecuteFailureCounter"
+
+	/*
+		the number of GetOperation requests the gRPC execution server received
+	*/
+	BazelGetOperationCounter = "bzGetOperationCounter"
+
+	/*
+		the amount of time it took to read the saga state for a GetOperation request
+	*/
+	BazelGetOperationLatency_ms = "bzGetOperationLatency_ms"
+
+	/*
+		the number of CancelOperation requests the gRPC execution server received
+	*/
+	BazelCancelOperationCounter = "bzCancelOperationCounter"
+
+	/*
+		the number of WaitOperation requests the gRPC execution server received
+	*/
+	BazelWaitOperationCounter = "bzWaitOperationCounter"
+
+	/************************* Bazel CAS API metrics **************************/
+	/*
+		the number of FindMissingBlobs requests the gRPC CAS server received
+	*/
+	BazelCasFindMissingBlobsCounter = "bzCasFindMissingBlobsCounter"
+
+	/*
+		the number of BatchUpdateBlobs requests the gRPC CAS server received
+	*/
+	BazelCasBatchUpdateBlobsCounter = "bzCasBatchUpdateBlobsCounter"
+
+	/*
+		the number of GetTree requests the gRPC CAS server received
+	*/
+	BazelCasGetTreeCounter = "bzCasGetTreeCounter"
+
+	/*
+		the number of ByteStream Read requests the gRPC CAS server received
+	*/
+	BazelCasReadCounter = "bzCasReadCounter"
+
+	/*
+		the amount of time it took to stream a blob to a client (includes reads that errored)
+	*/
+	BazelCasReadLatency_ms = "bzCasReadLatency_ms"
+
+	/*
+		the number of ByteStream Write requests the gRPC CAS server received
+	*/
+	BazelCasWriteCounter = "bzCasWriteCounter"
+
+	/*
+		the amount of time it took to receive and store a blob from a client (includes writes that errored)
+	*/
+	BazelCasWriteLatency_ms = "bzCasWriteLatency_ms"
+
+	/*
+		the number of ByteStream QueryWriteStatus requests the gRPC CAS server received
+	*/
+	BazelCasQueryWriteStatusCounter = "bzCasQueryWriteStatusCounter"
+
+	/*
+		the number of GetActionResult requests the gRPC ActionCache server received
+	*/
+	BazelActionCacheGetCounter = "bzActionCacheGetCounter"
+
+	/*
+		the number of GetActionResult requests that found a cached ActionResult
+	*/
+	BazelActionCacheHitCounter = "bzActionCacheHitCounter"
+
+	/*
+		the number of UpdateActionResult requests the gRPC ActionCache server received
+	*/
+	BazelActionCacheUpdateCounter = "bzActionCacheUpdateCounter"
+
 	/************************* Bundlestore metrics **************************/
 	//TODO - verify all the bundlestore descriptions
 	/*
@@ -94,20 +186,45 @@ const (
 	ClusterAvailableNodes = "availableNodes"
 
 	/*
-		the number of free worker nodes (available, but not running a task)
+		the number of free task slots on available worker nodes (equal to free nodes when each node has one slot)
 	*/
 	ClusterFreeNodes = "freeNodes"
 
 	/*
-		the number of running worker nodes (running + free + suspended ~= allNodes (may lag))
+		the number of running tasks (running + free + suspended ~= allSlots (may lag))
 	*/
 	ClusterRunningNodes = "runningNodes"
 
+	/*
+		the number of task slots across available worker nodes, nodes advertise how many tasks they can run concurrently
+	*/
+	ClusterAvailableSlots = "availableSlots"
+
 	/*
 		the number of lost worker nodes (not responding to status requests)
 	*/
 	ClusterLostNodes = "lostNodes"
 
+	/*
+		the number of worker nodes marked as draining, they accept no new tasks
+	*/
+	ClusterDrainingNodes = "drainingNodes"
+
+	/*
+		the number of workers registered with the scheduler by heartbeat
+	*/
+	ClusterRegisteredWorkers = "registeredWorkers"
+
+	/*
+		the number of tasks running on registered workers, as reported in their latest heartbeats
+	*/
+	ClusterRegisteredRunningTasks = "registeredRunningTasks"
+
+	/*
+		the number of distinct versions reported by registered workers, more than one while a new version rolls out
+	*/
+	ClusterRegisteredVersions = "registeredVersions"
+
 	/************************* Groupcache Metrics ***************************/ // TODO verify/update all groupcache descriptions
 	/*
 		the number of time groupcache tried to determine if a bundle existed
@@ -236,6 +353,16 @@ const (
 
 	SchedAcceptedJobsGauge = "schedAcceptedJobsGauge"
 
+	/*
+		the number of new tasks that were completed from the action cache without running
+	*/
+	SchedActionCacheHitCounter = "schedActionCacheHitCounter"
+
+	/*
+		the number of new tasks that had no result in the action cache and must be run
+	*/
+	SchedActionCacheMissCounter = "schedActionCacheMissCounter"
+
 	/*
 		the number of tasks that have finished (including those that have been killed)
 	*/
@@ -286,6 +413,11 @@ const (
 	*/
 	SchedPreemptedTasksCounter = "preemptedTasksCounter"
 
+	/*
+		the number of tasks left unassigned because no free or killable node had the resources or labels they require
+	*/
+	SchedUnplaceableTasksCounter = "unplaceableTasksCounter"
+
 	/*
 		the number of jobs with priority 0
 	*/
@@ -323,6 +455,41 @@ const (
 	*/
 	SchedScheduledTasksCounter = "scheduledTasksCounter"
 
+	/*
+		the number of tasks that were skipped without running because a task they depend on failed
+	*/
+	SchedSkippedTaskCounter = "skippedTaskCounter"
+
+	/*
+		the number of jobs that were killed because a newer job with the same requestor and basis was submitted
+	*/
+	SchedSupersededJobsCounter = "supersededJobsCounter"
+
+	/*
+		the number of task durations recorded for longest-task-first scheduling
+	*/
+	SchedTaskDurationsRecordedCounter = "taskDurationsRecordedCounter"
+
+	/*
+		the number of distinct tasks (task id and job type) with a recorded duration history
+	*/
+	SchedTaskDurationsTrackedGauge = "taskDurationsTrackedGauge"
+
+	/*
+		the number of jobs rejected because their requestor exceeded its queued tasks or node hours quota
+	*/
+	SchedQuotaRejectedJobsCounter = "quotaRejectedJobsCounter"
+
+	/*
+		the number of requestors whose tasks aren't being started because they reached their quota
+	*/
+	SchedQuotaThrottledRequestorsGauge = "quotaThrottledRequestorsGauge"
+
+	/*
+		the number of running lower priority tasks that the preemption policy keeps priority 3 jobs from killing
+	*/
+	SchedPreemptionProtectedTasksGauge = "preemptionProtectedTasksGauge"
+
 	/*
 		the number of times the server received a job kill request
 	*/
@@ -353,6 +520,37 @@ const (
 	*/
 	SchedServerRunJobLatency_ms = "runJobLatency_ms"
 
+	/*
+		the number of job watch requests the thrift server received
+	*/
+	SchedServerWatchJobCounter = "watchJobRpmCounter"
+
+	/*
+		the number of job watch requests that weren't served from the scheduler's in-memory state
+		and fell back to reading the saga log (finished, unknown or not yet added jobs)
+	*/
+	SchedServerWatchJobFallbackCounter = "watchJobFallbackCounter"
+
+	/*
+		the number of list jobs requests the thrift server received
+	*/
+	SchedServerListJobsCounter = "listJobsRpmCounter"
+
+	/*
+		the number of worker heartbeats the thrift server received
+	*/
+	SchedServerHeartbeatCounter = "heartbeatRpmCounter"
+
+	/*
+		the number of requests to drain or undrain nodes the thrift server received
+	*/
+	SchedServerDrainNodesCounter = "drainNodesRpmCounter"
+
+	/*
+		the number of job requests the server rejected with CanNotScheduleNow because of a requestor quota
+	*/
+	SchedServerRunJobQuotaExceededCounter = "runJobQuotaExceededCounter"
+
 	/*
 		The amount of time it takes to assign the tasks to nodes
 	*/
@@ -420,6 +618,11 @@ const (
 	*/
 	WorkerActiveInitLatency_ms = "workerActiveInitLatency_ms"
 
+	/*
+		the number of heartbeats the worker failed to send to the scheduler
+	*/
+	WorkerHeartbeatFailures = "heartbeatFailures"
+
 	/*
 		the amount of worker's memory currently consumed by the current command (and its subprocesses)
 		TODO- verify with Ryan that this description is correct
@@ -2,13 +2,29 @@ package scootconfig
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/cloud/cluster/dns"
+	"github.com/twitter/scoot/cloud/cluster/endpoint"
+	"github.com/twitter/scoot/cloud/cluster/file"
 	"github.com/twitter/scoot/cloud/cluster/local"
+	"github.com/twitter/scoot/cloud/cluster/registry"
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/ice"
 )
 
+// How often to fetch cluster membership if FetchInterval isn't configured.
+const DefaultClusterFetchInterval = time.Second
+
+// How long a worker may go without a heartbeat before it's removed, if HeartbeatTimeout isn't configured.
+const DefaultHeartbeatTimeout = 10 * time.Second
+
+// Timeout for each request to a cluster endpoint, if Timeout isn't configured.
+// A fetch that hangs would otherwise stop cluster updates for good.
+const DefaultClusterEndpointTimeout = 10 * time.Second
+
 // Parameters for configuring an in-memory Scoot cluster
 // Count - number of in-memory workers
 type ClusterMemoryConfig struct {
@@ -42,3 +58,117 @@ func (c *ClusterLocalConfig) Create() (*cluster.Cluster, error) {
 	updates := cluster.MakeFetchCron(f, time.NewTicker(time.Second).C)
 	return cluster.NewCluster(nil, updates), nil
 }
+
+// Parameters for configuring a Scoot cluster whose nodes are listed in a json or yaml file, see file.MakeFetcher.
+// Path - the file listing nodes, ex: {"nodes": ["host1:9091", "host2:9091"]}
+// FetchInterval - how often to check the file for changes, human readable ex: "10s"
+type ClusterFileConfig struct {
+	Type          string
+	Path          string
+	FetchInterval string
+}
+
+func (c *ClusterFileConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+func (c *ClusterFileConfig) Create() (*cluster.Cluster, error) {
+	if c.Path == "" {
+		return nil, fmt.Errorf("ClusterFileConfig requires a Path")
+	}
+	return createFetchedCluster(file.MakeFetcher(c.Path), c.FetchInterval)
+}
+
+// Parameters for configuring a Scoot cluster whose nodes are found with a DNS SRV lookup, see dns.MakeFetcher.
+// Service, Proto, Name - the SRV record to look up: _Service._Proto.Name
+// FetchInterval - how often to repeat the lookup, human readable ex: "30s"
+type ClusterDNSConfig struct {
+	Type          string
+	Service       string
+	Proto         string
+	Name          string
+	FetchInterval string
+}
+
+func (c *ClusterDNSConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+func (c *ClusterDNSConfig) Create() (*cluster.Cluster, error) {
+	if c.Name == "" {
+		return nil, fmt.Errorf("ClusterDNSConfig requires a Name")
+	}
+	return createFetchedCluster(dns.MakeFetcher(c.Service, c.Proto, c.Name), c.FetchInterval)
+}
+
+// Parameters for configuring a Scoot cluster whose nodes are served by an http endpoint, see endpoint.MakeFetcher.
+// URL - the endpoint returning the node list, ex: {"nodes": ["host1:9091", "host2:9091"]}
+// Timeout - timeout for each request, human readable ex: "5s", DefaultClusterEndpointTimeout if unset
+// FetchInterval - how often to query the endpoint, human readable ex: "30s"
+type ClusterEndpointConfig struct {
+	Type          string
+	URL           string
+	Timeout       string
+	FetchInterval string
+}
+
+func (c *ClusterEndpointConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+func (c *ClusterEndpointConfig) Create() (*cluster.Cluster, error) {
+	if c.URL == "" {
+		return nil, fmt.Errorf("ClusterEndpointConfig requires a URL")
+	}
+	client := &http.Client{Timeout: DefaultClusterEndpointTimeout}
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		client.Timeout = timeout
+	}
+	return createFetchedCluster(endpoint.MakeFetcher(c.URL, client), c.FetchInterval)
+}
+
+// Parameters for configuring a Scoot cluster whose workers register themselves by sending heartbeats
+// to the scheduler, see registry.Registry.
+// HeartbeatTimeout - how long a worker may go without a heartbeat before it's removed, human readable ex: "10s"
+type ClusterHeartbeatConfig struct {
+	Type             string
+	HeartbeatTimeout string
+}
+
+func (c *ClusterHeartbeatConfig) Install(bag *ice.MagicBag) {
+	bag.PutMany(c.CreateRegistry, c.Create)
+}
+
+func (c *ClusterHeartbeatConfig) CreateRegistry(stat stats.StatsReceiver) (*registry.Registry, error) {
+	timeout := DefaultHeartbeatTimeout
+	if c.HeartbeatTimeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(c.HeartbeatTimeout); err != nil {
+			return nil, err
+		}
+	}
+	// Check for lost workers a few times per timeout so they're removed soon after it passes.
+	return registry.NewRegistry(timeout, time.NewTicker(timeout/4).C, stat), nil
+}
+
+func (c *ClusterHeartbeatConfig) Create(r *registry.Registry) (*cluster.Cluster, error) {
+	return cluster.NewCluster(nil, r.Updates()), nil
+}
+
+// Creates a cluster with the initially fetched nodes, if any, that is updated by fetching every interval.
+func createFetchedCluster(f cluster.Fetcher, interval string) (*cluster.Cluster, error) {
+	d := DefaultClusterFetchInterval
+	if interval != "" {
+		var err error
+		if d, err = time.ParseDuration(interval); err != nil {
+			return nil, err
+		}
+	}
+	nodes, _ := f.Fetch()
+	updates := cluster.MakeFetchCron(f, time.NewTicker(d).C)
+	return cluster.NewCluster(nodes, updates), nil
+}
@@ -1,6 +1,9 @@
 package scootconfig
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/twitter/scoot/ice"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
@@ -26,9 +29,11 @@ func (c *InMemorySagaLogConfig) Create() saga.SagaLog {
 // instance of the SagaLog interface
 // Directory specifies the name of the directory to store
 // Sagalog files in.
+// Retention specifies when completed sagas are compacted or deleted.
 type FileSagaLogConfig struct {
 	Type      string
 	Directory string
+	Retention SagaLogRetentionConfig
 }
 
 // Adds the FileSagaLogConfig Create function to the goice MagicBag
@@ -38,5 +43,157 @@ func (c *FileSagaLogConfig) Install(bag *ice.MagicBag) {
 
 // Creates an instance of the FileSagaLog
 func (c *FileSagaLogConfig) Create() (saga.SagaLog, error) {
-	return sagalogs.MakeFileSagaLog(c.Directory)
+	policy, err := c.Retention.Create()
+	if err != nil {
+		return nil, err
+	}
+	slog, err := sagalogs.MakeFileSagaLog(c.Directory)
+	if err != nil {
+		return nil, err
+	}
+	startRetention(slog, policy)
+	return slog, nil
+}
+
+// BoltSagaLogConfig struct is used by goice to create a saga log stored in
+// a single file with an embedded key-value store.
+// Path specifies the file to store the Sagalog in.
+// Sync specifies when to fsync: "always" (the default), "interval" or "never". Only "always"
+// keeps the file from being corrupted by a machine failure, see sagalogs.SyncPolicy.
+// SyncInterval is the time between fsyncs with "interval", human readable ex: "100ms"
+// Retention specifies when completed sagas are compacted or deleted.
+type BoltSagaLogConfig struct {
+	Type         string
+	Path         string
+	Sync         string
+	SyncInterval string
+	Retention    SagaLogRetentionConfig
+}
+
+// Adds the BoltSagaLogConfig Create function to the goice MagicBag
+func (c *BoltSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the BoltSagaLog
+func (c *BoltSagaLogConfig) Create() (saga.SagaLog, error) {
+	var policy sagalogs.SyncPolicy
+	switch c.Sync {
+	case "", "always":
+		policy = sagalogs.SyncAlways
+	case "interval":
+		policy = sagalogs.SyncInterval
+	case "never":
+		policy = sagalogs.SyncNever
+	default:
+		return nil, fmt.Errorf("Unknown saga log sync policy %q, expected always, interval or never", c.Sync)
+	}
+	interval, err := parseDuration(c.SyncInterval)
+	if err != nil {
+		return nil, err
+	}
+	retention, err := c.Retention.Create()
+	if err != nil {
+		return nil, err
+	}
+	slog, err := sagalogs.MakeBoltSagaLog(c.Path, policy, interval)
+	if err != nil {
+		return nil, err
+	}
+	startRetention(slog, retention)
+	return slog, nil
+}
+
+// RaftSagaLogConfig struct is used by goice to create a saga log replicated to
+// a quorum of peers, so a standby scheduler can take over if the leader fails.
+// BindAddr is the address to listen on for raft traffic ex: "localhost:9100", and identifies this peer.
+// Peers is the BindAddr of every peer including this one, used to form the cluster on first start.
+// Directory specifies the directory to store the raft log and snapshots in.
+// ApplyTimeout is the time to wait for a message to be replicated, human readable ex: "10s"
+// HeartbeatTimeout and ElectionTimeout control how quickly a failed leader is replaced, ex: "1s"
+// Retention specifies when completed sagas are compacted or deleted.
+//
+// Several schedulers can share a log on one machine by giving each its own BindAddr and Directory.
+// Only the leader serves requests, see RecoverJobsOnStartup to recover its jobs when it takes over.
+type RaftSagaLogConfig struct {
+	Type             string
+	BindAddr         string
+	Peers            []string
+	Directory        string
+	ApplyTimeout     string
+	HeartbeatTimeout string
+	ElectionTimeout  string
+	Retention        SagaLogRetentionConfig
+}
+
+// Adds the RaftSagaLogConfig Create function to the goice MagicBag
+func (c *RaftSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the RaftSagaLog
+func (c *RaftSagaLogConfig) Create() (saga.SagaLog, error) {
+	config := sagalogs.RaftConfig{BindAddr: c.BindAddr, Peers: c.Peers, Directory: c.Directory}
+	var err error
+	if config.ApplyTimeout, err = parseDuration(c.ApplyTimeout); err != nil {
+		return nil, err
+	}
+	if config.HeartbeatTimeout, err = parseDuration(c.HeartbeatTimeout); err != nil {
+		return nil, err
+	}
+	if config.ElectionTimeout, err = parseDuration(c.ElectionTimeout); err != nil {
+		return nil, err
+	}
+	retention, err := c.Retention.Create()
+	if err != nil {
+		return nil, err
+	}
+	slog, err := sagalogs.MakeRaftSagaLog(config)
+	if err != nil {
+		return nil, err
+	}
+	startRetention(slog, retention)
+	return slog, nil
+}
+
+// SagaLogRetentionConfig determines when completed sagas are compacted or deleted,
+// see saga.RetentionPolicy. Durations are human readable ex: "24h", and empty or zero
+// values disable the corresponding limit.
+type SagaLogRetentionConfig struct {
+	CompactAfter      string
+	DeleteAfter       string
+	MaxCompletedSagas int
+	Interval          string
+}
+
+// Creates the retention policy
+func (c SagaLogRetentionConfig) Create() (saga.RetentionPolicy, error) {
+	var p saga.RetentionPolicy
+	var err error
+	if p.CompactAfter, err = parseDuration(c.CompactAfter); err != nil {
+		return p, err
+	}
+	if p.DeleteAfter, err = parseDuration(c.DeleteAfter); err != nil {
+		return p, err
+	}
+	if p.Interval, err = parseDuration(c.Interval); err != nil {
+		return p, err
+	}
+	p.MaxCompletedSagas = c.MaxCompletedSagas
+	return p, nil
+}
+
+// Starts applying the retention policy to the log in the background, unless it retains everything.
+func startRetention(slog saga.CompactableSagaLog, policy saga.RetentionPolicy) {
+	if !policy.IsZero() {
+		saga.StartRetention(slog, policy)
+	}
+}
+
+// Parses a human readable duration, empty is zero.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
 }
@@ -27,6 +27,10 @@ const DefaultReadyFnBackoff = 5 * time.Second
 // RecoverJobsOnStartup - if true, the scheduler recovers active sagas,
 //             from the sagalog, and restarts them.
 // DefaultTaskTimeout - default timeout for tasks, human readable ex: "30m"
+// QuotaWindow - rolling window for node hour quotas, human readable ex: "24h"
+// PreemptMinRuntime - see scheduler.PreemptionPolicy.MinRuntime, human readable ex: "5m"
+// PreemptMaxJobFraction - see scheduler.PreemptionPolicy.MaxJobFraction
+// PreemptNearCompletionFraction - see scheduler.PreemptionPolicy.NearCompletionFraction
 //
 // See scheduler.SchedulerConfig for comments on the remaining fields.
 type StatefulSchedulerConfig struct {
@@ -39,6 +43,18 @@ type StatefulSchedulerConfig struct {
 	MaxRequestors           int
 	MaxJobsPerRequestor     int
 	SoftMaxSchedulableTasks int
+	ActionCacheSize         int
+	TaskDurationsFile       string
+	DrainingNodesFile       string
+	FairShare               bool
+	RequestorWeights        map[string]float64
+	RequestorQuotas         map[string]scheduler.RequestorQuota
+	DefaultRequestorQuota   scheduler.RequestorQuota
+	QuotaWindow             string
+
+	PreemptMinRuntime             string
+	PreemptMaxJobFraction         float64
+	PreemptNearCompletionFraction float64
 }
 
 func (c *StatefulSchedulerConfig) Install(bag *ice.MagicBag) {
@@ -61,6 +77,20 @@ func (c *StatefulSchedulerConfig) Create() (scheduler.SchedulerConfig, error) {
 			return scheduler.SchedulerConfig{}, err
 		}
 	}
+	var qw time.Duration
+	if c.QuotaWindow != "" {
+		qw, err = time.ParseDuration(c.QuotaWindow)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
+	var pmr time.Duration
+	if c.PreemptMinRuntime != "" {
+		pmr, err = time.ParseDuration(c.PreemptMinRuntime)
+		if err != nil {
+			return scheduler.SchedulerConfig{}, err
+		}
+	}
 
 	return scheduler.SchedulerConfig{
 		MaxRetriesPerTask:       c.MaxRetriesPerTask,
@@ -74,5 +104,18 @@ func (c *StatefulSchedulerConfig) Create() (scheduler.SchedulerConfig, error) {
 		MaxRequestors:           c.MaxRequestors,
 		MaxJobsPerRequestor:     c.MaxJobsPerRequestor,
 		SoftMaxSchedulableTasks: c.SoftMaxSchedulableTasks,
+		ActionCacheSize:         c.ActionCacheSize,
+		TaskDurationsFile:       c.TaskDurationsFile,
+		DrainingNodesFile:       c.DrainingNodesFile,
+		FairShare:               c.FairShare,
+		RequestorWeights:        c.RequestorWeights,
+		RequestorQuotas:         c.RequestorQuotas,
+		DefaultRequestorQuota:   c.DefaultRequestorQuota,
+		QuotaWindow:             qw,
+		Preemption: scheduler.PreemptionPolicy{
+			MinRuntime:             pmr,
+			MaxJobFraction:         c.PreemptMaxJobFraction,
+			NearCompletionFraction: c.PreemptNearCompletionFraction,
+		},
 	}, nil
 }
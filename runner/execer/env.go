@@ -0,0 +1,76 @@
+package execer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvPolicy determines the environment of the processes an Execer runs, in addition to each Command's EnvVars.
+// The zero value inherits the whole environment of the executing process, which is the legacy behavior.
+type EnvPolicy struct {
+	// Names of the host's environment variables processes inherit. Nil inherits all of them.
+	Inherit []string
+	// If true processes inherit none of the host's environment variables, for hermetic runs. Overrides Inherit.
+	Clean bool
+	// Variables set for every process, overriding inherited ones. Command EnvVars override these.
+	Defaults map[string]string
+}
+
+// Returns the environment, as sorted key=value pairs, of a process run with envVars
+// by a host with the environment hostEnv, formatted like os.Environ().
+func (p EnvPolicy) Environ(hostEnv []string, envVars map[string]string) []string {
+	env := map[string]string{}
+	if !p.Clean {
+		inherit := map[string]bool{}
+		for _, k := range p.Inherit {
+			inherit[k] = true
+		}
+		for _, kv := range hostEnv {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) == 2 && (p.Inherit == nil || inherit[parts[0]]) {
+				env[parts[0]] = parts[1]
+			}
+		}
+	}
+	for k, v := range p.Defaults {
+		env[k] = v
+	}
+	for k, v := range envVars {
+		env[k] = v
+	}
+
+	environ := make([]string, 0, len(env))
+	for k, v := range env {
+		environ = append(environ, k+"="+v)
+	}
+	sort.Strings(environ)
+	return environ
+}
+
+// Parses comma separated variable names to inherit, ex: "PATH,HOME", and comma separated
+// key=value default variables, ex: "JAVA_HOME=/usr/lib/jvm/default,LANG=C".
+// An empty inherit string inherits all variables.
+func ParseEnvPolicy(inherit, defaults string, clean bool) (EnvPolicy, error) {
+	p := EnvPolicy{Clean: clean, Defaults: map[string]string{}}
+	for _, k := range strings.Split(inherit, ",") {
+		if k == "" {
+			continue
+		}
+		if strings.Contains(k, "=") {
+			return EnvPolicy{}, fmt.Errorf("Invalid inherited environment variable name %q", k)
+		}
+		p.Inherit = append(p.Inherit, k)
+	}
+	for _, kv := range strings.Split(defaults, ",") {
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return EnvPolicy{}, fmt.Errorf("Invalid default environment variable %q, expected key=value", kv)
+		}
+		p.Defaults[parts[0]] = parts[1]
+	}
+	return p, nil
+}
@@ -0,0 +1,47 @@
+package execer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnviron(t *testing.T) {
+	host := []string{"PATH=/bin", "HOME=/home/worker", "SECRET=hunter2"}
+	envVars := map[string]string{"SHARD": "1", "HOME": "/tmp"}
+
+	if env := (EnvPolicy{}).Environ(host, envVars); !reflect.DeepEqual(env, []string{
+		"HOME=/tmp", "PATH=/bin", "SECRET=hunter2", "SHARD=1"}) {
+		t.Errorf("Expected the whole host environment to be inherited by default, got %v", env)
+	}
+
+	p := EnvPolicy{Inherit: []string{"PATH", "HOME"}, Defaults: map[string]string{"JAVA_HOME": "/jdk", "SHARD": "0"}}
+	if env := p.Environ(host, envVars); !reflect.DeepEqual(env, []string{
+		"HOME=/tmp", "JAVA_HOME=/jdk", "PATH=/bin", "SHARD=1"}) {
+		t.Errorf("Expected only allowed variables and defaults overridden by the command, got %v", env)
+	}
+
+	p.Clean = true
+	if env := p.Environ(host, envVars); !reflect.DeepEqual(env, []string{"HOME=/tmp", "JAVA_HOME=/jdk", "SHARD=1"}) {
+		t.Errorf("Expected no inherited variables with a clean environment, got %v", env)
+	}
+}
+
+func TestParseEnvPolicy(t *testing.T) {
+	p, err := ParseEnvPolicy("PATH,HOME", "JAVA_HOME=/jdk,EMPTY=", false)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := EnvPolicy{Inherit: []string{"PATH", "HOME"}, Defaults: map[string]string{"JAVA_HOME": "/jdk", "EMPTY": ""}}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, p)
+	}
+	if p, err := ParseEnvPolicy("", "", true); err != nil || p.Inherit != nil || !p.Clean {
+		t.Errorf("Expected a clean policy, got %+v, %v", p, err)
+	}
+	if _, err := ParseEnvPolicy("", "JAVA_HOME", false); err == nil {
+		t.Errorf("Expected an error parsing a default without a value")
+	}
+	if _, err := ParseEnvPolicy("A=B", "", false); err == nil {
+		t.Errorf("Expected an error parsing an invalid name to inherit")
+	}
+}
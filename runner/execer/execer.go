@@ -2,6 +2,7 @@ package execer
 
 import (
 	"io"
+	"time"
 
 	"github.com/twitter/scoot/common/log/tags"
 )
@@ -12,15 +13,21 @@ import (
 
 // Memory in bytes.
 //FIXME(jschiller) arbitrary commands can spawn dissociated/untracked child processes (ppid=1)
+// Those are only tracked and cleaned up when running with cgroups, cf. os.CgroupConfig.
 type Memory uint64
 
 type Command struct {
-	Argv   []string
-	Dir    string
-	Stdout io.Writer
-	Stderr io.Writer
+	Argv []string
+	// Environment variables set for the process, overriding those from the Execer's EnvPolicy.
+	EnvVars map[string]string
+	Dir     string
+	Stdout  io.Writer
+	Stderr  io.Writer
+	// Run the process in a sandbox isolated from the host, even if the Execer doesn't sandbox every process.
+	Sandbox bool
+	// Run the process sandboxed in this image's root filesystem rather than the host's, if not empty.
+	Image string
 	tags.LogTags
-	// TODO(dbentley): environment variables?
 }
 
 type ProcessState int
@@ -68,4 +75,8 @@ type ProcessStatus struct {
 	State    ProcessState
 	ExitCode int
 	Error    string
+
+	// Resources used by the process and its descendants, zero if the Execer couldn't measure them.
+	PeakMemory Memory
+	CPUTime    time.Duration
 }
@@ -0,0 +1,580 @@
+// Package oci unpacks OCI images from a worker's local disk into root filesystems commands can run in,
+// without needing a container daemon.
+package oci
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// The annotation naming a manifest in an image layout's index, ex: "latest".
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+// Directories created in every rootfs, so they can be mounted on when running in it.
+var mountPoints = []string{"proc", "dev", "tmp", strings.TrimPrefix(CheckoutDir, "/")}
+
+// Where a command's checkout is mounted when it runs in an image.
+const CheckoutDir = "/scoot/checkout"
+
+// The number of images and extracted tarballs a worker keeps cached by default.
+const DefaultMaxCachedImages = 8
+
+// An image unpacked into a root filesystem.
+type Image struct {
+	// The host directory of the image's root filesystem, shared by every command run in it. Treat it as read-only.
+	Rootfs string
+	// The image's default environment, as key=value pairs.
+	Env []string
+
+	release func()
+	once    sync.Once
+}
+
+// Lets the image be evicted from its Store once nothing else is using it. Call it once the command run in it exits.
+func (i *Image) Release() {
+	i.once.Do(i.release)
+}
+
+// Store unpacks images under an image directory into cached root filesystems, so commands
+// using the same image don't unpack it again. Images are OCI image layouts, either as
+// directories or tarballs like those written by `skopeo copy ... oci-archive:<path>`.
+// The least recently used images and tarballs that aren't in use are evicted past maxCached of each.
+type Store struct {
+	imageDir  string
+	cacheDir  string
+	maxCached int
+
+	// Guards the maps below. It isn't held while extracting or unpacking, which only hold the lock
+	// of the tarball or image they're working on, so different images are unpacked concurrently.
+	mu    sync.Mutex
+	locks map[string]*keyLock
+	// Layouts extracted from tarballs, by the tarball's path.
+	archives map[string]*archive
+	// Unpacked images, by their manifest's digest.
+	images map[string]*cachedImage
+}
+
+// A lock on a tarball path or image digest, removed once nothing holds or waits for it.
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+type archive struct {
+	layout   string
+	size     int64
+	mtime    int64
+	lastUsed time.Time
+}
+
+type cachedImage struct {
+	env      []string
+	refs     int
+	lastUsed time.Time
+}
+
+// Creates a Store of the images under imageDir, unpacking them under cacheDir and keeping at most maxCached of them.
+// Images unpacked in cacheDir by a previous Store are reused, everything else it left there is removed.
+func NewStore(imageDir, cacheDir string, maxCached int) (*Store, error) {
+	s := &Store{
+		imageDir:  imageDir,
+		cacheDir:  cacheDir,
+		maxCached: maxCached,
+		locks:     map[string]*keyLock{},
+		archives:  map[string]*archive{},
+		images:    map[string]*cachedImage{},
+	}
+	entries, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range entries {
+		dir := filepath.Join(cacheDir, fi.Name())
+		if strings.HasPrefix(fi.Name(), "rootfs-") {
+			if env, err := readCachedEnv(dir); err == nil {
+				s.images[strings.TrimPrefix(fi.Name(), "rootfs-")] = &cachedImage{env: env, lastUsed: fi.ModTime()}
+				continue
+			}
+		}
+		// Partially unpacked or evicted images, and extracted tarballs, which aren't worth reusing without their tarball's stat.
+		log.Infof("Removing %s left in the image cache", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+	}
+	s.evict()
+	return s, nil
+}
+
+// Returns the image named name, unpacking it if it isn't cached. Name is the path of an image layout
+// directory or tarball relative to the image directory, optionally followed by ':' and the ref name of one
+// of its manifests, ex: "toolchains/go.tar:1.8". Without a ref name the layout must have one linux manifest for
+// this worker's architecture. Layers are unpacked as the worker's user, so ownership in the image is lost.
+// The image isn't evicted until it's released.
+func (s *Store) Get(name string) (*Image, error) {
+	path, ref := name, ""
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		path, ref = name[:i], name[i+1:]
+	}
+	path = filepath.Clean(path)
+	if path == "." || filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("Invalid image %q, it must be a path relative to the image directory", name)
+	}
+	path = filepath.Join(s.imageDir, path)
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't find image %q: %v", name, err)
+	}
+	layout := path
+	if !fi.IsDir() {
+		// Held until the image is unpacked, so the extracted layout isn't replaced or evicted while it's read.
+		unlock := s.lock(path)
+		defer unlock()
+		if layout, err = s.extractArchive(path, fi); err != nil {
+			return nil, fmt.Errorf("Couldn't extract image %q: %v", name, err)
+		}
+	}
+
+	manifestDesc, err := findManifest(layout, ref)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't find manifest of image %q: %v", name, err)
+	}
+	img, err := s.unpack(layout, manifestDesc)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't unpack image %q: %v", name, err)
+	}
+	return img, nil
+}
+
+// Locks key, returning a func that unlocks it.
+func (s *Store) lock(key string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = &keyLock{}
+		s.locks[key] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(s.locks, key)
+		}
+	}
+}
+
+// Returns the cached image with the manifest manifestDesc describes, unpacking it if it isn't cached yet.
+// Each image is cached in a directory containing its rootfs and config.
+func (s *Store) unpack(layout string, manifestDesc descriptor) (*Image, error) {
+	digest, err := manifestDesc.hex()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(s.cacheDir, "rootfs-"+digest)
+	if img := s.use(digest); img != nil {
+		return img, nil
+	}
+	unlock := s.lock(digest)
+	defer unlock()
+	// Another command may have unpacked it while this one waited for the lock.
+	if img := s.use(digest); img != nil {
+		return img, nil
+	}
+
+	var m manifest
+	if err := readJSONBlob(layout, manifestDesc, &m); err != nil {
+		return nil, fmt.Errorf("Couldn't read manifest: %v", err)
+	}
+	var c config
+	if err := readJSONBlob(layout, m.Config, &c); err != nil {
+		return nil, fmt.Errorf("Couldn't read config: %v", err)
+	}
+	// Unpack next to the cache entry and rename it into place, so a partially unpacked rootfs is never used.
+	tmp, err := ioutil.TempDir(s.cacheDir, "unpacking-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	rootfs := filepath.Join(tmp, "rootfs")
+	if err := os.Mkdir(rootfs, 0755); err != nil {
+		return nil, err
+	}
+	for _, l := range m.Layers {
+		if err := unpackLayerBlob(layout, l, rootfs); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range mountPoints {
+		mountPoint, err := Resolve(rootfs, p)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(mountPoint, 0755); err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, "config.json"), data, 0644); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.images[digest] = &cachedImage{env: c.Config.Env}
+	s.mu.Unlock()
+	img := s.use(digest)
+	s.evict()
+	return img, nil
+}
+
+// Returns the cached image with digest, marked as in use until it's released, or nil if it isn't cached.
+func (s *Store) use(digest string) *Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ci, ok := s.images[digest]
+	if !ok {
+		return nil
+	}
+	ci.refs++
+	ci.lastUsed = time.Now()
+	return &Image{
+		Rootfs: filepath.Join(s.cacheDir, "rootfs-"+digest, "rootfs"),
+		Env:    ci.env,
+		release: func() {
+			s.mu.Lock()
+			ci.refs--
+			ci.lastUsed = time.Now()
+			s.mu.Unlock()
+			s.evict()
+		},
+	}
+}
+
+// Removes the least recently used images and extracted tarballs that aren't in use, until at most maxCached of each are left.
+func (s *Store) evict() {
+	var remove []string
+	s.mu.Lock()
+	for len(s.images) > s.maxCached {
+		oldest := ""
+		for digest, ci := range s.images {
+			if ci.refs == 0 && s.locks[digest] == nil && (oldest == "" || ci.lastUsed.Before(s.images[oldest].lastUsed)) {
+				oldest = digest
+			}
+		}
+		if oldest == "" {
+			break
+		}
+		delete(s.images, oldest)
+		// Move it out of the way under the lock, so it isn't in the way of unpacking it again while it's removed.
+		dir := filepath.Join(s.cacheDir, "rootfs-"+oldest)
+		trash, err := ioutil.TempDir(s.cacheDir, "evicted-")
+		if err == nil {
+			if err = os.Rename(dir, filepath.Join(trash, "rootfs")); err != nil {
+				os.Remove(trash)
+			}
+		}
+		if err != nil {
+			log.Errorf("Couldn't move evicted image %s, removing it in place: %v", dir, err)
+			trash = dir
+		}
+		remove = append(remove, trash)
+	}
+	for len(s.archives) > s.maxCached {
+		oldest := ""
+		for path, a := range s.archives {
+			if s.locks[path] == nil && (oldest == "" || a.lastUsed.Before(s.archives[oldest].lastUsed)) {
+				oldest = path
+			}
+		}
+		if oldest == "" {
+			break
+		}
+		remove = append(remove, s.archives[oldest].layout)
+		delete(s.archives, oldest)
+	}
+	s.mu.Unlock()
+
+	for _, dir := range remove {
+		os.RemoveAll(dir)
+	}
+}
+
+// Returns the environment in the config of the image cached in dir.
+func readCachedEnv(dir string) ([]string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+	var c config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return c.Config.Env, nil
+}
+
+func unpackLayerBlob(layout string, d descriptor, rootfs string) error {
+	var r io.Reader
+	blob, err := openBlob(layout, d)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	switch {
+	case strings.HasSuffix(d.MediaType, "gzip"):
+		gz, err := gzip.NewReader(blob)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case strings.HasSuffix(d.MediaType, "tar"):
+		r = blob
+	default:
+		return fmt.Errorf("Unsupported layer media type %q", d.MediaType)
+	}
+	if err := unpackLayer(rootfs, r); err != nil {
+		return fmt.Errorf("Couldn't unpack layer %s: %v", d.Digest, err)
+	}
+	// Read the rest of the blob, which tar may not have, so its digest can be checked.
+	if _, err := io.Copy(ioutil.Discard, blob); err != nil {
+		return err
+	}
+	return blob.Close()
+}
+
+// Extracts the image layout in the tarball at path, unless it was already extracted and hasn't changed since.
+// The caller must hold path's lock.
+func (s *Store) extractArchive(path string, fi os.FileInfo) (string, error) {
+	s.mu.Lock()
+	a, ok := s.archives[path]
+	if ok && a.size == fi.Size() && a.mtime == fi.ModTime().UnixNano() {
+		a.lastUsed = time.Now()
+		s.mu.Unlock()
+		return a.layout, nil
+	}
+	delete(s.archives, path)
+	s.mu.Unlock()
+	if ok {
+		os.RemoveAll(a.layout)
+	}
+
+	layout, err := ioutil.TempDir(s.cacheDir, "layout-")
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		os.RemoveAll(layout)
+		return "", err
+	}
+	defer f.Close()
+	if err := extractLayout(layout, f); err != nil {
+		os.RemoveAll(layout)
+		return "", err
+	}
+	s.mu.Lock()
+	s.archives[path] = &archive{layout: layout, size: fi.Size(), mtime: fi.ModTime().UnixNano(), lastUsed: time.Now()}
+	s.mu.Unlock()
+	s.evict()
+	return layout, nil
+}
+
+// Extracts the regular files and directories of an image layout tarball into dir.
+func extractLayout(dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		path, err := Resolve(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(path, tr, 0644); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Image layout, manifest and config formats, cf. https://github.com/opencontainers/image-spec.
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type index struct {
+	Manifests []descriptor `json:"manifests"`
+}
+
+type manifest struct {
+	Config descriptor   `json:"config"`
+	Layers []descriptor `json:"layers"`
+}
+
+type config struct {
+	Config struct {
+		Env []string `json:"Env"`
+	} `json:"config"`
+}
+
+const indexMediaType = "application/vnd.oci.image.index.v1+json"
+
+// Returns the hex encoded sha256 digest of the blob d describes. Other algorithms aren't supported.
+func (d descriptor) hex() (string, error) {
+	parts := strings.SplitN(d.Digest, ":", 2)
+	if len(parts) != 2 || parts[0] != "sha256" || len(parts[1]) != sha256.Size*2 {
+		return "", fmt.Errorf("Unsupported digest %q", d.Digest)
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return "", fmt.Errorf("Invalid digest %q", d.Digest)
+	}
+	return parts[1], nil
+}
+
+// Returns the descriptor of the manifest in layout named ref, or the only one for this platform if ref is empty.
+func findManifest(layout, ref string) (descriptor, error) {
+	data, err := ioutil.ReadFile(filepath.Join(layout, "index.json"))
+	if err != nil {
+		return descriptor{}, err
+	}
+	var idx index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return descriptor{}, err
+	}
+	// A manifest may be an index of manifests for different platforms, so look for matching manifests in those too.
+	for depth := 0; depth < 8; depth++ {
+		var matches []descriptor
+		for _, d := range idx.Manifests {
+			if ref != "" && d.Annotations[refNameAnnotation] != ref {
+				continue
+			}
+			if d.Platform != nil && (d.Platform.OS != "linux" || d.Platform.Architecture != runtime.GOARCH) {
+				continue
+			}
+			matches = append(matches, d)
+		}
+		switch {
+		case len(matches) == 0 && ref != "":
+			return descriptor{}, fmt.Errorf("No manifest named %q for linux/%s", ref, runtime.GOARCH)
+		case len(matches) == 0:
+			return descriptor{}, fmt.Errorf("No manifest for linux/%s", runtime.GOARCH)
+		case len(matches) > 1:
+			return descriptor{}, errors.New("More than one manifest, name one with image:ref")
+		case matches[0].MediaType != indexMediaType:
+			return matches[0], nil
+		}
+		idx = index{}
+		if err := readJSONBlob(layout, matches[0], &idx); err != nil {
+			return descriptor{}, err
+		}
+		// Ref names only apply to the top level index.
+		ref = ""
+	}
+	return descriptor{}, errors.New("Too many nested indexes")
+}
+
+func readJSONBlob(layout string, d descriptor, v interface{}) error {
+	blob, err := openBlob(layout, d)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	data, err := ioutil.ReadAll(blob)
+	if err != nil {
+		return err
+	}
+	if err := blob.Close(); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// A blob that checks its digest once it's been read completely, when it's closed.
+type verifiedBlob struct {
+	f        *os.File
+	expected string
+	hash     hash.Hash
+	r        io.Reader
+	closed   bool
+}
+
+func openBlob(layout string, d descriptor) (*verifiedBlob, error) {
+	hexDigest, err := d.hex()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(layout, "blobs", "sha256", hexDigest))
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	return &verifiedBlob{f: f, expected: hexDigest, hash: h, r: io.TeeReader(f, h)}, nil
+}
+
+func (b *verifiedBlob) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
+
+// Closes the blob, returning an error if it was read completely but didn't match its digest.
+func (b *verifiedBlob) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	defer b.f.Close()
+	if n, _ := b.f.Read(make([]byte, 1)); n > 0 {
+		// Not completely read, which is only the case when returning an earlier error.
+		return nil
+	}
+	if actual := hex.EncodeToString(b.hash.Sum(nil)); actual != b.expected {
+		return fmt.Errorf("Blob sha256:%s has digest sha256:%s", b.expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// A layer tarball entry. Directories end with "/", symlinks have a link, and hard links start it with "=".
+type entry struct {
+	name string
+	data string
+	link string
+}
+
+func makeLayer(t *testing.T, entries []entry) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(e.data))}
+		switch {
+		case strings.HasSuffix(e.name, "/"):
+			hdr.Typeflag, hdr.Mode = tar.TypeDir, 0755
+		case strings.HasPrefix(e.link, "="):
+			hdr.Typeflag, hdr.Linkname = tar.TypeLink, e.link[1:]
+		case e.link != "":
+			hdr.Typeflag, hdr.Linkname = tar.TypeSymlink, e.link
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			tw.Write([]byte(e.data))
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+// Writes blob to layout, returning its descriptor.
+func writeBlob(t *testing.T, layout, mediaType string, blob []byte) descriptor {
+	sum := sha256.Sum256(blob)
+	digest := hex.EncodeToString(sum[:])
+	if err := os.MkdirAll(filepath.Join(layout, "blobs", "sha256"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(layout, "blobs", "sha256", digest), blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return descriptor{MediaType: mediaType, Digest: "sha256:" + digest, Size: int64(len(blob))}
+}
+
+func writeJSON(t *testing.T, layout, mediaType string, v interface{}) descriptor {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeBlob(t, layout, mediaType, data)
+}
+
+// Writes an image layout dir under imageDir with one manifest per element of layers, named by refs.
+func makeImage(t *testing.T, imageDir, name string, env []string, refs []string, layers ...[][]entry) {
+	layout := filepath.Join(imageDir, name)
+	idx := index{}
+	for i, ls := range layers {
+		var c config
+		c.Config.Env = env
+		m := manifest{Config: writeJSON(t, layout, "application/vnd.oci.image.config.v1+json", c)}
+		for _, l := range ls {
+			m.Layers = append(m.Layers, writeBlob(t, layout, "application/vnd.oci.image.layer.v1.tar+gzip", makeLayer(t, l)))
+		}
+		d := writeJSON(t, layout, "application/vnd.oci.image.manifest.v1+json", m)
+		d.Platform = &platform{OS: "linux", Architecture: runtime.GOARCH}
+		d.Annotations = map[string]string{refNameAnnotation: refs[i]}
+		idx.Manifests = append(idx.Manifests, d)
+	}
+	data, _ := json.Marshal(idx)
+	if err := ioutil.WriteFile(filepath.Join(layout, "index.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func makeStore(t *testing.T) (*Store, string) {
+	dir, err := ioutil.TempDir("", "oci_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Mkdir(filepath.Join(dir, "images"), 0755)
+	os.Mkdir(filepath.Join(dir, "cache"), 0755)
+	s, err := NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "cache"), DefaultMaxCachedImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, dir
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Couldn't read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestUnpackLayers(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	makeImage(t, s.imageDir, "img", []string{"PATH=/usr/bin"}, []string{"latest"}, [][]entry{
+		{
+			{name: "usr/bin/tool", data: "v1"},
+			{name: "etc/old", data: "old"},
+			{name: "etc/kept", data: "kept"},
+			{name: "opt/lower/file", data: "lower"},
+			{name: "bin", link: "usr/bin"},
+		},
+		{
+			{name: "usr/bin/tool", data: "v2"},
+			{name: "etc/.wh.old"},
+			{name: "opt/"},
+			{name: "opt/new", data: "new"},
+			{name: "opt/.wh..wh..opq"},
+			{name: "bin/other", data: "other"},
+			{name: "usr/bin/linked", link: "=/usr/bin/tool"},
+		},
+	})
+
+	img, err := s.Get("img")
+	if err != nil {
+		t.Fatalf("Couldn't get image: %v", err)
+	}
+	if len(img.Env) != 1 || img.Env[0] != "PATH=/usr/bin" {
+		t.Fatalf("Expected the image's env, got %v", img.Env)
+	}
+	if got := readFile(t, filepath.Join(img.Rootfs, "usr/bin/tool")); got != "v2" {
+		t.Fatalf("Expected the upper layer's file, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(img.Rootfs, "usr/bin/linked")); got != "v2" {
+		t.Fatalf("Expected a hard link to the upper layer's file, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(img.Rootfs, "usr/bin/other")); got != "other" {
+		t.Fatalf("Expected a file written through a symlink to be in its target, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(img.Rootfs, "etc/kept")); got != "kept" {
+		t.Fatalf("Expected a file without a whiteout to be kept, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(img.Rootfs, "opt/new")); got != "new" {
+		t.Fatalf("Expected a file in an opaque dir's own layer to be kept, got %q", got)
+	}
+	for _, p := range []string{"etc/old", "opt/lower"} {
+		if _, err := os.Lstat(filepath.Join(img.Rootfs, p)); !os.IsNotExist(err) {
+			t.Fatalf("Expected %s to be whited out, got %v", p, err)
+		}
+	}
+	if fi, err := os.Stat(filepath.Join(img.Rootfs, CheckoutDir)); err != nil || !fi.IsDir() {
+		t.Fatalf("Expected a checkout mount point, got %v", err)
+	}
+
+	// The rootfs is cached, even if the layout goes away.
+	os.RemoveAll(filepath.Join(s.imageDir, "img", "blobs"))
+	cached, err := s.Get("img:latest")
+	if err != nil || cached.Rootfs != img.Rootfs {
+		t.Fatalf("Expected the cached rootfs %s, got %v %v", img.Rootfs, cached, err)
+	}
+}
+
+func TestUnpackStaysInRootfs(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	outside := filepath.Join(dir, "outside")
+	os.Mkdir(outside, 0755)
+	makeImage(t, s.imageDir, "img", nil, []string{"latest"}, [][]entry{
+		{
+			{name: "escape", link: outside},
+			{name: "up", link: "../../../../../../../../" + outside},
+			{name: "../../dotdot", data: "dotdot"},
+		},
+		{
+			{name: "escape/abs", data: "abs"},
+			{name: "up/rel", data: "rel"},
+		},
+	})
+
+	img, err := s.Get("img")
+	if err != nil {
+		t.Fatalf("Couldn't get image: %v", err)
+	}
+	if files, _ := ioutil.ReadDir(outside); len(files) != 0 {
+		t.Fatalf("Expected nothing written outside the rootfs, got %v", files)
+	}
+	for _, p := range []string{filepath.Join(outside, "abs"), filepath.Join(outside, "rel"), "dotdot"} {
+		if _, err := os.Stat(filepath.Join(img.Rootfs, p)); err != nil {
+			t.Fatalf("Expected %s in the rootfs, got %v", p, err)
+		}
+	}
+
+	if _, err := s.Get("../images/img"); err == nil {
+		t.Fatalf("Expected an error getting an image outside the image dir")
+	}
+}
+
+func TestImageTarballsAndRefs(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	layouts := filepath.Join(dir, "layouts")
+	makeImage(t, layouts, "img", nil, []string{"a", "b"},
+		[][]entry{{{name: "name", data: "a"}}},
+		[][]entry{{{name: "name", data: "b"}}})
+
+	// Tar up the layout like an oci-archive.
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	filepath.Walk(filepath.Join(layouts, "img"), func(path string, fi os.FileInfo, err error) error {
+		rel, _ := filepath.Rel(filepath.Join(layouts, "img"), path)
+		if fi.IsDir() {
+			return tw.WriteHeader(&tar.Header{Name: rel + "/", Typeflag: tar.TypeDir, Mode: 0755})
+		}
+		tw.WriteHeader(&tar.Header{Name: rel, Typeflag: tar.TypeReg, Mode: 0644, Size: fi.Size()})
+		data, _ := ioutil.ReadFile(path)
+		_, err = tw.Write(data)
+		return err
+	})
+	tw.Close()
+	if err := ioutil.WriteFile(filepath.Join(s.imageDir, "img.tar"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get("img.tar"); err == nil || !strings.Contains(err.Error(), "More than one manifest") {
+		t.Fatalf("Expected an error getting an image with two manifests without a ref, got %v", err)
+	}
+	for _, ref := range []string{"a", "b"} {
+		img, err := s.Get("img.tar:" + ref)
+		if err != nil {
+			t.Fatalf("Couldn't get image %s: %v", ref, err)
+		}
+		if got := readFile(t, filepath.Join(img.Rootfs, "name")); got != ref {
+			t.Fatalf("Expected image %s, got %s", ref, got)
+		}
+	}
+	if _, err := s.Get("img.tar:c"); err == nil {
+		t.Fatalf("Expected an error getting a ref that doesn't exist")
+	}
+}
+
+func TestCorruptBlob(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	makeImage(t, s.imageDir, "img", nil, []string{"latest"}, [][]entry{{{name: "file", data: "data"}}})
+	blobs, _ := filepath.Glob(filepath.Join(s.imageDir, "img", "blobs", "sha256", "*"))
+	for _, b := range blobs {
+		if data := readFile(t, b); !strings.HasPrefix(data, "{") {
+			// Replace the layer with a valid one with different contents.
+			ioutil.WriteFile(b, makeLayer(t, []entry{{name: "file", data: "evil"}}), 0644)
+		}
+	}
+	if _, err := s.Get("img"); err == nil || !strings.Contains(err.Error(), "digest") {
+		t.Fatalf("Expected a digest mismatch, got %v", err)
+	}
+	if entries, _ := filepath.Glob(filepath.Join(s.cacheDir, "rootfs-*")); len(entries) != 0 {
+		t.Fatalf("Expected nothing cached for a corrupt image, got %v", entries)
+	}
+}
+
+func TestEvictionAndRestart(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	s.maxCached = 1
+	makeImage(t, s.imageDir, "a", nil, []string{"latest"}, [][]entry{{{name: "name", data: "a"}}})
+	makeImage(t, s.imageDir, "b", nil, []string{"latest"}, [][]entry{{{name: "name", data: "b"}}})
+	cached := func() []string {
+		entries, _ := filepath.Glob(filepath.Join(s.cacheDir, "rootfs-*"))
+		return entries
+	}
+
+	a, err := s.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.Get("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Neither is evicted while they're in use.
+	if got := readFile(t, filepath.Join(a.Rootfs, "name")); got != "a" || len(cached()) != 2 {
+		t.Fatalf("Expected both images cached while in use, got %v", cached())
+	}
+	a.Release()
+	if got := readFile(t, filepath.Join(b.Rootfs, "name")); got != "b" || len(cached()) != 1 {
+		t.Fatalf("Expected only b cached once a was released, got %v", cached())
+	}
+	b.Release()
+
+	// A new Store reuses the cached image and removes what an interrupted unpack left behind.
+	os.Mkdir(filepath.Join(s.cacheDir, "unpacking-1"), 0755)
+	os.Mkdir(filepath.Join(s.cacheDir, "layout-1"), 0755)
+	if s, err = NewStore(s.imageDir, s.cacheDir, 1); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := ioutil.ReadDir(s.cacheDir); len(entries) != 1 || len(s.images) != 1 {
+		t.Fatalf("Expected only the cached image after restarting, got %v", cached())
+	}
+	if b, err = s.Get("b"); err != nil || readFile(t, filepath.Join(b.Rootfs, "name")) != "b" {
+		t.Fatalf("Couldn't get cached image after restarting: %v", err)
+	}
+}
+
+func TestConcurrentGet(t *testing.T) {
+	s, dir := makeStore(t)
+	defer os.RemoveAll(dir)
+	makeImage(t, s.imageDir, "img", nil, []string{"latest"}, [][]entry{{{name: "name", data: "img"}}})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			img, err := s.Get("img")
+			if err == nil {
+				img.Release()
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Couldn't get image concurrently: %v", err)
+		}
+	}
+	if entries, _ := ioutil.ReadDir(s.cacheDir); len(entries) != 1 {
+		t.Fatalf("Expected the image to be unpacked once, got %d cache entries", len(entries))
+	}
+}
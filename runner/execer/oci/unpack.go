@@ -0,0 +1,183 @@
+package oci
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Layer entries that delete a path from the layers below, or every entry in a directory from them.
+const (
+	whiteoutPrefix = ".wh."
+	opaqueWhiteout = ".wh..wh..opq"
+)
+
+// The most symlinks followed resolving one path, like the kernel's limit.
+const maxSymlinks = 40
+
+// Applies the layer tarball read from r to rootfs, following the OCI whiteout conventions.
+// Only regular files, directories, symlinks and hard links are created, devices and fifos are skipped.
+func unpackLayer(rootfs string, r io.Reader) error {
+	// Paths created by this layer, which its own whiteouts don't apply to.
+	created := map[string]bool{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		dir, base := path.Split(path.Clean("/" + hdr.Name))
+		if base == "" {
+			// The layer's root, which already exists.
+			continue
+		}
+		parent, err := Resolve(rootfs, dir)
+		if err != nil {
+			return err
+		}
+
+		if base == opaqueWhiteout {
+			if err := removeChildren(parent, created); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			if err := os.RemoveAll(filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return err
+		}
+		target := filepath.Join(parent, base)
+		// Replace what the layers below have at target, unless both are directories, whose contents are merged.
+		if fi, err := os.Lstat(target); err == nil && !(fi.IsDir() && hdr.Typeflag == tar.TypeDir) {
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		if hdr.Mode&04000 != 0 {
+			mode |= os.ModeSetuid
+		}
+		if hdr.Mode&02000 != 0 {
+			mode |= os.ModeSetgid
+		}
+		if hdr.Mode&01000 != 0 {
+			mode |= os.ModeSticky
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.Mkdir(target, 0755); err != nil && !os.IsExist(err) {
+				return err
+			}
+			// Directories stay writable by the worker so the rootfs can be removed, like any other temp dir.
+			err = os.Chmod(target, mode|0700)
+		case tar.TypeReg, tar.TypeRegA:
+			if err = writeFile(target, tr, mode); err == nil {
+				err = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+			}
+		case tar.TypeSymlink:
+			err = os.Symlink(hdr.Linkname, target)
+		case tar.TypeLink:
+			var src string
+			linkDir, linkBase := path.Split(path.Clean("/" + hdr.Linkname))
+			if src, err = Resolve(rootfs, linkDir); err == nil {
+				err = os.Link(filepath.Join(src, linkBase), target)
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		created[target] = true
+	}
+}
+
+// Returns the host path of p, a path in rootfs, following symlinks as if rootfs was the root
+// directory, so that a layer can't use them to write outside of it. The last part of p is
+// resolved too, and p's parts that don't exist are left as they are.
+func Resolve(rootfs, p string) (string, error) {
+	parts := strings.Split(p, "/")
+	resolved := "/"
+	for links := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			// The parent of the root is the root.
+			resolved = path.Dir(resolved)
+			continue
+		}
+		next := path.Join(resolved, part)
+		fi, err := os.Lstat(filepath.Join(rootfs, next))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		if err != nil || fi.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		if links++; links > maxSymlinks {
+			return "", errors.New("Too many symlinks resolving " + p)
+		}
+		link, err := os.Readlink(filepath.Join(rootfs, next))
+		if err != nil {
+			return "", err
+		}
+		if path.IsAbs(link) {
+			resolved = "/"
+		}
+		parts = append(strings.Split(link, "/"), parts...)
+	}
+	return filepath.Join(rootfs, resolved), nil
+}
+
+// Removes the entries of dir that weren't created by the current layer.
+func removeChildren(dir string, created map[string]bool) error {
+	children, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, c := range children {
+		if p := filepath.Join(dir, c.Name()); !created[p] {
+			if err := os.RemoveAll(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Creates a file at path with mode containing what's read from r.
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// Set the mode explicitly so it isn't masked by the umask and keeps setuid and setgid bits.
+	return os.Chmod(path, mode)
+}
@@ -0,0 +1,203 @@
+package os
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// How long to wait for a killed cgroup's processes to exit before giving up on removing it.
+const cgroupKillTimeout = 10 * time.Second
+
+// Runs each command in its own linux cgroup v2, which limits the resources of the command and all of
+// its descendants, accounts for their usage, and lets every one of them be killed, including those that
+// left the command's process group or were reparented to init.
+// Zero limits are unlimited. Memory is limited by the execer's memCap.
+type CgroupConfig struct {
+	// An existing or creatable cgroup, ex: /sys/fs/cgroup/scoot, for this worker's runs alone.
+	// The worker must be able to write to it, and its parent must make the controllers of configured limits available.
+	Parent string
+	// Cpu time available to each run as a number of cpus, ex: 1.5, set as cpu.max.
+	CPUs float64
+	// The number of processes and threads each run may have at once, set as pids.max.
+	Pids int
+}
+
+// Creates the cgroups of runs, see CgroupConfig.
+type cgroups struct {
+	CgroupConfig
+	memCap execer.Memory
+	nextId int64
+}
+
+// Prepares config.Parent to hold the cgroups of runs, killing and removing any left over by a previous worker.
+func makeCgroups(config CgroupConfig, memCap execer.Memory) (*cgroups, error) {
+	if err := os.MkdirAll(config.Parent, 0755); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(config.Parent, "cgroup.controllers"))
+	if err != nil {
+		return nil, fmt.Errorf("%s isn't a cgroup v2: %v", config.Parent, err)
+	}
+	available := map[string]bool{}
+	for _, c := range strings.Fields(string(data)) {
+		available[c] = true
+	}
+	// Enable the memory controller even when memory is unlimited to account for usage, if it's available.
+	required := map[string]bool{"memory": memCap > 0, "cpu": config.CPUs > 0, "pids": config.Pids > 0}
+	for _, c := range []string{"memory", "cpu", "pids"} {
+		if !available[c] {
+			if required[c] {
+				return nil, fmt.Errorf("Cgroup %s doesn't have the %s controller needed to limit runs", config.Parent, c)
+			}
+			continue
+		}
+		err := ioutil.WriteFile(filepath.Join(config.Parent, "cgroup.subtree_control"), []byte("+"+c), 0644)
+		if err != nil && required[c] {
+			return nil, fmt.Errorf("Couldn't enable the %s controller of cgroup %s: %v", c, config.Parent, err)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(config.Parent)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			log.Infof("Cleaning up cgroup %s left by a previous worker", entry.Name())
+			stale := &cgroup{dir: filepath.Join(config.Parent, entry.Name())}
+			if err := stale.remove(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &cgroups{CgroupConfig: config, memCap: memCap}, nil
+}
+
+// Creates a cgroup with the configured limits for a new run.
+func (c *cgroups) create() (*cgroup, error) {
+	id := atomic.AddInt64(&c.nextId, 1)
+	cg := &cgroup{dir: filepath.Join(c.Parent, fmt.Sprintf("run-%d", id))}
+	if err := os.Mkdir(cg.dir, 0755); err != nil {
+		return nil, err
+	}
+	limits := map[string]string{}
+	if c.memCap > 0 {
+		limits["memory.max"] = strconv.FormatUint(uint64(c.memCap), 10)
+		// Kill the whole run rather than a single process when it runs out of memory.
+		limits["memory.oom.group"] = "1"
+	}
+	if c.CPUs > 0 {
+		limits["cpu.max"] = fmt.Sprintf("%d 100000", int64(c.CPUs*100000))
+	}
+	if c.Pids > 0 {
+		limits["pids.max"] = strconv.Itoa(c.Pids)
+	}
+	for name, value := range limits {
+		if err := cg.write(name, value); err != nil {
+			cg.remove()
+			return nil, err
+		}
+	}
+	return cg, nil
+}
+
+// The cgroup of a single run.
+type cgroup struct {
+	dir string
+}
+
+func (c *cgroup) write(name, value string) error {
+	return ioutil.WriteFile(filepath.Join(c.dir, name), []byte(value), 0644)
+}
+
+// Returns the values of a flat keyed file like cgroup.events or cpu.stat, ex: "populated 0".
+func (c *cgroup) readKeyed(name string) (map[string]uint64, error) {
+	f, err := os.Open(filepath.Join(c.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+				values[fields[0]] = v
+			}
+		}
+	}
+	return values, scanner.Err()
+}
+
+// Wraps argv so its process moves itself into this cgroup before executing argv. Starting the process
+// directly and then moving it would let any children it forks in between escape the cgroup.
+func (c *cgroup) wrap(argv []string) []string {
+	return append([]string{"/bin/sh", "-c", `echo $$ > "$0" && exec "$@"`, filepath.Join(c.dir, "cgroup.procs")}, argv...)
+}
+
+// Kills every process in the cgroup and waits for them to exit.
+func (c *cgroup) kill() error {
+	// cgroup.kill requires linux 5.14, before that freeze the processes so they can't fork while they're killed.
+	if err := c.write("cgroup.kill", "1"); err != nil {
+		c.write("cgroup.freeze", "1")
+		data, err := ioutil.ReadFile(filepath.Join(c.dir, "cgroup.procs"))
+		if err != nil {
+			return err
+		}
+		for _, p := range strings.Fields(string(data)) {
+			if pid, err := strconv.Atoi(p); err == nil {
+				syscall.Kill(pid, syscall.SIGKILL)
+			}
+		}
+		c.write("cgroup.freeze", "0")
+	}
+	for start := time.Now(); time.Since(start) < cgroupKillTimeout; time.Sleep(10 * time.Millisecond) {
+		events, err := c.readKeyed("cgroup.events")
+		if err != nil {
+			return err
+		}
+		if events["populated"] == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("Processes of cgroup %s still running %v after being killed", c.dir, cgroupKillTimeout)
+}
+
+// Returns the resources used by the cgroup's processes, zero if they can't be read,
+// and whether the cgroup ran out of memory.
+func (c *cgroup) usage() (peakMemory execer.Memory, cpuTime time.Duration, oom bool) {
+	// memory.peak requires linux 5.19.
+	if data, err := ioutil.ReadFile(filepath.Join(c.dir, "memory.peak")); err == nil {
+		if peak, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			peakMemory = execer.Memory(peak)
+		}
+	}
+	if stat, err := c.readKeyed("cpu.stat"); err == nil {
+		cpuTime = time.Duration(stat["usage_usec"]) * time.Microsecond
+	}
+	if events, err := c.readKeyed("memory.events"); err == nil {
+		oom = events["oom_kill"] > 0
+	}
+	return peakMemory, cpuTime, oom
+}
+
+// Kills any remaining processes and removes the cgroup.
+func (c *cgroup) remove() error {
+	if err := c.kill(); err != nil {
+		return err
+	}
+	return syscall.Rmdir(c.dir)
+}
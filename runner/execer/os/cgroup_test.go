@@ -0,0 +1,168 @@
+package os
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Returns a new cgroup to use as a CgroupConfig.Parent, skipping the test if there's no writable cgroup v2 hierarchy.
+func makeTestCgroupParent(t *testing.T) string {
+	f, err := os.Open("/proc/self/mounts")
+	if err != nil {
+		t.Skip("Can't find cgroup v2 mount: ", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 2 && fields[2] == "cgroup2" {
+			parent := filepath.Join(fields[1], fmt.Sprintf("scoot-test-%d", os.Getpid()))
+			if err := os.Mkdir(parent, 0755); err != nil {
+				t.Skip("Can't create cgroup: ", err)
+			}
+			return parent
+		}
+	}
+	t.Skip("No cgroup v2 mount")
+	return ""
+}
+
+// Returns the names of the cgroups under parent.
+func childCgroups(parent string) []string {
+	children := []string{}
+	entries, _ := ioutil.ReadDir(parent)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			children = append(children, entry.Name())
+		}
+	}
+	return children
+}
+
+// Returns true if pid has exited, zombies included.
+func isProcessDone(pid int) bool {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	// The state follows the parenthesized command name.
+	fields := strings.Fields(string(data[bytes.LastIndexByte(data, ')')+1:]))
+	return len(fields) == 0 || fields[0] == "Z" || fields[0] == "X"
+}
+
+func TestCgroupKillsDetachedProcesses(t *testing.T) {
+	parent := makeTestCgroupParent(t)
+	defer syscall.Rmdir(parent)
+	e, err := NewCgroupExecer(0, CgroupConfig{Parent: parent}, execer.EnvPolicy{}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf("Couldn't make execer %v", err)
+	}
+
+	// Start a process in its own session, and so its own process group, which outlives the command.
+	var stdout bytes.Buffer
+	cmd := execer.Command{
+		Argv:   []string{"/bin/sh", "-c", "setsid sleep 1000 >/dev/null 2>&1 & echo -n $!"},
+		Stdout: &stdout,
+		Stderr: ioutil.Discard,
+	}
+	p, err := e.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	if status := p.Wait(); status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	pid, err := strconv.Atoi(stdout.String())
+	if err != nil {
+		t.Fatalf("Expected the detached pid, got %q", stdout.String())
+	}
+	if !isProcessDone(pid) {
+		syscall.Kill(pid, syscall.SIGKILL)
+		t.Fatalf("Expected detached process %d to be killed", pid)
+	}
+	if children := childCgroups(parent); len(children) != 0 {
+		t.Fatalf("Expected the run's cgroup to be removed, got %v", children)
+	}
+}
+
+func TestCgroupAbort(t *testing.T) {
+	parent := makeTestCgroupParent(t)
+	defer syscall.Rmdir(parent)
+	e, err := NewCgroupExecer(0, CgroupConfig{Parent: parent}, execer.EnvPolicy{}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf("Couldn't make execer %v", err)
+	}
+
+	cmd := execer.Command{
+		Argv:   []string{"/bin/sh", "-c", "setsid sleep 1000 & sleep 1000"},
+		Stdout: ioutil.Discard,
+		Stderr: ioutil.Discard,
+	}
+	p, err := e.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if status := p.Abort(); status.State != execer.FAILED {
+		t.Fatalf("Got unexpected status aborting sh %v", status)
+	}
+	if children := childCgroups(parent); len(children) != 0 {
+		t.Fatalf("Expected the run's cgroup to be removed after killing its processes, got %v", children)
+	}
+}
+
+func TestCgroupUsage(t *testing.T) {
+	parent := makeTestCgroupParent(t)
+	defer syscall.Rmdir(parent)
+	e, err := NewCgroupExecer(0, CgroupConfig{Parent: parent}, execer.EnvPolicy{}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf("Couldn't make execer %v", err)
+	}
+
+	cmd := execer.Command{
+		Argv:   []string{"/bin/sh", "-c", "i=0; while [ $i -lt 100000 ]; do i=$((i+1)); done"},
+		Stdout: ioutil.Discard,
+		Stderr: ioutil.Discard,
+	}
+	p, err := e.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	status := p.Wait()
+	if status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	if status.CPUTime <= 0 {
+		t.Fatalf("Expected cpu time to be measured, got %v", status)
+	}
+
+	if _, err := e.Exec(execer.Command{Argv: []string{"not-a-real-binary"}}); err == nil {
+		t.Fatalf("Expected an error running a binary that doesn't exist")
+	}
+}
+
+func TestCgroupCleansUpStaleRuns(t *testing.T) {
+	parent := makeTestCgroupParent(t)
+	defer syscall.Rmdir(parent)
+	if err := os.Mkdir(filepath.Join(parent, "run-1"), 0755); err != nil {
+		t.Fatalf("Couldn't create cgroup %v", err)
+	}
+	if _, err := NewCgroupExecer(0, CgroupConfig{Parent: parent}, execer.EnvPolicy{}, stats.NilStatsReceiver()); err != nil {
+		t.Fatalf("Couldn't make execer %v", err)
+	}
+	if children := childCgroups(parent); len(children) != 0 {
+		t.Fatalf("Expected the stale cgroup to be removed, got %v", children)
+	}
+}
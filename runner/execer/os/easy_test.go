@@ -2,6 +2,7 @@ package os
 
 import (
 	"bytes"
+	"os"
 	"testing"
 	"time"
 
@@ -75,6 +76,37 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestEnv(t *testing.T) {
+	os.Setenv("SCOOT_TEST_INHERITED", "inherited")
+	os.Setenv("SCOOT_TEST_NOT_INHERITED", "not inherited")
+	defer os.Unsetenv("SCOOT_TEST_INHERITED")
+	defer os.Unsetenv("SCOOT_TEST_NOT_INHERITED")
+
+	env := execer.EnvPolicy{
+		Inherit:  []string{"SCOOT_TEST_INHERITED"},
+		Defaults: map[string]string{"SCOOT_TEST_DEFAULT": "default", "SCOOT_TEST_TASK": "overridden"},
+	}
+	exer := NewBoundedExecer(0, env, stats.NilStatsReceiver())
+
+	var stdout, stderr bytes.Buffer
+	cmd := execer.Command{
+		Argv:    []string{"/bin/sh", "-c", "echo -n $SCOOT_TEST_INHERITED,$SCOOT_TEST_NOT_INHERITED,$SCOOT_TEST_DEFAULT,$SCOOT_TEST_TASK"},
+		EnvVars: map[string]string{"SCOOT_TEST_TASK": "task"},
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	p, err := exer.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	if status := p.Wait(); status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	if expected := "inherited,,default,task"; stdout.String() != expected {
+		t.Fatalf("Incorrect environment, got %q; expected %q", stdout.String(), expected)
+	}
+}
+
 func TestMemUsage(t *testing.T) {
 	// Command to increase memory by 1MB every .1s until we hit 50MB after 5s.
 	// Creates a bash process and under that a python process. They should both contribute to MemUsage.
@@ -127,7 +159,7 @@ func TestMemCap(t *testing.T) {
 		},
 	}
 	// Terminate nearly immediately, after memory grows to 1MB.
-	e := NewBoundedExecer(execer.Memory(1024*1024), stats.NilStatsReceiver())
+	e := NewBoundedExecer(execer.Memory(1024*1024), execer.EnvPolicy{}, stats.NilStatsReceiver())
 	process, err := e.Exec(cmd)
 	if err != nil {
 		t.Fatalf(err.Error())
@@ -27,14 +27,28 @@ func NewExecer() *osExecer {
 
 // For now memory can be capped on a per-execer basis rather than a per-command basis.
 // This is ok since we currently (Q1 2017) only support one run at a time in our codebase.
-func NewBoundedExecer(memCap execer.Memory, stat stats.StatsReceiver) *osExecer {
-	return &osExecer{memCap: memCap, stat: stat.Scope("osexecer")}
+// The env policy determines the environment each command's EnvVars are added to.
+func NewBoundedExecer(memCap execer.Memory, env execer.EnvPolicy, stat stats.StatsReceiver) *osExecer {
+	return &osExecer{memCap: memCap, env: env, stat: stat.Scope("osexecer")}
+}
+
+// Like NewBoundedExecer, but runs each command in its own cgroup which the kernel limits to memCap
+// and config's limits, instead of monitoring its memory usage. Only supported on linux with cgroup v2.
+func NewCgroupExecer(memCap execer.Memory, config CgroupConfig, env execer.EnvPolicy, stat stats.StatsReceiver) (*osExecer, error) {
+	cgroups, err := makeCgroups(config, memCap)
+	if err != nil {
+		return nil, err
+	}
+	return &osExecer{memCap: memCap, cgroups: cgroups, env: env, stat: stat.Scope("osexecer")}, nil
 }
 
 type osExecer struct {
 	// Best effort monitoring of command to kill it if resident memory usage exceeds this cap. Ignored if zero.
 	memCap execer.Memory
-	stat   stats.StatsReceiver
+	// If set, the cgroups commands are run in, which enforce memCap in place of monitoring.
+	cgroups *cgroups
+	env     execer.EnvPolicy
+	stat    stats.StatsReceiver
 }
 
 type WriterDelegater interface {
@@ -47,16 +61,55 @@ type WriterDelegater interface {
 }
 
 func (e *osExecer) Exec(command execer.Command) (result execer.Process, err error) {
+	return e.exec(command, nil)
+}
+
+// Execs command, in a sandbox configured by sandbox if it's not nil.
+func (e *osExecer) exec(command execer.Command, sandbox *sandboxSpec) (result execer.Process, err error) {
 	if len(command.Argv) == 0 {
 		return nil, errors.New("No command specified.")
 	}
 
-	cmd := exec.Command(command.Argv[0], command.Argv[1:]...)
+	argv := command.Argv
+	if sandbox != nil {
+		if argv, err = sandboxArgv(*sandbox, argv); err != nil {
+			return nil, err
+		}
+	}
+	var cg *cgroup
+	if e.cgroups != nil {
+		// Check the binary exists so it fails to exec as it would without the cgroup wrapper.
+		if _, err := exec.LookPath(argv[0]); err != nil {
+			return nil, err
+		}
+		if cg, err = e.cgroups.create(); err != nil {
+			return nil, fmt.Errorf("Couldn't create cgroup: %v", err)
+		}
+		argv = cg.wrap(argv)
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
 	cmd.Dir = command.Dir
+	cmd.Env = e.env.Environ(os.Environ(), command.EnvVars)
+	if sandbox != nil && sandbox.Rootfs != "" {
+		// Commands run in an image get its environment rather than the host's.
+		cmd.Env = execer.EnvPolicy{}.Environ(sandbox.Env, command.EnvVars)
+	}
 
 	// Sets pgid of all child processes to cmd's pid
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
+	var sandboxErrs, sandboxErrsW *os.File
+	if sandbox != nil {
+		if sandboxErrs, sandboxErrsW, err = sandboxCmd(cmd, sandbox.SandboxConfig); err != nil {
+			if cg != nil {
+				cg.remove()
+			}
+			return nil, err
+		}
+		defer sandboxErrs.Close()
+	}
+
 	// Make sure to get the best possible Writer, so if possible os/exec can connect
 	// the command's stdout/stderr directly to a file, instead of having to go through
 	// our delegation
@@ -90,12 +143,24 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 
 	// Async start of the command.
 	err = cmd.Start()
+	if sandboxErrsW != nil {
+		sandboxErrsW.Close()
+	}
 	if err != nil {
+		if cg != nil {
+			cg.remove()
+		}
 		return nil, err
 	}
 
-	proc := &osProcess{cmd: cmd, wg: &wg, LogTags: command.LogTags}
-	if e.memCap > 0 {
+	proc := &osProcess{cmd: cmd, wg: &wg, cgroup: cg, memCap: e.memCap, LogTags: command.LogTags}
+	if sandboxErrs != nil {
+		if err := waitForSandbox(sandboxErrs); err != nil {
+			proc.Abort()
+			return nil, err
+		}
+	}
+	if e.memCap > 0 && cg == nil {
 		go e.monitorMem(proc)
 	}
 	return proc, nil
@@ -104,8 +169,12 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 type osProcess struct {
 	cmd    *exec.Cmd
 	wg     *sync.WaitGroup
+	cgroup *cgroup
+	memCap execer.Memory
 	result *execer.ProcessStatus
-	mutex  sync.Mutex
+	// The most memory monitoring saw in use, when not running in a cgroup.
+	peakMem execer.Memory
+	mutex   sync.Mutex
 	tags.LogTags
 }
 
@@ -159,6 +228,9 @@ func (e *osExecer) monitorMem(p *osProcess) {
 			}
 			mem, _ := e.memUsage(pid)
 			e.stat.Gauge(stats.WorkerMemory).Update(int64(mem))
+			if mem > p.peakMem {
+				p.peakMem = mem
+			}
 			// Aborting process, above memCap
 			if mem >= e.memCap {
 				msg := fmt.Sprintf("Cmd exceeded MemoryCap, aborting %d: %d > %d (%v)", pid, mem, e.memCap, p.cmd.Args)
@@ -173,8 +245,9 @@ func (e *osExecer) monitorMem(p *osProcess) {
 						"taskID": p.TaskID,
 					}).Info(msg)
 				p.result = &execer.ProcessStatus{
-					State: execer.FAILED,
-					Error: msg,
+					State:      execer.FAILED,
+					Error:      msg,
+					PeakMemory: p.peakMem,
 				}
 				p.mutex.Unlock()
 				p.Abort()
@@ -298,6 +371,7 @@ func (p *osProcess) Wait() (result execer.ProcessStatus) {
 	} else {
 		p.result = &result
 	}
+	defer func() { p.finish(&result, p.cmd.ProcessState) }()
 	if err == nil {
 		// the command finished without an error
 		result.State = execer.COMPLETE
@@ -340,15 +414,59 @@ func (p *osProcess) Abort() (result execer.ProcessStatus) {
 	if err != nil {
 		result.Error = "Aborted. Couldn't kill process. Will still attempt cleanup."
 	}
-	_, err = p.cmd.Process.Wait()
+	if p.cgroup != nil {
+		// Also kill descendants which left the process group or were reparented.
+		if err := p.cgroup.kill(); err != nil {
+			result.Error = fmt.Sprintf("Aborted. Couldn't kill all processes: %v", err)
+		}
+	}
+	state, err := p.cmd.Process.Wait()
 	if err, ok := err.(*exec.ExitError); ok {
 		if status, ok := err.Sys().(syscall.WaitStatus); ok {
 			result.ExitCode = status.ExitStatus()
 		}
 	}
+	p.finish(&result, state)
 	return result
 }
 
+// Records the resources used by the process and, when running in a cgroup, kills any
+// processes it left behind and removes the cgroup. Must be called once with the mutex held.
+func (p *osProcess) finish(result *execer.ProcessStatus, state *os.ProcessState) {
+	if p.cgroup == nil {
+		result.PeakMemory = p.peakMem
+		// Only includes the cpu time of descendants the process waited for.
+		if state != nil {
+			result.CPUTime = state.UserTime() + state.SystemTime()
+		}
+		return
+	}
+	if err := p.cgroup.kill(); err != nil {
+		log.WithFields(
+			log.Fields{
+				"error":  err,
+				"tag":    p.Tag,
+				"jobID":  p.JobID,
+				"taskID": p.TaskID,
+			}).Error("Error killing processes left in cgroup")
+	}
+	var oom bool
+	result.PeakMemory, result.CPUTime, oom = p.cgroup.usage()
+	if oom && result.State != execer.FAILED {
+		result.State = execer.FAILED
+		result.Error = fmt.Sprintf("Cmd exceeded MemoryCap %d and was killed (%v)", p.memCap, p.cmd.Args)
+	}
+	if err := p.cgroup.remove(); err != nil {
+		log.WithFields(
+			log.Fields{
+				"error":  err,
+				"tag":    p.Tag,
+				"jobID":  p.JobID,
+				"taskID": p.TaskID,
+			}).Error("Error removing cgroup")
+	}
+}
+
 // Kill process along with all child processes, assuming no child processes called setpgid
 func cleanupProcs(pgid int) (err error) {
 	log.WithFields(
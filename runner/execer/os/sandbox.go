@@ -0,0 +1,134 @@
+package os
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/oci"
+)
+
+// The argument the worker's binary is executed with to become a sandbox's init process,
+// which sets up the sandbox from inside of it and then executes the sandboxed command.
+const sandboxInitArg = "scoot-sandbox-init"
+
+// The file descriptor a sandbox's init process reports setup errors on. It's closed once the command is executed.
+const sandboxErrFd = 3
+
+// Isolates commands in a sandbox of linux user, mount, pid and network namespaces. Sandboxed commands can
+// only write to their checkout and /tmp if it's a tmpfs, and they and all of their descendants are killed
+// when they exit. They run as root in the sandbox, which is the worker's user outside of it.
+type SandboxConfig struct {
+	// Sandbox every command, not only those that ask for it with Command.Sandbox.
+	All bool
+	// Let sandboxed commands use the host's network. Otherwise they only have a loopback interface.
+	Network bool
+	// Size of an empty tmpfs mounted on /tmp for each sandboxed command. Zero leaves /tmp read-only.
+	TmpfsSize execer.Memory
+}
+
+// How a sandbox init process sets up a command's sandbox.
+type sandboxSpec struct {
+	SandboxConfig
+	// The root filesystem of the image the command runs in, with its checkout mounted at oci.CheckoutDir.
+	// Empty runs the command in the host's filesystem.
+	Rootfs string
+	// The image's environment, which the command gets instead of the host's.
+	Env []string `json:"-"`
+}
+
+// Runs commands with delegate, in a sandbox if config.All is set or the command asks for one.
+// Commands that name an image are always sandboxed, in the image's root filesystem from images,
+// which may be nil if this worker doesn't run commands in images.
+// Sandboxing is only supported on linux by binaries that import this package, which
+// the sandbox's init process runs as.
+func NewSandboxExecer(config SandboxConfig, images *oci.Store, delegate *osExecer) *sandboxExecer {
+	return &sandboxExecer{config: config, images: images, delegate: delegate}
+}
+
+type sandboxExecer struct {
+	config   SandboxConfig
+	images   *oci.Store
+	delegate *osExecer
+}
+
+func (s *sandboxExecer) Exec(command execer.Command) (execer.Process, error) {
+	if command.Image != "" {
+		if s.images == nil {
+			return nil, fmt.Errorf("Can't run in image %q, this worker has no images", command.Image)
+		}
+		img, err := s.images.Get(command.Image)
+		if err != nil {
+			return nil, err
+		}
+		p, err := s.delegate.exec(command, &sandboxSpec{SandboxConfig: s.config, Rootfs: img.Rootfs, Env: img.Env})
+		if err != nil {
+			img.Release()
+			return nil, err
+		}
+		return &imageProcess{Process: p, img: img}, nil
+	}
+	if !s.config.All && !command.Sandbox {
+		return s.delegate.Exec(command)
+	}
+	return s.delegate.exec(command, &sandboxSpec{SandboxConfig: s.config})
+}
+
+// A process running in an image, which is released once the process exits.
+type imageProcess struct {
+	execer.Process
+	img *oci.Image
+}
+
+func (p *imageProcess) Wait() execer.ProcessStatus {
+	defer p.img.Release()
+	return p.Process.Wait()
+}
+
+func (p *imageProcess) Abort() execer.ProcessStatus {
+	defer p.img.Release()
+	return p.Process.Abort()
+}
+
+// Returns the argv of a sandbox init process that executes argv.
+func sandboxArgv(spec sandboxSpec, argv []string) ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{exe, sandboxInitArg, string(data)}, argv...), nil
+}
+
+// Sets cmd up to run a sandbox init process, returning the read end of the pipe it reports errors on,
+// and the write end, which is closed once cmd is started.
+func sandboxCmd(cmd *exec.Cmd, config SandboxConfig) (r *os.File, w *os.File, err error) {
+	if err := sandboxSysProcAttr(cmd.SysProcAttr, config); err != nil {
+		return nil, nil, err
+	}
+	if r, w, err = os.Pipe(); err != nil {
+		return nil, nil, err
+	}
+	cmd.ExtraFiles = []*os.File{w}
+	return r, w, nil
+}
+
+// Blocks until a sandbox init process executes its command, returning why it couldn't if it didn't.
+func waitForSandbox(errs *os.File) error {
+	msg, err := ioutil.ReadAll(errs)
+	if err != nil {
+		return err
+	}
+	if len(msg) > 0 {
+		return errors.New("Couldn't set up sandbox: " + strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
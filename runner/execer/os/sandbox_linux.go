@@ -0,0 +1,306 @@
+package os
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/oci"
+)
+
+// Statfs flags of a mount that can't be changed by a less privileged user namespace, like the sandbox's.
+const (
+	stNosuid     = 0x2
+	stNodev      = 0x4
+	stNoexec     = 0x8
+	stNoatime    = 0x400
+	stNodiratime = 0x800
+	stRelatime   = 0x1000
+)
+
+// If this process is a sandbox init process, sets up the sandbox and executes the sandboxed command,
+// otherwise does nothing. See sandboxArgv.
+func init() {
+	if len(os.Args) < 4 || os.Args[1] != sandboxInitArg {
+		return
+	}
+	err := initSandbox(os.Args[2], os.Args[3:])
+	errs := os.NewFile(sandboxErrFd, "sandbox errors")
+	fmt.Fprint(errs, err)
+	os.Exit(1)
+}
+
+func sandboxSysProcAttr(attr *syscall.SysProcAttr, config SandboxConfig) error {
+	attr.Cloneflags = syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID
+	if !config.Network {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	// The init process needs to be root in the sandbox to set up its mounts.
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	attr.GidMappingsEnableSetgroups = false
+	return nil
+}
+
+// Runs in the sandbox's new namespaces as pid 1, in the command's checkout. Only returns on error.
+func initSandbox(specJSON string, argv []string) error {
+	var spec sandboxSpec
+	if err := json.Unmarshal([]byte(specJSON), &spec); err != nil {
+		return err
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	// Keep the sandbox's mounts from propagating to the host.
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("Couldn't make mounts private: %v", err)
+	}
+	// The checkout may be under /tmp, so keep a reference to it before it's hidden by the tmpfs.
+	checkout, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer checkout.Close()
+	checkoutPath := fmt.Sprintf("/proc/self/fd/%d", checkout.Fd())
+	if spec.Rootfs != "" {
+		if err := enterImage(spec, checkoutPath); err != nil {
+			return err
+		}
+		dir = oci.CheckoutDir
+	} else if err := mountHost(spec.SandboxConfig, checkoutPath, dir); err != nil {
+		return err
+	}
+	if !spec.Network {
+		if err := setLoopbackUp(); err != nil {
+			return fmt.Errorf("Couldn't set up loopback interface: %v", err)
+		}
+	}
+
+	// Enter the checkout's mount rather than the directory it covers.
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	// Look for the command in the sandbox's filesystem, and its PATH, which is the image's when running in one.
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		return err
+	}
+	syscall.CloseOnExec(sandboxErrFd)
+	return syscall.Exec(path, argv, os.Environ())
+}
+
+// Makes the host's filesystem read-only, except for the checkout at dir and /tmp if it's a tmpfs.
+func mountHost(config SandboxConfig, checkoutPath, dir string) error {
+	if config.TmpfsSize > 0 {
+		if err := mountTmpfs("/tmp", config.TmpfsSize); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	if err := syscall.Mount(checkoutPath, dir, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("Couldn't mount checkout: %v", err)
+	}
+
+	mounts, err := mountPoints()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if isUnder(m, dir) || (config.TmpfsSize > 0 && isUnder(m, "/tmp")) {
+			continue
+		}
+		if err := remountReadOnly(m); err != nil {
+			return fmt.Errorf("Couldn't make %s read-only: %v", m, err)
+		}
+	}
+	// Only show the sandbox's processes. This isn't allowed if the host's /proc is partially hidden,
+	// as it is in some containers, in which case the host's /proc is left in place.
+	mountProc("/proc")
+	return nil
+}
+
+// Makes the image's read-only rootfs the sandbox's root, with the checkout mounted at oci.CheckoutDir,
+// a minimal /dev, and a tmpfs on /tmp if configured. Nothing from the host is left visible.
+func enterImage(spec sandboxSpec, checkoutPath string) error {
+	rootfs := spec.Rootfs
+	// Mount the rootfs on itself so it can become the root, read-only since it's shared by every command using the image.
+	if err := syscall.Mount(rootfs, rootfs, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("Couldn't mount image: %v", err)
+	}
+	if err := remountReadOnly(rootfs); err != nil {
+		return fmt.Errorf("Couldn't make image read-only: %v", err)
+	}
+	// Mount targets are resolved in the rootfs so the image's symlinks can't point them at the host.
+	checkout, err := oci.Resolve(rootfs, oci.CheckoutDir)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Mount(checkoutPath, checkout, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("Couldn't mount checkout: %v", err)
+	}
+	if spec.TmpfsSize > 0 {
+		tmp, err := oci.Resolve(rootfs, "/tmp")
+		if err != nil {
+			return err
+		}
+		if err := mountTmpfs(tmp, spec.TmpfsSize); err != nil {
+			return err
+		}
+	}
+	dev, err := oci.Resolve(rootfs, "/dev")
+	if err != nil {
+		return err
+	}
+	if err := mountDev(dev); err != nil {
+		return fmt.Errorf("Couldn't mount /dev: %v", err)
+	}
+	// Unlike on the host, a partially hidden /proc can't be left in place, so fall back to the host's.
+	proc, err := oci.Resolve(rootfs, "/proc")
+	if err != nil {
+		return err
+	}
+	if err := mountProc(proc); err != nil {
+		if err := syscall.Mount("/proc", proc, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			return fmt.Errorf("Couldn't mount /proc: %v", err)
+		}
+	}
+
+	// Switch to the rootfs and detach the host's root, which pivot_root leaves stacked under it.
+	if err := os.Chdir(rootfs); err != nil {
+		return err
+	}
+	if err := syscall.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("Couldn't pivot to image: %v", err)
+	}
+	if err := syscall.Unmount(".", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("Couldn't unmount host: %v", err)
+	}
+	return os.Chdir("/")
+}
+
+func mountTmpfs(path string, size execer.Memory) error {
+	opts := fmt.Sprintf("size=%d,mode=1777", size)
+	if err := syscall.Mount("tmpfs", path, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, opts); err != nil {
+		return fmt.Errorf("Couldn't mount tmpfs: %v", err)
+	}
+	return nil
+}
+
+func mountProc(path string) error {
+	return syscall.Mount("proc", path, "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, "")
+}
+
+// Mounts a read-only tmpfs at path with the host's common character devices bound into it,
+// since the sandbox isn't allowed to create device nodes.
+func mountDev(path string) error {
+	if err := syscall.Mount("tmpfs", path, "tmpfs", syscall.MS_NOSUID|syscall.MS_NOEXEC, "mode=755"); err != nil {
+		return err
+	}
+	for _, d := range []string{"null", "zero", "full", "random", "urandom", "tty"} {
+		dev := filepath.Join(path, d)
+		if err := ioutil.WriteFile(dev, nil, 0666); err != nil {
+			return err
+		}
+		if err := syscall.Mount("/dev/"+d, dev, "", syscall.MS_BIND, ""); err != nil {
+			return err
+		}
+	}
+	for name, target := range map[string]string{
+		"fd": "/proc/self/fd", "stdin": "/proc/self/fd/0", "stdout": "/proc/self/fd/1", "stderr": "/proc/self/fd/2"} {
+		if err := os.Symlink(target, filepath.Join(path, name)); err != nil {
+			return err
+		}
+	}
+	return syscall.Mount("", path, "", syscall.MS_REMOUNT|syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NOEXEC, "")
+}
+
+// Returns true if path is dir or a path under it.
+func isUnder(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// Returns the mount points of this mount namespace.
+func mountPoints() ([]string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	mounts := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 4 {
+			// Whitespace and backslashes in mount points are octal escaped.
+			r := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+			mounts = append(mounts, r.Replace(fields[4]))
+		}
+	}
+	return mounts, scanner.Err()
+}
+
+// Remounts the mount at path read-only, keeping the flags the sandbox isn't allowed to change.
+func remountReadOnly(path string) error {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return err
+	}
+	flags := uintptr(syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY)
+	if st.Flags&stNosuid != 0 {
+		flags |= syscall.MS_NOSUID
+	}
+	if st.Flags&stNodev != 0 {
+		flags |= syscall.MS_NODEV
+	}
+	if st.Flags&stNoexec != 0 {
+		flags |= syscall.MS_NOEXEC
+	}
+	if st.Flags&stNodiratime != 0 {
+		flags |= syscall.MS_NODIRATIME
+	}
+	switch {
+	case st.Flags&stNoatime != 0:
+		flags |= syscall.MS_NOATIME
+	case st.Flags&stRelatime != 0:
+		flags |= syscall.MS_RELATIME
+	default:
+		flags |= syscall.MS_STRICTATIME
+	}
+	return syscall.Mount("", path, "", flags, "")
+}
+
+// The part of struct ifreq used to get and set an interface's flags.
+type ifreqFlags struct {
+	name  [syscall.IFNAMSIZ]byte
+	flags uint16
+	_     [22]byte
+}
+
+// Brings up the loopback interface of a new network namespace, which starts out down.
+func setLoopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+	var req ifreqFlags
+	copy(req.name[:], "lo")
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCGIFFLAGS, uintptr(unsafe.Pointer(&req))); errno != 0 {
+		return errno
+	}
+	req.flags |= syscall.IFF_UP
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&req))); errno != 0 {
+		return errno
+	}
+	return nil
+}
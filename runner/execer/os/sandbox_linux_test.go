@@ -0,0 +1,246 @@
+package os
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/oci"
+)
+
+// Returns a sandboxing execer and a checkout dir, skipping the test if user namespaces aren't available.
+func makeTestSandbox(t *testing.T, config SandboxConfig) (*sandboxExecer, string) {
+	cmd := exec.Command("true")
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	sandboxSysProcAttr(cmd.SysProcAttr, config)
+	if err := cmd.Run(); err != nil {
+		t.Skip("Can't create namespaces: ", err)
+	}
+	dir, err := ioutil.TempDir("", "sandbox_test")
+	if err != nil {
+		t.Fatalf("Couldn't create checkout %v", err)
+	}
+	return NewSandboxExecer(config, nil, NewBoundedExecer(0, execer.EnvPolicy{}, stats.NilStatsReceiver())), dir
+}
+
+// Runs script with sh in dir, returning its stdout.
+func runSandboxed(t *testing.T, e execer.Execer, dir, script string, sandbox bool) string {
+	var stdout, stderr bytes.Buffer
+	p, err := e.Exec(execer.Command{
+		Argv:    []string{"/bin/sh", "-c", script},
+		Dir:     dir,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+		Sandbox: sandbox,
+	})
+	if err != nil {
+		t.Fatalf("Couldn't run %q: %v", script, err)
+	}
+	if status := p.Wait(); status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running %q: %v, stderr: %s", script, status, stderr.String())
+	}
+	return strings.TrimSpace(stdout.String())
+}
+
+func TestSandboxFilesystem(t *testing.T) {
+	e, dir := makeTestSandbox(t, SandboxConfig{})
+	defer os.RemoveAll(dir)
+	outside, err := ioutil.TempDir("", "sandbox_test_outside")
+	if err != nil {
+		t.Fatalf("Couldn't create dir %v", err)
+	}
+	defer os.RemoveAll(outside)
+
+	script := "echo in > checkout_file && (echo out > " + outside + "/file 2>/dev/null && echo wrote || echo read-only)"
+	if out := runSandboxed(t, e, dir, script, true); out != "read-only" {
+		t.Fatalf("Expected writes outside the checkout to fail, got %q", out)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(dir, "checkout_file")); err != nil || string(data) != "in\n" {
+		t.Fatalf("Expected the checkout to be writable, got %q %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "file")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no file written outside the checkout, got %v", err)
+	}
+}
+
+func TestSandboxTmpfs(t *testing.T) {
+	e, dir := makeTestSandbox(t, SandboxConfig{TmpfsSize: 1024 * 1024})
+	defer os.RemoveAll(dir)
+
+	// The checkout is under the host's /tmp, so also check it's still reachable under the tmpfs.
+	name := filepath.Base(dir) + "_tmp_file"
+	script := "echo in > checkout_file && echo tmp > /tmp/" + name + " && cat /tmp/" + name
+	if out := runSandboxed(t, e, dir, script, true); out != "tmp" {
+		t.Fatalf("Expected /tmp to be writable, got %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "checkout_file")); err != nil {
+		t.Fatalf("Expected the checkout to be writable, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(os.TempDir(), name)); !os.IsNotExist(err) {
+		t.Fatalf("Expected the file written to the sandbox's /tmp to not be on the host, got %v", err)
+	}
+}
+
+func TestSandboxNamespaces(t *testing.T) {
+	e, dir := makeTestSandbox(t, SandboxConfig{})
+	defer os.RemoveAll(dir)
+
+	// The sandboxed command is the only process in its pid namespace, with only a loopback interface.
+	if out := runSandboxed(t, e, dir, "echo $$ $(tail -n +3 /proc/self/net/dev | cut -d: -f1)", true); out != "1 lo" {
+		t.Fatalf("Expected to be pid 1 with only a loopback interface, got %q", out)
+	}
+	// Commands that don't ask for a sandbox aren't sandboxed unless they all are.
+	if out := runSandboxed(t, e, dir, "echo $$", false); out == "1" {
+		t.Fatalf("Expected a command that didn't ask for a sandbox to run on the host")
+	}
+	e.config.All = true
+	if out := runSandboxed(t, e, dir, "echo $$", false); out != "1" {
+		t.Fatalf("Expected every command to be sandboxed, got pid %q", out)
+	}
+
+	_, err := e.Exec(execer.Command{Argv: []string{"not-a-real-binary"}, Dir: dir, Stdout: ioutil.Discard, Stderr: ioutil.Discard})
+	if err == nil || !strings.Contains(err.Error(), "sandbox") {
+		t.Fatalf("Expected an error setting up a sandbox for a binary that doesn't exist, got %v", err)
+	}
+}
+
+// Writes an image layout named name to imageDir, with one layer containing the host binaries at paths,
+// along with the shared libraries they need, and env as the image's environment.
+func makeTestImage(t *testing.T, imageDir, name string, env []string, paths ...string) {
+	var layer bytes.Buffer
+	tw := tar.NewWriter(&layer)
+	added := map[string]bool{}
+	for len(paths) > 0 {
+		p := paths[0]
+		paths = paths[1:]
+		if added[p] {
+			continue
+		}
+		added[p] = true
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			t.Fatalf("Couldn't read %s: %v", p, err)
+		}
+		tw.WriteHeader(&tar.Header{Name: p, Mode: 0755, Size: int64(len(data)), Typeflag: tar.TypeReg})
+		tw.Write(data)
+		paths = append(paths, elfDeps(t, p)...)
+	}
+	tw.Close()
+
+	layout := filepath.Join(imageDir, name)
+	os.MkdirAll(filepath.Join(layout, "blobs", "sha256"), 0755)
+	writeBlob := func(mediaType string, blob []byte) map[string]interface{} {
+		sum := sha256.Sum256(blob)
+		digest := hex.EncodeToString(sum[:])
+		ioutil.WriteFile(filepath.Join(layout, "blobs", "sha256", digest), blob, 0644)
+		return map[string]interface{}{"mediaType": mediaType, "digest": "sha256:" + digest, "size": len(blob)}
+	}
+	marshal := func(v interface{}) []byte {
+		data, _ := json.Marshal(v)
+		return data
+	}
+	manifest := writeBlob("application/vnd.oci.image.manifest.v1+json", marshal(map[string]interface{}{
+		"config": writeBlob("application/vnd.oci.image.config.v1+json", marshal(map[string]interface{}{
+			"config": map[string]interface{}{"Env": env}})),
+		"layers": []interface{}{writeBlob("application/vnd.oci.image.layer.v1.tar", layer.Bytes())},
+	}))
+	ioutil.WriteFile(filepath.Join(layout, "index.json"), marshal(map[string]interface{}{
+		"manifests": []interface{}{manifest}}), 0644)
+}
+
+// Returns the paths of the interpreter and shared libraries the ELF binary at path needs.
+func elfDeps(t *testing.T, path string) []string {
+	f, err := elf.Open(path)
+	if err != nil {
+		t.Fatalf("Couldn't open %s: %v", path, err)
+	}
+	defer f.Close()
+	deps := []string{}
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_INTERP {
+			interp, _ := ioutil.ReadAll(p.Open())
+			deps = append(deps, strings.TrimRight(string(interp), "\x00"))
+		}
+	}
+	libs, _ := f.ImportedLibraries()
+	for _, lib := range libs {
+		found := false
+		for _, dir := range []string{"/lib64", "/lib/x86_64-linux-gnu", "/usr/lib/x86_64-linux-gnu", "/lib/aarch64-linux-gnu", "/usr/lib/aarch64-linux-gnu", "/lib", "/usr/lib"} {
+			if _, err := os.Stat(filepath.Join(dir, lib)); err == nil {
+				deps = append(deps, filepath.Join(dir, lib))
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Skipf("Couldn't find library %s of %s", lib, path)
+		}
+	}
+	return deps
+}
+
+func TestSandboxImage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sandbox_test_images")
+	if err != nil {
+		t.Fatalf("Couldn't create dir %v", err)
+	}
+	defer os.RemoveAll(dir)
+	os.Mkdir(filepath.Join(dir, "images"), 0755)
+	os.Mkdir(filepath.Join(dir, "cache"), 0755)
+	sh, err := filepath.EvalSymlinks("/bin/sh")
+	if err != nil {
+		t.Skip("No /bin/sh: ", err)
+	}
+	makeTestImage(t, filepath.Join(dir, "images"), "sh", []string{"PATH=/bin:/usr/bin", "FROM_IMAGE=image"}, sh)
+
+	e, checkout := makeTestSandbox(t, SandboxConfig{TmpfsSize: 1024 * 1024})
+	defer os.RemoveAll(checkout)
+	if e.images, err = oci.NewStore(filepath.Join(dir, "images"), filepath.Join(dir, "cache"), oci.DefaultMaxCachedImages); err != nil {
+		t.Fatalf("Couldn't create image store: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	script := "echo $PWD $FROM_IMAGE $FROM_TASK $$ > out && " +
+		"(echo x > /file 2>/dev/null && echo wrote || echo read-only) >> out && " +
+		"echo tmp > /tmp/file && read tmp < /tmp/file && echo $tmp >> out && " +
+		"([ -e " + dir + " ] && echo host || echo no-host) >> out && echo hi > /dev/null"
+	p, err := e.Exec(execer.Command{
+		Argv:    []string{sh, "-c", script},
+		EnvVars: map[string]string{"FROM_TASK": "task"},
+		Dir:     checkout,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+		Image:   "sh",
+	})
+	if err != nil {
+		t.Fatalf("Couldn't run in image: %v", err)
+	}
+	if status := p.Wait(); status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running in image: %v, stderr: %s", status, stderr.String())
+	}
+	expected := oci.CheckoutDir + " image task 1\nread-only\ntmp\nno-host\n"
+	if data, err := ioutil.ReadFile(filepath.Join(checkout, "out")); err != nil || string(data) != expected {
+		t.Fatalf("Expected output %q in the checkout, got %q %v", expected, data, err)
+	}
+
+	// Commands are looked up in the image's PATH.
+	if _, err := e.Exec(execer.Command{Argv: []string{"cat"}, Dir: checkout, Stdout: ioutil.Discard, Stderr: ioutil.Discard, Image: "sh"}); err == nil {
+		t.Fatalf("Expected an error running a binary that isn't in the image")
+	}
+	e.images = nil
+	if _, err := e.Exec(execer.Command{Argv: []string{sh}, Dir: checkout, Stdout: ioutil.Discard, Stderr: ioutil.Discard, Image: "sh"}); err == nil {
+		t.Fatalf("Expected an error running in an image without images")
+	}
+}
@@ -0,0 +1,12 @@
+// +build !linux
+
+package os
+
+import (
+	"errors"
+	"syscall"
+)
+
+func sandboxSysProcAttr(attr *syscall.SysProcAttr, config SandboxConfig) error {
+	return errors.New("Sandboxing is only supported on linux")
+}
@@ -4,6 +4,12 @@ import (
 	"io"
 )
 
+// Names of the files a run's stdout and stderr are saved as, at the root of its result snapshot.
+const (
+	StdoutName = "STDOUT"
+	StderrName = "STDERR"
+)
+
 // OutputCreator lets clients create new Outputs so they can save data.
 // This is how Runner can save stdout and stderr.
 // OutputCreator is the filesystem that creates many Outputs; Output is one file in that.
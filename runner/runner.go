@@ -33,13 +33,19 @@ type Command struct {
 	// Runner can optionally use this to run against a particular snapshot. Empty value is ignored.
 	SnapshotID string
 
-	// TODO(jschiller): get consensus on design and either implement or delete.
-	// Runner can optionally use this to specify content if creating a new snapshot.
-	// Keys: relative src file & dir paths in SnapshotId checkout. May contain '*' wildcard.
-	// Values: relative dest path=dir/base in new snapshot (if src has a wildcard, then dest path is treated as a parent dir).
-	//
-	// Note: nil and empty maps are different!, nil means don't filter, empty means filter everything.
-	// SnapshotPlan map[string]string
+	// Run the command in a sandbox, isolated from the host's network and only able to write to its checkout,
+	// even if the runner doesn't sandbox every command. Fails on runners that can't sandbox.
+	Sandbox bool
+
+	// Run the command in this OCI image rather than on the runner's host, sandboxed, with its checkout
+	// mounted at oci.CheckoutDir. Names an image in the runner's image directory, ex: "go.tar:1.8".
+	// Empty runs on the host. Fails on runners without images.
+	Image string
+
+	// Files and directories in the checkout to copy into the result snapshot, at the same paths, along
+	// with STDOUT and STDERR. Paths are relative to the checkout and may be globs, ex: "reports/*.xml".
+	// If the command exits zero, paths that aren't globs must exist or the run fails.
+	Outputs []string
 
 	// Runner is given JobID, TaskID, and Tag to help trace tasks throughout their lifecycle
 	tags.LogTags
@@ -54,6 +60,15 @@ func (c Command) String() string {
 		c.TaskID,
 		c.Tag)
 
+	if c.Sandbox {
+		s += " # Sandbox"
+	}
+	if c.Image != "" {
+		s += " # Image: " + c.Image
+	}
+	if len(c.Outputs) > 0 {
+		s += fmt.Sprintf(" # Outputs: %q", c.Outputs)
+	}
 	if len(c.EnvVars) > 0 {
 		s += fmt.Sprintf(" # Env:")
 		for k, v := range c.EnvVars {
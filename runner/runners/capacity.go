@@ -0,0 +1,56 @@
+package runners
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+)
+
+// Returns the capacity of this host when running the given number of concurrent commands
+// with checkouts and outputs under tmp.
+func hostCapacity(slots int, tmp *temp.TempDir) runner.Capacity {
+	c := runner.Capacity{Slots: slots, CPUs: runtime.NumCPU(), MemoryBytes: totalMemory()}
+	if tmp != nil {
+		c.DiskBytes = availableDisk(tmp.Dir)
+	}
+	return c
+}
+
+// Returns the disk space available to unprivileged users in the filesystem containing dir,
+// or zero if it can't be determined.
+func availableDisk(dir string) int64 {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return 0
+	}
+	return int64(st.Bavail) * int64(st.Bsize)
+}
+
+// Returns the total memory of this host in bytes, or zero if it can't be determined.
+// Only linux is supported for now, via /proc/meminfo.
+func totalMemory() int64 {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Formatted as "MemTotal:       16314500 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "MemTotal:" && fields[2] == "kB" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kb * 1024
+		}
+	}
+	return 0
+}
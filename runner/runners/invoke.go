@@ -26,7 +26,7 @@ func NewInvoker(exec execer.Execer, filer snapshot.Filer, output runner.OutputCr
 	if stat == nil {
 		stat = stats.NilStatsReceiver()
 	}
-	return &Invoker{exec: exec, filer: filer, output: output, tmp: tmp, stat: stat}
+	return &Invoker{exec: exec, filer: filer, output: output, tmp: tmp, outputLimit: DefaultOutputLimit, stat: stat}
 }
 
 // TODO(dbentley): test this separately from the end-to-end runner tests
@@ -35,11 +35,15 @@ func NewInvoker(exec execer.Execer, filer snapshot.Filer, output runner.OutputCr
 // (E.g., checking out a Snapshot, or saving the Output once it's done)
 // Unlike a full Runner, it has no idea of what else is running or has run.
 type Invoker struct {
-	exec   execer.Execer
-	filer  snapshot.Filer
-	output runner.OutputCreator
-	tmp    *temp.TempDir
-	stat   stats.StatsReceiver
+	exec        execer.Execer
+	filer       snapshot.Filer
+	output      runner.OutputCreator
+	tmp         *temp.TempDir
+	outputLimit OutputLimit
+	stat        stats.StatsReceiver
+
+	// If set, called with the snapshot of each successful checkout.
+	checkedOut func(snapshotID string)
 }
 
 // Run runs cmd
@@ -145,6 +149,9 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		}
 		// Checkout is ok, continue with run and when finished release checkout.
 		defer co.Release()
+		if cmd.SnapshotID != "" && inv.checkedOut != nil {
+			inv.checkedOut(cmd.SnapshotID)
+		}
 	}
 	log.WithFields(
 		log.Fields{
@@ -185,9 +192,12 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 
 	p, err := inv.exec.Exec(execer.Command{
 		Argv:    cmd.Argv,
+		EnvVars: cmd.EnvVars,
 		Dir:     co.Path(),
 		Stdout:  stdout,
 		Stderr:  stderr,
+		Sandbox: cmd.Sandbox,
+		Image:   cmd.Image,
 		LogTags: cmd.LogTags,
 	})
 	if err != nil {
@@ -215,13 +225,13 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 	case <-abortCh:
 		stdout.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask aborted: %v", marker, cmd.String())))
 		stderr.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask aborted: %v", marker, cmd.String())))
-		p.Abort()
-		return runner.AbortStatus(id,
-			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		st = p.Abort()
+		return withUsage(runner.AbortStatus(id,
+			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag}), st)
 	case <-timeoutCh:
 		stdout.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask exceeded timeout %v: %v", marker, cmd.Timeout, cmd.String())))
 		stderr.Write([]byte(fmt.Sprintf("\n\n%s\n\nFAILED\n\nTask exceeded timeout %v: %v", marker, cmd.Timeout, cmd.String())))
-		p.Abort()
+		st = p.Abort()
 		log.WithFields(
 			log.Fields{
 				"cmd":    cmd.String(),
@@ -229,8 +239,8 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 				"jobID":  cmd.JobID,
 				"taskID": cmd.TaskID,
 			}).Info("Run timedout")
-		return runner.TimeoutStatus(id,
-			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		return withUsage(runner.TimeoutStatus(id,
+			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag}), st)
 	case st = <-processCh:
 	}
 	log.WithFields(
@@ -258,14 +268,12 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		}()
 		outPath := stdout.AsFile()
 		errPath := stderr.AsFile()
-		stdoutName := "STDOUT"
-		stderrName := "STDERR"
 		var writer *os.File
 		var reader *os.File
 		defer writer.Close()
 		defer reader.Close()
 
-		if writer, err = os.Create(filepath.Join(tmp.Dir, stdoutName)); err != nil {
+		if writer, err = os.Create(filepath.Join(tmp.Dir, runner.StdoutName)); err != nil {
 			return runner.FailedStatus(id, fmt.Errorf("error staging ingestion for stdout: %v", err),
 				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 		} else if reader, err = os.Open(outPath); err != nil {
@@ -278,7 +286,7 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 
 		writer.Close()
 		reader.Close()
-		if writer, err = os.Create(filepath.Join(tmp.Dir, stderrName)); err != nil {
+		if writer, err = os.Create(filepath.Join(tmp.Dir, runner.StderrName)); err != nil {
 			return runner.FailedStatus(id, fmt.Errorf("error staging ingestion for stderr: %v", err),
 				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 		} else if reader, err = os.Open(errPath); err != nil {
@@ -289,9 +297,26 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 		}
 
+		// Declared outputs are copied into the snapshot alongside stdout and stderr. A command that failed
+		// may not have produced them all, so only require them if it succeeded.
+		var srcToDest map[string]string
+		if len(cmd.Outputs) > 0 {
+			if srcToDest, err = collectOutputs(co.Path(), cmd.Outputs, st.ExitCode == 0, inv.outputLimit); err != nil {
+				return withUsage(runner.FailedStatus(id, fmt.Errorf("error collecting outputs: %v", err),
+					tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag}), st)
+			}
+			srcToDest[tmp.Dir] = ""
+		}
+
 		ingestCh := make(chan interface{})
 		go func() {
-			snapshotID, err := inv.filer.Ingest(tmp.Dir)
+			var snapshotID string
+			var err error
+			if srcToDest != nil {
+				snapshotID, err = inv.filer.IngestMap(srcToDest)
+			} else {
+				snapshotID, err = inv.filer.Ingest(tmp.Dir)
+			}
 			if err != nil {
 				ingestCh <- err
 			} else {
@@ -318,15 +343,22 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		status := runner.CompleteStatus(id, snapshotID, st.ExitCode,
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 		if cmd.SnapshotID != "" {
-			status.StdoutRef = snapshotID + "/" + stdoutName
-			status.StderrRef = snapshotID + "/" + stderrName
+			status.StdoutRef = snapshotID + "/" + runner.StdoutName
+			status.StderrRef = snapshotID + "/" + runner.StderrName
 		}
-		return status
+		return withUsage(status, st)
 	case execer.FAILED:
-		return runner.FailedStatus(id, fmt.Errorf("error execing: %v", st.Error),
-			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		return withUsage(runner.FailedStatus(id, fmt.Errorf("error execing: %v", st.Error),
+			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag}), st)
 	default:
 		return runner.FailedStatus(id, fmt.Errorf("unexpected exec state: %v", st),
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 	}
 }
+
+// Returns status with the resources used by the process that ran its command.
+func withUsage(status runner.RunStatus, st execer.ProcessStatus) runner.RunStatus {
+	status.PeakMemoryBytes = int64(st.PeakMemory)
+	status.CPUTime = st.CPUTime
+	return status
+}
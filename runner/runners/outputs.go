@@ -0,0 +1,100 @@
+package runners
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/twitter/scoot/runner"
+)
+
+// The most bytes of declared outputs a run may add to its result snapshot. Zero means no limit.
+type OutputLimit int64
+
+const DefaultOutputLimit OutputLimit = 1 << 30
+
+// Returns the outputs declared in checkout as a map of their absolute paths to their paths relative
+// to the result snapshot, for Ingester.IngestMap. Outputs that aren't globs must exist if required is set,
+// and together the outputs may be at most limit bytes.
+func collectOutputs(checkout string, outputs []string, required bool, limit OutputLimit) (map[string]string, error) {
+	root, err := filepath.EvalSymlinks(checkout)
+	if err != nil {
+		return nil, err
+	}
+	srcToDest := map[string]string{}
+	for _, o := range outputs {
+		clean := filepath.Clean(o)
+		if filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("Invalid output %q, it must be a path relative to the checkout", o)
+		}
+		if clean == runner.StdoutName || clean == runner.StderrName {
+			return nil, fmt.Errorf("Invalid output %q, it would replace %s", o, clean)
+		}
+
+		matches := []string{filepath.Join(root, clean)}
+		if strings.ContainsAny(clean, `*?[\`) {
+			if matches, err = filepath.Glob(matches[0]); err != nil {
+				return nil, fmt.Errorf("Invalid output %q: %v", o, err)
+			}
+		} else if _, err := os.Lstat(matches[0]); os.IsNotExist(err) {
+			if required {
+				return nil, fmt.Errorf("Output %q doesn't exist", o)
+			}
+			continue
+		}
+
+		for _, m := range matches {
+			// Only copy what's in the checkout, even if the output is a symlink to something outside of it.
+			src, err := filepath.EvalSymlinks(m)
+			if err != nil {
+				return nil, fmt.Errorf("Couldn't read output %q: %v", o, err)
+			}
+			if src != root && !strings.HasPrefix(src, root+string(filepath.Separator)) {
+				return nil, fmt.Errorf("Output %q is outside the checkout", o)
+			}
+			dest, err := filepath.Rel(root, m)
+			if err != nil {
+				return nil, err
+			}
+			if dest == runner.StdoutName || dest == runner.StderrName {
+				return nil, fmt.Errorf("Invalid output %q, it would replace %s", o, dest)
+			}
+			srcToDest[src] = dest
+		}
+	}
+
+	size := int64(0)
+	for src, dest := range srcToDest {
+		// Outputs inside another output's dir are already counted with it.
+		if underOutput(srcToDest, src) {
+			continue
+		}
+		err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read output %q: %v", dest, err)
+		}
+		if limit > 0 && size > int64(limit) {
+			return nil, fmt.Errorf("Outputs are more than the limit of %d bytes", limit)
+		}
+	}
+	return srcToDest, nil
+}
+
+// Returns true if one of path's parent dirs is also a src in srcToDest.
+func underOutput(srcToDest map[string]string, path string) bool {
+	for dir := filepath.Dir(path); dir != path; path, dir = dir, filepath.Dir(dir) {
+		if _, ok := srcToDest[dir]; ok {
+			return true
+		}
+	}
+	return false
+}
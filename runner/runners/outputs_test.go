@@ -0,0 +1,149 @@
+package runners
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
+	os_execer "github.com/twitter/scoot/runner/execer/os"
+	"github.com/twitter/scoot/snapshot/snapshots"
+)
+
+// Creates a checkout containing files, by their relative paths.
+func makeCheckout(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "outputs_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Resolve the dir, like collectOutputs, in case the temp dir is under a symlink.
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCollectOutputs(t *testing.T) {
+	dir := makeCheckout(t, map[string]string{
+		"out/a":           "a",
+		"out/b/c":         "c",
+		"reports/1.xml":   "1",
+		"reports/2.xml":   "2",
+		"reports/3.txt":   "3",
+		"not-an-output":   "x",
+		"big/file":        strings.Repeat("x", 100),
+		"inside/file.txt": "inside",
+	})
+	defer os.RemoveAll(dir)
+	os.Symlink(filepath.Join(dir, "inside"), filepath.Join(dir, "link"))
+
+	srcToDest, err := collectOutputs(dir, []string{"out", "reports/*.xml", "./link/file.txt", "missing/*"}, true, 0)
+	if err != nil {
+		t.Fatalf("Couldn't collect outputs: %v", err)
+	}
+	expected := map[string]string{
+		filepath.Join(dir, "out"):             "out",
+		filepath.Join(dir, "reports/1.xml"):   "reports/1.xml",
+		filepath.Join(dir, "reports/2.xml"):   "reports/2.xml",
+		filepath.Join(dir, "inside/file.txt"): "link/file.txt",
+	}
+	if len(srcToDest) != len(expected) {
+		t.Fatalf("Expected outputs %v, got %v", expected, srcToDest)
+	}
+	for src, dest := range expected {
+		if srcToDest[src] != dest {
+			t.Fatalf("Expected outputs %v, got %v", expected, srcToDest)
+		}
+	}
+
+	if _, err := collectOutputs(dir, []string{"out", "missing"}, true, 0); err == nil || !strings.Contains(err.Error(), `"missing" doesn't exist`) {
+		t.Fatalf("Expected an error for a missing output, got %v", err)
+	}
+	if srcToDest, err := collectOutputs(dir, []string{"out", "missing"}, false, 0); err != nil || len(srcToDest) != 1 {
+		t.Fatalf("Expected missing outputs to be skipped if not required, got %v %v", srcToDest, err)
+	}
+	if _, err := collectOutputs(dir, []string{"big", "out"}, true, 100); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("Expected an error for outputs over the limit, got %v", err)
+	}
+	// A file inside a dir that's also an output only counts once.
+	if _, err := collectOutputs(dir, []string{"big", "big/file"}, true, 100); err != nil {
+		t.Fatalf("Expected nested outputs to be counted once against the limit, got %v", err)
+	}
+	for _, o := range []string{"/etc/passwd", "../outside", ".", "out/../..", "STDOUT"} {
+		if _, err := collectOutputs(dir, []string{o}, false, 0); err == nil {
+			t.Fatalf("Expected an error for invalid output %q", o)
+		}
+	}
+	os.Symlink("/etc", filepath.Join(dir, "escape"))
+	if _, err := collectOutputs(dir, []string{"escape"}, false, 0); err == nil || !strings.Contains(err.Error(), "outside") {
+		t.Fatalf("Expected an error for an output outside the checkout, got %v", err)
+	}
+}
+
+func TestRunOutputs(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+	filer := snapshots.MakeTempFiler(tmp)
+	ex := os_execer.NewBoundedExecer(0, execer.EnvPolicy{}, stats.NilStatsReceiver())
+	r := NewWorkerRunner(ex, filer, nil, NewNullOutputCreator(), tmp, 1, 1024, nil)
+
+	runCmd := func(script string, outputs ...string) runner.RunStatus {
+		st, err := r.Run(&runner.Command{Argv: []string{"sh", "-c", script}, Outputs: outputs})
+		if err != nil {
+			t.Fatalf("Couldn't run %q: %v", script, err)
+		}
+		query := runner.Query{Runs: []runner.RunID{st.RunID}, States: runner.MaskForState(runner.COMPLETE, runner.FAILED)}
+		if st, _, err = runner.SingleStatus(r.Query(query, runner.WaitForever())); err != nil {
+			t.Fatalf("Couldn't wait for %q: %v", script, err)
+		}
+		return st
+	}
+
+	st := runCmd("mkdir -p out/sub reports && echo a > out/sub/a && echo r > reports/r.xml && echo x > ignored",
+		"out", "reports/*.xml")
+	if st.State != runner.COMPLETE || st.ExitCode != 0 {
+		t.Fatalf("Expected the run to complete, got %v", st)
+	}
+	co, err := filer.Checkout(st.SnapshotID)
+	if err != nil {
+		t.Fatalf("Couldn't checkout the result snapshot: %v", err)
+	}
+	for path, expected := range map[string]string{"out/sub/a": "a\n", "reports/r.xml": "r\n"} {
+		if data, err := ioutil.ReadFile(filepath.Join(co.Path(), path)); err != nil || string(data) != expected {
+			t.Fatalf("Expected output %s to be %q, got %q %v", path, expected, data, err)
+		}
+	}
+	for _, path := range []string{"STDOUT", "STDERR"} {
+		if _, err := os.Stat(filepath.Join(co.Path(), path)); err != nil {
+			t.Fatalf("Expected %s in the result snapshot, got %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(co.Path(), "ignored")); !os.IsNotExist(err) {
+		t.Fatalf("Expected files that aren't outputs to not be in the result snapshot, got %v", err)
+	}
+
+	if st := runCmd("true", "missing"); st.State != runner.FAILED || !strings.Contains(st.Error, `"missing" doesn't exist`) {
+		t.Fatalf("Expected the run to fail with a missing output, got %v", st)
+	}
+	if st := runCmd("exit 3", "missing"); st.State != runner.COMPLETE || st.ExitCode != 3 {
+		t.Fatalf("Expected a failed command's missing outputs to be ignored, got %v", st)
+	}
+	if st := runCmd("head -c 2048 /dev/zero > big", "big"); st.State != runner.FAILED || !strings.Contains(st.Error, "limit") {
+		t.Fatalf("Expected the run to fail with outputs over the limit, got %v", st)
+	}
+}
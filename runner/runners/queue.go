@@ -72,10 +72,6 @@ The init chan is optional and may be nil if the filer has no initializion step.
 func NewQueueRunner(
 	exec execer.Execer, filer snapshot.Filer, idc snapshot.InitDoneCh, output runner.OutputCreator, tmp *temp.TempDir, capacity int, stat stats.StatsReceiver) runner.Service {
 
-	if stat == nil {
-		stat = stats.NilStatsReceiver()
-	}
-
 	//FIXME(jschiller): proper history config rather than keying off of capacity and if this is a SingleRunner.
 	history := 1
 	if capacity > 0 {
@@ -83,37 +79,81 @@ func NewQueueRunner(
 	} else if capacity == 0 {
 		capacity = 1 // singleRunner, override capacity so it can actually run a command.
 	}
+	return newQueueRunner(exec, filer, idc, output, tmp, capacity, 1, history, DefaultOutputLimit, stat)
+}
+
+func NewSingleRunner(
+	exec execer.Execer, filer snapshot.Filer, idc snapshot.InitDoneCh, output runner.OutputCreator, tmp *temp.TempDir, stat stats.StatsReceiver) runner.Service {
+	return NewQueueRunner(exec, filer, idc, output, tmp, 0, stat)
+}
+
+// NewMultiRunner creates a Service that runs up to the given number of commands concurrently,
+// each with its own checkout and output, and rejects commands once all slots are in use.
+// The filer must support concurrent checkouts. A slots value less than 1 is treated as 1.
+func NewMultiRunner(
+	exec execer.Execer, filer snapshot.Filer, idc snapshot.InitDoneCh, output runner.OutputCreator, tmp *temp.TempDir, slots int, stat stats.StatsReceiver) runner.Service {
+	if slots < 1 {
+		slots = 1
+	}
+	// Keep unlimited history, a bounded fifo could drop the status of a long run that's still in progress.
+	return newQueueRunner(exec, filer, idc, output, tmp, slots, slots, 0, DefaultOutputLimit, stat)
+}
+
+// NewWorkerRunner creates the Service a worker runs commands with: a SingleRunner, or a MultiRunner
+// with more than one slot, whose runs may add at most outputLimit bytes of outputs to their snapshots.
+func NewWorkerRunner(
+	exec execer.Execer, filer snapshot.Filer, idc snapshot.InitDoneCh, output runner.OutputCreator, tmp *temp.TempDir,
+	slots Slots, outputLimit OutputLimit, stat stats.StatsReceiver) runner.Service {
+	if slots > 1 {
+		return newQueueRunner(exec, filer, idc, output, tmp, int(slots), int(slots), 0, outputLimit, stat)
+	}
+	return newQueueRunner(exec, filer, idc, output, tmp, 1, 1, 1, outputLimit, stat)
+}
+
+func newQueueRunner(
+	exec execer.Execer, filer snapshot.Filer, idc snapshot.InitDoneCh, output runner.OutputCreator, tmp *temp.TempDir,
+	capacity, slots, history int, outputLimit OutputLimit, stat stats.StatsReceiver) runner.Service {
+
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
+	}
 
 	statusManager := NewStatusManager(history)
 	inv := NewInvoker(exec, filer, output, tmp, stat)
+	inv.outputLimit = outputLimit
+	inv.checkedOut = statusManager.SnapshotCheckedOut
 
 	controller := &QueueController{
 		statusManager: statusManager,
 		inv:           inv,
 		filer:         filer,
 		capacity:      capacity,
+		slots:         slots,
+		running:       make(map[runner.RunID]*runningCmd),
 		reqCh:         make(chan interface{}),
 		updateCh:      make(chan interface{}),
+		doneCh:        make(chan runner.RunID),
 		cancelTimerCh: make(chan interface{}, 1),
 	}
 	run := &Service{controller, statusManager, statusManager}
 
 	// QueueRunner will not serve requests if an idc is defined and returns an error
 	log.Info("Starting goroutine to check for snapshot init? ", (idc != nil))
+	capac := hostCapacity(slots, tmp)
 	var err error = nil
 	if idc != nil {
 		go func() {
 			err = <-idc
 			if err != nil {
 				stat.Counter(stats.WorkerDownloadInitFailure).Inc(1)
-				statusManager.UpdateService(runner.ServiceStatus{Initialized: false, Error: err})
+				statusManager.UpdateService(runner.ServiceStatus{Initialized: false, Error: err, Capacity: capac})
 			} else {
-				statusManager.UpdateService(runner.ServiceStatus{Initialized: true})
+				statusManager.UpdateService(runner.ServiceStatus{Initialized: true, Capacity: capac})
 				startUpdateTicker(filer.UpdateInterval(), controller.updateCh, controller.cancelTimerCh)
 			}
 		}()
 	} else {
-		statusManager.UpdateService(runner.ServiceStatus{Initialized: true})
+		statusManager.UpdateService(runner.ServiceStatus{Initialized: true, Capacity: capac})
 		startUpdateTicker(filer.UpdateInterval(), controller.updateCh, controller.cancelTimerCh)
 	}
 
@@ -122,12 +162,14 @@ func NewQueueRunner(
 	return run
 }
 
-func NewSingleRunner(
-	exec execer.Execer, filer snapshot.Filer, idc snapshot.InitDoneCh, output runner.OutputCreator, tmp *temp.TempDir, stat stats.StatsReceiver) runner.Service {
-	return NewQueueRunner(exec, filer, idc, output, tmp, 0, stat)
+// A command that has been started, and the channel used to abort it.
+type runningCmd struct {
+	cmd   *runner.Command
+	abort chan<- struct{}
 }
 
-// QueueController maintains a queue of commands to run (up to capacity).
+// QueueController maintains a queue of commands to run (up to capacity, including running commands),
+// running up to slots of them at a time.
 // Manages updates to underlying Filer via Filer's Update interface,
 // if a non-zero update interval is defined (updates and tasks cannot run concurrently)
 type QueueController struct {
@@ -135,16 +177,18 @@ type QueueController struct {
 	filer         snapshot.Filer
 	statusManager *StatusManager
 	capacity      int
+	slots         int
 
-	queue        []cmdAndID
-	runningID    runner.RunID
-	runningCmd   *runner.Command
-	runningAbort chan<- struct{}
+	// commands waiting for a free slot
+	queue   []cmdAndID
+	running map[runner.RunID]*runningCmd
 
 	// used to signal a cmd run request
 	reqCh chan interface{}
 	// used to signal a request to update the Filer
 	updateCh chan interface{}
+	// used to signal that a running cmd finished
+	doneCh chan runner.RunID
 	// used to cancel the timer goroutine if started.
 	cancelTimerCh chan interface{}
 }
@@ -183,9 +227,9 @@ func (c *QueueController) enqueue(cmd *runner.Command) (runner.RunStatus, error)
 		log.Fields{
 			"ready":          svcStatus.Initialized,
 			"err":            svcStatus.Error,
-			"availableSlots": c.capacity - len(c.queue),
+			"availableSlots": c.capacity - len(c.queue) - len(c.running),
 			"totalSlots":     c.capacity,
-			"currentRun":     c.runningID,
+			"numRunning":     len(c.running),
 			"jobID":          cmd.JobID,
 			"taskID":         cmd.TaskID,
 			"tag":            cmd.Tag,
@@ -198,7 +242,7 @@ func (c *QueueController) enqueue(cmd *runner.Command) (runner.RunStatus, error)
 		}
 		return runner.RunStatus{Error: errStr}, fmt.Errorf(QueueInitingMsg)
 	}
-	if len(c.queue) >= c.capacity {
+	if len(c.queue)+len(c.running) >= c.capacity {
 		return runner.RunStatus{}, fmt.Errorf(QueueFullMsg)
 	}
 
@@ -220,17 +264,17 @@ func (c *QueueController) Abort(run runner.RunID) (runner.RunStatus, error) {
 }
 
 func (c *QueueController) abort(run runner.RunID) (runner.RunStatus, error) {
-	if run == c.runningID {
-		if c.runningAbort != nil {
+	if r, ok := c.running[run]; ok {
+		if r.abort != nil {
 			log.WithFields(
 				log.Fields{
-					"currentRun": c.runningID,
-					"jobID":      c.runningCmd.JobID,
-					"taskID":     c.runningCmd.TaskID,
-					"tag":        c.runningCmd.Tag,
+					"currentRun": run,
+					"jobID":      r.cmd.JobID,
+					"taskID":     r.cmd.TaskID,
+					"tag":        r.cmd.Tag,
 				}).Info("Aborting")
-			close(c.runningAbort)
-			c.runningAbort = nil
+			close(r.abort)
+			r.abort = nil
 		}
 	} else {
 		for i, cmdID := range c.queue {
@@ -266,24 +310,18 @@ func (c *QueueController) Release() {
 
 // Handle requests to run and update, to provide concurrency management between the two.
 // Although we can still receive run requests, runs and updates are done blocking.
+// Once an update is requested no new runs are started, so that it can go ahead when the current runs finish.
 func (c *QueueController) loop() {
-	var watchCh chan runner.RunStatus
 	var updateDoneCh chan interface{}
 	updateRequested := false
 
 	tryUpdate := func() {
-		if watchCh == nil && updateDoneCh == nil {
+		if len(c.running) == 0 && updateDoneCh == nil {
 			updateRequested = false
 			updateDoneCh = make(chan interface{})
 			go func() {
 				if err := c.filer.Update(); err != nil {
-					fields := log.Fields{"err": err}
-					if c.runningCmd != nil {
-						fields["tag"] = c.runningCmd.Tag
-						fields["jobID"] = c.runningCmd.JobID
-						fields["taskID"] = c.runningCmd.TaskID
-					}
-					log.WithFields(fields).Error("error running Filer Update")
+					log.WithFields(log.Fields{"err": err}).Error("error running Filer Update")
 				}
 				updateDoneCh <- nil
 			}()
@@ -293,9 +331,10 @@ func (c *QueueController) loop() {
 	}
 
 	tryRun := func() {
-		if watchCh == nil && updateDoneCh == nil && len(c.queue) > 0 {
+		for updateDoneCh == nil && !updateRequested && len(c.queue) > 0 && len(c.running) < c.slots {
 			cmdID := c.queue[0]
-			watchCh = c.runAndWatch(cmdID)
+			c.queue = c.queue[1:]
+			c.runAndWatch(cmdID)
 		}
 	}
 
@@ -312,7 +351,7 @@ func (c *QueueController) loop() {
 			}
 		}
 
-		// Wait on update, updateDone, run start, or run abort.
+		// Wait on update, updateDone, run start, run abort, or run finish.
 		select {
 		case <-c.updateCh:
 			tryUpdate()
@@ -338,33 +377,28 @@ func (c *QueueController) loop() {
 				r.resultCh <- result{st, err}
 			}
 
-		case <-watchCh:
-			// Handle finished run by resetting state.
-			watchCh = nil
-			c.runningID = ""
-			c.runningCmd = nil
-			c.runningAbort = nil
-			c.queue = c.queue[1:]
+		case id := <-c.doneCh:
+			// Handle finished run by freeing its slot.
+			delete(c.running, id)
 		}
 	}
 }
 
 // Run cmd and then start a new goroutine to watch the cmd.
-// Returns a watchCh for goroutine completion.
-func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
+// The goroutine sends the run's id on doneCh when it completes.
+func (c *QueueController) runAndWatch(cmdID cmdAndID) {
 	log.WithFields(
 		log.Fields{
 			"jobID":  cmdID.cmd.JobID,
 			"taskID": cmdID.cmd.TaskID,
 			"runID":  cmdID.id,
 			"newLen": len(c.queue),
+			"nRuns":  len(c.running) + 1,
 			"tag":    cmdID.cmd.Tag,
 		}).Info("Running")
-	watchCh := make(chan runner.RunStatus)
 	abortCh, statusUpdateCh := c.inv.Run(cmdID.cmd, cmdID.id)
-	c.runningAbort = abortCh
-	c.runningID = cmdID.id
-	c.runningCmd = cmdID.cmd
+	c.running[cmdID.id] = &runningCmd{cmd: cmdID.cmd, abort: abortCh}
+	doneCh := c.doneCh
 	go func() {
 		for st := range statusUpdateCh {
 			log.WithFields(
@@ -382,10 +416,9 @@ func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
 				}).Info("Queue received status update")
 			c.statusManager.Update(st)
 			if st.State.IsDone() {
-				watchCh <- st
+				doneCh <- cmdID.id
 				return
 			}
 		}
 	}()
-	return watchCh
 }
@@ -2,13 +2,18 @@ package runners
 
 import (
 	"fmt"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/runner/execer/execers"
+	os_execer "github.com/twitter/scoot/runner/execer/os"
 	"github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/snapshots"
 )
@@ -199,7 +204,92 @@ func TestAbortQueuedCommand(t *testing.T) {
 	assertWait(t, env.r, run1, aborted())
 }
 
+func TestMultiRunnerRunsConcurrently(t *testing.T) {
+	env := setupMulti(2, snapshot.NoDuration, t)
+	defer env.teardown()
+
+	_, svc, err := env.r.StatusAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.Slots != 2 || svc.CPUs == 0 {
+		t.Fatalf("Expected 2 slots and a cpu count to be advertised, got %v", svc)
+	}
+
+	// both commands run at the same time
+	run1 := assertRun(t, env.r, running(), "pause", "complete 0")
+	run2 := assertRun(t, env.r, running(), "pause", "complete 1")
+
+	// all slots are in use
+	_, err = env.r.Run(&runner.Command{Argv: []string{"complete 5"}})
+	if err == nil || strings.Compare(QueueFullMsg, err.Error()) != 0 {
+		t.Fatal("Should not be able to schedule: ", err)
+	}
+
+	// aborting one frees its slot for another run
+	if _, err := env.r.Abort(run1); err != nil {
+		t.Fatal(err)
+	}
+	assertWait(t, env.r, run1, aborted())
+	run3 := assertRun(t, env.r, running(), "pause", "complete 2")
+
+	env.sim.Resume()
+	env.sim.Resume()
+	assertWait(t, env.r, run2, complete(1), "n/a")
+	assertWait(t, env.r, run3, complete(2), "n/a")
+}
+
+func TestCheckedOutSnapshots(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+	filer := snapshots.MakeTempFiler(tmp)
+	ex := os_execer.NewBoundedExecer(0, execer.EnvPolicy{}, stats.NilStatsReceiver())
+	r := NewWorkerRunner(ex, filer, nil, NewNullOutputCreator(), tmp, 1, 0, nil)
+
+	run := func(snapshotID string) runner.RunStatus {
+		st, err := r.Run(&runner.Command{Argv: []string{"true"}, SnapshotID: snapshotID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		query := runner.Query{Runs: []runner.RunID{st.RunID}, States: runner.MaskForState(runner.COMPLETE, runner.FAILED)}
+		if st, _, err = runner.SingleStatus(r.Query(query, runner.WaitForever())); err != nil || st.State != runner.COMPLETE {
+			t.Fatalf("Expected the run to complete, got %v %v", st, err)
+		}
+		return st
+	}
+
+	// Runs without a snapshot, or the snapshots runs create, aren't checked out.
+	first := run("")
+	second := run(first.SnapshotID)
+	run(second.SnapshotID)
+	run(first.SnapshotID)
+	_, svc, err := r.StatusAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(svc.SnapshotIDs, []string{first.SnapshotID, second.SnapshotID}) {
+		t.Fatalf("Expected checked out snapshots %v, got %v", []string{first.SnapshotID, second.SnapshotID}, svc.SnapshotIDs)
+	}
+}
+
 func setup(capacity int, interval time.Duration, t *testing.T) *env {
+	return setupWith(func(sim *execers.SimExecer, filer snapshot.Filer, tmpDir *temp.TempDir, oc runner.OutputCreator) runner.Service {
+		return NewQueueRunner(sim, filer, nil, oc, tmpDir, capacity, nil)
+	}, interval, t)
+}
+
+func setupMulti(slots int, interval time.Duration, t *testing.T) *env {
+	return setupWith(func(sim *execers.SimExecer, filer snapshot.Filer, tmpDir *temp.TempDir, oc runner.OutputCreator) runner.Service {
+		return NewMultiRunner(sim, filer, nil, oc, tmpDir, slots, nil)
+	}, interval, t)
+}
+
+func setupWith(
+	newRunner func(*execers.SimExecer, snapshot.Filer, *temp.TempDir, runner.OutputCreator) runner.Service,
+	interval time.Duration, t *testing.T) *env {
 	sim := execers.NewSimExecer()
 	tmpDir, err := temp.TempDirDefault()
 	if err != nil {
@@ -213,7 +303,7 @@ func setup(capacity int, interval time.Duration, t *testing.T) *env {
 
 	updateCount := 0
 	updater := snapshots.MakeCountingUpdater(&updateCount, interval, true).(*snapshots.CountingUpdater)
-	r := NewQueueRunner(sim, snapshots.MakeInvalidFilerUpdater(updater), nil, outputCreator, tmpDir, capacity, nil)
+	r := newRunner(sim, snapshots.MakeInvalidFilerUpdater(updater), tmpDir, outputCreator)
 
 	return &env{sim: sim, r: r, u: updater, uc: &updateCount}
 }
@@ -3,12 +3,17 @@ package runners
 import (
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/runner/execer/execers"
+	"github.com/twitter/scoot/runner/execer/oci"
 	osexec "github.com/twitter/scoot/runner/execer/os"
 	"github.com/twitter/scoot/snapshot"
 )
 
+// The number of commands a worker runs concurrently.
+type Slots int
+
 // Module returns a module that creates a new Runner.
 func Module() ice.Module {
 	return module{}
@@ -19,12 +24,32 @@ type module struct{}
 // Install installs functions for creating a new Runner.
 func (m module) Install(b *ice.MagicBag) {
 	b.PutMany(
-		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
-			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
+		NewExecer,
+		func(db snapshot.DB, tmp *temp.TempDir) snapshot.Filer {
+			return snapshot.NewDBAdapter(db, tmp)
+		},
+		func() Slots {
+			return 1
 		},
-		func(db snapshot.DB) snapshot.Filer {
-			return snapshot.NewDBAdapter(db)
+		func() OutputLimit {
+			return DefaultOutputLimit
 		},
-		NewSingleRunner,
+		NewWorkerRunner,
 	)
 }
+
+// NewExecer creates the Execer a worker runs commands with: commands run bounded by m,
+// in cgroups under cg.Parent if it's set, and sandboxed or in images according to sb
+// and images, unless they're intercepted as sim commands.
+func NewExecer(
+	m execer.Memory, cg osexec.CgroupConfig, sb osexec.SandboxConfig, images *oci.Store,
+	env execer.EnvPolicy, s stats.StatsReceiver) (execer.Execer, error) {
+	ex := osexec.NewBoundedExecer(m, env, s)
+	if cg.Parent != "" {
+		var err error
+		if ex, err = osexec.NewCgroupExecer(m, cg, env, s); err != nil {
+			return nil, err
+		}
+	}
+	return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewSandboxExecer(sb, images, ex)), nil
+}
@@ -12,6 +12,9 @@ import (
 
 const UnknownRunIDMsg = "unknown run id %v"
 
+// Number of recently checked out snapshots reported in the ServiceStatus.
+const MaxRecentSnapshots = 10
+
 // NewStatusManager creates a new empty StatusManager
 func NewStatusManager(capacity int) *StatusManager {
 	return &StatusManager{runs: make(map[runner.RunID]runner.RunStatus), fifo: make([]runner.RunID, 0), capacity: capacity}
@@ -25,6 +28,7 @@ type StatusManager struct {
 	fifo      []runner.RunID
 	capacity  int
 	svcStatus runner.ServiceStatus
+	snapshots []string // Most recently checked out first.
 	nextRunID int64
 	listeners []queryAndCh
 }
@@ -71,6 +75,26 @@ func (s *StatusManager) UpdateService(svcStatus runner.ServiceStatus) error {
 	return nil
 }
 
+// Records that a run checked out the snapshot, to be reported in the ServiceStatus.
+func (s *StatusManager) SnapshotCheckedOut(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshots := []string{id}
+	for _, other := range s.snapshots {
+		if other != id && len(snapshots) < MaxRecentSnapshots {
+			snapshots = append(snapshots, other)
+		}
+	}
+	s.snapshots = snapshots
+}
+
+// Returns the service status along with the recently checked out snapshots. Caller must hold mu.
+func (s *StatusManager) service() runner.ServiceStatus {
+	svc := s.svcStatus
+	svc.SnapshotIDs = append([]string{}, s.snapshots...)
+	return svc
+}
+
 // Update writes a new status for a run.
 // It enforces several rules:
 //   cannot change a status once it is Done
@@ -128,7 +152,7 @@ func (s *StatusManager) Query(q runner.Query, wait runner.Wait) (rs []runner.Run
 	if err != nil || len(current) > 0 || wait.Timeout == 0 {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
-		return current, s.svcStatus, err
+		return current, s.service(), err
 	}
 
 	var timeout <-chan time.Time
@@ -142,16 +166,16 @@ func (s *StatusManager) Query(q runner.Query, wait runner.Wait) (rs []runner.Run
 	case st := <-listenerCh:
 		s.mu.RLock()
 		defer s.mu.RUnlock()
-		return []runner.RunStatus{st}, s.svcStatus, nil
+		return []runner.RunStatus{st}, s.service(), nil
 	case <-timeout:
 		s.mu.RLock()
 		defer s.mu.RUnlock()
-		return nil, s.svcStatus, nil
+		return nil, s.service(), nil
 	case <-wait.AbortCh:
 		st := runner.RunStatus{State: runner.ABORTED}
 		s.mu.RLock()
 		defer s.mu.RUnlock()
-		return []runner.RunStatus{st}, s.svcStatus, nil
+		return []runner.RunStatus{st}, s.service(), nil
 	}
 }
 
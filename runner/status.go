@@ -2,6 +2,7 @@ package runner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/twitter/scoot/common/log/tags"
 )
@@ -80,6 +81,10 @@ type RunStatus struct {
 	// Only valid if State == (FAILED || BADREQUEST)
 	Error string
 
+	// Resources used by the command once it ran, zero if unknown.
+	PeakMemoryBytes int64
+	CPUTime         time.Duration
+
 	tags.LogTags
 }
 
@@ -93,6 +98,9 @@ func (p RunStatus) String() string {
 	if p.State == FAILED || p.State == BADREQUEST {
 		s += fmt.Sprintf(" # Error: %s", p.Error)
 	}
+	if p.PeakMemoryBytes != 0 || p.CPUTime != 0 {
+		s += fmt.Sprintf(" # PeakMemory: %d # CPUTime: %v", p.PeakMemoryBytes, p.CPUTime)
+	}
 	s += fmt.Sprintf(" # Stdout: %s # Stderr: %s", p.StdoutRef, p.StderrRef)
 
 	return s
@@ -162,12 +170,66 @@ func PreparingStatus(runID RunID, tags tags.LogTags) (r RunStatus) {
 	return r
 }
 
-// This is for overall runner status, just 'initialized' status and error for now.
+// Resources a runner makes available, zero values are unknown.
+// Slots -
+//     the number of commands the runner can run concurrently.
+// CPUs, MemoryBytes, DiskBytes -
+//     the host resources shared by those runs.
+// Labels -
+//     describe the host, ex: os=linux-x86 or has=docker, to match against a command's node selector.
+type Capacity struct {
+	Slots       int
+	CPUs        int
+	MemoryBytes int64
+	DiskBytes   int64
+	Labels      map[string]string
+}
+
+// Resources a command needs from the runner that runs it, zero values are no requirement.
+type Resources struct {
+	CPUs        int
+	MemoryBytes int64
+	DiskBytes   int64
+}
+
+// Returns the sum of both resources.
+func (r Resources) Add(o Resources) Resources {
+	return Resources{CPUs: r.CPUs + o.CPUs, MemoryBytes: r.MemoryBytes + o.MemoryBytes, DiskBytes: r.DiskBytes + o.DiskBytes}
+}
+
+// Returns the difference of both resources.
+func (r Resources) Sub(o Resources) Resources {
+	return Resources{CPUs: r.CPUs - o.CPUs, MemoryBytes: r.MemoryBytes - o.MemoryBytes, DiskBytes: r.DiskBytes - o.DiskBytes}
+}
+
+// Returns true if the given resources fit within this capacity.
+// A resource this capacity doesn't know about (zero) isn't constrained.
+func (c Capacity) Fits(r Resources) bool {
+	return (c.CPUs == 0 || r.CPUs <= c.CPUs) &&
+		(c.MemoryBytes == 0 || r.MemoryBytes <= c.MemoryBytes) &&
+		(c.DiskBytes == 0 || r.DiskBytes <= c.DiskBytes)
+}
+
+// Returns true if every key in the selector is a label with the same value.
+func (c Capacity) Matches(selector map[string]string) bool {
+	for k, v := range selector {
+		if l, ok := c.Labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+// This is for overall runner status: 'initialized' status, error, and the capacity the runner advertises.
 type ServiceStatus struct {
 	Initialized bool
 	Error       error
+	Capacity
+	// Snapshots the runner checked out recently, most recent first, which it likely still has cached.
+	SnapshotIDs []string
 }
 
 func (s ServiceStatus) String() string {
-	return fmt.Sprintf("--- Service Status ---\n\tInitialized:%t\n", s.Initialized)
+	return fmt.Sprintf("--- Service Status ---\n\tInitialized:%t\n\tSlots:%d CPUs:%d Memory:%d Disk:%d Labels:%v\n",
+		s.Initialized, s.Slots, s.CPUs, s.MemoryBytes, s.DiskBytes, s.Labels)
 }
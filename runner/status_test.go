@@ -68,3 +68,22 @@ func TestProcStatusStringError(t *testing.T) {
 		t.Errorf("Got:\n%s\nExpected:\n%s\n", s, expected)
 	}
 }
+
+func TestCapacityFitsAndMatches(t *testing.T) {
+	c := Capacity{CPUs: 4, MemoryBytes: 1024, Labels: map[string]string{"os": "linux-x86", "has": "docker"}}
+	if !c.Fits(Resources{CPUs: 4, MemoryBytes: 1024, DiskBytes: 1 << 40}) {
+		t.Errorf("Expected resources to fit, disk is unknown and shouldn't be constrained")
+	}
+	if c.Fits(Resources{MemoryBytes: 1025}) {
+		t.Errorf("Expected memory to exceed capacity")
+	}
+	if c.Fits(Resources{CPUs: 2}.Add(Resources{CPUs: 3})) {
+		t.Errorf("Expected combined cpus to exceed capacity")
+	}
+	if !c.Matches(nil) || !c.Matches(map[string]string{"has": "docker"}) {
+		t.Errorf("Expected selector to match labels")
+	}
+	if c.Matches(map[string]string{"os": "osx"}) || c.Matches(map[string]string{"gpu": ""}) {
+		t.Errorf("Expected selector not to match labels")
+	}
+}
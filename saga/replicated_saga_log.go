@@ -0,0 +1,17 @@
+package saga
+
+//
+// A SagaLog replicated to several processes, only one of which, the leader,
+// can log messages at a time. Another process takes over as leader if it fails.
+//
+type ReplicatedSagaLog interface {
+	SagaLog
+
+	/*
+	 * Blocks until this process is the leader, and has applied all messages logged by previous
+	 * leaders so GetActiveSagas and GetMessages are up to date.
+	 * Returns a channel which is closed once this process stops being the leader, or an error
+	 * if the log was closed.
+	 */
+	WaitForLeadership() (<-chan struct{}, error)
+}
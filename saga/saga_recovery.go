@@ -36,7 +36,13 @@ func recoverState(sagaId string, saga SagaCoordinator) (*SagaState, error) {
 		return nil, nil
 	}
 
-	// Reconstruct Saga State from Logged Messages
+	return stateFromMessages(sagaId, msgs)
+}
+
+//
+// Reconstructs SagaState from the messages logged for a saga
+//
+func stateFromMessages(sagaId string, msgs []SagaMessage) (*SagaState, error) {
 	startMsg := msgs[0]
 	if startMsg.MsgType != StartSaga {
 		return nil, fmt.Errorf("InvalidMessages: first message must be StartSaga")
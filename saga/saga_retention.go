@@ -0,0 +1,184 @@
+package saga
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//
+// A SagaLog which can rewrite and remove completed sagas, so a RetentionPolicy
+// can keep it from growing forever.
+//
+type CompactableSagaLog interface {
+	SagaLog
+
+	/*
+	 * Returns all completed sagas, i.e. those with an EndSaga message, in any order.
+	 * Returns an error if it fails.
+	 */
+	GetCompletedSagas() ([]CompletedSaga, error)
+
+	/*
+	 * Atomically replaces all messages of a completed saga with msgs, and marks it as compacted.
+	 * Returns an error if it fails.
+	 */
+	ReplaceMessages(sagaId string, msgs []SagaMessage) error
+
+	/*
+	 * Removes a completed saga and all of its messages.
+	 * Returns an error if it fails.
+	 */
+	DeleteSaga(sagaId string) error
+}
+
+// A completed saga, and the time its EndSaga message was logged.
+type CompletedSaga struct {
+	SagaId    string
+	Completed time.Time
+	Compacted bool
+}
+
+type completedSagasByTime []CompletedSaga
+
+func (s completedSagasByTime) Len() int           { return len(s) }
+func (s completedSagasByTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s completedSagasByTime) Less(i, j int) bool { return s[i].Completed.Before(s[j].Completed) }
+
+// The default time between retention passes.
+const DefaultRetentionInterval = time.Minute
+
+// Determines how long completed sagas are kept.
+// CompactAfter - completed sagas older than this are compacted to their final state,
+//     which still answers status queries. Zero disables compaction.
+// DeleteAfter - completed sagas older than this are deleted. Zero keeps them.
+// MaxCompletedSagas - if more sagas than this are completed, the oldest are deleted. Zero is unlimited.
+// Interval - the time between retention passes, DefaultRetentionInterval if zero.
+//
+// Sagas that haven't completed are never compacted or deleted.
+type RetentionPolicy struct {
+	CompactAfter      time.Duration
+	DeleteAfter       time.Duration
+	MaxCompletedSagas int
+	Interval          time.Duration
+}
+
+// Returns true if the policy would never compact or delete anything.
+func (p RetentionPolicy) IsZero() bool {
+	return p.CompactAfter == 0 && p.DeleteAfter == 0 && p.MaxCompletedSagas == 0
+}
+
+//
+// Starts a goroutine applying the retention policy to the log every policy.Interval,
+// in the background so it doesn't hold up logging.
+//
+func StartRetention(slog CompactableSagaLog, policy RetentionPolicy) {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = DefaultRetentionInterval
+	}
+	go func() {
+		for range time.Tick(interval) {
+			compacted, deleted, err := ApplyRetention(slog, policy, time.Now())
+			if err != nil {
+				log.Errorf("Error applying saga log retention policy: %v", err)
+			}
+			if compacted > 0 || deleted > 0 {
+				log.Infof("Saga log retention compacted %d and deleted %d completed sagas", compacted, deleted)
+			}
+		}
+	}()
+}
+
+//
+// Compacts and deletes the completed sagas in the log which the policy doesn't retain as of now.
+// Returns the number of sagas compacted and deleted, and the last error encountered if any.
+// Sagas that fail to compact or delete are skipped and retried in the next pass.
+//
+func ApplyRetention(slog CompactableSagaLog, policy RetentionPolicy, now time.Time) (compacted, deleted int, err error) {
+	if policy.IsZero() {
+		return 0, 0, nil
+	}
+	completed, err := slog.GetCompletedSagas()
+	if err != nil {
+		return 0, 0, err
+	}
+	sort.Sort(completedSagasByTime(completed))
+
+	// The oldest sagas over the max count are deleted regardless of age.
+	numOverMax := 0
+	if policy.MaxCompletedSagas > 0 && len(completed) > policy.MaxCompletedSagas {
+		numOverMax = len(completed) - policy.MaxCompletedSagas
+	}
+	for i, cs := range completed {
+		age := now.Sub(cs.Completed)
+		switch {
+		case i < numOverMax || (policy.DeleteAfter > 0 && age > policy.DeleteAfter):
+			if e := slog.DeleteSaga(cs.SagaId); e != nil {
+				err = e
+			} else {
+				deleted++
+			}
+		case !cs.Compacted && policy.CompactAfter > 0 && age > policy.CompactAfter:
+			if e := compactSaga(slog, cs.SagaId); e != nil {
+				err = e
+			} else {
+				compacted++
+			}
+		}
+	}
+	return compacted, deleted, err
+}
+
+func compactSaga(slog CompactableSagaLog, sagaId string) error {
+	msgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	compacted, err := CompactMessages(sagaId, msgs)
+	if err != nil {
+		return err
+	}
+	return slog.ReplaceMessages(sagaId, compacted)
+}
+
+//
+// Returns the fewest messages that result in the same final state as msgs, which must be the messages of
+// a completed saga: its StartSaga message, each task's StartTask without data and final EndTask, and for an
+// aborted saga the AbortSaga message and each task's StartCompTask without data and final EndCompTask,
+// followed by EndSaga. Task data only needed while the saga was in progress is dropped.
+//
+func CompactMessages(sagaId string, msgs []SagaMessage) ([]SagaMessage, error) {
+	state, err := stateFromMessages(sagaId, msgs)
+	if err != nil {
+		return nil, err
+	}
+	if !state.IsSagaCompleted() {
+		return nil, NewInvalidRequestError(fmt.Sprintf("Cannot compact saga %s, it hasn't completed", sagaId))
+	}
+
+	taskIds := state.GetTaskIds()
+	sort.Strings(taskIds)
+	compacted := []SagaMessage{MakeStartSagaMessage(sagaId, state.Job())}
+	for _, id := range taskIds {
+		compacted = append(compacted, MakeStartTaskMessage(sagaId, id, nil))
+		if state.IsTaskCompleted(id) {
+			compacted = append(compacted, MakeEndTaskMessage(sagaId, id, state.GetEndTaskData(id)))
+		}
+	}
+	if state.IsSagaAborted() {
+		compacted = append(compacted, MakeAbortSagaMessage(sagaId))
+		for _, id := range taskIds {
+			compacted = append(compacted,
+				MakeStartCompTaskMessage(sagaId, id, nil),
+				MakeEndCompTaskMessage(sagaId, id, state.GetEndCompTaskData(id)))
+		}
+	}
+	compacted = append(compacted, MakeEndSagaMessage(sagaId))
+	return compacted, nil
+}
@@ -0,0 +1,84 @@
+package saga
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompactMessages_CompletedSaga(t *testing.T) {
+	sagaId := "sagaId"
+	msgs := []SagaMessage{
+		MakeStartSagaMessage(sagaId, []byte("job")),
+		MakeStartTaskMessage(sagaId, "task2", []byte("started 2")),
+		MakeStartTaskMessage(sagaId, "task1", []byte("started 1")),
+		MakeEndTaskMessage(sagaId, "task1", []byte("done 1")),
+		MakeEndTaskMessage(sagaId, "task2", []byte("done 2")),
+		MakeEndSagaMessage(sagaId),
+	}
+	compacted, err := CompactMessages(sagaId, msgs)
+	if err != nil {
+		t.Fatalf("Unexpected Error %v", err)
+	}
+	expected := []SagaMessage{
+		MakeStartSagaMessage(sagaId, []byte("job")),
+		MakeStartTaskMessage(sagaId, "task1", nil),
+		MakeEndTaskMessage(sagaId, "task1", []byte("done 1")),
+		MakeStartTaskMessage(sagaId, "task2", nil),
+		MakeEndTaskMessage(sagaId, "task2", []byte("done 2")),
+		MakeEndSagaMessage(sagaId),
+	}
+	if !reflect.DeepEqual(compacted, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, compacted)
+	}
+
+	state, err := stateFromMessages(sagaId, compacted)
+	if err != nil {
+		t.Fatalf("Expected compacted messages to be a valid saga, got %v", err)
+	}
+	if !state.IsSagaCompleted() || !state.IsTaskCompleted("task1") || string(state.GetEndTaskData("task2")) != "done 2" {
+		t.Errorf("Expected compacted saga to keep its final state, got %v", state)
+	}
+}
+
+func TestCompactMessages_AbortedSaga(t *testing.T) {
+	sagaId := "sagaId"
+	msgs := []SagaMessage{
+		MakeStartSagaMessage(sagaId, []byte("job")),
+		MakeStartTaskMessage(sagaId, "task1", nil),
+		MakeEndTaskMessage(sagaId, "task1", []byte("done 1")),
+		MakeStartTaskMessage(sagaId, "task2", nil),
+		MakeAbortSagaMessage(sagaId),
+		MakeStartCompTaskMessage(sagaId, "task1", []byte("undo 1")),
+		MakeEndCompTaskMessage(sagaId, "task1", []byte("undone 1")),
+		MakeStartCompTaskMessage(sagaId, "task2", nil),
+		MakeEndCompTaskMessage(sagaId, "task2", nil),
+		MakeEndSagaMessage(sagaId),
+	}
+	compacted, err := CompactMessages(sagaId, msgs)
+	if err != nil {
+		t.Fatalf("Unexpected Error %v", err)
+	}
+	state, err := stateFromMessages(sagaId, compacted)
+	if err != nil {
+		t.Fatalf("Expected compacted messages to be a valid saga, got %v", err)
+	}
+	if !state.IsSagaAborted() || !state.IsSagaCompleted() || !state.IsTaskCompleted("task1") ||
+		state.IsTaskCompleted("task2") || !state.IsCompTaskCompleted("task2") ||
+		string(state.GetEndCompTaskData("task1")) != "undone 1" {
+		t.Errorf("Expected compacted saga to keep its final state, got %v", state)
+	}
+	if len(compacted) != 10 {
+		t.Errorf("Expected 10 compacted messages, got %+v", compacted)
+	}
+}
+
+func TestCompactMessages_InProgressSaga(t *testing.T) {
+	sagaId := "sagaId"
+	msgs := []SagaMessage{
+		MakeStartSagaMessage(sagaId, []byte("job")),
+		MakeStartTaskMessage(sagaId, "task1", nil),
+	}
+	if _, err := CompactMessages(sagaId, msgs); err == nil {
+		t.Error("Expected an error compacting a saga that hasn't completed")
+	}
+}
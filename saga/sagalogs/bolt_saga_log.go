@@ -0,0 +1,332 @@
+package sagalogs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Controls when the bolt saga log fsyncs its file.
+type SyncPolicy int
+
+const (
+	// Fsync on every commit, a logged message survives machine failure once LogMessage returns.
+	// This is the only policy under which the file is never left partially written.
+	SyncAlways SyncPolicy = iota
+	// Fsync every SyncInterval. Commits run with bolt's NoSync, so a machine failure between syncs
+	// can lose recent messages, or corrupt the file so the log can't be opened. Safe against process failure.
+	SyncInterval
+	// Never fsync explicitly and leave it to the OS, with the same risks as SyncInterval on machine failure.
+	SyncNever
+)
+
+// The default time between fsyncs with SyncInterval.
+const DefaultSyncInterval = time.Second
+
+var (
+	// Contains a nested bucket per saga, keyed by sagaId, whose values are the saga's messages
+	// keyed by a big endian sequence number so they're iterated in the order they were logged.
+	sagasBucket = []byte("sagas")
+	// Contains a key per saga which hasn't logged an EndSaga message.
+	activeBucket = []byte("active")
+	// Contains a key per saga which logged an EndSaga message, whose value is the big endian
+	// unix nanos when it was logged followed by a byte which is 1 once the saga was compacted.
+	completedBucket = []byte("completed")
+)
+
+// Writes the Saga Log to a single file with an embedded transactional
+// key-value store. Each message is appended in its own transaction, so a process crash never
+// leaves a partially written message behind (see SyncPolicy for machine failures), and an index of active sagas is kept
+// alongside the messages so GetActiveSagas doesn't have to scan every saga.
+type boltSagaLog struct {
+	db       *bolt.DB
+	doneCh   chan struct{}
+	doneOnce sync.Once
+	syncWg   sync.WaitGroup // Done once the sync loop, if any, exited.
+}
+
+// Creates a BoltSagaLog stored in the file at path, creating it if it doesn't exist.
+// With SyncInterval, syncInterval is the time between fsyncs, DefaultSyncInterval if zero.
+func MakeBoltSagaLog(path string, policy SyncPolicy, syncInterval time.Duration) (*boltSagaLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	db.NoSync = policy != SyncAlways
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{sagasBucket, activeBucket, completedBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	log := &boltSagaLog{db: db, doneCh: make(chan struct{})}
+	if policy == SyncInterval {
+		if syncInterval <= 0 {
+			syncInterval = DefaultSyncInterval
+		}
+		log.syncWg.Add(1)
+		go log.syncLoop(syncInterval)
+	}
+	return log, nil
+}
+
+// Fsyncs the file every interval until the log is closed.
+func (log *boltSagaLog) syncLoop(interval time.Duration) {
+	defer log.syncWg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			log.db.Sync()
+		case <-log.doneCh:
+			return
+		}
+	}
+}
+
+// Syncs and closes the underlying file.
+func (log *boltSagaLog) Close() error {
+	log.doneOnce.Do(func() { close(log.doneCh) })
+	log.syncWg.Wait()
+	log.db.Sync()
+	return log.db.Close()
+}
+
+// Log a Start Saga Message message to the log and mark the saga as active.
+// Starting a saga that was already started appends another StartSaga message.
+// Returns an error if it fails.
+func (log *boltSagaLog) StartSaga(sagaId string, job []byte) error {
+	if sagaId == "" {
+		return saga.NewInvalidRequestError("sagaId must not be empty")
+	}
+	return log.append(saga.MakeStartSagaMessage(sagaId, job), true)
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails, or if the saga wasn't started.
+func (log *boltSagaLog) LogMessage(message saga.SagaMessage) error {
+	return log.append(message, false)
+}
+
+// Appends the message to its saga in a single transaction, which also updates the
+// active saga index. Concurrent appends are batched into one transaction and fsync.
+func (log *boltSagaLog) append(message saga.SagaMessage, start bool) error {
+	sagaId := []byte(message.SagaId)
+	err := log.db.Batch(func(tx *bolt.Tx) error {
+		sagas := tx.Bucket(sagasBucket)
+		msgs := sagas.Bucket(sagaId)
+		if msgs == nil {
+			if !start {
+				return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not Started yet.", message.SagaId))
+			}
+			var err error
+			if msgs, err = sagas.CreateBucket(sagaId); err != nil {
+				return err
+			}
+		}
+		seq, err := msgs.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		if err := msgs.Put(key, encodeMessage(message)); err != nil {
+			return err
+		}
+
+		if message.MsgType == saga.EndSaga {
+			if err := tx.Bucket(activeBucket).Delete(sagaId); err != nil {
+				return err
+			}
+			return tx.Bucket(completedBucket).Put(sagaId, encodeCompleted(time.Now(), false))
+		} else if start {
+			return tx.Bucket(activeBucket).Put(sagaId, []byte{})
+		}
+		return nil
+	})
+	return toSagaLogError(err)
+}
+
+// Returns all of the messages logged so far for the
+// specified saga, nil if it doesn't exist.
+func (log *boltSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	var msgs []saga.SagaMessage
+	err := log.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sagasBucket).Bucket([]byte(sagaId))
+		if b == nil {
+			return nil
+		}
+		msgs = make([]saga.SagaMessage, 0)
+		return b.ForEach(func(k, v []byte) error {
+			msg, err := decodeMessage(sagaId, v)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, toSagaLogError(err)
+	}
+	return msgs, nil
+}
+
+// Returns the ids of all sagas that haven't logged an EndSaga message, read from the index.
+func (log *boltSagaLog) GetActiveSagas() ([]string, error) {
+	sagaIds := []string{}
+	err := log.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(activeBucket).ForEach(func(k, v []byte) error {
+			sagaIds = append(sagaIds, string(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, toSagaLogError(err)
+	}
+	return sagaIds, nil
+}
+
+// Returns all completed sagas, read from the index.
+func (log *boltSagaLog) GetCompletedSagas() ([]saga.CompletedSaga, error) {
+	completed := []saga.CompletedSaga{}
+	err := log.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(completedBucket).ForEach(func(k, v []byte) error {
+			if len(v) != 9 {
+				return saga.NewCorruptedSagaLogError(string(k), "Invalid completed saga index entry")
+			}
+			completed = append(completed, saga.CompletedSaga{
+				SagaId:    string(k),
+				Completed: time.Unix(0, int64(binary.BigEndian.Uint64(v))),
+				Compacted: v[8] == 1,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, toSagaLogError(err)
+	}
+	return completed, nil
+}
+
+// Replaces the saga's messages with msgs and marks it as compacted in a single transaction.
+// Space freed in the file is reused by later messages, the file itself doesn't shrink.
+func (log *boltSagaLog) ReplaceMessages(sagaId string, msgs []saga.SagaMessage) error {
+	err := log.db.Update(func(tx *bolt.Tx) error {
+		id := []byte(sagaId)
+		v := tx.Bucket(completedBucket).Get(id)
+		if len(v) != 9 {
+			return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not completed.", sagaId))
+		}
+		completed := time.Unix(0, int64(binary.BigEndian.Uint64(v)))
+
+		sagas := tx.Bucket(sagasBucket)
+		if err := sagas.DeleteBucket(id); err != nil {
+			return err
+		}
+		b, err := sagas.CreateBucket(id)
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			key := make([]byte, 8)
+			binary.BigEndian.PutUint64(key, seq)
+			if err := b.Put(key, encodeMessage(msg)); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(completedBucket).Put(id, encodeCompleted(completed, true))
+	})
+	return toSagaLogError(err)
+}
+
+// Removes the saga's messages and its index entries in a single transaction.
+func (log *boltSagaLog) DeleteSaga(sagaId string) error {
+	err := log.db.Update(func(tx *bolt.Tx) error {
+		id := []byte(sagaId)
+		if err := tx.Bucket(sagasBucket).DeleteBucket(id); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		if err := tx.Bucket(activeBucket).Delete(id); err != nil {
+			return err
+		}
+		return tx.Bucket(completedBucket).Delete(id)
+	})
+	return toSagaLogError(err)
+}
+
+// Encodes a completed saga index entry.
+func encodeCompleted(t time.Time, compacted bool) []byte {
+	v := make([]byte, 9)
+	binary.BigEndian.PutUint64(v, uint64(t.UnixNano()))
+	if compacted {
+		v[8] = 1
+	}
+	return v
+}
+
+// Store errors may succeed on retry, saga errors are returned as is.
+func toSagaLogError(err error) error {
+	switch err.(type) {
+	case nil, saga.InvalidRequestError, saga.CorruptedSagaLogError:
+		return err
+	default:
+		return saga.NewInternalLogError(err.Error())
+	}
+}
+
+// Encodes a message as its type, followed by the uvarint length of its taskId, the taskId, and its data.
+// The sagaId isn't stored since it's the key of the message's bucket.
+func encodeMessage(msg saga.SagaMessage) []byte {
+	buf := make([]byte, 1+binary.MaxVarintLen64+len(msg.TaskId)+len(msg.Data))
+	buf[0] = byte(msg.MsgType)
+	n := 1 + binary.PutUvarint(buf[1:], uint64(len(msg.TaskId)))
+	n += copy(buf[n:], msg.TaskId)
+	n += copy(buf[n:], msg.Data)
+	return buf[:n]
+}
+
+// Decodes a message written by encodeMessage. Values are only valid during
+// their transaction, so the data is copied.
+func decodeMessage(sagaId string, v []byte) (saga.SagaMessage, error) {
+	if len(v) < 2 {
+		return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(sagaId, "Message too short")
+	}
+	msg := saga.SagaMessage{SagaId: sagaId, MsgType: saga.SagaMessageType(v[0])}
+	if msg.MsgType.String() == "unknown" {
+		return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(
+			sagaId, fmt.Sprintf("Unrecognized message type, %d", v[0]))
+	}
+	taskIdLen, n := binary.Uvarint(v[1:])
+	if n <= 0 || uint64(len(v)-1-n) < taskIdLen {
+		return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(sagaId, "Invalid taskId length")
+	}
+	start := 1 + n
+	msg.TaskId = string(v[start : start+int(taskIdLen)])
+	if data := v[start+int(taskIdLen):]; len(data) > 0 {
+		msg.Data = append([]byte{}, data...)
+	}
+	return msg, nil
+}
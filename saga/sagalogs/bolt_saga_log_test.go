@@ -0,0 +1,134 @@
+package sagalogs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/saga"
+)
+
+func makeTestBoltSagaLog(t *testing.T, policy SyncPolicy) (*boltSagaLog, string) {
+	dir, err := ioutil.TempDir("", "bolt_saga_log_test")
+	if err != nil {
+		t.Fatalf("Unexpected Error creating temp dir %v", err)
+	}
+	path := filepath.Join(dir, "sagas", "saga.db")
+	slog, err := MakeBoltSagaLog(path, policy, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected Error Returned %v", err)
+	}
+	return slog, path
+}
+
+func TestBoltSagaLog_FullSaga(t *testing.T) {
+	slog, path := makeTestBoltSagaLog(t, SyncAlways)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(path)))
+
+	sagaId := "fullsaga"
+	jobData := []byte{0, 1, 2, 3, 4, 5}
+	loggedMsgs := []saga.SagaMessage{
+		saga.MakeStartSagaMessage(sagaId, jobData),
+		saga.MakeStartTaskMessage(sagaId, "task1", []byte("run task 1")),
+		saga.MakeEndTaskMessage(sagaId, "task1", []byte("success")),
+		saga.MakeAbortSagaMessage(sagaId),
+		saga.MakeStartCompTaskMessage(sagaId, "task1", []byte("rollingback task1")),
+		saga.MakeEndCompTaskMessage(sagaId, "task1", []byte("finished rollingback task1")),
+	}
+	if err := slog.StartSaga(sagaId, jobData); err != nil {
+		t.Fatalf("Unexpected Error starting Saga %v", err)
+	}
+	for _, msg := range loggedMsgs[1:] {
+		if err := slog.LogMessage(msg); err != nil {
+			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
+		}
+	}
+	if !isSagaInActiveList(sagaId, slog) {
+		t.Errorf("Expected Saga to be in active list")
+	}
+
+	// Messages should survive reopening the log, and be returned in the order they were logged.
+	slog.Close()
+	slog, err := MakeBoltSagaLog(path, SyncAlways, 0)
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening %v", err)
+	}
+	defer slog.Close()
+	rtnMsgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		t.Fatalf("Unexpected Error returned from GetMessages. %v", err)
+	}
+	if !reflect.DeepEqual(loggedMsgs, rtnMsgs) {
+		t.Fatalf("Expected GetMessages to return %+v, Actual %+v", loggedMsgs, rtnMsgs)
+	}
+
+	// Ending the saga removes it from the active index.
+	if err := slog.LogMessage(saga.MakeEndSagaMessage(sagaId)); err != nil {
+		t.Fatalf("Unexpected Error ending Saga %v", err)
+	}
+	if isSagaInActiveList(sagaId, slog) {
+		t.Errorf("Expected ended Saga to not be in active list")
+	}
+	if msgs, _ := slog.GetMessages(sagaId); len(msgs) != len(loggedMsgs)+1 {
+		t.Errorf("Expected %d messages after EndSaga, got %+v", len(loggedMsgs)+1, msgs)
+	}
+}
+
+func TestBoltSagaLog_Errors(t *testing.T) {
+	slog, path := makeTestBoltSagaLog(t, SyncNever)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(path)))
+	defer slog.Close()
+
+	err := slog.LogMessage(saga.MakeEndSagaMessage("not_started"))
+	if _, ok := err.(saga.InvalidRequestError); !ok {
+		t.Errorf("Expected InvalidRequestError logging to a saga that wasn't started, got %v", err)
+	}
+	if msgs, err := slog.GetMessages("does_not_exist"); err != nil || msgs != nil {
+		t.Errorf("Expected no messages and no error, got %+v, %v", msgs, err)
+	}
+	if _, err := MakeBoltSagaLog(path, SyncAlways, 0); err == nil {
+		t.Errorf("Expected Error opening a log that's already open")
+	}
+}
+
+func TestBoltSagaLog_ConcurrentSagas(t *testing.T) {
+	slog, path := makeTestBoltSagaLog(t, SyncInterval)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(path)))
+	defer slog.Close()
+
+	errCh := make(chan error)
+	for i := 0; i < 20; i++ {
+		go func(sagaId string) {
+			err := slog.StartSaga(sagaId, []byte(sagaId))
+			if err == nil {
+				err = slog.LogMessage(saga.MakeStartTaskMessage(sagaId, "task1", nil))
+			}
+			errCh <- err
+		}(fmt.Sprintf("saga%d", i))
+	}
+	for i := 0; i < 20; i++ {
+		if err := <-errCh; err != nil {
+			t.Fatalf("Unexpected Error %v", err)
+		}
+	}
+	active, _ := slog.GetActiveSagas()
+	if len(active) != 20 {
+		t.Fatalf("Expected 20 active sagas, got %v", active)
+	}
+	for _, sagaId := range active {
+		if msgs, _ := slog.GetMessages(sagaId); len(msgs) != 2 || msgs[1].TaskId != "task1" {
+			t.Errorf("Expected a StartSaga and a StartTask message for %s, got %+v", sagaId, msgs)
+		}
+	}
+}
+
+func TestBoltSagaLog_Retention(t *testing.T) {
+	slog, path := makeTestBoltSagaLog(t, SyncAlways)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(path)))
+	defer slog.Close()
+	testRetention(t, slog)
+}
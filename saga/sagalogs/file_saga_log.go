@@ -2,7 +2,9 @@ package sagalogs
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -44,6 +46,11 @@ import (
 
 // EndSaga Message
 // EndSaga
+//
+// Once EndSaga is logged an empty "ended" file is created in the saga's directory,
+// and an empty "compacted" file once its messages have been compacted.
+// Sagas that ended before the "ended" file was introduced get one the first time
+// their log is found to end with EndSaga.
 type fileSagaLog struct {
 	dirName string
 }
@@ -204,6 +211,11 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 	}
 
 	logFile.Sync()
+
+	// mark the saga as completed so it's no longer returned by GetActiveSagas
+	if message.MsgType == saga.EndSaga {
+		return touch(log.getSagaMarkerFileName(message.SagaId, endedMarker))
+	}
 	return nil
 }
 
@@ -371,8 +383,7 @@ func createUnexpectedScanEndMsg(scanner *bufio.Scanner) string {
 // It may also included completed sagas
 // Returns an error if it fails.
 //
-// This is a very dumb implementation currently just returns
-// a list of all sagas.  This can be improved with an index
+// Returns all sagas that haven't logged an EndSaga message.
 //
 func (log *fileSagaLog) GetActiveSagas() ([]string, error) {
 	files, err := ioutil.ReadDir(log.dirName)
@@ -380,10 +391,178 @@ func (log *fileSagaLog) GetActiveSagas() ([]string, error) {
 		return nil, err
 	}
 
-	sagaIds := make([]string, len(files))
-	for i, file := range files {
-		sagaIds[i] = file.Name()
+	sagaIds := make([]string, 0, len(files))
+	for _, file := range files {
+		ended, err := log.getSagaEnded(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ended == nil {
+			sagaIds = append(sagaIds, file.Name())
+		}
 	}
 
 	return sagaIds, nil
 }
+
+// Names of the empty marker files in a saga's directory.
+const (
+	endedMarker     = "ended"
+	compactedMarker = "compacted"
+)
+
+// Returns the name of the given marker file for the specified saga.
+func (log *fileSagaLog) getSagaMarkerFileName(sagaId string, marker string) string {
+	return path.Join(log.getSagaDirectory(sagaId), marker)
+}
+
+// Returns the "ended" marker of the saga, or nil if it hasn't ended. Sagas whose log ends with an
+// EndSaga message but that have no marker, because they ended before markers were written, are
+// marked now with the time of their log's last write.
+func (log *fileSagaLog) getSagaEnded(sagaId string) (os.FileInfo, error) {
+	markerFileName := log.getSagaMarkerFileName(sagaId, endedMarker)
+	if ended, err := os.Stat(markerFileName); err == nil {
+		return ended, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	logFileName := log.getSagaLogFileName(sagaId)
+	logFile, err := os.Open(logFileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+	fi, err := logFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// EndSaga is the only message without a data file, so it's the last line only if the saga ended.
+	tail := []byte(fmt.Sprintf("\n%v\n", saga.EndSaga.String()))
+	if fi.Size() < int64(len(tail)) {
+		return nil, nil
+	}
+	buf := make([]byte, len(tail))
+	if _, err := logFile.ReadAt(buf, fi.Size()-int64(len(tail))); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if !bytes.Equal(buf, tail) {
+		return nil, nil
+	}
+
+	if err := touch(markerFileName); err != nil {
+		return nil, err
+	}
+	if err := os.Chtimes(markerFileName, fi.ModTime(), fi.ModTime()); err != nil {
+		return nil, err
+	}
+	return os.Stat(markerFileName)
+}
+
+// Creates an empty file, or updates its modification time if it exists.
+func touch(fileName string) error {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// Returns all sagas that logged an EndSaga message, with the time they were marked as ended.
+func (log *fileSagaLog) GetCompletedSagas() ([]saga.CompletedSaga, error) {
+	files, err := ioutil.ReadDir(log.dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := []saga.CompletedSaga{}
+	for _, file := range files {
+		ended, err := log.getSagaEnded(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ended == nil {
+			continue
+		}
+		_, err = os.Stat(log.getSagaMarkerFileName(file.Name(), compactedMarker))
+		completed = append(completed, saga.CompletedSaga{
+			SagaId:    file.Name(),
+			Completed: ended.ModTime(),
+			Compacted: err == nil,
+		})
+	}
+	return completed, nil
+}
+
+// Writes msgs and their data files to a new log file, atomically renames it over
+// the saga's log, and then removes the data files that are no longer referenced.
+// Returns an InvalidRequestError if the saga hasn't completed.
+func (log *fileSagaLog) ReplaceMessages(sagaId string, msgs []saga.SagaMessage) error {
+	if ended, err := log.getSagaEnded(sagaId); err != nil {
+		return err
+	} else if ended == nil {
+		return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not completed.", sagaId))
+	}
+
+	referenced := map[string]bool{
+		log.getSagaLogFileName(sagaId):                     true,
+		log.getSagaMarkerFileName(sagaId, endedMarker):     true,
+		log.getSagaMarkerFileName(sagaId, compactedMarker): true,
+	}
+
+	var logContents []byte
+	for i, msg := range msgs {
+		logContents = append(logContents, []byte(fmt.Sprintf("%v\n", msg.MsgType.String()))...)
+		switch msg.MsgType {
+		case saga.StartSaga, saga.StartTask, saga.EndTask, saga.StartCompTask, saga.EndCompTask:
+			// data file names are unique within the compacted log and distinct from those of the original.
+			dataFileName := path.Join(log.getSagaDirectory(sagaId), fmt.Sprintf("compacted_%d_data", i))
+			if err := ioutil.WriteFile(dataFileName, msg.Data, os.ModePerm); err != nil {
+				return err
+			}
+			referenced[dataFileName] = true
+			if msg.MsgType != saga.StartSaga {
+				logContents = append(logContents, []byte(fmt.Sprintf("%v\n", msg.TaskId))...)
+			}
+			logContents = append(logContents, []byte(fmt.Sprintf("%v\n", dataFileName))...)
+		}
+	}
+
+	tmpFileName := log.getSagaLogFileName(sagaId) + ".compacted"
+	tmpFile, err := os.Create(tmpFileName)
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(logContents); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFileName, log.getSagaLogFileName(sagaId)); err != nil {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(log.getSagaDirectory(sagaId))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if fileName := path.Join(log.getSagaDirectory(sagaId), file.Name()); !referenced[fileName] {
+			os.Remove(fileName)
+		}
+	}
+	return touch(log.getSagaMarkerFileName(sagaId, compactedMarker))
+}
+
+// Removes the saga's directory and everything in it.
+func (log *fileSagaLog) DeleteSaga(sagaId string) error {
+	return os.RemoveAll(log.getSagaDirectory(sagaId))
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/twitter/scoot/saga"
 )
@@ -171,3 +172,122 @@ func TestGetMessages_SagaDoesNotExist(t *testing.T) {
 		t.Errorf("Expeceted no messages to be returned %+v", msgs)
 	}
 }
+
+func TestFileSagaLog_Retention(t *testing.T) {
+	defer testCleanup(t)
+	slog, _ := MakeFileSagaLog(getDirName())
+	testRetention(t, slog)
+}
+
+// Sagas that ended before the ended marker was written are recognized by their EndSaga message.
+func TestFileSagaLog_EndedWithoutMarker(t *testing.T) {
+	defer testCleanup(t)
+	slog, _ := MakeFileSagaLog(getDirName())
+	slog.StartSaga("old", []byte("job"))
+	slog.LogMessage(saga.MakeEndSagaMessage("old"))
+	slog.StartSaga("in_progress", []byte("job"))
+	slog.LogMessage(saga.MakeStartTaskMessage("in_progress", "task1", nil))
+
+	os.Remove(slog.getSagaMarkerFileName("old", endedMarker))
+	lastWrite := time.Now().Add(-time.Hour).Truncate(time.Second)
+	os.Chtimes(slog.getSagaLogFileName("old"), lastWrite, lastWrite)
+
+	if isSagaInActiveList("old", slog) || !isSagaInActiveList("in_progress", slog) {
+		t.Fatalf("Expected only the in progress Saga to be in the active list")
+	}
+	completed, err := slog.GetCompletedSagas()
+	if err != nil || len(completed) != 1 || completed[0].SagaId != "old" || !completed[0].Completed.Equal(lastWrite) {
+		t.Fatalf("Expected old to be completed at %v, got %+v %v", lastWrite, completed, err)
+	}
+	if err := slog.ReplaceMessages("old", []saga.SagaMessage{saga.MakeStartSagaMessage("old", nil), saga.MakeEndSagaMessage("old")}); err != nil {
+		t.Fatalf("Unexpected error replacing the messages of a completed saga: %v", err)
+	}
+}
+
+// Logs a completed saga with the given id, along with an in progress one, and checks
+// that the retention policy compacts then deletes only the completed saga.
+func testRetention(t *testing.T, slog saga.CompactableSagaLog) {
+	sagaId := "completed"
+	loggedMsgs := []saga.SagaMessage{
+		saga.MakeStartTaskMessage(sagaId, "task1", []byte("run task 1")),
+		saga.MakeEndTaskMessage(sagaId, "task1", []byte("success")),
+		saga.MakeEndSagaMessage(sagaId),
+	}
+	slog.StartSaga(sagaId, []byte("job"))
+	for _, msg := range loggedMsgs {
+		if err := slog.LogMessage(msg); err != nil {
+			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
+		}
+	}
+	slog.StartSaga("in_progress", []byte("job"))
+	if isSagaInActiveList(sagaId, slog) || !isSagaInActiveList("in_progress", slog) {
+		t.Fatalf("Expected only the in progress Saga to be in the active list")
+	}
+	if err := slog.ReplaceMessages("in_progress", nil); err == nil {
+		t.Fatalf("Expected an error replacing the messages of an in progress saga")
+	}
+
+	policy := saga.RetentionPolicy{CompactAfter: time.Hour, DeleteAfter: 2 * time.Hour}
+	if compacted, deleted, err := saga.ApplyRetention(slog, policy, time.Now()); compacted != 0 || deleted != 0 || err != nil {
+		t.Fatalf("Expected nothing to be retained yet, got %d compacted, %d deleted, %v", compacted, deleted, err)
+	}
+	compacted, deleted, err := saga.ApplyRetention(slog, policy, time.Now().Add(90*time.Minute))
+	if compacted != 1 || deleted != 0 || err != nil {
+		t.Fatalf("Expected the completed saga to be compacted, got %d compacted, %d deleted, %v", compacted, deleted, err)
+	}
+	msgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		t.Fatalf("Unexpected Error Getting Messages %v", err)
+	}
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage(sagaId, []byte("job")),
+		saga.MakeStartTaskMessage(sagaId, "task1", nil),
+		saga.MakeEndTaskMessage(sagaId, "task1", []byte("success")),
+		saga.MakeEndSagaMessage(sagaId),
+	}
+	if !messagesEqual(msgs, expected) {
+		t.Fatalf("Expected compacted messages %+v, got %+v", expected, msgs)
+	}
+	if completed, _ := slog.GetCompletedSagas(); len(completed) != 1 || !completed[0].Compacted {
+		t.Fatalf("Expected a single compacted saga, got %+v", completed)
+	}
+
+	compacted, deleted, err = saga.ApplyRetention(slog, policy, time.Now().Add(3*time.Hour))
+	if compacted != 0 || deleted != 1 || err != nil {
+		t.Fatalf("Expected the completed saga to be deleted, got %d compacted, %d deleted, %v", compacted, deleted, err)
+	}
+	if msgs, _ := slog.GetMessages(sagaId); msgs != nil {
+		t.Fatalf("Expected no messages for deleted saga, got %+v", msgs)
+	}
+	if msgs, _ := slog.GetMessages("in_progress"); len(msgs) != 1 || !isSagaInActiveList("in_progress", slog) {
+		t.Fatalf("Expected in progress saga to be retained, got %+v", msgs)
+	}
+
+	// Only the oldest completed sagas over the max count are deleted.
+	for _, id := range []string{"saga1", "saga2", "saga3"} {
+		slog.StartSaga(id, nil)
+		slog.LogMessage(saga.MakeEndSagaMessage(id))
+		time.Sleep(10 * time.Millisecond)
+	}
+	policy = saga.RetentionPolicy{MaxCompletedSagas: 2}
+	if _, deleted, err := saga.ApplyRetention(slog, policy, time.Now()); deleted != 1 || err != nil {
+		t.Fatalf("Expected 1 saga over the max count to be deleted, got %d, %v", deleted, err)
+	}
+	if msgs, _ := slog.GetMessages("saga1"); msgs != nil {
+		t.Fatalf("Expected the oldest saga to be deleted, got %+v", msgs)
+	}
+}
+
+// Returns true if the messages are equal, treating nil and empty data as the same.
+func messagesEqual(a, b []saga.SagaMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].SagaId != b[i].SagaId || a[i].MsgType != b[i].MsgType || a[i].TaskId != b[i].TaskId ||
+			!bytes.Equal(a[i].Data, b[i].Data) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,5 +1,7 @@
-// Package sagalogs provides an implementation of Saga Log.
-// Currently, this is an in-memory impl, and is not durable.
+// Package sagalogs provides implementations of Saga Log: an in-memory impl which is
+// not durable, a file impl storing each saga in its own directory, a bolt impl
+// storing all sagas in a single transactional key-value store file, and a raft impl
+// replicating sagas to a quorum of peers.
 package sagalogs
 
 import (
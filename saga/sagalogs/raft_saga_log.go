@@ -0,0 +1,558 @@
+package sagalogs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// The default time to wait for a message to be replicated to a quorum of peers.
+const DefaultRaftApplyTimeout = 10 * time.Second
+
+// The number of raft snapshots kept on disk.
+const raftSnapshotsRetained = 2
+
+// Configures a RaftSagaLog.
+type RaftConfig struct {
+	// The address to listen on for raft traffic from peers, ex: "localhost:9100".
+	// It also identifies this process to its peers, so it must be reachable by them.
+	BindAddr string
+	// The BindAddr of every process sharing the log, including this one. Only used to form the
+	// cluster the first time the log starts, after that the membership is read from the log.
+	// If empty, the log is replicated to this process only.
+	Peers []string
+	// The directory the raft log and snapshots are stored in.
+	Directory string
+	// The time to wait for a message to be replicated to a quorum, DefaultRaftApplyTimeout if zero.
+	ApplyTimeout time.Duration
+	// The time without contact from the leader before a follower starts an election, and the time
+	// an election lasts before it's retried. Lower values fail over sooner but are more sensitive
+	// to slow networks. The raft defaults of 1s are used if zero.
+	HeartbeatTimeout time.Duration
+	ElectionTimeout  time.Duration
+}
+
+//
+// Replicates the Saga Log to a quorum of peers with the raft consensus protocol, so
+// the log survives the failure of a minority of them. Messages can only be logged
+// by the leader, which is elected by the peers, and a message is only acknowledged
+// once a quorum stored it. Every peer applies the replicated messages to its own copy
+// of the sagas, kept in memory and periodically snapshotted to disk, so a follower
+// elected leader can recover the active sagas.
+//
+type raftSagaLog struct {
+	raft         *raft.Raft
+	fsm          *raftSagaFSM
+	store        *raftBoltStore
+	transport    raft.Transport
+	applyTimeout time.Duration
+	logWriter    *io.PipeWriter
+
+	mutex     sync.Mutex
+	leader    bool
+	electedCh chan struct{} // Closed once this process is the leader.
+	lostCh    chan struct{} // Closed once this process stops being the leader.
+	doneCh    chan struct{}
+	doneOnce  sync.Once
+}
+
+// Creates a RaftSagaLog listening for its peers on config.BindAddr,
+// restoring the sagas stored in config.Directory if there are any.
+func MakeRaftSagaLog(config RaftConfig) (*raftSagaLog, error) {
+	if config.BindAddr == "" {
+		return nil, errors.New("The raft saga log requires a BindAddr")
+	}
+	logWriter := log.StandardLogger().Writer()
+	transport, err := raft.NewTCPTransport(config.BindAddr, nil, 3, 10*time.Second, logWriter)
+	if err != nil {
+		logWriter.Close()
+		return nil, err
+	}
+	slog, err := makeRaftSagaLog(config, transport, logWriter)
+	if err != nil {
+		transport.Close()
+		logWriter.Close()
+		return nil, err
+	}
+	return slog, nil
+}
+
+// Creates a RaftSagaLog communicating with its peers through transport.
+func makeRaftSagaLog(config RaftConfig, transport raft.Transport, logWriter *io.PipeWriter) (*raftSagaLog, error) {
+	localAddr := string(transport.LocalAddr())
+	peers := config.Peers
+	if len(peers) == 0 {
+		peers = []string{localAddr}
+	}
+	servers := []raft.Server{}
+	isPeer := false
+	for _, p := range peers {
+		servers = append(servers, raft.Server{ID: raft.ServerID(p), Address: raft.ServerAddress(p)})
+		isPeer = isPeer || p == localAddr
+	}
+	if !isPeer {
+		return nil, fmt.Errorf("The raft saga log Peers %v must include its BindAddr %s", peers, localAddr)
+	}
+
+	conf := raft.DefaultConfig()
+	conf.LocalID = raft.ServerID(localAddr)
+	conf.LogOutput = logWriter
+	conf.LogLevel = "INFO"
+	if config.HeartbeatTimeout > 0 {
+		conf.HeartbeatTimeout = config.HeartbeatTimeout
+	}
+	if config.ElectionTimeout > 0 {
+		conf.ElectionTimeout = config.ElectionTimeout
+	}
+	if conf.LeaderLeaseTimeout > conf.HeartbeatTimeout {
+		conf.LeaderLeaseTimeout = conf.HeartbeatTimeout
+	}
+	notifyCh := make(chan bool, 1)
+	conf.NotifyCh = notifyCh
+
+	if err := os.MkdirAll(config.Directory, os.ModePerm); err != nil {
+		return nil, err
+	}
+	snaps, err := raft.NewFileSnapshotStore(config.Directory, raftSnapshotsRetained, logWriter)
+	if err != nil {
+		return nil, err
+	}
+	store, err := makeRaftBoltStore(filepath.Join(config.Directory, "raft.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	hasState, err := raft.HasExistingState(store, store, snaps)
+	if err == nil && !hasState {
+		log.Infof("Forming raft saga log cluster with peers %v", peers)
+		err = raft.BootstrapCluster(conf, store, store, snaps, transport, raft.Configuration{Servers: servers})
+	}
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	fsm := &raftSagaFSM{sagas: make(map[string]*raftSaga)}
+	r, err := raft.NewRaft(conf, fsm, store, store, snaps, transport)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	applyTimeout := config.ApplyTimeout
+	if applyTimeout <= 0 {
+		applyTimeout = DefaultRaftApplyTimeout
+	}
+	slog := &raftSagaLog{
+		raft:         r,
+		fsm:          fsm,
+		store:        store,
+		transport:    transport,
+		applyTimeout: applyTimeout,
+		logWriter:    logWriter,
+		electedCh:    make(chan struct{}),
+		lostCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+	go slog.watchLeadership(notifyCh)
+	return slog, nil
+}
+
+// Tracks leadership changes until the log is closed.
+func (slog *raftSagaLog) watchLeadership(notifyCh chan bool) {
+	for {
+		select {
+		case leader := <-notifyCh:
+			slog.setLeader(leader)
+		case <-slog.doneCh:
+			return
+		}
+	}
+}
+
+func (slog *raftSagaLog) setLeader(leader bool) {
+	slog.mutex.Lock()
+	defer slog.mutex.Unlock()
+	if leader == slog.leader {
+		return
+	}
+	slog.leader = leader
+	if leader {
+		log.Info("Elected saga log leader")
+		slog.lostCh = make(chan struct{})
+		close(slog.electedCh)
+	} else {
+		log.Info("Lost saga log leadership")
+		slog.electedCh = make(chan struct{})
+		close(slog.lostCh)
+	}
+}
+
+// Blocks until this process is the leader and has applied all messages committed by
+// previous leaders. Returns a channel closed once it stops being the leader.
+func (slog *raftSagaLog) WaitForLeadership() (<-chan struct{}, error) {
+	for {
+		slog.mutex.Lock()
+		leader, electedCh, lostCh := slog.leader, slog.electedCh, slog.lostCh
+		slog.mutex.Unlock()
+
+		if !leader {
+			select {
+			case <-electedCh:
+				continue
+			case <-slog.doneCh:
+				return nil, errors.New("Saga log closed while waiting for leadership")
+			}
+		}
+		// The barrier is applied after every message committed so far, including by previous leaders.
+		if err := slog.raft.Barrier(slog.applyTimeout).Error(); err != nil {
+			select {
+			case <-slog.doneCh:
+				return nil, errors.New("Saga log closed while waiting for leadership")
+			default:
+				log.Infof("Failed to apply saga log messages committed by previous leaders, retrying: %v", err)
+				continue
+			}
+		}
+		return lostCh, nil
+	}
+}
+
+// Stops replicating and closes the underlying files.
+func (slog *raftSagaLog) Close() error {
+	err := slog.raft.Shutdown().Error()
+	slog.doneOnce.Do(func() { close(slog.doneCh) })
+	if c, ok := slog.transport.(io.Closer); ok {
+		c.Close()
+	}
+	slog.store.Close()
+	slog.logWriter.Close()
+	return err
+}
+
+// Log a Start Saga Message message to the log and mark the saga as active.
+// Starting a saga that was already started appends another StartSaga message.
+// Returns an error if it fails, or if this process isn't the leader.
+func (slog *raftSagaLog) StartSaga(sagaId string, job []byte) error {
+	if sagaId == "" {
+		return saga.NewInvalidRequestError("sagaId must not be empty")
+	}
+	return slog.apply(encodeRaftCommand(raftLogOp, sagaId, encodeMessage(saga.MakeStartSagaMessage(sagaId, job))))
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails, if the saga wasn't started, or if this process isn't the leader.
+func (slog *raftSagaLog) LogMessage(message saga.SagaMessage) error {
+	return slog.apply(encodeRaftCommand(raftLogOp, message.SagaId, encodeMessage(message)))
+}
+
+// Replicates the command, and returns once it was applied to this process's sagas.
+func (slog *raftSagaLog) apply(cmd []byte) error {
+	f := slog.raft.Apply(cmd, slog.applyTimeout)
+	if err := f.Error(); err == raft.ErrNotLeader {
+		return saga.NewInternalLogError(fmt.Sprintf("Not the saga log leader, the leader is %q", slog.raft.Leader()))
+	} else if err != nil {
+		return toSagaLogError(err)
+	}
+	if err, ok := f.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Returns all of the messages applied so far for the specified saga, nil if it doesn't exist.
+// Followers may not have applied the latest messages yet.
+func (slog *raftSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	return slog.fsm.getMessages(sagaId), nil
+}
+
+// Returns the ids of all sagas that haven't logged an EndSaga message.
+// Followers may not have applied the latest messages yet.
+func (slog *raftSagaLog) GetActiveSagas() ([]string, error) {
+	return slog.fsm.getActiveSagas(), nil
+}
+
+// Returns all completed sagas. Only the leader can compact or delete them,
+// so followers return none.
+func (slog *raftSagaLog) GetCompletedSagas() ([]saga.CompletedSaga, error) {
+	if slog.raft.State() != raft.Leader {
+		return []saga.CompletedSaga{}, nil
+	}
+	return slog.fsm.getCompletedSagas(), nil
+}
+
+// Replaces the messages of a completed saga with msgs on every peer.
+func (slog *raftSagaLog) ReplaceMessages(sagaId string, msgs []saga.SagaMessage) error {
+	var payload []byte
+	for _, msg := range msgs {
+		payload = appendBytes(payload, encodeMessage(msg))
+	}
+	return slog.apply(encodeRaftCommand(raftReplaceOp, sagaId, payload))
+}
+
+// Removes a saga and all of its messages on every peer.
+func (slog *raftSagaLog) DeleteSaga(sagaId string) error {
+	return slog.apply(encodeRaftCommand(raftDeleteOp, sagaId, nil))
+}
+
+type raftCommandOp byte
+
+const (
+	// Appends a message to a saga, starting it if it's a StartSaga message.
+	raftLogOp raftCommandOp = iota
+	// Replaces the messages of a completed saga and marks it as compacted.
+	raftReplaceOp
+	// Removes a saga.
+	raftDeleteOp
+)
+
+// Encodes a command as its op, the big endian unix nanos it was created at, the
+// uvarint length of the sagaId, the sagaId and the op's payload. The time is set
+// by the leader so every peer records the same completion time for a saga.
+func encodeRaftCommand(op raftCommandOp, sagaId string, payload []byte) []byte {
+	buf := make([]byte, 9, 9+binary.MaxVarintLen64+len(sagaId)+len(payload))
+	buf[0] = byte(op)
+	binary.BigEndian.PutUint64(buf[1:], uint64(time.Now().UnixNano()))
+	buf = appendBytes(buf, []byte(sagaId))
+	return append(buf, payload...)
+}
+
+func decodeRaftCommand(cmd []byte) (op raftCommandOp, t time.Time, sagaId string, payload []byte, err error) {
+	if len(cmd) < 10 {
+		return 0, t, "", nil, errors.New("Raft saga log command too short")
+	}
+	op = raftCommandOp(cmd[0])
+	t = time.Unix(0, int64(binary.BigEndian.Uint64(cmd[1:])))
+	id, payload, err := readBytes(cmd[9:])
+	return op, t, string(id), payload, err
+}
+
+// Appends the uvarint length of b followed by b to buf.
+func appendBytes(buf []byte, b []byte) []byte {
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(b)))
+	return append(append(buf, lenBuf[:n]...), b...)
+}
+
+// Reads bytes written by appendBytes from the start of buf, and returns them and the rest of buf.
+func readBytes(buf []byte) ([]byte, []byte, error) {
+	l, n := binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf)-n) < l {
+		return nil, nil, errors.New("Invalid length in raft saga log command")
+	}
+	return buf[n : n+int(l)], buf[n+int(l):], nil
+}
+
+// A saga as applied to a peer's copy of the log.
+type raftSaga struct {
+	msgs      []saga.SagaMessage
+	completed time.Time // The time EndSaga was logged, zero while the saga is active.
+	compacted bool
+}
+
+// The raft state machine, which applies the replicated commands to the sagas.
+type raftSagaFSM struct {
+	sagas map[string]*raftSaga
+	mutex sync.RWMutex
+}
+
+// Applies a committed command, returning the resulting error if any.
+func (f *raftSagaFSM) Apply(l *raft.Log) interface{} {
+	op, t, sagaId, payload, err := decodeRaftCommand(l.Data)
+	if err != nil {
+		return saga.NewCorruptedSagaLogError(sagaId, err.Error())
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	s := f.sagas[sagaId]
+
+	switch op {
+	case raftLogOp:
+		msg, err := decodeMessage(sagaId, payload)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			if msg.MsgType != saga.StartSaga {
+				return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not Started yet.", sagaId))
+			}
+			s = &raftSaga{}
+			f.sagas[sagaId] = s
+		}
+		s.msgs = append(s.msgs, msg)
+		if msg.MsgType == saga.EndSaga {
+			s.completed = t
+		}
+	case raftReplaceOp:
+		if s == nil || s.completed.IsZero() {
+			return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not completed.", sagaId))
+		}
+		msgs := []saga.SagaMessage{}
+		for len(payload) > 0 {
+			var v []byte
+			if v, payload, err = readBytes(payload); err != nil {
+				return saga.NewCorruptedSagaLogError(sagaId, err.Error())
+			}
+			msg, err := decodeMessage(sagaId, v)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		s.msgs = msgs
+		s.compacted = true
+	case raftDeleteOp:
+		delete(f.sagas, sagaId)
+	default:
+		return saga.NewCorruptedSagaLogError(sagaId, fmt.Sprintf("Unrecognized raft saga log command, %d", op))
+	}
+	return nil
+}
+
+func (f *raftSagaFSM) getMessages(sagaId string) []saga.SagaMessage {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	s, ok := f.sagas[sagaId]
+	if !ok {
+		return nil
+	}
+	return append([]saga.SagaMessage{}, s.msgs...)
+}
+
+func (f *raftSagaFSM) getActiveSagas() []string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	sagaIds := []string{}
+	for id, s := range f.sagas {
+		if s.completed.IsZero() {
+			sagaIds = append(sagaIds, id)
+		}
+	}
+	return sagaIds
+}
+
+func (f *raftSagaFSM) getCompletedSagas() []saga.CompletedSaga {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	completed := []saga.CompletedSaga{}
+	for id, s := range f.sagas {
+		if !s.completed.IsZero() {
+			completed = append(completed, saga.CompletedSaga{SagaId: id, Completed: s.completed, Compacted: s.compacted})
+		}
+	}
+	return completed
+}
+
+// Returns a point in time copy of the sagas. Messages are only ever appended to a
+// saga or replaced wholesale, so the copy can share the messages.
+func (f *raftSagaFSM) Snapshot() (raft.FSMSnapshot, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	sagas := make(map[string]raftSaga, len(f.sagas))
+	for id, s := range f.sagas {
+		sagas[id] = raftSaga{msgs: s.msgs[:len(s.msgs):len(s.msgs)], completed: s.completed, compacted: s.compacted}
+	}
+	return &raftSagaSnapshot{sagas: sagas}, nil
+}
+
+// Replaces the sagas with those in a snapshot written by raftSagaSnapshot.Persist.
+func (f *raftSagaFSM) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	r := bufio.NewReader(rc)
+	sagas := make(map[string]*raftSaga)
+	for {
+		buf, err := readSnapshotEntry(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		id, buf, err := readBytes(buf)
+		if err != nil || len(buf) < 9 {
+			return errors.New("Invalid saga in raft saga log snapshot")
+		}
+		sagaId := string(id)
+		s := &raftSaga{compacted: buf[8] == 1}
+		if nanos := int64(binary.BigEndian.Uint64(buf)); nanos != 0 {
+			s.completed = time.Unix(0, nanos)
+		}
+		for buf = buf[9:]; len(buf) > 0; {
+			var v []byte
+			if v, buf, err = readBytes(buf); err != nil {
+				return err
+			}
+			msg, err := decodeMessage(sagaId, v)
+			if err != nil {
+				return err
+			}
+			s.msgs = append(s.msgs, msg)
+		}
+		sagas[sagaId] = s
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.sagas = sagas
+	return nil
+}
+
+type raftSagaSnapshot struct {
+	sagas map[string]raftSaga
+}
+
+// Writes each saga as the uvarint length of an entry holding the saga's sagaId,
+// its big endian completion unix nanos or 0 if it's active, a byte which is 1 if
+// it was compacted, and each of its messages.
+func (s *raftSagaSnapshot) Persist(sink raft.SnapshotSink) error {
+	w := bufio.NewWriter(sink)
+	for id, rs := range s.sagas {
+		entry := appendBytes(nil, []byte(id))
+		var header [9]byte
+		if !rs.completed.IsZero() {
+			binary.BigEndian.PutUint64(header[:], uint64(rs.completed.UnixNano()))
+		}
+		if rs.compacted {
+			header[8] = 1
+		}
+		entry = append(entry, header[:]...)
+		for _, msg := range rs.msgs {
+			entry = appendBytes(entry, encodeMessage(msg))
+		}
+		if _, err := w.Write(appendBytes(nil, entry)); err != nil {
+			sink.Cancel()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+func (s *raftSagaSnapshot) Release() {}
+
+// Reads an entry written by appendBytes, io.EOF if there are no more entries.
+func readSnapshotEntry(r *bufio.Reader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, l)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,183 @@
+package sagalogs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Creates count raft saga logs connected by in memory transports, storing their state in dir.
+func makeTestRaftSagaLogs(t *testing.T, dir string, count int) []*raftSagaLog {
+	addrs := []string{}
+	transports := []*raft.InmemTransport{}
+	for i := 0; i < count; i++ {
+		addr, trans := raft.NewInmemTransport("")
+		addrs = append(addrs, string(addr))
+		transports = append(transports, trans)
+	}
+	for _, t1 := range transports {
+		for _, t2 := range transports {
+			t1.Connect(t2.LocalAddr(), t2)
+		}
+	}
+
+	logs := []*raftSagaLog{}
+	for i, trans := range transports {
+		config := RaftConfig{
+			Peers:            addrs,
+			Directory:        filepath.Join(dir, addrs[i]),
+			ApplyTimeout:     time.Second,
+			HeartbeatTimeout: 50 * time.Millisecond,
+			ElectionTimeout:  50 * time.Millisecond,
+		}
+		slog, err := makeRaftSagaLog(config, trans, log.StandardLogger().Writer())
+		if err != nil {
+			t.Fatalf("Unexpected Error Returned %v", err)
+		}
+		logs = append(logs, slog)
+	}
+	return logs
+}
+
+// Returns the first of the logs to become leader.
+func waitForRaftLeader(t *testing.T, logs []*raftSagaLog) *raftSagaLog {
+	leaderCh := make(chan *raftSagaLog, len(logs))
+	for _, slog := range logs {
+		go func(slog *raftSagaLog) {
+			if _, err := slog.WaitForLeadership(); err == nil {
+				leaderCh <- slog
+			}
+		}(slog)
+	}
+	select {
+	case leader := <-leaderCh:
+		return leader
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected a leader to be elected")
+	}
+	return nil
+}
+
+func TestRaftSagaLog_ReplicationAndFailover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft_saga_log_test")
+	if err != nil {
+		t.Fatalf("Unexpected Error creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir)
+	logs := makeTestRaftSagaLogs(t, dir, 3)
+	leader := waitForRaftLeader(t, logs)
+
+	sagaId := "replicated"
+	loggedMsgs := []saga.SagaMessage{
+		saga.MakeStartSagaMessage(sagaId, []byte("job")),
+		saga.MakeStartTaskMessage(sagaId, "task1", []byte("run task 1")),
+		saga.MakeEndTaskMessage(sagaId, "task1", []byte("success")),
+	}
+	if err := leader.StartSaga(sagaId, []byte("job")); err != nil {
+		t.Fatalf("Unexpected Error starting Saga %v", err)
+	}
+	for _, msg := range loggedMsgs[1:] {
+		if err := leader.LogMessage(msg); err != nil {
+			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
+		}
+	}
+	err = leader.LogMessage(saga.MakeEndSagaMessage("not_started"))
+	if _, ok := err.(saga.InvalidRequestError); !ok {
+		t.Errorf("Expected InvalidRequestError logging to a saga that wasn't started, got %v", err)
+	}
+
+	followers := []*raftSagaLog{}
+	for _, slog := range logs {
+		if slog != leader {
+			followers = append(followers, slog)
+		}
+	}
+	err = followers[0].LogMessage(saga.MakeEndSagaMessage(sagaId))
+	if _, ok := err.(saga.InternalLogError); !ok {
+		t.Errorf("Expected a retryable InternalLogError logging to a follower, got %v", err)
+	}
+
+	// A follower takes over once the leader fails, with all of the messages it acknowledged.
+	leader.Close()
+	newLeader := waitForRaftLeader(t, followers)
+	defer followers[0].Close()
+	defer followers[1].Close()
+	if msgs, _ := newLeader.GetMessages(sagaId); !messagesEqual(msgs, loggedMsgs) {
+		t.Fatalf("Expected new leader to have messages %+v, got %+v", loggedMsgs, msgs)
+	}
+	if !isSagaInActiveList(sagaId, newLeader) {
+		t.Errorf("Expected Saga to be in the new leader's active list")
+	}
+	if err := newLeader.LogMessage(saga.MakeEndSagaMessage(sagaId)); err != nil {
+		t.Fatalf("Unexpected Error ending Saga on new leader %v", err)
+	}
+	if isSagaInActiveList(sagaId, newLeader) {
+		t.Errorf("Expected ended Saga to not be in active list")
+	}
+}
+
+func TestRaftSagaLog_Restart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft_saga_log_test")
+	if err != nil {
+		t.Fatalf("Unexpected Error creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir)
+	slog := waitForRaftLeader(t, makeTestRaftSagaLogs(t, dir, 1))
+
+	for _, id := range []string{"saga1", "saga2", "saga3"} {
+		if err := slog.StartSaga(id, []byte(id)); err != nil {
+			t.Fatalf("Unexpected Error starting Saga %v", err)
+		}
+	}
+	slog.LogMessage(saga.MakeEndSagaMessage("saga1"))
+	// Messages before the snapshot are restored from it, later messages from the raft log.
+	if err := slog.raft.Snapshot().Error(); err != nil {
+		t.Fatalf("Unexpected Error taking snapshot %v", err)
+	}
+	slog.LogMessage(saga.MakeStartTaskMessage("saga2", "task1", nil))
+	slog.Close()
+
+	addr, trans := raft.NewInmemTransport(slog.transport.LocalAddr())
+	config := RaftConfig{
+		Peers:            []string{string(addr)},
+		Directory:        filepath.Join(dir, string(addr)),
+		HeartbeatTimeout: 50 * time.Millisecond,
+		ElectionTimeout:  50 * time.Millisecond,
+	}
+	slog, err = makeRaftSagaLog(config, trans, log.StandardLogger().Writer())
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening %v", err)
+	}
+	defer slog.Close()
+	if _, err := slog.WaitForLeadership(); err != nil {
+		t.Fatalf("Unexpected Error waiting for leadership %v", err)
+	}
+	if isSagaInActiveList("saga1", slog) || !isSagaInActiveList("saga2", slog) || !isSagaInActiveList("saga3", slog) {
+		t.Errorf("Expected saga2 and saga3 to be active after restarting")
+	}
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage("saga2", []byte("saga2")),
+		saga.MakeStartTaskMessage("saga2", "task1", nil),
+	}
+	if msgs, _ := slog.GetMessages("saga2"); !messagesEqual(msgs, expected) {
+		t.Errorf("Expected messages %+v after restarting, got %+v", expected, msgs)
+	}
+}
+
+func TestRaftSagaLog_Retention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft_saga_log_test")
+	if err != nil {
+		t.Fatalf("Unexpected Error creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir)
+	slog := waitForRaftLeader(t, makeTestRaftSagaLogs(t, dir, 1))
+	defer slog.Close()
+	testRetention(t, slog)
+}
@@ -0,0 +1,188 @@
+package sagalogs
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/hashicorp/raft"
+)
+
+var (
+	// Contains the raft log entries keyed by their big endian index.
+	raftLogsBucket = []byte("logs")
+	// Contains the raft's current term, vote and other state it needs to persist.
+	raftStableBucket = []byte("stable")
+)
+
+// Stores the raft log and stable state of a raftSagaLog in a bolt file. Every write
+// is fsynced, raft relies on log entries and votes surviving machine failure.
+type raftBoltStore struct {
+	db *bolt.DB
+}
+
+func makeRaftBoltStore(path string) (*raftBoltStore, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{raftLogsBucket, raftStableBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &raftBoltStore{db: db}, nil
+}
+
+func (s *raftBoltStore) Close() error {
+	return s.db.Close()
+}
+
+// Returns the first index written, 0 for no entries.
+func (s *raftBoltStore) FirstIndex() (uint64, error) {
+	var index uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if k, _ := tx.Bucket(raftLogsBucket).Cursor().First(); k != nil {
+			index = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	return index, err
+}
+
+// Returns the last index written, 0 for no entries.
+func (s *raftBoltStore) LastIndex() (uint64, error) {
+	var index uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if k, _ := tx.Bucket(raftLogsBucket).Cursor().Last(); k != nil {
+			index = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	return index, err
+}
+
+// Reads the log entry at index into l, raft.ErrLogNotFound if there isn't one.
+func (s *raftBoltStore) GetLog(index uint64, l *raft.Log) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(raftLogsBucket).Get(uint64Key(index))
+		if v == nil {
+			return raft.ErrLogNotFound
+		}
+		return decodeRaftLog(index, v, l)
+	})
+}
+
+func (s *raftBoltStore) StoreLog(l *raft.Log) error {
+	return s.StoreLogs([]*raft.Log{l})
+}
+
+// Stores the log entries in a single transaction.
+func (s *raftBoltStore) StoreLogs(logs []*raft.Log) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(raftLogsBucket)
+		for _, l := range logs {
+			if err := b.Put(uint64Key(l.Index), encodeRaftLog(l)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Deletes the log entries from min to max inclusive.
+func (s *raftBoltStore) DeleteRange(min, max uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(raftLogsBucket).Cursor()
+		for k, _ := c.Seek(uint64Key(min)); k != nil && binary.BigEndian.Uint64(k) <= max; k, _ = c.Next() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *raftBoltStore) Set(key []byte, val []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(raftStableBucket).Put(key, val)
+	})
+}
+
+// Returns the value for key, nil if it wasn't set.
+func (s *raftBoltStore) Get(key []byte) ([]byte, error) {
+	var val []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(raftStableBucket).Get(key); v != nil {
+			val = append([]byte{}, v...)
+		}
+		return nil
+	})
+	return val, err
+}
+
+func (s *raftBoltStore) SetUint64(key []byte, val uint64) error {
+	return s.Set(key, uint64Key(val))
+}
+
+// Returns the value for key, 0 if it wasn't set.
+func (s *raftBoltStore) GetUint64(key []byte) (uint64, error) {
+	val, err := s.Get(key)
+	if err != nil || val == nil {
+		return 0, err
+	}
+	if len(val) != 8 {
+		return 0, errors.New("Invalid uint64 value in raft stable store")
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func uint64Key(i uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, i)
+	return k
+}
+
+// Encodes a log entry as its big endian term, its type, the uvarint length of its data,
+// its data and its extensions. The index isn't stored since it's the entry's key.
+func encodeRaftLog(l *raft.Log) []byte {
+	buf := make([]byte, 9+binary.MaxVarintLen64+len(l.Data)+len(l.Extensions))
+	binary.BigEndian.PutUint64(buf, l.Term)
+	buf[8] = byte(l.Type)
+	n := 9 + binary.PutUvarint(buf[9:], uint64(len(l.Data)))
+	n += copy(buf[n:], l.Data)
+	n += copy(buf[n:], l.Extensions)
+	return buf[:n]
+}
+
+// Decodes a log entry written by encodeRaftLog. Values are only valid during
+// their transaction, so the data is copied.
+func decodeRaftLog(index uint64, v []byte, l *raft.Log) error {
+	if len(v) < 10 {
+		return errors.New("Invalid raft log entry, too short")
+	}
+	dataLen, n := binary.Uvarint(v[9:])
+	if n <= 0 || uint64(len(v)-9-n) < dataLen {
+		return errors.New("Invalid raft log entry, bad data length")
+	}
+	start := 9 + n
+	end := start + int(dataLen)
+	*l = raft.Log{
+		Index: index,
+		Term:  binary.BigEndian.Uint64(v),
+		Type:  raft.LogType(v[8]),
+		Data:  append([]byte{}, v[start:end]...),
+	}
+	if len(v) > end {
+		l.Extensions = append([]byte{}, v[end:]...)
+	}
+	return nil
+}
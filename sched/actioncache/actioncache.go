@@ -0,0 +1,119 @@
+// Package actioncache provides a cache of task results, allowing the scheduler to
+// answer a task from a previous identical run instead of dispatching it to a worker.
+package actioncache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/twitter/scoot/runner"
+)
+
+// The parts of a runner.RunStatus that are reused when a task is answered from the cache
+type Result struct {
+	SnapshotID string
+	ExitCode   int
+}
+
+// ActionCache stores Results keyed by a hash of the command that produced them.
+// Implementations must be safe for concurrent use.
+type ActionCache interface {
+	// Returns the cached Result for the key, or nil if there is none.
+	Get(key string) (*Result, error)
+
+	// Stores the Result for the key, replacing any previous Result.
+	Put(key string, result Result) error
+}
+
+// Returns true if a RunStatus is a clean result that is safe to reuse for identical commands
+func IsCacheable(st runner.RunStatus) bool {
+	return st.State == runner.COMPLETE && st.ExitCode == 0
+}
+
+// Returns the cache key for a command, a hash of its argv, env vars, snapshot ID, image,
+// whether it's sandboxed and the outputs it ingests, in any order.
+// Timeouts and log tags don't affect the result of a command and are not part of the key.
+func CommandKey(cmd *runner.Command) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "argv:%d\n", len(cmd.Argv))
+	for _, arg := range cmd.Argv {
+		fmt.Fprintf(h, "%d:%s\n", len(arg), arg)
+	}
+
+	keys := make([]string, 0, len(cmd.EnvVars))
+	for k := range cmd.EnvVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(h, "env:%d\n", len(keys))
+	for _, k := range keys {
+		v := cmd.EnvVars[k]
+		fmt.Fprintf(h, "%d:%s=%d:%s\n", len(k), k, len(v), v)
+	}
+
+	fmt.Fprintf(h, "snapshot:%s\n", cmd.SnapshotID)
+	fmt.Fprintf(h, "image:%d:%s\n", len(cmd.Image), cmd.Image)
+	fmt.Fprintf(h, "sandbox:%t\n", cmd.Sandbox)
+
+	outputs := append([]string(nil), cmd.Outputs...)
+	sort.Strings(outputs)
+	fmt.Fprintf(h, "outputs:%d\n", len(outputs))
+	for _, o := range outputs {
+		fmt.Fprintf(h, "%d:%s\n", len(o), o)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// In-memory ActionCache that evicts the least recently used Result once it holds maxEntries
+type memoryActionCache struct {
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+	mu         sync.Mutex
+}
+
+type entry struct {
+	key    string
+	result Result
+}
+
+// Creates an in-memory ActionCache holding at most maxEntries Results
+func NewMemoryActionCache(maxEntries int) ActionCache {
+	return &memoryActionCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (c *memoryActionCache) Get(key string) (*Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	c.lru.MoveToFront(e)
+	result := e.Value.(*entry).result
+	return &result, nil
+}
+
+func (c *memoryActionCache) Put(key string, result Result) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*entry).result = result
+		c.lru.MoveToFront(e)
+		return nil
+	}
+	c.entries[key] = c.lru.PushFront(&entry{key: key, result: result})
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package actioncache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner"
+)
+
+func TestCommandKey(t *testing.T) {
+	cmd := &runner.Command{
+		Argv:       []string{"echo", "hello"},
+		EnvVars:    map[string]string{"A": "1", "B": "2"},
+		SnapshotID: "snap1",
+	}
+	key := CommandKey(cmd)
+
+	same := &runner.Command{
+		Argv:       []string{"echo", "hello"},
+		EnvVars:    map[string]string{"B": "2", "A": "1"},
+		SnapshotID: "snap1",
+		Timeout:    time.Minute,
+		LogTags:    tags.LogTags{JobID: "job", TaskID: "task"},
+	}
+	if CommandKey(same) != key {
+		t.Errorf("Expected timeout, log tags and env order to not affect the key")
+	}
+
+	different := []*runner.Command{
+		{Argv: []string{"echo hello"}, EnvVars: cmd.EnvVars, SnapshotID: cmd.SnapshotID},
+		{Argv: cmd.Argv, EnvVars: map[string]string{"A": "1"}, SnapshotID: cmd.SnapshotID},
+		{Argv: cmd.Argv, EnvVars: map[string]string{"A": "1", "B": "3"}, SnapshotID: cmd.SnapshotID},
+		{Argv: cmd.Argv, EnvVars: cmd.EnvVars, SnapshotID: "snap2"},
+		{Argv: cmd.Argv, EnvVars: cmd.EnvVars, SnapshotID: cmd.SnapshotID, Image: "sha256:abc"},
+		{Argv: cmd.Argv, EnvVars: cmd.EnvVars, SnapshotID: cmd.SnapshotID, Sandbox: true},
+		{Argv: cmd.Argv, EnvVars: cmd.EnvVars, SnapshotID: cmd.SnapshotID, Outputs: []string{"out"}},
+	}
+	for _, c := range different {
+		if CommandKey(c) == key {
+			t.Errorf("Expected different key for %+v", c)
+		}
+	}
+
+	outputs := *cmd
+	outputs.Outputs = []string{"out/a", "out/b"}
+	reordered := *cmd
+	reordered.Outputs = []string{"out/b", "out/a"}
+	if CommandKey(&outputs) != CommandKey(&reordered) {
+		t.Errorf("Expected output order to not affect the key")
+	}
+}
+
+func TestMemoryActionCache(t *testing.T) {
+	c := NewMemoryActionCache(2)
+
+	if r, err := c.Get("a"); r != nil || err != nil {
+		t.Fatalf("Expected miss for empty cache, got %v, %v", r, err)
+	}
+
+	c.Put("a", Result{SnapshotID: "snapA", ExitCode: 0})
+	c.Put("b", Result{SnapshotID: "snapB", ExitCode: 0})
+	if r, _ := c.Get("a"); r == nil || r.SnapshotID != "snapA" {
+		t.Fatalf("Expected hit for a, got %v", r)
+	}
+
+	// b is now the least recently used entry and should be evicted
+	c.Put("c", Result{SnapshotID: "snapC", ExitCode: 0})
+	if r, _ := c.Get("b"); r != nil {
+		t.Errorf("Expected b to be evicted, got %v", r)
+	}
+	if r, _ := c.Get("a"); r == nil {
+		t.Errorf("Expected a to be retained")
+	}
+	if r, _ := c.Get("c"); r == nil {
+		t.Errorf("Expected c to be retained")
+	}
+}
+
+func TestIsCacheable(t *testing.T) {
+	lt := tags.LogTags{}
+	if !IsCacheable(runner.CompleteStatus("run", "snap", 0, lt)) {
+		t.Errorf("Expected successful run to be cacheable")
+	}
+	if IsCacheable(runner.CompleteStatus("run", "snap", 1, lt)) {
+		t.Errorf("Expected run with non-zero exit code to not be cacheable")
+	}
+	if IsCacheable(runner.TimeoutStatus("run", lt)) {
+		t.Errorf("Expected timed out run to not be cacheable")
+	}
+}
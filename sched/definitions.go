@@ -53,6 +53,25 @@ type JobDefinition struct {
 // Task is one task to run
 type TaskDefinition struct {
 	runner.Command
+
+	// TaskIDs of other tasks in the same job that must complete successfully before this task is run.
+	// If any of them fail, this task is skipped.
+	Dependencies []string
+
+	// TaskID of one of the Dependencies whose output snapshot is used as this task's SnapshotID.
+	SnapshotFromTask string
+
+	// Resources this task needs from the node it runs on, on top of what the node's other tasks need.
+	Resources runner.Resources
+
+	// Labels the node running this task must have, ex: {"os": "linux-x86"}.
+	NodeSelector map[string]string
+
+	// If set, the task is run even if the action cache has a result for it.
+	SkipCacheLookup bool
+
+	// If set, the task's result isn't stored in the action cache.
+	DoNotCache bool
 }
 
 // Status for Job & Tasks
@@ -124,13 +143,32 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 				EnvVars:    cmd.GetEnvVars(),
 				Timeout:    time.Duration(cmd.GetTimeout()),
 				SnapshotID: cmd.GetSnapshotId(),
+				Sandbox:    cmd.GetSandbox(),
+				Image:      cmd.GetImage(),
+				Outputs:    cmd.GetOutputs(),
 				LogTags: tags.LogTags{
 					JobID:  jobID,
 					TaskID: task.GetTaskId(),
 					Tag:    thriftJobDef.GetTag(),
 				},
 			}
-			domainTasks = append(domainTasks, TaskDefinition{command})
+			var resources runner.Resources
+			if res := task.GetResources(); res != nil {
+				resources = runner.Resources{
+					CPUs:        int(res.GetCpus()),
+					MemoryBytes: res.GetMemoryBytes(),
+					DiskBytes:   res.GetDiskBytes(),
+				}
+			}
+			domainTasks = append(domainTasks, TaskDefinition{
+				Command:          command,
+				Dependencies:     task.GetDependencies(),
+				SnapshotFromTask: task.GetSnapshotFromTask(),
+				Resources:        resources,
+				NodeSelector:     task.GetNodeSelector(),
+				SkipCacheLookup:  task.GetSkipCacheLookup(),
+				DoNotCache:       task.GetDoNotCache(),
+			})
 		}
 
 		jobType = thriftJobDef.GetJobType()
@@ -170,9 +208,37 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 			Timeout:    &to,
 			SnapshotId: domainTask.SnapshotID,
 		}
+		if domainTask.Sandbox {
+			sandbox := true
+			cmd.Sandbox = &sandbox
+		}
+		if domainTask.Image != "" {
+			image := domainTask.Image
+			cmd.Image = &image
+		}
+		cmd.Outputs = domainTask.Outputs
 
 		taskId := domainTask.TaskID
-		thriftTask := schedthrift.TaskDefinition{Command: &cmd, TaskId: &taskId}
+		thriftTask := schedthrift.TaskDefinition{Command: &cmd, TaskId: &taskId, Dependencies: domainTask.Dependencies}
+		if domainTask.SnapshotFromTask != "" {
+			snapshotFromTask := domainTask.SnapshotFromTask
+			thriftTask.SnapshotFromTask = &snapshotFromTask
+		}
+		if res := domainTask.Resources; res != (runner.Resources{}) {
+			cpus := int32(res.CPUs)
+			thriftTask.Resources = &schedthrift.Resources{Cpus: &cpus, MemoryBytes: &res.MemoryBytes, DiskBytes: &res.DiskBytes}
+		}
+		if len(domainTask.NodeSelector) > 0 {
+			thriftTask.NodeSelector = domainTask.NodeSelector
+		}
+		if domainTask.SkipCacheLookup {
+			skipCacheLookup := true
+			thriftTask.SkipCacheLookup = &skipCacheLookup
+		}
+		if domainTask.DoNotCache {
+			doNotCache := true
+			thriftTask.DoNotCache = &doNotCache
+		}
 		thriftTasks = append(thriftTasks, &thriftTask)
 	}
 
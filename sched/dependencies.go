@@ -0,0 +1,65 @@
+package sched
+
+import (
+	"fmt"
+)
+
+// Returns an error if the task dependencies in a job don't form a valid graph:
+// dependencies must name other tasks in the same job, must not form a cycle,
+// and SnapshotFromTask must name one of the task's own dependencies.
+func ValidateDependencies(tasks []TaskDefinition) error {
+	byID := map[string]*TaskDefinition{}
+	for i := range tasks {
+		byID[tasks[i].TaskID] = &tasks[i]
+	}
+
+	for _, task := range tasks {
+		seen := map[string]bool{}
+		for _, dep := range task.Dependencies {
+			if dep == task.TaskID {
+				return fmt.Errorf("Task %s depends on itself", task.TaskID)
+			}
+			if _, ok := byID[dep]; !ok {
+				return fmt.Errorf("Task %s depends on unknown task %s", task.TaskID, dep)
+			}
+			if seen[dep] {
+				return fmt.Errorf("Task %s has duplicate dependency %s", task.TaskID, dep)
+			}
+			seen[dep] = true
+		}
+		if task.SnapshotFromTask != "" && !seen[task.SnapshotFromTask] {
+			return fmt.Errorf("Task %s takes its snapshot from %s, which is not one of its dependencies",
+				task.TaskID, task.SnapshotFromTask)
+		}
+	}
+
+	// Depth first search for cycles, tracking tasks on the current path and tasks already known to be acyclic.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("Task dependencies contain a cycle through %s", id)
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, dep := range byID[id].Dependencies {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+	for _, task := range tasks {
+		if err := visit(task.TaskID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
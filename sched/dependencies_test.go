@@ -0,0 +1,41 @@
+package sched
+
+import (
+	"testing"
+
+	"github.com/twitter/scoot/runner"
+)
+
+func makeDependentTask(id string, deps ...string) TaskDefinition {
+	task := TaskDefinition{Command: runner.Command{Argv: []string{"true"}}, Dependencies: deps}
+	task.TaskID = id
+	return task
+}
+
+func Test_ValidateDependencies(t *testing.T) {
+	valid := []TaskDefinition{
+		makeDependentTask("a"),
+		makeDependentTask("b", "a"),
+		makeDependentTask("c", "a", "b"),
+	}
+	valid[2].SnapshotFromTask = "b"
+	if err := ValidateDependencies(valid); err != nil {
+		t.Errorf("Expected valid dependencies, got: %v", err)
+	}
+
+	snapshotNotDep := []TaskDefinition{makeDependentTask("a"), makeDependentTask("b")}
+	snapshotNotDep[1].SnapshotFromTask = "a"
+
+	invalid := map[string][]TaskDefinition{
+		"self":           {makeDependentTask("a", "a")},
+		"unknown":        {makeDependentTask("a", "b")},
+		"duplicate":      {makeDependentTask("a"), makeDependentTask("b", "a", "a")},
+		"cycle":          {makeDependentTask("a", "c"), makeDependentTask("b", "a"), makeDependentTask("c", "b")},
+		"snapshotNotDep": snapshotNotDep,
+	}
+	for name, tasks := range invalid {
+		if err := ValidateDependencies(tasks); err == nil {
+			t.Errorf("Expected error for %s dependencies", name)
+		}
+	}
+}
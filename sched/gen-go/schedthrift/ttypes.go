@@ -21,11 +21,17 @@ var GoUnusedProtection__ int
 //  - EnvVars
 //  - Timeout
 //  - SnapshotId
+//  - Sandbox
+//  - Image
+//  - Outputs
 type Command struct {
 	Argv       []string          `thrift:"argv,1,required" json:"argv"`
 	EnvVars    map[string]string `thrift:"envVars,2" json:"envVars,omitempty"`
 	Timeout    *int64            `thrift:"timeout,3" json:"timeout,omitempty"`
 	SnapshotId string            `thrift:"snapshotId,4,required" json:"snapshotId"`
+	Sandbox    *bool             `thrift:"sandbox,5" json:"sandbox,omitempty"`
+	Image      *string           `thrift:"image,6" json:"image,omitempty"`
+	Outputs    []string          `thrift:"outputs,7" json:"outputs,omitempty"`
 }
 
 func NewCommand() *Command {
@@ -54,6 +60,30 @@ func (p *Command) GetTimeout() int64 {
 func (p *Command) GetSnapshotId() string {
 	return p.SnapshotId
 }
+
+var Command_Sandbox_DEFAULT bool
+
+func (p *Command) GetSandbox() bool {
+	if !p.IsSetSandbox() {
+		return Command_Sandbox_DEFAULT
+	}
+	return *p.Sandbox
+}
+
+var Command_Image_DEFAULT string
+
+func (p *Command) GetImage() string {
+	if !p.IsSetImage() {
+		return Command_Image_DEFAULT
+	}
+	return *p.Image
+}
+
+var Command_Outputs_DEFAULT []string
+
+func (p *Command) GetOutputs() []string {
+	return p.Outputs
+}
 func (p *Command) IsSetEnvVars() bool {
 	return p.EnvVars != nil
 }
@@ -62,6 +92,18 @@ func (p *Command) IsSetTimeout() bool {
 	return p.Timeout != nil
 }
 
+func (p *Command) IsSetSandbox() bool {
+	return p.Sandbox != nil
+}
+
+func (p *Command) IsSetImage() bool {
+	return p.Image != nil
+}
+
+func (p *Command) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
 func (p *Command) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -97,6 +139,18 @@ func (p *Command) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetSnapshotId = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -186,6 +240,46 @@ func (p *Command) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *Command) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sandbox = &v
+	}
+	return nil
+}
+
+func (p *Command) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Image = &v
+	}
+	return nil
+}
+
+func (p *Command) readField7(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Outputs = tSlice
+	for i := 0; i < size; i++ {
+		var _elem3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem3 = v
+		}
+		p.Outputs = append(p.Outputs, _elem3)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
 func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("Command"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -202,6 +296,15 @@ func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -286,6 +389,59 @@ func (p *Command) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *Command) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSandbox() {
+		if err := oprot.WriteFieldBegin("sandbox", thrift.BOOL, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:sandbox: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Sandbox)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.sandbox (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:sandbox: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetImage() {
+		if err := oprot.WriteFieldBegin("image", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:image: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Image)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.image (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:image: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.LIST, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:outputs: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Outputs {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *Command) String() string {
 	if p == nil {
 		return "<nil>"
@@ -293,12 +449,218 @@ func (p *Command) String() string {
 	return fmt.Sprintf("Command(%+v)", *p)
 }
 
+// Attributes:
+//  - Cpus
+//  - MemoryBytes
+//  - DiskBytes
+type Resources struct {
+	Cpus        *int32 `thrift:"cpus,1" json:"cpus,omitempty"`
+	MemoryBytes *int64 `thrift:"memoryBytes,2" json:"memoryBytes,omitempty"`
+	DiskBytes   *int64 `thrift:"diskBytes,3" json:"diskBytes,omitempty"`
+}
+
+func NewResources() *Resources {
+	return &Resources{}
+}
+
+var Resources_Cpus_DEFAULT int32
+
+func (p *Resources) GetCpus() int32 {
+	if !p.IsSetCpus() {
+		return Resources_Cpus_DEFAULT
+	}
+	return *p.Cpus
+}
+
+var Resources_MemoryBytes_DEFAULT int64
+
+func (p *Resources) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return Resources_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var Resources_DiskBytes_DEFAULT int64
+
+func (p *Resources) GetDiskBytes() int64 {
+	if !p.IsSetDiskBytes() {
+		return Resources_DiskBytes_DEFAULT
+	}
+	return *p.DiskBytes
+}
+func (p *Resources) IsSetCpus() bool {
+	return p.Cpus != nil
+}
+
+func (p *Resources) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *Resources) IsSetDiskBytes() bool {
+	return p.DiskBytes != nil
+}
+
+func (p *Resources) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Resources) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Cpus = &v
+	}
+	return nil
+}
+
+func (p *Resources) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *Resources) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.DiskBytes = &v
+	}
+	return nil
+}
+
+func (p *Resources) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Resources"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Resources) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpus() {
+		if err := oprot.WriteFieldBegin("cpus", thrift.I32, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cpus: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Cpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpus (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDiskBytes() {
+		if err := oprot.WriteFieldBegin("diskBytes", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:diskBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.DiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.diskBytes (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:diskBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Resources) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Resources(%+v)", *p)
+}
+
 // Attributes:
 //  - Command
 //  - TaskId
+//  - Dependencies
+//  - SnapshotFromTask
+//  - Resources
+//  - NodeSelector
+//  - SkipCacheLookup
+//  - DoNotCache
 type TaskDefinition struct {
-	Command *Command `thrift:"command,1,required" json:"command"`
-	TaskId  *string  `thrift:"taskId,2" json:"taskId,omitempty"`
+	Command          *Command          `thrift:"command,1,required" json:"command"`
+	TaskId           *string           `thrift:"taskId,2" json:"taskId,omitempty"`
+	Dependencies     []string          `thrift:"dependencies,3" json:"dependencies,omitempty"`
+	SnapshotFromTask *string           `thrift:"snapshotFromTask,4" json:"snapshotFromTask,omitempty"`
+	Resources        *Resources        `thrift:"resources,5" json:"resources,omitempty"`
+	NodeSelector     map[string]string `thrift:"nodeSelector,6" json:"nodeSelector,omitempty"`
+	SkipCacheLookup  *bool             `thrift:"skipCacheLookup,7" json:"skipCacheLookup,omitempty"`
+	DoNotCache       *bool             `thrift:"doNotCache,8" json:"doNotCache,omitempty"`
 }
 
 func NewTaskDefinition() *TaskDefinition {
@@ -322,6 +684,54 @@ func (p *TaskDefinition) GetTaskId() string {
 	}
 	return *p.TaskId
 }
+
+var TaskDefinition_Dependencies_DEFAULT []string
+
+func (p *TaskDefinition) GetDependencies() []string {
+	return p.Dependencies
+}
+
+var TaskDefinition_SnapshotFromTask_DEFAULT string
+
+func (p *TaskDefinition) GetSnapshotFromTask() string {
+	if !p.IsSetSnapshotFromTask() {
+		return TaskDefinition_SnapshotFromTask_DEFAULT
+	}
+	return *p.SnapshotFromTask
+}
+
+var TaskDefinition_Resources_DEFAULT *Resources
+
+func (p *TaskDefinition) GetResources() *Resources {
+	if !p.IsSetResources() {
+		return TaskDefinition_Resources_DEFAULT
+	}
+	return p.Resources
+}
+
+var TaskDefinition_NodeSelector_DEFAULT map[string]string
+
+func (p *TaskDefinition) GetNodeSelector() map[string]string {
+	return p.NodeSelector
+}
+
+var TaskDefinition_SkipCacheLookup_DEFAULT bool
+
+func (p *TaskDefinition) GetSkipCacheLookup() bool {
+	if !p.IsSetSkipCacheLookup() {
+		return TaskDefinition_SkipCacheLookup_DEFAULT
+	}
+	return *p.SkipCacheLookup
+}
+
+var TaskDefinition_DoNotCache_DEFAULT bool
+
+func (p *TaskDefinition) GetDoNotCache() bool {
+	if !p.IsSetDoNotCache() {
+		return TaskDefinition_DoNotCache_DEFAULT
+	}
+	return *p.DoNotCache
+}
 func (p *TaskDefinition) IsSetCommand() bool {
 	return p.Command != nil
 }
@@ -330,6 +740,30 @@ func (p *TaskDefinition) IsSetTaskId() bool {
 	return p.TaskId != nil
 }
 
+func (p *TaskDefinition) IsSetDependencies() bool {
+	return p.Dependencies != nil
+}
+
+func (p *TaskDefinition) IsSetSnapshotFromTask() bool {
+	return p.SnapshotFromTask != nil
+}
+
+func (p *TaskDefinition) IsSetResources() bool {
+	return p.Resources != nil
+}
+
+func (p *TaskDefinition) IsSetNodeSelector() bool {
+	return p.NodeSelector != nil
+}
+
+func (p *TaskDefinition) IsSetSkipCacheLookup() bool {
+	return p.SkipCacheLookup != nil
+}
+
+func (p *TaskDefinition) IsSetDoNotCache() bool {
+	return p.DoNotCache != nil
+}
+
 func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -355,6 +789,30 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -390,6 +848,91 @@ func (p *TaskDefinition) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Dependencies = tSlice
+	for i := 0; i < size; i++ {
+		var _elem4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem4 = v
+		}
+		p.Dependencies = append(p.Dependencies, _elem4)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.SnapshotFromTask = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	p.Resources = &Resources{}
+	if err := p.Resources.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Resources), err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.NodeSelector = tMap
+	for i := 0; i < size; i++ {
+		var _key5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key5 = v
+		}
+		var _val6 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val6 = v
+		}
+		p.NodeSelector[_key5] = _val6
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.SkipCacheLookup = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.DoNotCache = &v
+	}
+	return nil
+}
+
 func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -400,6 +943,24 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -437,6 +998,115 @@ func (p *TaskDefinition) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDependencies() {
+		if err := oprot.WriteFieldBegin("dependencies", thrift.LIST, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:dependencies: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Dependencies)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Dependencies {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:dependencies: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotFromTask() {
+		if err := oprot.WriteFieldBegin("snapshotFromTask", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:snapshotFromTask: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotFromTask)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotFromTask (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:snapshotFromTask: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetResources() {
+		if err := oprot.WriteFieldBegin("resources", thrift.STRUCT, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:resources: ", p), err)
+		}
+		if err := p.Resources.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Resources), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:resources: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNodeSelector() {
+		if err := oprot.WriteFieldBegin("nodeSelector", thrift.MAP, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:nodeSelector: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.NodeSelector)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.NodeSelector {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:nodeSelector: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSkipCacheLookup() {
+		if err := oprot.WriteFieldBegin("skipCacheLookup", thrift.BOOL, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:skipCacheLookup: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.SkipCacheLookup)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.skipCacheLookup (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:skipCacheLookup: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDoNotCache() {
+		if err := oprot.WriteFieldBegin("doNotCache", thrift.BOOL, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:doNotCache: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.DoNotCache)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.doNotCache (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:doNotCache: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *TaskDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -608,11 +1278,11 @@ func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
 	tSlice := make([]*TaskDefinition, 0, size)
 	p.Tasks = tSlice
 	for i := 0; i < size; i++ {
-		_elem3 := &TaskDefinition{}
-		if err := _elem3.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem3), err)
+		_elem7 := &TaskDefinition{}
+		if err := _elem7.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem7), err)
 		}
-		p.Tasks = append(p.Tasks, _elem3)
+		p.Tasks = append(p.Tasks, _elem7)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
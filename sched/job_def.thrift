@@ -17,11 +17,26 @@ struct Command {
   2: optional map<string, string> envVars
   3: optional i64 timeout
   4: required string snapshotId
+  5: optional bool sandbox
+  6: optional string image
+  7: optional list<string> outputs
+}
+
+struct Resources {
+  1: optional i32 cpus
+  2: optional i64 memoryBytes
+  3: optional i64 diskBytes
 }
 
 struct TaskDefinition {
   1: required Command command
   2: optional string taskId
+  3: optional list<string> dependencies
+  4: optional string snapshotFromTask
+  5: optional Resources resources
+  6: optional map<string, string> nodeSelector
+  7: optional bool skipCacheLookup
+  8: optional bool doNotCache
 }
 
 struct JobDefinition {
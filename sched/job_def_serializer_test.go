@@ -64,6 +64,11 @@ func makeFixedSampleJob() *Job {
 	jobDef.Tasks = append(jobDef.Tasks, taskDefinition)
 	taskDefinition.TaskID = "taskId1"
 	taskDefinition.Argv = []string{"argA", "argB"}
+	taskDefinition.Sandbox = true
+	taskDefinition.Image = "go.tar:1.8"
+	taskDefinition.Outputs = []string{"out", "reports/*.xml"}
+	taskDefinition.SkipCacheLookup = true
+	taskDefinition.DoNotCache = true
 	jobDef.Tasks = append(jobDef.Tasks, taskDefinition)
 	job.Def = jobDef
 
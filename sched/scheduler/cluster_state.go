@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -9,30 +10,34 @@ import (
 
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 )
 
-const noJob = ""
-const noTask = ""
 const defaultMaxLostDuration = time.Minute
 const defaultMaxFlakyDuration = 15 * time.Minute
 
 var nilTime = time.Time{}
 
-// Cluster will use this function to determine if newly added nodes are ready to be used.
-type ReadyFn func(cluster.Node) (ready bool, backoffDuration time.Duration)
+// Cluster will use this function to determine if newly added nodes are ready to be used,
+// and the capacity they advertise: how many tasks they can run concurrently, their resources and labels.
+// Slots less than 1 are treated as 1.
+type ReadyFn func(cluster.Node) (ready bool, capacity runner.Capacity, backoffDuration time.Duration)
 
-// clusterState maintains a cluster of nodes and information about what task is running on each node.
+// clusterState maintains a cluster of nodes and information about what tasks are running on each node.
 // nodeGroups is for node affinity where we want to remember which node last ran with what snapshot.
+// A node is idle in its group while it has free slots, and busy once all its slots are running tasks.
+// Draining nodes accept no new tasks, they're drained once their running tasks finish.
 // NOTE: a node can be both running in scheduler and suspended here (distributed system eventual consistency...)
 type clusterState struct {
 	updateCh         chan []cluster.NodeUpdate
 	nodes            map[cluster.NodeId]*nodeState // All healthy nodes.
 	suspendedNodes   map[cluster.NodeId]*nodeState // All new, lost, or flaky nodes, disjoint from 'nodes'.
 	nodeGroups       map[string]*nodeGroup         // key is a snapshotId.
+	draining         map[cluster.NodeId]bool       // Nodes marked as draining, whether or not they're in the cluster.
 	maxLostDuration  time.Duration                 // after which we remove a node from the cluster entirely
 	maxFlakyDuration time.Duration                 // after which we mark it not flaky and put it back in rotation.
 	readyFn          ReadyFn                       // If provided, new nodes will be suspended until this returns true.
-	numRunning       int                           // Number of running nodes. running + free + suspended ~= allNodes (may lag)
+	numRunning       int                           // Number of running tasks. running + free + suspended ~= allSlots (may lag)
 	stats            stats.StatsReceiver           // for collecting stats about node availability
 }
 
@@ -45,21 +50,64 @@ func newNodeGroup() *nodeGroup {
 	return &nodeGroup{idle: map[cluster.NodeId]*nodeState{}, busy: map[cluster.NodeId]*nodeState{}}
 }
 
+// Identifies a task running on a node.
+type taskKey struct {
+	jobId  string
+	taskId string
+}
+
 // The State of A Node in the Cluster
 type nodeState struct {
-	node        cluster.Node
-	runningJob  string
-	runningTask string
-	snapshotId  string
-	timeLost    time.Time        // Time when node was marked lost, if set (lost and flaky are mutually exclusive).
-	timeFlaky   time.Time        // Time when node was marked flaky, if set (lost and flaky are mutually exclusive).
-	readyCh     chan interface{} // We create goroutines for each new node which will close this channel once the node is ready.
-	removedCh   chan interface{} // We send nil when a node has been removed and we want the above goroutine to exit.
+	node          cluster.Node
+	slots         int                          // The number of tasks this node can run concurrently.
+	capacity      runner.Capacity              // Resources and labels advertised by the node, zero values are unknown.
+	running       map[taskKey]runner.Resources // The tasks currently running on this node and the resources they need.
+	snapshotId    string
+	timeLost      time.Time        // Time when node was marked lost, if set (lost and flaky are mutually exclusive).
+	timeFlaky     time.Time        // Time when node was marked flaky, if set (lost and flaky are mutually exclusive).
+	readyCh       chan interface{} // We create goroutines for each new node which will close this channel once the node is ready.
+	readyCapacity runner.Capacity  // Capacity reported by the ready goroutine, only read once readyCh is closed.
+	removedCh     chan interface{} // We send nil when a node has been removed and we want the above goroutine to exit.
+	draining      bool             // True if this node accepts no new tasks.
 }
 
 func (n *nodeState) String() string {
-	return fmt.Sprintf("{node:%s, jobId:%s, taskId:%s, snapshotId:%s, timeLost:%v, timeFlaky:%v, ready:%t}",
-		spew.Sdump(n.node), n.runningJob, n.runningTask, n.snapshotId, n.timeLost, n.timeFlaky, (n.readyCh == nil))
+	return fmt.Sprintf("{node:%s, slots:%d, capacity:%+v, running:%v, snapshotId:%s, timeLost:%v, timeFlaky:%v, ready:%t, draining:%t}",
+		spew.Sdump(n.node), n.slots, n.capacity, n.running, n.snapshotId, n.timeLost, n.timeFlaky, (n.readyCh == nil), n.draining)
+}
+
+// The number of tasks running on this node.
+func (ns *nodeState) numRunning() int {
+	return len(ns.running)
+}
+
+// The number of additional tasks this node can run, zero if it's busy or draining.
+func (ns *nodeState) numFreeSlots() int {
+	if ns.draining {
+		return 0
+	}
+	return max(0, ns.slots-len(ns.running))
+}
+
+// Returns true if the given task is running on this node.
+func (ns *nodeState) isRunning(jobId, taskId string) bool {
+	_, ok := ns.running[taskKey{jobId, taskId}]
+	return ok
+}
+
+// The resources needed by the tasks running on this node.
+func (ns *nodeState) used() runner.Resources {
+	res := runner.Resources{}
+	for _, r := range ns.running {
+		res = res.Add(r)
+	}
+	return res
+}
+
+// Returns true if this node has the labels in selector, and enough resources left over from its running tasks
+// for res. Resources the node doesn't advertise aren't constrained. Draining nodes can't run new tasks.
+func (ns *nodeState) canRun(selector map[string]string, res runner.Resources) bool {
+	return !ns.draining && ns.capacity.Matches(selector) && ns.capacity.Fits(ns.used().Add(res))
 }
 
 // This node was either reported lost by a NodeUpdate and we keep it around for a bit in case it revives,
@@ -88,7 +136,9 @@ func (ns *nodeState) startReadyLoop(rfn ReadyFn) {
 	go func() {
 		done := false
 		for !done {
-			if ready, backoff := rfn(ns.node); ready {
+			if ready, capacity, backoff := rfn(ns.node); ready {
+				// Written before the close so update() can read it once it sees the channel closed.
+				ns.readyCapacity = capacity
 				close(ns.readyCh)
 				done = true
 			} else if backoff == 0 {
@@ -107,15 +157,27 @@ func (ns *nodeState) startReadyLoop(rfn ReadyFn) {
 
 // Initializes a Node State for the specified Node
 func newNodeState(node cluster.Node) *nodeState {
-	return &nodeState{
-		node:        node,
-		runningJob:  noJob,
-		runningTask: noTask,
-		snapshotId:  "",
-		timeLost:    nilTime,
-		timeFlaky:   nilTime,
-		readyCh:     nil,
-		removedCh:   make(chan interface{}),
+	ns := &nodeState{
+		node:       node,
+		slots:      1,
+		running:    map[taskKey]runner.Resources{},
+		snapshotId: "",
+		timeLost:   nilTime,
+		timeFlaky:  nilTime,
+		readyCh:    nil,
+		removedCh:  make(chan interface{}),
+	}
+	ns.advertise(node)
+	return ns
+}
+
+// Takes the slots and labels of nodes that advertise them when they join or change, ex: workers registered by heartbeat.
+// A readiness check, if any, replaces them with the capacity the node reports once it's ready.
+func (ns *nodeState) advertise(node cluster.Node) {
+	if cn, ok := node.(cluster.CapacityNode); ok {
+		ns.capacity.Slots = cn.Slots()
+		ns.capacity.Labels = cn.Labels()
+		ns.slots = max(1, cn.Slots())
 	}
 }
 
@@ -132,6 +194,7 @@ func newClusterState(initial []cluster.Node, updateCh chan []cluster.NodeUpdate,
 		nodes:            make(map[cluster.NodeId]*nodeState),
 		suspendedNodes:   map[cluster.NodeId]*nodeState{},
 		nodeGroups:       map[string]*nodeGroup{"": newNodeGroup()},
+		draining:         map[cluster.NodeId]bool{},
 		maxLostDuration:  defaultMaxLostDuration,
 		maxFlakyDuration: defaultMaxFlakyDuration,
 		readyFn:          rfn,
@@ -141,37 +204,89 @@ func newClusterState(initial []cluster.Node, updateCh chan []cluster.NodeUpdate,
 	return cs
 }
 
-// Number of free nodes that are not in a suspended state.
+// Number of task slots on nodes that are not in a suspended or draining state.
+func (c *clusterState) numSlots() int {
+	n := 0
+	for _, ns := range c.nodes {
+		if !ns.draining {
+			n += ns.slots
+		}
+	}
+	return n
+}
+
+// Number of free task slots on nodes that are not in a suspended or draining state.
 func (c *clusterState) numFree() int {
+	// Tasks still running on draining nodes don't take up any of the remaining slots.
+	running := c.numRunning
+	for _, ns := range c.nodes {
+		if ns.draining {
+			running -= ns.numRunning()
+		}
+	}
 	// This can go negative due to lost nodes, set lower bound at zero.
-	return max(0, len(c.nodes)-c.numRunning)
+	return max(0, c.numSlots()-running)
+}
+
+// Returns true if some healthy node could run the task once it's idle, given the task's resources and node selector.
+// Tasks that fail this check are left unscheduled so they don't hold up tasks which can run.
+func (c *clusterState) canEverRun(task *taskState) bool {
+	def := &task.Def
+	if def.Resources == (runner.Resources{}) && len(def.NodeSelector) == 0 {
+		return true
+	}
+	for _, ns := range c.nodes {
+		if !ns.suspended() && !ns.draining && ns.capacity.Matches(def.NodeSelector) && ns.capacity.Fits(def.Resources) {
+			return true
+		}
+	}
+	return false
 }
 
 // Update ClusterState to reflect that a task has been scheduled on a particular node
-// SnapshotId should be the value from the task definition associated with the given taskId.
-func (c *clusterState) taskScheduled(nodeId cluster.NodeId, jobId, taskId, snapshotId string) {
+// SnapshotId and res should be the values from the task definition associated with the given taskId.
+func (c *clusterState) taskScheduled(nodeId cluster.NodeId, jobId, taskId, snapshotId string, res runner.Resources) {
 	ns := c.nodes[nodeId]
+	ns.running[taskKey{jobId, taskId}] = res
+	c.numRunning++
+	c.regroup(ns, snapshotId)
+}
 
-	delete(c.nodeGroups[ns.snapshotId].idle, nodeId)
-	empty := len(c.nodeGroups[ns.snapshotId].idle) == 0 && len(c.nodeGroups[ns.snapshotId].busy) == 0
-	if ns.snapshotId != "" && empty {
-		delete(c.nodeGroups, ns.snapshotId)
+// Moves the node from its group to the group for snapshotId, as idle if it has free slots and busy otherwise.
+func (c *clusterState) regroup(ns *nodeState, snapshotId string) {
+	nodeId := ns.node.Id()
+	if group, ok := c.nodeGroups[ns.snapshotId]; ok {
+		delete(group.idle, nodeId)
+		delete(group.busy, nodeId)
+		if ns.snapshotId != "" && len(group.idle) == 0 && len(group.busy) == 0 {
+			delete(c.nodeGroups, ns.snapshotId)
+		}
 	}
 
+	ns.snapshotId = snapshotId
 	if _, ok := c.nodeGroups[snapshotId]; !ok {
 		c.nodeGroups[snapshotId] = newNodeGroup()
 	}
-	c.nodeGroups[snapshotId].busy[nodeId] = ns
+	if ns.numFreeSlots() > 0 {
+		c.nodeGroups[snapshotId].idle[nodeId] = ns
+	} else {
+		c.nodeGroups[snapshotId].busy[nodeId] = ns
+	}
+}
 
-	ns.runningJob = jobId
-	ns.runningTask = taskId
-	ns.snapshotId = snapshotId
-	c.numRunning++
+// Moves a node that hasn't been scheduled any tasks to the group of the snapshot it most recently checked out,
+// if it advertises one, so tasks with that snapshot prefer it.
+func (c *clusterState) preferSnapshot(ns *nodeState) {
+	cn, ok := ns.node.(cluster.CapacityNode)
+	if !ok || ns.snapshotId != "" || ns.numRunning() > 0 || len(cn.SnapshotIDs()) == 0 {
+		return
+	}
+	c.regroup(ns, cn.SnapshotIDs()[0])
 }
 
 // Update ClusterState to reflect that a task has finished running on
 // a particular node, whether successfully or unsuccessfully
-func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
+func (c *clusterState) taskCompleted(nodeId cluster.NodeId, jobId, taskId string, flaky bool) {
 	var ns *nodeState
 	var ok bool
 	if ns, ok = c.nodes[nodeId]; !ok {
@@ -184,8 +299,7 @@ func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
 			c.suspendedNodes[nodeId] = ns
 			ns.timeFlaky = time.Now()
 		}
-		ns.runningJob = noJob
-		ns.runningTask = noTask
+		delete(ns.running, taskKey{jobId, taskId})
 		delete(c.nodeGroups[ns.snapshotId].busy, nodeId)
 		c.nodeGroups[ns.snapshotId].idle[nodeId] = ns
 	} else {
@@ -199,6 +313,56 @@ func (c *clusterState) getNodeState(nodeId cluster.NodeId) (*nodeState, bool) {
 	return ns, ok
 }
 
+// Marks the given nodes as draining, or returns them to rotation if draining is false.
+// Nodes not yet in the cluster are remembered and start out draining when they're added.
+func (c *clusterState) setDraining(nodeIds []cluster.NodeId, draining bool) {
+	for _, id := range nodeIds {
+		if draining {
+			c.draining[id] = true
+		} else {
+			delete(c.draining, id)
+		}
+		ns, ok := c.nodes[id]
+		if !ok {
+			ns, ok = c.suspendedNodes[id]
+		}
+		if !ok {
+			continue
+		}
+		ns.draining = draining
+	}
+	log.Infof("Nodes draining=%t: %v, %s", draining, nodeIds, c.status())
+}
+
+// Returns the drain status of every draining node, ordered by node id.
+func (c *clusterState) drainStatus() []NodeDrainStatus {
+	statuses := []NodeDrainStatus{}
+	for id := range c.draining {
+		st := NodeDrainStatus{NodeId: string(id)}
+		ns, ok := c.nodes[id]
+		if !ok {
+			ns, ok = c.suspendedNodes[id]
+		}
+		if ok {
+			st.InCluster = true
+			st.NumRunning = ns.numRunning()
+		}
+		st.Drained = st.NumRunning == 0
+		statuses = append(statuses, st)
+	}
+	sort.Sort(drainStatusById(statuses))
+	return statuses
+}
+
+// Returns the ids of the draining nodes, ordered by node id.
+func (c *clusterState) drainingNodes() []cluster.NodeId {
+	ids := []cluster.NodeId{}
+	for _, st := range c.drainStatus() {
+		ids = append(ids, cluster.NodeId(st.NodeId))
+	}
+	return ids
+}
+
 // upate cluster state to reflect added and removed nodes
 func (c *clusterState) updateCluster() {
 	select {
@@ -212,7 +376,7 @@ func (c *clusterState) updateCluster() {
 	}
 }
 
-// Processes nodes being added and removed from the cluster & updates the distributor state accordingly.
+// Processes nodes being added, removed and changed in the cluster & updates the distributor state accordingly.
 // Note, we don't expect there to be many updates after startup if the cluster is relatively stable.
 //TODO(jschiller) this assumes that new nodes never have the same id as previous ones but we shouldn't rely on that.
 func (c *clusterState) update(updates []cluster.NodeUpdate) {
@@ -229,8 +393,11 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 				} else if ns.timeLost != nilTime {
 					// This node was suspended as lost earlier, we can recover it now.
 					ns.timeLost = nilTime
+					ns.node = update.Node
+					ns.advertise(update.Node)
 					c.nodes[update.Id] = ns
 					delete(c.suspendedNodes, update.Id)
+					c.preferSnapshot(ns)
 					log.Infof("Recovered suspended node %v (%#v), %s", update.Id, ns, c.status())
 				} else {
 					log.Infof("Ignoring NodeAdded event for suspended flaky node. %v (%#v)", update.Id, ns)
@@ -251,7 +418,9 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 					log.Infof("Added new suspended node: %v (%#v), %s", update.Id, update.Node, c.status())
 					newNode.startReadyLoop(c.readyFn)
 				}
+				newNode.draining = c.draining[update.Id]
 				c.nodeGroups[""].idle[update.Id] = newNode
+				c.preferSnapshot(newNode)
 
 			} else {
 				// This node is already present, log this spurious add.
@@ -276,6 +445,23 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 				// We don't know about this node, log spurious remove.
 				log.Infof("Cannot remove unknown node: %v", update.Id)
 			}
+
+		case cluster.NodeChanged:
+			ns, ok := c.nodes[update.Id]
+			if !ok {
+				ns, ok = c.suspendedNodes[update.Id]
+			}
+			if ok {
+				// Take the node's new capacity, which may change whether it's idle or busy, and its snapshots.
+				ns.node = update.Node
+				ns.advertise(update.Node)
+				c.regroup(ns, ns.snapshotId)
+				c.preferSnapshot(ns)
+				log.Infof("Changed node: %v (%#v), %s", update.Id, update.Node, c.status())
+			} else {
+				// We don't know about this node, log spurious change.
+				log.Infof("Cannot change unknown node: %v", update.Id)
+			}
 		}
 	}
 
@@ -283,8 +469,10 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 	// and check if newly added non-ready nodes are ready to be put into rotation.
 	now := time.Now()
 	for _, ns := range c.suspendedNodes {
-		if ns.ready() {
+		if ns.readyCh != nil && ns.ready() {
 			ns.readyCh = nil
+			ns.capacity = ns.readyCapacity
+			ns.slots = max(1, ns.capacity.Slots)
 		}
 		if !ns.suspended() {
 			// This node is initialized, remove it from suspended nodes and add it to the healthy node pool.
@@ -324,10 +512,12 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 	c.stats.Gauge(stats.ClusterAvailableNodes).Update(int64(len(c.nodes)))
 	c.stats.Gauge(stats.ClusterFreeNodes).Update(int64(c.numFree()))
 	c.stats.Gauge(stats.ClusterRunningNodes).Update(int64(c.numRunning))
+	c.stats.Gauge(stats.ClusterAvailableSlots).Update(int64(c.numSlots()))
 	c.stats.Gauge(stats.ClusterLostNodes).Update(int64(len(c.suspendedNodes)))
+	c.stats.Gauge(stats.ClusterDrainingNodes).Update(int64(len(c.draining)))
 }
 
 func (c *clusterState) status() string {
-	return fmt.Sprintf("now have %d healthy (%d free, %d running), and %d suspended",
-		len(c.nodes), c.numFree(), c.numRunning, len(c.suspendedNodes))
+	return fmt.Sprintf("now have %d healthy (%d slots, %d free, %d running), %d suspended, and %d draining",
+		len(c.nodes), c.numSlots(), c.numFree(), c.numRunning, len(c.suspendedNodes), len(c.draining))
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 )
 
 // ensures nodes can be added and removed
@@ -34,8 +35,8 @@ func Test_ClusterState_UpdateCluster(t *testing.T) {
 	}
 
 	ns, _ := cs.getNodeState(cluster.NodeId("node1"))
-	if ns.runningTask != noTask {
-		t.Errorf("expected newly added node to have no tasks")
+	if ns.numRunning() != 0 || ns.slots != 1 {
+		t.Errorf("expected newly added node to have no tasks and a single slot")
 	}
 
 	// test remove existing node
@@ -73,7 +74,7 @@ func Test_ClusterState_DuplicateNodeAdd(t *testing.T) {
 	cl := makeTestCluster("node1")
 	cs := newClusterState(cl.nodes, cl.ch, nil, statsReceiver)
 
-	cs.taskScheduled("node1", "job1", "task1", "")
+	cs.taskScheduled("node1", "job1", "task1", "", runner.Resources{})
 
 	// readd node to cluster
 	cl.add("node1")
@@ -86,8 +87,8 @@ func Test_ClusterState_DuplicateNodeAdd(t *testing.T) {
 
 	ns, _ := cs.getNodeState("node1")
 	// verify that the state wasn't modified
-	if ns.runningTask != "task1" {
-		t.Errorf("Expected adding an already tracked node to not modify state %v", cs.nodes[cluster.NodeId("node1")].runningTask)
+	if !ns.isRunning("job1", "task1") {
+		t.Errorf("Expected adding an already tracked node to not modify state %v", cs.nodes[cluster.NodeId("node1")].running)
 	}
 }
 
@@ -98,10 +99,10 @@ func Test_ClusterState_TaskStarted(t *testing.T) {
 	cl := makeTestCluster("node1")
 	cs := newClusterState(cl.nodes, cl.ch, nil, statsReceiver)
 
-	cs.taskScheduled("node1", "job1", "task1", "")
+	cs.taskScheduled("node1", "job1", "task1", "", runner.Resources{})
 	ns, _ := cs.getNodeState("node1")
 
-	if ns.runningTask != "task1" {
+	if !ns.isRunning("job1", "task1") {
 		t.Errorf("Expected Node1 to be running task1")
 	}
 }
@@ -113,12 +114,12 @@ func Test_ClusterState_TaskCompleted(t *testing.T) {
 	cl := makeTestCluster("node1")
 	cs := newClusterState(cl.nodes, cl.ch, nil, statsReceiver)
 
-	cs.taskScheduled("node1", "job1", "task1", "")
+	cs.taskScheduled("node1", "job1", "task1", "", runner.Resources{})
 	ns, _ := cs.getNodeState("node1")
 
-	cs.taskCompleted("node1", false)
-	if ns.runningTask != noTask {
-		t.Errorf("Expected Node1 to be running task1")
+	cs.taskCompleted("node1", "job1", "task1", false)
+	if ns.numRunning() != 0 {
+		t.Errorf("Expected Node1 to not be running any tasks")
 	}
 
 }
@@ -132,12 +133,12 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 		"node1": make(chan interface{}), "node2": make(chan interface{}),
 		"node3": make(chan interface{}), "node4": make(chan interface{}),
 	}
-	readyFn := func(node cluster.Node) (bool, time.Duration) {
+	readyFn := func(node cluster.Node) (bool, runner.Capacity, time.Duration) {
 		select {
 		case <-ready[string(node.Id())]:
-			return true, time.Duration(0)
+			return true, runner.Capacity{}, time.Duration(0)
 		default:
-			return false, time.Millisecond
+			return false, runner.Capacity{}, time.Millisecond
 		}
 	}
 	setReady := func(node string) {
@@ -224,9 +225,9 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 		t.Fatal("stats check did not pass.")
 	}
 	// Test the the right idle/busy maps are filled out for each snapshotId.
-	cs.taskScheduled("node1", "job1", "task1", "snapA")
-	cs.taskScheduled("node2", "job1", "task2", "snapA")
-	cs.taskScheduled("node3", "job1", "task3", "snapB")
+	cs.taskScheduled("node1", "job1", "task1", "snapA", runner.Resources{})
+	cs.taskScheduled("node2", "job1", "task2", "snapA", runner.Resources{})
+	cs.taskScheduled("node3", "job1", "task3", "snapB", runner.Resources{})
 	expectedGroups := map[string]*nodeGroup{
 		"": &nodeGroup{
 			idle: map[cluster.NodeId]*nodeState{
@@ -253,7 +254,7 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 	}
 
 	// Test that finishing a jobs moves it to the idle list for its snapshotId.
-	cs.taskCompleted("node1", false)
+	cs.taskCompleted("node1", "job1", "task1", false)
 	expectedGroups["snapA"].idle["node1"] = cs.nodes["node1"]
 	delete(expectedGroups["snapA"].busy, "node1")
 	if !reflect.DeepEqual(cs.nodeGroups, expectedGroups) {
@@ -261,7 +262,7 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 	}
 
 	// Test the rescheduling a task moves it correctly from an idle list to a busy one.
-	cs.taskScheduled("node1", "job1", "task1", "snapB")
+	cs.taskScheduled("node1", "job1", "task1", "snapB", runner.Resources{})
 	expectedGroups["snapB"].busy["node1"] = cs.nodes["node1"]
 	delete(expectedGroups["snapA"].idle, "node1")
 	if !reflect.DeepEqual(cs.nodeGroups, expectedGroups) {
@@ -269,7 +270,7 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 	}
 
 	// Task finished and is marked as flaky
-	cs.taskCompleted("node1", true)
+	cs.taskCompleted("node1", "job1", "task1", true)
 	if _, ok := cs.nodes["node1"]; ok {
 		t.Fatalf("Flaky node was not moved out of cs.nodes")
 	} else if _, ok := cs.suspendedNodes["node1"]; !ok {
@@ -330,6 +331,116 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 
 }
 
+// verify that nodes advertising multiple slots stay idle until all their slots are used.
+func Test_ClusterState_Slots(t *testing.T) {
+	readyFn := func(node cluster.Node) (bool, runner.Capacity, time.Duration) {
+		if node.Id() == "node1" {
+			return true, runner.Capacity{Slots: 2}, 0
+		}
+		return true, runner.Capacity{}, 0
+	}
+	cl := makeTestCluster("node1", "node2")
+	cs := newClusterState(cl.nodes, cl.ch, readyFn, stats.NilStatsReceiver())
+	time.Sleep(10 * time.Millisecond)
+	cs.updateCluster()
+	if len(cs.nodes) != 2 || cs.nodes["node1"].slots != 2 || cs.nodes["node2"].slots != 1 {
+		t.Fatalf("Expected node1 with 2 slots and node2 with the default of 1, got: %s", spew.Sdump(cs.nodes))
+	}
+	if cs.numSlots() != 3 || cs.numFree() != 3 {
+		t.Fatalf("Expected 3 free slots, got %d of %d", cs.numFree(), cs.numSlots())
+	}
+
+	cs.taskScheduled("node1", "job1", "task1", "snapA", runner.Resources{})
+	if cs.numFree() != 2 || cs.nodeGroups["snapA"].idle["node1"] == nil {
+		t.Fatalf("Expected node1 to remain idle with a free slot, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+	cs.taskScheduled("node1", "job1", "task2", "snapA", runner.Resources{})
+	if cs.numFree() != 1 || cs.nodeGroups["snapA"].busy["node1"] == nil || len(cs.nodeGroups["snapA"].idle) != 0 {
+		t.Fatalf("Expected node1 to be busy once its slots are used, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+
+	cs.taskCompleted("node1", "job1", "task1", false)
+	ns := cs.nodes["node1"]
+	if ns.isRunning("job1", "task1") || !ns.isRunning("job1", "task2") || cs.nodeGroups["snapA"].idle["node1"] == nil {
+		t.Fatalf("Expected only task2 running on an idle node1, got: %s", ns)
+	}
+}
+
+func Test_ClusterState_Draining(t *testing.T) {
+	cl := makeTestCluster("node1", "node2")
+	cs := newClusterState(cl.nodes, cl.ch, nil, stats.NilStatsReceiver())
+	cs.taskScheduled("node1", "job1", "task1", "snapA", runner.Resources{})
+
+	// node3 isn't in the cluster yet, it should start out draining once it's added.
+	cs.setDraining([]cluster.NodeId{"node1", "node3"}, true)
+	if cs.numSlots() != 1 || cs.numFree() != 1 {
+		t.Fatalf("Expected only node2's slot to be free, got %d of %d", cs.numFree(), cs.numSlots())
+	}
+	if ns := cs.nodes["node1"]; ns.numFreeSlots() != 0 || ns.canRun(nil, runner.Resources{}) {
+		t.Fatalf("Expected draining node1 to accept no tasks, got: %s", ns)
+	}
+	expected := []NodeDrainStatus{
+		{NodeId: "node1", NumRunning: 1, InCluster: true},
+		{NodeId: "node3", Drained: true},
+	}
+	if st := cs.drainStatus(); !reflect.DeepEqual(st, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, st)
+	}
+
+	cs.taskCompleted("node1", "job1", "task1", false)
+	cl.add("node3")
+	cs.updateCluster()
+	expected = []NodeDrainStatus{
+		{NodeId: "node1", Drained: true, InCluster: true},
+		{NodeId: "node3", Drained: true, InCluster: true},
+	}
+	if st := cs.drainStatus(); !reflect.DeepEqual(st, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, st)
+	}
+	if cs.numSlots() != 1 {
+		t.Fatalf("Expected added node3 to be draining, got %d slots", cs.numSlots())
+	}
+
+	cs.setDraining([]cluster.NodeId{"node1", "node3"}, false)
+	if cs.numSlots() != 3 || cs.numFree() != 3 || len(cs.drainStatus()) != 0 {
+		t.Fatalf("Expected all nodes back in rotation, got %d of %d free, %+v", cs.numFree(), cs.numSlots(), cs.drainStatus())
+	}
+}
+
+// verify that nodes take the capacity they advertise when they change, and prefer the snapshot they last checked out.
+func Test_ClusterState_NodeChanged(t *testing.T) {
+	cl := makeTestCluster()
+	cs := newClusterState(cl.nodes, cl.ch, nil, stats.NilStatsReceiver())
+	cl.ch <- []cluster.NodeUpdate{cluster.NewAdd(cluster.NewCapacityNode("node1", 1, nil, []string{"snapA", "snapB"}))}
+	cs.updateCluster()
+	if cs.nodeGroups["snapA"] == nil || cs.nodeGroups["snapA"].idle["node1"] == nil || len(cs.nodeGroups[""].idle) != 0 {
+		t.Fatalf("Expected node1 to be idle in the group of its latest snapshot, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+
+	cs.taskScheduled("node1", "job1", "task1", "snapC", runner.Resources{})
+	if cs.nodeGroups["snapC"].busy["node1"] == nil || cs.nodeGroups["snapA"] != nil {
+		t.Fatalf("Expected node1 to be busy running snapC, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+
+	// node1 gets another slot and a label, it stays with the snapshot it's running but is now idle.
+	labels := map[string]string{"os": "linux"}
+	cl.ch <- []cluster.NodeUpdate{cluster.NewChange(cluster.NewCapacityNode("node1", 2, labels, []string{"snapC"}))}
+	cs.updateCluster()
+	if ns := cs.nodes["node1"]; ns.slots != 2 || !reflect.DeepEqual(ns.capacity.Labels, labels) {
+		t.Fatalf("Expected node1 to have its new capacity, got: %s", ns)
+	}
+	if cs.numSlots() != 2 || cs.numFree() != 1 || cs.nodeGroups["snapC"].idle["node1"] == nil {
+		t.Fatalf("Expected node1 to be idle with a free slot, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+
+	// Changes to nodes that aren't in the cluster are ignored.
+	cl.ch <- []cluster.NodeUpdate{cluster.NewChange(cluster.NewCapacityNode("node2", 1, nil, nil))}
+	cs.updateCluster()
+	if len(cs.nodes) != 1 || len(cs.suspendedNodes) != 0 {
+		t.Fatalf("Expected only node1 in the cluster, got: %s", spew.Sdump(cs.nodes))
+	}
+}
+
 type testCluster struct {
 	ch    chan []cluster.NodeUpdate
 	nodes []cluster.Node
@@ -14,7 +14,23 @@ SoftMaxSchedulableTasks:
   This limit helps determine nodes per job but doesn’t actually result in scheduler backpressure.
 
 NumRunningNodes:
-  The total number of nodes in the cluster which are capable of running a task, even if currently busy.
+  The total number of task slots in the cluster which are capable of running a task, even if currently busy.
+
+Slots:
+  A worker may run several tasks concurrently, advertising its slots in its status. The count is read by the
+  readiness check when a node joins (requires ReadyFnBackoff), otherwise each node has a single slot.
+  Tasks are packed onto the idle node with the fewest free slots, so whole nodes stay free for larger jobs.
+
+Resources, NodeSelector:
+  A task may request cpus, memory and disk, and require labels on its node. Workers advertise these alongside
+  their slots, and a task is only placed (or preempts a task) on a node with matching labels and enough left
+  over from its running tasks. Tasks that no healthy node could run are left unscheduled.
+
+Draining:
+  Nodes can be marked as draining for maintenance through the API. Draining nodes accept no new tasks, including
+  preempting tasks, and don't count towards NumRunningNodes. A node is drained once its running tasks finish.
+  If DrainingNodesFile is set the draining nodes are persisted there and survive scheduler restarts, a drain or
+  undrain request that fails to persist returns an error and leaves the draining nodes unchanged.
 
 NodeScaleFactor:
   Used to calculate how many tasks a job can run without adversely affecting other jobs.
@@ -24,10 +40,34 @@ NodeScaleFactor:
 MinRunningNodesForGivenJob:
   = ceil(min(NumFreeNodes, Job.NumRequestedTasks * NodeScaleFactor, Job.NumRemainingTasks))
 
+TaskDependencies:
+  A task may depend on other tasks in the same job. It is only schedulable once all of its dependencies completed
+  successfully, and may run against the output snapshot of one of them (SnapshotFromTask).
+  If a dependency fails, the task and everything depending on it is skipped and logged as failed without running.
+
+TaskDurations:
+  The recent durations of each task, keyed by task id and job type, are kept in a moving window.
+  A job's tasks are started longest average duration first, with tasks that have no history going first.
+  If TaskDurationsFile is set the history is persisted there and survives scheduler restarts.
+
 MaxJobsPerRequestor,  MaxRequestors:
   These limits are somewhat arbitrary and are only meant to prevent spamming, not to ensure fairness.
   Scheduler will apply backpressure if we hit these limits.
 
+FairShare, RequestorWeights:
+  When FairShare is set, nodes left after priority 3 jobs are split across requestors in proportion to their weight,
+  counting the tasks each requestor already has running. Priority still orders jobs within a requestor.
+  A requestor that gets less than its share because nodes were busy accrues a bounded deficit and is served first later.
+
+RequestorQuotas, DefaultRequestorQuota, QuotaWindow:
+  Hard caps on a requestor's running tasks, queued tasks, and node hours used within the rolling QuotaWindow.
+  Jobs that would exceed the queued tasks or node hours quota are rejected with an estimate of when to retry.
+  Tasks of a requestor at its running tasks or node hours quota aren't started, leaving the nodes to other requestors.
+
+Preemption:
+  Limits which running tasks priority 3 jobs can kill: not before a minimum runtime, not more than a fraction of a job's
+  tasks, and not tasks that have nearly run for their average recorded duration. Preemption never counts as a retry.
+
 * Logic *
 Schedule Loop:
 Group new job requests with existing jobs sharing the same RequestTag
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+)
+
+// The drain status of a node marked as draining.
+type NodeDrainStatus struct {
+	NodeId     string
+	Drained    bool // True once the node has no running tasks left.
+	NumRunning int  // The number of tasks still running on the node.
+	InCluster  bool // False if the node isn't currently known to the cluster, it starts out draining if it joins.
+}
+
+type drainStatusById []NodeDrainStatus
+
+func (s drainStatusById) Len() int           { return len(s) }
+func (s drainStatusById) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s drainStatusById) Less(i, j int) bool { return s[i].NodeId < s[j].NodeId }
+
+type drainOp int
+
+const (
+	drainStatusOp drainOp = iota
+	drainNodesOp
+	undrainNodesOp
+)
+
+// contains the nodes to drain or undrain, if any, and callback for the resulting drain status
+type drainRequest struct {
+	op         drainOp
+	nodeIds    []cluster.NodeId
+	responseCh chan drainResponse
+}
+
+type drainResponse struct {
+	statuses []NodeDrainStatus
+	err      error
+}
+
+/*
+Put the drain request on channel that is processed by the main
+scheduler loop, and wait for the response
+*/
+func (s *statefulScheduler) DrainNodes(nodeIds []string) ([]NodeDrainStatus, error) {
+	return s.requestDrain(drainNodesOp, nodeIds)
+}
+
+// Returns the given nodes to rotation, the returned status lists the nodes still draining.
+func (s *statefulScheduler) UndrainNodes(nodeIds []string) ([]NodeDrainStatus, error) {
+	return s.requestDrain(undrainNodesOp, nodeIds)
+}
+
+// Returns the status of all draining nodes.
+func (s *statefulScheduler) DrainStatus() ([]NodeDrainStatus, error) {
+	return s.requestDrain(drainStatusOp, nil)
+}
+
+func (s *statefulScheduler) requestDrain(op drainOp, nodeIds []string) ([]NodeDrainStatus, error) {
+	ids := []cluster.NodeId{}
+	for _, id := range nodeIds {
+		if id == "" {
+			return nil, errors.New("Node id must not be empty")
+		}
+		ids = append(ids, cluster.NodeId(id))
+	}
+	if op != drainStatusOp {
+		log.WithFields(
+			log.Fields{
+				"nodeIds": nodeIds,
+				"drain":   op == drainNodesOp,
+			}).Info("Drain requested")
+	}
+	req := drainRequest{op: op, nodeIds: ids, responseCh: make(chan drainResponse, 1)}
+	s.drainCh <- req
+	resp := <-req.responseCh
+	return resp.statuses, resp.err
+}
+
+// process all drain requests, persisting the draining nodes before they change.
+// A request whose change can't be persisted isn't applied, so it doesn't get lost on restart.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) processDrainRequests() {
+	for haveDrainRequest := true; haveDrainRequest == true; {
+		select {
+		case req := <-s.drainCh:
+			var err error
+			if req.op != drainStatusOp {
+				draining := req.op == drainNodesOp
+				if err = s.saveDrainingNodes(req.nodeIds, draining); err == nil {
+					s.clusterState.setDraining(req.nodeIds, draining)
+				}
+			}
+			req.responseCh <- drainResponse{statuses: s.clusterState.drainStatus(), err: err}
+		default:
+			haveDrainRequest = false
+		}
+	}
+}
+
+// Writes the nodes that are draining once the given nodes are drained or undrained to config.DrainingNodesFile,
+// if set, so they're still draining after a restart.
+func (s *statefulScheduler) saveDrainingNodes(nodeIds []cluster.NodeId, draining bool) error {
+	if s.config.DrainingNodesFile == "" {
+		return nil
+	}
+	ids := map[cluster.NodeId]bool{}
+	for _, id := range s.clusterState.drainingNodes() {
+		ids[id] = true
+	}
+	for _, id := range nodeIds {
+		ids[id] = draining
+	}
+	names := []string{}
+	for id, ok := range ids {
+		if ok {
+			names = append(names, string(id))
+		}
+	}
+	sort.Strings(names)
+	drainingIds := []cluster.NodeId{}
+	for _, name := range names {
+		drainingIds = append(drainingIds, cluster.NodeId(name))
+	}
+	err := saveDrainingNodes(s.config.DrainingNodesFile, drainingIds)
+	if err != nil {
+		log.WithFields(
+			log.Fields{
+				"path": s.config.DrainingNodesFile,
+				"err":  err,
+			}).Error("Failed to persist draining nodes")
+	}
+	return err
+}
+
+// Reads the ids of the draining nodes persisted to path, none if the file doesn't exist yet.
+func loadDrainingNodes(path string) ([]cluster.NodeId, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	ids := []cluster.NodeId{}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Atomically replaces the contents of path with the ids of the draining nodes.
+func saveDrainingNodes(path string, ids []cluster.NodeId) error {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/sched"
+)
+
+// Weight of requestors that aren't listed in SchedulerConfig.RequestorWeights.
+const DefaultRequestorWeight = 1.0
+
+// A requestor's claim on the cluster during one round of fair share scheduling.
+type requestorShare struct {
+	requestor string
+	weight    float64
+	entitled  float64      // Number of nodes the requestor should be using given its weight.
+	usage     float64      // Number of running tasks, plus tasks assigned this round.
+	queue     []*taskState // Unscheduled tasks in the order they should run, at most numFree of them.
+	waiting   bool         // True if the requestor has unscheduled tasks.
+	exhausted bool         // True if queue holds all of the requestor's unscheduled tasks.
+}
+
+type requestorSharesByName []*requestorShare
+
+func (s requestorSharesByName) Len() int           { return len(s) }
+func (s requestorSharesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s requestorSharesByName) Less(i, j int) bool { return s[i].requestor < s[j].requestor }
+
+// Requestors with the lowest weighted usage, net of their deficit, are served first.
+func (s *requestorShare) key(deficit float64) float64 {
+	return (s.usage - deficit) / s.weight
+}
+
+func requestorWeight(weights map[string]float64, requestor string) float64 {
+	if w, ok := weights[requestor]; ok && w > 0 {
+		return w
+	}
+	return DefaultRequestorWeight
+}
+
+// Distributes numFree nodes across the unscheduled tasks of priority 0-2 jobs so that each requestor's
+// running task count tends towards its weighted share of the nodes those requestors can use.
+// Tasks already assigned this round (i.e. to priority 3 jobs) count towards their requestor's usage.
+//
+// Nodes are handed out one at a time to the requestor with the lowest weighted usage. A requestor that is
+// below its share at the end of a round, because nodes weren't free, carries the difference over as a
+// deficit that moves it ahead of other requestors in later rounds (and a requestor above its share carries
+// a negative deficit). Deficits are bounded by the requestor's share, and are dropped once it has no more
+// tasks waiting. deficits is updated in place.
+//
+// A requestor listed in limits is assigned at most that many tasks. Tasks for which canRun returns false are
+// skipped, canRun may be nil.
+//
+// Within a requestor, tasks of higher priority jobs come first, then jobs in fifo order.
+func getFairShareTasks(
+	jobs []*jobState,
+	assigned []*taskState,
+	numFree int,
+	weights map[string]float64,
+	deficits map[string]float64,
+	limits map[string]int,
+	canRun func(*taskState) bool,
+) []*taskState {
+	shares := map[string]*requestorShare{}
+	requestorOf := map[string]string{}
+	getShare := func(requestor string) *requestorShare {
+		s, ok := shares[requestor]
+		if !ok {
+			s = &requestorShare{requestor: requestor, weight: requestorWeight(weights, requestor), exhausted: true}
+			shares[requestor] = s
+		}
+		return s
+	}
+	for _, job := range jobs {
+		requestorOf[job.Job.Id] = job.Job.Def.Requestor
+		getShare(job.Job.Def.Requestor).usage += float64(job.TasksRunning)
+	}
+	for _, task := range assigned {
+		getShare(requestorOf[task.JobId]).usage++
+	}
+	for _, p := range []sched.Priority{sched.P2, sched.P1, sched.P0} {
+		for _, job := range jobs {
+			if job.Job.Def.Priority != p {
+				continue
+			}
+			unsched := placeableTasks(job.getUnScheduledTasks(), canRun)
+			if len(unsched) == 0 {
+				continue
+			}
+			s := getShare(job.Job.Def.Requestor)
+			s.waiting = true
+			if len(s.queue) >= numFree {
+				// We won't assign more than numFree tasks, but remember there are more.
+				s.exhausted = false
+				continue
+			}
+			s.queue = append(s.queue, unsched...)
+		}
+	}
+	for r, limit := range limits {
+		if s, ok := shares[r]; ok && len(s.queue) > limit {
+			s.queue = s.queue[:limit]
+			s.exhausted = false
+		}
+	}
+
+	// Only requestors with waiting tasks compete for nodes.
+	active := []*requestorShare{}
+	totalNodes, totalWeight := float64(numFree), 0.0
+	for _, s := range shares {
+		if !s.waiting {
+			delete(deficits, s.requestor)
+			continue
+		}
+		active = append(active, s)
+		totalNodes += s.usage
+		totalWeight += s.weight
+	}
+	for r := range deficits {
+		if _, ok := shares[r]; !ok {
+			delete(deficits, r)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	sort.Sort(requestorSharesByName(active))
+	for _, s := range active {
+		s.entitled = totalNodes * s.weight / totalWeight
+	}
+
+	tasks := []*taskState{}
+	for len(tasks) < numFree {
+		var next *requestorShare
+		for _, s := range active {
+			if len(s.queue) > 0 && (next == nil || s.key(deficits[s.requestor]) < next.key(deficits[next.requestor])) {
+				next = s
+			}
+		}
+		if next == nil {
+			break
+		}
+		tasks = append(tasks, next.queue[0])
+		next.queue = next.queue[1:]
+		next.usage++
+	}
+
+	for _, s := range active {
+		if len(s.queue) == 0 && s.exhausted {
+			delete(deficits, s.requestor)
+			continue
+		}
+		deficit := deficits[s.requestor] + s.entitled - s.usage
+		deficits[s.requestor] = clamp(deficit, -s.entitled, s.entitled)
+		log.WithFields(
+			log.Fields{
+				"requestor": s.requestor,
+				"weight":    s.weight,
+				"entitled":  s.entitled,
+				"usage":     s.usage,
+				"deficit":   deficits[s.requestor],
+			}).Debug("Fair share")
+	}
+	return tasks
+}
+
+func clamp(n, lo, hi float64) float64 {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+func makeFairShareJob(jobId, requestor string, p sched.Priority, numTasks, numRunning int) *jobState {
+	js := &jobState{
+		Job:          &sched.Job{Id: jobId, Def: sched.JobDefinition{Requestor: requestor, Tag: jobId, Priority: p}},
+		TasksRunning: numRunning,
+	}
+	for i := 0; i < numTasks; i++ {
+		status := sched.NotStarted
+		if i < numRunning {
+			status = sched.InProgress
+		}
+		js.Tasks = append(js.Tasks, &taskState{JobId: jobId, TaskId: fmt.Sprintf("task%d", i), Status: status})
+	}
+	return js
+}
+
+func countByRequestor(jobs []*jobState, tasks []*taskState) map[string]int {
+	requestors := map[string]string{}
+	for _, js := range jobs {
+		requestors[js.Job.Id] = js.Job.Def.Requestor
+	}
+	counts := map[string]int{}
+	for _, t := range tasks {
+		counts[requestors[t.JobId]]++
+	}
+	return counts
+}
+
+func Test_FairShare_Weights(t *testing.T) {
+	// The big job was submitted first and would take every node in fifo order.
+	jobs := []*jobState{
+		makeFairShareJob("big", "teamA", sched.P0, 5000, 0),
+		makeFairShareJob("small", "teamB", sched.P0, 10, 0),
+		makeFairShareJob("medium", "teamC", sched.P0, 100, 0),
+	}
+	weights := map[string]float64{"teamC": 2}
+	deficits := map[string]float64{}
+
+	counts := countByRequestor(jobs, getFairShareTasks(jobs, nil, 40, weights, deficits, nil, nil))
+	if counts["teamA"] != 10 || counts["teamB"] != 10 || counts["teamC"] != 20 {
+		t.Errorf("Expected nodes split 1:1:2, got %v", counts)
+	}
+	if len(deficits) != 2 || deficits["teamA"] != 0 || deficits["teamC"] != 0 {
+		t.Errorf("Expected no deficits for requestors that got their share, got %v", deficits)
+	}
+
+	// Running tasks count towards the share, so a requestor already over its share gets nothing.
+	jobs = []*jobState{
+		makeFairShareJob("big", "teamA", sched.P0, 5000, 30),
+		makeFairShareJob("small", "teamB", sched.P0, 10, 0),
+	}
+	counts = countByRequestor(jobs, getFairShareTasks(jobs, nil, 10, nil, map[string]float64{}, nil, nil))
+	if counts["teamA"] != 0 || counts["teamB"] != 10 {
+		t.Errorf("Expected all free nodes to go to teamB, got %v", counts)
+	}
+}
+
+func Test_FairShare_PriorityWithinRequestor(t *testing.T) {
+	jobs := []*jobState{
+		makeFairShareJob("low", "teamA", sched.P0, 5, 0),
+		makeFairShareJob("high", "teamA", sched.P2, 5, 0),
+	}
+	tasks := getFairShareTasks(jobs, nil, 5, nil, map[string]float64{}, nil, nil)
+	for _, task := range tasks {
+		if task.JobId != "high" {
+			t.Fatalf("Expected only tasks from the higher priority job, got %s", task.JobId)
+		}
+	}
+}
+
+func Test_FairShare_Deficits(t *testing.T) {
+	// The cluster is busy with teamA's tasks, so teamB accumulates a deficit while it waits.
+	jobs := []*jobState{
+		makeFairShareJob("big", "teamA", sched.P0, 100, 10),
+		makeFairShareJob("small", "teamB", sched.P0, 10, 0),
+	}
+	deficits := map[string]float64{}
+	if tasks := getFairShareTasks(jobs, nil, 0, nil, deficits, nil, nil); len(tasks) != 0 {
+		t.Fatalf("Expected no tasks without free nodes, got %d", len(tasks))
+	}
+	if deficits["teamB"] != 5 || deficits["teamA"] != -5 {
+		t.Fatalf("Expected teamB to be owed 5 nodes by teamA, got %v", deficits)
+	}
+
+	// Once teamA frees some nodes, teamB makes up its deficit before teamA gets anything.
+	jobs[0] = makeFairShareJob("big", "teamA", sched.P0, 100, 6)
+	counts := countByRequestor(jobs, getFairShareTasks(jobs, nil, 4, nil, deficits, nil, nil))
+	if counts["teamB"] != 4 || counts["teamA"] != 0 {
+		t.Errorf("Expected all free nodes to go to teamB, got %v", counts)
+	}
+
+	// Deficits are dropped for requestors without waiting tasks.
+	jobs = jobs[:1]
+	getFairShareTasks(jobs, nil, 0, nil, deficits, nil, nil)
+	if _, ok := deficits["teamB"]; ok {
+		t.Errorf("Expected teamB's deficit to be dropped, got %v", deficits)
+	}
+}
+
+func Test_TaskAssignments_FairShare(t *testing.T) {
+	jobs := []*jobState{
+		makeFairShareJob("big", "teamA", sched.P0, 100, 0),
+		makeFairShareJob("urgent", "teamC", sched.P3, 2, 0),
+		makeFairShareJob("small", "teamB", sched.P0, 100, 0),
+	}
+	req := map[string][]*jobState{}
+	for _, js := range jobs {
+		req[js.Job.Def.Requestor] = append(req[js.Job.Def.Requestor], js)
+	}
+	nodes := []string{}
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, fmt.Sprintf("node%d", i))
+	}
+	testCluster := makeTestCluster(nodes...)
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+	config := &SchedulerConfig{SoftMaxSchedulableTasks: 10, FairShare: true}
+
+	assignments, _ := getTaskAssignments(cs, jobs, req, config, map[string]float64{}, nil, nil)
+	tasks := []*taskState{}
+	for _, a := range assignments {
+		tasks = append(tasks, a.task)
+	}
+	// Priority 3 tasks are scheduled first, and the remaining nodes are split evenly.
+	counts := countByRequestor(jobs, tasks)
+	if len(tasks) != 10 || counts["teamC"] != 2 || counts["teamA"] != 4 || counts["teamB"] != 4 {
+		t.Errorf("Expected 2 tasks for teamC and 4 each for teamA and teamB, got %v", counts)
+	}
+}
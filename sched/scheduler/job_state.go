@@ -4,8 +4,11 @@ import (
 	"math"
 	"time"
 
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/taskdurations"
+	"github.com/twitter/scoot/workerapi"
 )
 
 // Contains all the information for a job in progress
@@ -18,6 +21,12 @@ type jobState struct {
 	TasksCompleted int          //number of tasks that've been marked completed so far.
 	TasksRunning   int          //number of tasks that've been scheduled or started.
 	JobKilled      bool         //indicates the job was killed
+	SupersededBy   string       //id of the newer job with the same Requestor and Basis that killed this one, if any.
+	TasksPreempted int          //number of times one of this job's tasks was preempted by a higher priority job.
+
+	tasksById       map[string]*taskState //taskStates indexed by taskId, may be nil.
+	hasDependencies bool                  //true if any task in this job depends on another task.
+	watcher         *jobWatcher           //notified of task status changes, may be nil.
 }
 
 // Contains all the information for a specified task
@@ -29,7 +38,8 @@ type taskState struct {
 	TimeStarted   time.Time
 	NumTimesTried int
 	TaskRunner    *taskRunner
-	AvgDuration   time.Duration //average duration for previous runs with this taskId, if any.
+	AvgDuration   time.Duration     //average duration for previous runs with this taskId, if any.
+	Result        *runner.RunStatus //final status once the task is completed, if known.
 }
 
 type taskStatesByDuration []*taskState
@@ -47,7 +57,7 @@ func (s taskStatesByDuration) Less(i, j int) bool {
 // Creates a New Job State based on the specified Job and Saga
 // The jobState will reflect any previous progress made on this job and logged to the Sagalog
 // Note: taskDurations is optional and only used to enable sorts using taskStatesByDuration above.
-func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]averageDuration) *jobState {
+func newJobState(job *sched.Job, saga *saga.Saga, taskDurations taskdurations.Store) *jobState {
 	j := &jobState{
 		Job:            job,
 		Saga:           saga,
@@ -56,10 +66,17 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 		TasksCompleted: 0,
 		TasksRunning:   0,
 		JobKilled:      false,
+		tasksById:      make(map[string]*taskState),
 	}
 
 	for _, taskDef := range job.Def.Tasks {
-		duration := taskDurations[taskDef.TaskID].duration // This is safe since the map value is not a pointer.
+		var duration time.Duration
+		if taskDurations != nil {
+			key := taskdurations.Key{TaskID: taskDef.TaskID, JobType: job.Def.JobType}
+			if st, err := taskDurations.Get(key); err == nil && st != nil {
+				duration = st.Average
+			}
+		}
 		if duration == 0 {
 			duration = math.MaxInt64 // Set max duration if we don't have the average duration.
 		}
@@ -73,16 +90,25 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 			AvgDuration:   duration,
 		}
 		j.Tasks = append(j.Tasks, task)
+		j.tasksById[task.TaskId] = task
+		if len(taskDef.Dependencies) > 0 {
+			j.hasDependencies = true
+		}
 	}
 
 	// Assumes Forward Recovery only, tasks are either
 	// done or not done.  Scheduler currently doesn't support
 	// scheduling compensating tasks.  In Progress tasks
 	// are considered not done and will be rescheduled.
-	for _, taskId := range saga.GetState().GetTaskIds() {
-		if saga.GetState().IsTaskCompleted(taskId) {
-			j.getTask(taskId).Status = sched.Completed
-			j.TasksCompleted++
+	// The results of completed tasks are recovered so that tasks depending on them can be released or skipped.
+	state := saga.GetState()
+	for _, taskId := range state.GetTaskIds() {
+		if state.IsTaskCompleted(taskId) {
+			var result *runner.RunStatus
+			if st, err := workerapi.DeserializeProcessStatus(state.GetEndTaskData(taskId)); err == nil {
+				result = &st
+			}
+			j.taskCompleted(taskId, false, result)
 		}
 	}
 
@@ -91,6 +117,9 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 
 // Helper, assumes that taskId is present given a consistent jobState.
 func (j *jobState) getTask(taskId string) *taskState {
+	if j.tasksById != nil {
+		return j.tasksById[taskId]
+	}
 	for _, task := range j.Tasks {
 		if task.TaskId == taskId {
 			return task
@@ -100,12 +129,13 @@ func (j *jobState) getTask(taskId string) *taskState {
 }
 
 // Returns a list of taskIds that can be scheduled currently.
+// Tasks are only schedulable once all of their dependencies have completed successfully.
 func (j *jobState) getUnScheduledTasks() []*taskState {
 
 	var tasksToRun []*taskState
 
 	for _, state := range j.Tasks {
-		if state.Status == sched.NotStarted {
+		if state.Status == sched.NotStarted && j.dependenciesSucceeded(state) {
 			tasksToRun = append(tasksToRun, state)
 		}
 	}
@@ -121,19 +151,32 @@ func (j *jobState) taskStarted(taskId string, tr *taskRunner) {
 	taskState.TaskRunner = tr
 	taskState.NumTimesTried++
 	j.TasksRunning++
+	j.watcher.taskChanged(j.Job.Id, taskState)
 }
 
 // Update JobState to reflect that a Task has been completed
 // Running param: true if taskStarted was called for this taskId.
-func (j *jobState) taskCompleted(taskId string, running bool) {
+// Result param: the final status of the task, or nil if unknown.
+func (j *jobState) taskCompleted(taskId string, running bool, result *runner.RunStatus) {
 	taskState := j.getTask(taskId)
 	taskState.Status = sched.Completed
 	taskState.TimeStarted = nilTime
 	taskState.TaskRunner = nil
+	taskState.Result = result
 	j.TasksCompleted++
 	if running {
 		j.TasksRunning--
 	}
+	j.watcher.taskChanged(j.Job.Id, taskState)
+
+	// Tasks that consume the output of this task run against its snapshot.
+	if j.hasDependencies && taskState.succeeded() {
+		for _, t := range j.Tasks {
+			if t.Def.SnapshotFromTask == taskId {
+				t.Def.SnapshotID = result.SnapshotID
+			}
+		}
+	}
 }
 
 // Update JobState to reflect that an error has occurred running this Task
@@ -143,9 +186,39 @@ func (j *jobState) errorRunningTask(taskId string, err error, preempted bool) {
 	taskState.TimeStarted = nilTime
 	taskState.TaskRunner = nil
 	j.TasksRunning--
+	// Preemption isn't the task's fault, so it doesn't count towards MaxRetriesPerTask.
 	if preempted {
 		taskState.NumTimesTried--
+		j.TasksPreempted++
+	}
+	j.watcher.taskChanged(j.Job.Id, taskState)
+}
+
+// Returns true if all of the task's dependencies have completed successfully.
+func (j *jobState) dependenciesSucceeded(t *taskState) bool {
+	for _, dep := range t.Def.Dependencies {
+		if !j.getTask(dep).succeeded() {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the taskId of a dependency that completed unsuccessfully, in which case the task
+// can never run, or "" if there is none.
+func (j *jobState) failedDependency(t *taskState) string {
+	for _, dep := range t.Def.Dependencies {
+		if d := j.getTask(dep); d.Status == sched.Completed && !d.succeeded() {
+			return dep
+		}
 	}
+	return ""
+}
+
+// Returns true if the task completed with a zero exit code.
+func (t *taskState) succeeded() bool {
+	return t.Status == sched.Completed && t.Result != nil &&
+		t.Result.State == runner.COMPLETE && t.Result.ExitCode == 0
 }
 
 // Returns the Current Job Status
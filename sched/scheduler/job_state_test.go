@@ -1,11 +1,18 @@
 package scheduler
 
 import (
+	"math"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga/sagalogs"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/taskdurations"
 	"github.com/twitter/scoot/tests/testhelpers"
+	"github.com/twitter/scoot/workerapi"
 )
 
 func Test_GetUnscheduledTasks_ReturnsAllUnscheduledTasks(t *testing.T) {
@@ -56,3 +63,66 @@ func Test_NewJobState_PreviousProgress_CompletedTasks(t *testing.T) {
 		t.Errorf("Expected all Tasks to be completed")
 	}
 }
+
+func Test_NewJobState_PreviousProgress_Dependencies(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 3)
+	a, b, c := job.Def.Tasks[0].TaskID, job.Def.Tasks[1].TaskID, job.Def.Tasks[2].TaskID
+	job.Def.Tasks[1].Dependencies = []string{a}
+	job.Def.Tasks[1].SnapshotFromTask = a
+	job.Def.Tasks[2].Dependencies = []string{b}
+	jobAsBytes, _ := job.Serialize()
+
+	// Mark the first task as completed successfully, then create jobState
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	st := runner.CompleteStatus("run", "snapA", 0, tags.LogTags{JobID: job.Id, TaskID: a})
+	stAsBytes, _ := workerapi.SerializeProcessStatus(st)
+	saga.StartTask(a, nil)
+	saga.EndTask(a, stAsBytes)
+	jobState := newJobState(&job, saga, nil)
+
+	tasks := jobState.getUnScheduledTasks()
+	if len(tasks) != 1 || tasks[0].TaskId != b {
+		t.Fatalf("Expected only %s to be unscheduled, got %+v", b, tasks)
+	}
+	if tasks[0].Def.SnapshotID != "snapA" {
+		t.Errorf("Expected %s to use the snapshot of %s, got %s", b, a, tasks[0].Def.SnapshotID)
+	}
+
+	// A failed dependency makes its dependents unschedulable
+	failed := runner.CompleteStatus("run", "snapB", 1, tags.LogTags{JobID: job.Id, TaskID: b})
+	jobState.taskCompleted(b, false, &failed)
+	if tasks := jobState.getUnScheduledTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no unscheduled tasks, got %+v", tasks)
+	}
+	if dep := jobState.failedDependency(jobState.getTask(c)); dep != b {
+		t.Errorf("Expected failed dependency %s for %s, got %q", b, c, dep)
+	}
+}
+
+func Test_NewJobState_TaskDurations(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 3)
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+
+	durations := taskdurations.NewMemoryStore(0, 0)
+	short, long := job.Def.Tasks[0].TaskID, job.Def.Tasks[1].TaskID
+	durations.Record(taskdurations.Key{TaskID: short, JobType: job.Def.JobType}, time.Second)
+	durations.Record(taskdurations.Key{TaskID: long, JobType: job.Def.JobType}, time.Minute)
+	durations.Record(taskdurations.Key{TaskID: long, JobType: job.Def.JobType}, 3*time.Minute)
+	// Durations from other job types don't apply.
+	durations.Record(taskdurations.Key{TaskID: short, JobType: "other"}, time.Hour)
+
+	jobState := newJobState(&job, saga, durations)
+	sort.Sort(sort.Reverse(taskStatesByDuration(jobState.Tasks)))
+
+	// Tasks without history are assumed to be the longest.
+	if jobState.Tasks[0].AvgDuration != math.MaxInt64 {
+		t.Errorf("Expected task without history first, got %s %v", jobState.Tasks[0].TaskId, jobState.Tasks[0].AvgDuration)
+	}
+	if jobState.Tasks[1].TaskId != long || jobState.Tasks[1].AvgDuration != 2*time.Minute {
+		t.Errorf("Expected %s second with a 2m average, got %s %v", long, jobState.Tasks[1].TaskId, jobState.Tasks[1].AvgDuration)
+	}
+	if jobState.Tasks[2].TaskId != short || jobState.Tasks[2].AvgDuration != time.Second {
+		t.Errorf("Expected %s last with a 1s average, got %s %v", short, jobState.Tasks[2].TaskId, jobState.Tasks[2].AvgDuration)
+	}
+}
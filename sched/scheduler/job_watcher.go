@@ -0,0 +1,265 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+)
+
+// The changes made to a job after a given version, as returned by WatchJob.
+type JobUpdate struct {
+	JobId   string
+	Version int64        // Pass to the next WatchJob call to get only the changes made after this update.
+	Status  sched.Status // Current status of the job.
+	Tasks   map[string]TaskUpdate
+
+	SupersededBy string // Id of the newer job with the same Requestor and Basis that killed this one, if any.
+}
+
+// The current state of a task that changed.
+type TaskUpdate struct {
+	Status sched.Status
+	Result *runner.RunStatus // Final status of a completed task, if known.
+}
+
+// Selects the jobs returned by ListJobs. Zero values match any job.
+type JobFilter struct {
+	Requestor string
+	Tag       string
+	Basis     string
+	Priority  *sched.Priority
+	Status    *sched.Status
+	Offset    int // Number of matching jobs to skip.
+	Limit     int // Maximum number of jobs to return, or zero for no limit.
+}
+
+// Describes a job known to the scheduler, as returned by ListJobs.
+type JobSummary struct {
+	JobId        string
+	Requestor    string
+	Tag          string
+	Basis        string
+	Priority     sched.Priority
+	Status       sched.Status // NotStarted until one of its tasks is scheduled.
+	SubmitTime   time.Time    // When the job was added to this scheduler instance.
+	NumTasks     int
+	NumRunning   int
+	NumCompleted int
+}
+
+// Tracks the status of in progress jobs so that clients can wait for changes instead of
+// polling and replaying the saga log. Written by the scheduler loop, read by WatchJob callers.
+type jobWatcher struct {
+	mu   sync.Mutex
+	jobs map[string]*watchedJob
+
+	// Version of newly added jobs. Based on the start time so that a version handed out by a previous
+	// scheduler instance is older than anything handed out by this one, and clients get a full update.
+	firstVersion int64
+}
+
+type watchedJob struct {
+	version      int64
+	status       sched.Status
+	tasks        map[string]*watchedTask
+	changed      chan struct{} // Closed and replaced each time the version is bumped.
+	supersededBy string
+
+	def       sched.JobDefinition // Only the requestor, tag, basis, and priority are used.
+	submitted time.Time
+}
+
+type watchedTask struct {
+	version int64 // Job version at which this task last changed.
+	status  sched.Status
+	result  *runner.RunStatus
+}
+
+func newJobWatcher() *jobWatcher {
+	return &jobWatcher{jobs: make(map[string]*watchedJob), firstVersion: time.Now().UnixNano()}
+}
+
+// Starts tracking a job with the current status of all of its tasks.
+func (w *jobWatcher) addJob(js *jobState) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job := &watchedJob{
+		version: w.firstVersion,
+		status:  sched.InProgress,
+		tasks:   make(map[string]*watchedTask),
+		changed: make(chan struct{}),
+		def: sched.JobDefinition{
+			Requestor: js.Job.Def.Requestor,
+			Tag:       js.Job.Def.Tag,
+			Basis:     js.Job.Def.Basis,
+			Priority:  js.Job.Def.Priority,
+		},
+		submitted: time.Now(),
+	}
+	for _, t := range js.Tasks {
+		job.tasks[t.TaskId] = &watchedTask{version: job.version, status: t.Status, result: t.Result}
+	}
+	w.jobs[js.Job.Id] = job
+}
+
+// Records the current status of a task. Safe to call with a nil watcher.
+func (w *jobWatcher) taskChanged(jobId string, t *taskState) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job, ok := w.jobs[jobId]
+	if !ok {
+		return
+	}
+	job.bump()
+	job.tasks[t.TaskId] = &watchedTask{version: job.version, status: t.Status, result: t.Result}
+}
+
+// Records that the job was killed by a newer job with the same requestor and basis.
+func (w *jobWatcher) jobSuperseded(jobId, newJobId string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job, ok := w.jobs[jobId]
+	if !ok {
+		return
+	}
+	job.bump()
+	job.supersededBy = newJobId
+}
+
+// Marks the job as finished, wakes up any waiters, and stops tracking it.
+// Later watches fall back to the saga log.
+func (w *jobWatcher) jobCompleted(jobId string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job, ok := w.jobs[jobId]
+	if !ok {
+		return
+	}
+	job.status = sched.Completed
+	job.bump()
+	delete(w.jobs, jobId)
+}
+
+// Waits up to timeout for the job to change after version, then returns the tasks that changed since then.
+// Returns nil if the job isn't being tracked.
+func (w *jobWatcher) watch(jobId string, version int64, timeout time.Duration) *JobUpdate {
+	w.mu.Lock()
+	job, ok := w.jobs[jobId]
+	if !ok {
+		w.mu.Unlock()
+		return nil
+	}
+	if job.version <= version && timeout > 0 {
+		changed := job.changed
+		w.mu.Unlock()
+		select {
+		case <-changed:
+		case <-time.After(timeout):
+		}
+		w.mu.Lock()
+	}
+	defer w.mu.Unlock()
+
+	update := &JobUpdate{
+		JobId:        jobId,
+		Version:      job.version,
+		Status:       job.status,
+		Tasks:        make(map[string]TaskUpdate),
+		SupersededBy: job.supersededBy,
+	}
+	for id, t := range job.tasks {
+		if t.version > version {
+			update.Tasks[id] = TaskUpdate{Status: t.status, Result: t.result}
+		}
+	}
+	return update
+}
+
+// Returns the jobs matching the filter ordered by submit time, along with the total number of
+// matching jobs before the offset and limit are applied.
+func (w *jobWatcher) list(filter JobFilter) ([]JobSummary, int) {
+	w.mu.Lock()
+	matches := []JobSummary{}
+	for id, job := range w.jobs {
+		summary := job.summarize(id)
+		if filter.matches(summary) {
+			matches = append(matches, summary)
+		}
+	}
+	w.mu.Unlock()
+
+	sort.Sort(jobSummariesBySubmitTime(matches))
+	total := len(matches)
+	if filter.Offset >= total {
+		return []JobSummary{}, total
+	}
+	matches = matches[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(matches) {
+		matches = matches[:filter.Limit]
+	}
+	return matches, total
+}
+
+type jobSummariesBySubmitTime []JobSummary
+
+func (s jobSummariesBySubmitTime) Len() int {
+	return len(s)
+}
+func (s jobSummariesBySubmitTime) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s jobSummariesBySubmitTime) Less(i, j int) bool {
+	if !s[i].SubmitTime.Equal(s[j].SubmitTime) {
+		return s[i].SubmitTime.Before(s[j].SubmitTime)
+	}
+	return s[i].JobId < s[j].JobId
+}
+
+func (f JobFilter) matches(s JobSummary) bool {
+	return (f.Requestor == "" || f.Requestor == s.Requestor) &&
+		(f.Tag == "" || f.Tag == s.Tag) &&
+		(f.Basis == "" || f.Basis == s.Basis) &&
+		(f.Priority == nil || *f.Priority == s.Priority) &&
+		(f.Status == nil || *f.Status == s.Status)
+}
+
+func (j *watchedJob) summarize(id string) JobSummary {
+	s := JobSummary{
+		JobId:      id,
+		Requestor:  j.def.Requestor,
+		Tag:        j.def.Tag,
+		Basis:      j.def.Basis,
+		Priority:   j.def.Priority,
+		SubmitTime: j.submitted,
+		NumTasks:   len(j.tasks),
+	}
+	for _, t := range j.tasks {
+		switch t.status {
+		case sched.InProgress:
+			s.NumRunning++
+		case sched.Completed:
+			s.NumCompleted++
+		}
+	}
+	switch {
+	case s.NumTasks > 0 && s.NumCompleted == s.NumTasks:
+		s.Status = sched.Completed
+	case s.NumRunning == 0 && s.NumCompleted == 0:
+		s.Status = sched.NotStarted
+	default:
+		s.Status = sched.InProgress
+	}
+	return s
+}
+
+func (j *watchedJob) bump() {
+	j.version++
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
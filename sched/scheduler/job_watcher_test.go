@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/tests/testhelpers"
+)
+
+func makeWatchedJobState(t *testing.T, w *jobWatcher, numTasks int) *jobState {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), numTasks)
+	jobAsBytes, _ := job.Serialize()
+	saga, err := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	if err != nil {
+		t.Fatalf("Unexpected error making saga: %v", err)
+	}
+	js := newJobState(&job, saga, nil)
+	js.watcher = w
+	w.addJob(js)
+	return js
+}
+
+func Test_JobWatcher_Updates(t *testing.T) {
+	w := newJobWatcher()
+	js := makeWatchedJobState(t, w, 2)
+	jobId := js.Job.Id
+
+	if update := w.watch("unknown", 0, 0); update != nil {
+		t.Fatalf("Expected nil update for unknown job, got %+v", update)
+	}
+
+	// The first watch returns every task.
+	update := w.watch(jobId, 0, 0)
+	if update == nil || len(update.Tasks) != 2 || update.Status != sched.InProgress {
+		t.Fatalf("Expected full update with 2 tasks, got %+v", update)
+	}
+	for _, task := range update.Tasks {
+		if task.Status != sched.NotStarted {
+			t.Errorf("Expected NotStarted task, got %v", task.Status)
+		}
+	}
+
+	// Nothing changed, so waiting times out with no tasks.
+	if next := w.watch(jobId, update.Version, 10*time.Millisecond); len(next.Tasks) != 0 || next.Version != update.Version {
+		t.Fatalf("Expected empty update at version %d, got %+v", update.Version, next)
+	}
+
+	// A change wakes up a waiting watch and only the changed task is returned.
+	taskId := js.Tasks[0].TaskId
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		js.taskStarted(taskId, nil)
+	}()
+	next := w.watch(jobId, update.Version, time.Minute)
+	if len(next.Tasks) != 1 || next.Tasks[taskId].Status != sched.InProgress {
+		t.Fatalf("Expected only %s to be InProgress, got %+v", taskId, next)
+	}
+
+	st := runner.CompleteStatus("", "snap", 0, tags.LogTags{JobID: jobId, TaskID: taskId})
+	js.taskCompleted(taskId, true, &st)
+	last := w.watch(jobId, next.Version, 0)
+	if task := last.Tasks[taskId]; task.Status != sched.Completed || task.Result == nil || task.Result.SnapshotID != "snap" {
+		t.Fatalf("Expected %s to be Completed with its result, got %+v", taskId, task)
+	}
+
+	// A completed job wakes up waiters with its final status and is no longer watched.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.jobCompleted(jobId)
+	}()
+	if final := w.watch(jobId, last.Version, time.Minute); final.Status != sched.Completed {
+		t.Fatalf("Expected Completed job status, got %v", final.Status)
+	}
+	if update := w.watch(jobId, 0, 0); update != nil {
+		t.Fatalf("Expected nil update for completed job, got %+v", update)
+	}
+}
+
+func Test_JobWatcher_List(t *testing.T) {
+	w := newJobWatcher()
+	first := makeWatchedJobState(t, w, 2)
+	second := makeWatchedJobState(t, w, 1)
+	w.jobs[second.Job.Id].submitted = w.jobs[first.Job.Id].submitted.Add(time.Second)
+	first.taskStarted(first.Tasks[0].TaskId, nil)
+
+	jobs, total := w.list(JobFilter{})
+	if total != 2 || len(jobs) != 2 || jobs[0].JobId != first.Job.Id || jobs[1].JobId != second.Job.Id {
+		t.Fatalf("Expected both jobs ordered by submit time, got %d %+v", total, jobs)
+	}
+	if jobs[0].Status != sched.InProgress || jobs[0].NumTasks != 2 || jobs[0].NumRunning != 1 {
+		t.Errorf("Expected first job in progress with 1 of 2 tasks running, got %+v", jobs[0])
+	}
+	if jobs[1].Status != sched.NotStarted || jobs[1].NumRunning != 0 {
+		t.Errorf("Expected second job not started, got %+v", jobs[1])
+	}
+
+	notStarted := sched.NotStarted
+	if jobs, total := w.list(JobFilter{Status: &notStarted}); total != 1 || jobs[0].JobId != second.Job.Id {
+		t.Errorf("Expected only the second job to be not started, got %d %+v", total, jobs)
+	}
+	if jobs, total := w.list(JobFilter{Offset: 1, Limit: 1}); total != 2 || len(jobs) != 1 || jobs[0].JobId != second.Job.Id {
+		t.Errorf("Expected the second page to hold the second job, got %d %+v", total, jobs)
+	}
+	if jobs, total := w.list(JobFilter{Requestor: "nobody"}); total != 0 || len(jobs) != 0 {
+		t.Errorf("Expected no jobs for unknown requestor, got %d %+v", total, jobs)
+	}
+}
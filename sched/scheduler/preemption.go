@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+)
+
+// Limits which lower priority tasks a priority 3 job can kill to take their node, so that work
+// that's likely to be worth keeping isn't discarded. Zero values disable the corresponding limit.
+// MinRuntime -
+//     tasks that have run for less than this aren't preemptible yet.
+// MaxJobFraction -
+//     the fraction of a job's tasks, rounded up, that can be preempted over the life of the job.
+//     Every preemption counts, including repeated preemptions of the same task.
+// NearCompletionFraction -
+//     tasks that have run for at least this fraction of their average recorded duration are close
+//     to completing and aren't preempted. Tasks without a recorded duration are unaffected.
+type PreemptionPolicy struct {
+	MinRuntime             time.Duration
+	MaxJobFraction         float64
+	NearCompletionFraction float64
+}
+
+// Returns true if the running task may be preempted at the given time.
+func (p *PreemptionPolicy) preemptible(t *taskState, now time.Time) bool {
+	runtime := now.Sub(t.TimeStarted)
+	if p.MinRuntime > 0 && runtime < p.MinRuntime {
+		return false
+	}
+	if p.NearCompletionFraction > 0 && t.AvgDuration > 0 && t.AvgDuration != math.MaxInt64 &&
+		float64(runtime) >= p.NearCompletionFraction*float64(t.AvgDuration) {
+		return false
+	}
+	return true
+}
+
+// Returns the number of the job's tasks that can still be preempted.
+func (p *PreemptionPolicy) remainingPreemptions(j *jobState) int {
+	if p.MaxJobFraction <= 0 {
+		return len(j.Tasks)
+	}
+	allowed := int(math.Ceil(p.MaxJobFraction * float64(len(j.Tasks))))
+	return max(0, allowed-j.TasksPreempted)
+}
+
+// Returns the running tasks of the given job that the policy allows to be preempted,
+// capped by remainingPreemptions in favor of the most recently started tasks.
+func (p *PreemptionPolicy) getPreemptibleTasks(j *jobState, now time.Time) KillableTasks {
+	ts := KillableTasks{}
+	for _, t := range j.Tasks {
+		if t.Status == sched.InProgress && p.preemptible(t, now) {
+			ts = append(ts, t)
+		}
+	}
+	if remaining := p.remainingPreemptions(j); len(ts) > remaining {
+		sort.Sort(ts)
+		ts = ts[:remaining]
+	}
+	return ts
+}
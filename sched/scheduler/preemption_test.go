@@ -0,0 +1,194 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+)
+
+func Test_PreemptionPolicy_Preemptible(t *testing.T) {
+	now := time.Now()
+	task := &taskState{Status: sched.InProgress, TimeStarted: now.Add(-10 * time.Minute), AvgDuration: math.MaxInt64}
+
+	p := &PreemptionPolicy{}
+	if !p.preemptible(task, now) {
+		t.Errorf("Expected all tasks to be preemptible without a policy")
+	}
+
+	p.MinRuntime = 15 * time.Minute
+	if p.preemptible(task, now) {
+		t.Errorf("Expected task to not be preemptible before MinRuntime")
+	}
+
+	p = &PreemptionPolicy{NearCompletionFraction: .8}
+	if !p.preemptible(task, now) {
+		t.Errorf("Expected task without a recorded duration to be preemptible")
+	}
+	task.AvgDuration = 12 * time.Minute
+	if p.preemptible(task, now) {
+		t.Errorf("Expected task close to its expected completion to not be preemptible")
+	}
+	task.AvgDuration = time.Hour
+	if !p.preemptible(task, now) {
+		t.Errorf("Expected task far from its expected completion to be preemptible")
+	}
+}
+
+func Test_PreemptionPolicy_MaxJobFraction(t *testing.T) {
+	now := time.Now()
+	js := makeFairShareJob("job1", "", sched.P0, 10, 4)
+	for i, task := range js.Tasks[:4] {
+		task.TimeStarted = now.Add(-time.Duration(i) * time.Minute)
+	}
+	js.TasksPreempted = 1
+
+	p := &PreemptionPolicy{MaxJobFraction: .25}
+	if n := p.remainingPreemptions(js); n != 2 {
+		t.Fatalf("Expected 2 more preemptions (3 allowed, 1 used), got %d", n)
+	}
+	tasks := p.getPreemptibleTasks(js, now)
+	if len(tasks) != 2 || tasks[0].TaskId != "task0" || tasks[1].TaskId != "task1" {
+		t.Errorf("Expected the two most recently started tasks, got %v", tasks)
+	}
+
+	js.TasksPreempted = 3
+	if tasks := p.getPreemptibleTasks(js, now); len(tasks) != 0 {
+		t.Errorf("Expected no preemptible tasks once the job used its allowance, got %d", len(tasks))
+	}
+}
+
+func Test_TaskAssignments_PreemptionPolicy(t *testing.T) {
+	nodes := []string{}
+	for i := 0; i < 4; i++ {
+		nodes = append(nodes, fmt.Sprintf("node%d", i))
+	}
+	testCluster := makeTestCluster(nodes...)
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+
+	// Fill the cluster with a P0 job, two of whose tasks just started.
+	now := time.Now()
+	running := makeFairShareJob("running", "", sched.P0, 4, 4)
+	for i, task := range running.Tasks {
+		nodeId := cluster.NodeId(nodes[i])
+		cs.taskScheduled(nodeId, task.JobId, task.TaskId, "", runner.Resources{})
+		task.TaskRunner = &taskRunner{nodeSt: cs.nodes[nodeId]}
+		task.TimeStarted = now.Add(-time.Hour)
+		if i < 2 {
+			task.TimeStarted = now
+		}
+	}
+	urgent := makeFairShareJob("urgent", "", sched.P3, 4, 0)
+	jobs := []*jobState{running, urgent}
+	req := map[string][]*jobState{"": jobs}
+
+	config := &SchedulerConfig{SoftMaxSchedulableTasks: 10, Preemption: PreemptionPolicy{MinRuntime: time.Minute}}
+	assignments, _ := getTaskAssignments(cs, jobs, req, config, nil, nil, nil)
+	if len(assignments) != 2 {
+		t.Fatalf("Expected only the two tasks that ran past MinRuntime to be preempted, got %d", len(assignments))
+	}
+	for _, a := range assignments {
+		if a.running == nil || a.running.TimeStarted.Equal(now) {
+			t.Errorf("Expected a task past MinRuntime to be preempted, got %+v", a.running)
+		}
+	}
+}
+
+func Test_JobState_PreemptionDoesntCountAsRetry(t *testing.T) {
+	js := makeFairShareJob("job1", "", sched.P0, 1, 0)
+	js.taskStarted("task0", nil)
+	js.errorRunningTask("task0", fmt.Errorf("preempted"), true)
+	if js.Tasks[0].NumTimesTried != 0 || js.TasksPreempted != 1 {
+		t.Errorf("Expected preemption to not count as a try, got tries:%d preempted:%d",
+			js.Tasks[0].NumTimesTried, js.TasksPreempted)
+	}
+	js.taskStarted("task0", nil)
+	js.errorRunningTask("task0", fmt.Errorf("failed"), false)
+	if js.Tasks[0].NumTimesTried != 1 || js.TasksPreempted != 1 {
+		t.Errorf("Expected failure to count as a try, got tries:%d preempted:%d",
+			js.Tasks[0].NumTimesTried, js.TasksPreempted)
+	}
+}
+
+// Preemptions aren't retries, so a task that's preempted more than MaxRetriesPerTask times still runs to completion.
+func Test_StatefulScheduler_PreemptedMoreThanMaxRetries(t *testing.T) {
+	deps, _ := getDepsWithPausingWorker()
+	cl := makeTestCluster("node1")
+	deps.initialCl, deps.clUpdates = cl.nodes, cl.ch
+	deps.config.MaxRetriesPerTask = 1
+	s := makeStatefulSchedulerDeps(deps)
+
+	scheduleJob := func(p sched.Priority, argv ...string) string {
+		jobDef := sched.GenJobDef(1)
+		jobDef.Priority = p
+		jobDef.Tasks[0].Argv = argv
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		jobId, err := s.ScheduleJob(jobDef)
+		if err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		return jobId
+	}
+
+	lowId := scheduleJob(sched.P0, "sleep 300", "complete 0")
+	s.step()
+	low := s.getJob(lowId)
+	numPreemptions := deps.config.MaxRetriesPerTask + 2
+	for i := 0; i < numPreemptions; i++ {
+		for low.TasksRunning == 0 {
+			s.step()
+		}
+		scheduleJob(sched.P3, "complete 0")
+		for low.TasksPreempted == i {
+			s.step()
+		}
+	}
+	for low.getJobStatus() != sched.Completed {
+		s.step()
+	}
+
+	task := low.Tasks[0]
+	if low.TasksPreempted != numPreemptions {
+		t.Errorf("Expected %d preemptions, got %d", numPreemptions, low.TasksPreempted)
+	}
+	if task.Result == nil || task.Result.State != runner.COMPLETE || task.Result.ExitCode != 0 {
+		t.Errorf("Expected the preempted task to run to completion, got %+v", task.Result)
+	}
+	if task.NumTimesTried != 1 {
+		t.Errorf("Expected only the completed run to count as a try, got %d", task.NumTimesTried)
+	}
+}
+
+func Test_TaskAssignments_OnlyP3Preempts(t *testing.T) {
+	for _, p := range []sched.Priority{sched.P0, sched.P1, sched.P2, sched.P3} {
+		// The small node is free but can't run the new task, the big node is running a P2 task.
+		testCluster := makeTestCluster("small", "big")
+		cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+		cs.nodes["small"].capacity = runner.Capacity{Slots: 1, MemoryBytes: 1 << 30}
+		cs.nodes["big"].capacity = runner.Capacity{Slots: 1, MemoryBytes: 64 << 30}
+		running := makeFairShareJob("running", "", sched.P2, 1, 1)
+		cs.taskScheduled("big", "running", "task0", "", runner.Resources{})
+		running.Tasks[0].TaskRunner = &taskRunner{nodeSt: cs.nodes["big"]}
+
+		waiting := makeFairShareJob("waiting", "", p, 1, 0)
+		waiting.Tasks[0].Def.Resources = runner.Resources{MemoryBytes: 8 << 30}
+		jobs := []*jobState{running, waiting}
+		req := map[string][]*jobState{"": jobs}
+
+		assignments, _ := getTaskAssignments(cs, jobs, req, nil, nil, nil, nil)
+		if p != sched.P3 && len(assignments) != 0 {
+			t.Errorf("Expected a P%d task to wait rather than preempt, got %d assignments", p, len(assignments))
+		}
+		if p == sched.P3 && (len(assignments) != 1 || assignments[0].running != running.Tasks[0]) {
+			t.Errorf("Expected a P3 task to preempt the running P2 task, got %d assignments", len(assignments))
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+)
+
+// Default length of the rolling window over which RequestorQuota.MaxNodeHours is enforced.
+const DefaultQuotaWindow = 24 * time.Hour
+
+// Suggested retry delay for a queued task quota when there's no task history to estimate it from.
+const DefaultQuotaRetryAfter = time.Minute
+
+// Limits on the resources a single requestor can use, zero means unlimited.
+// MaxRunningTasks -
+//     the number of tasks the requestor can run concurrently, the scheduler stops starting
+//     the requestor's tasks at this boundary.
+// MaxQueuedTasks -
+//     the number of tasks the requestor can have waiting to run, job submissions that would
+//     exceed this are rejected.
+// MaxNodeHours -
+//     node time used by the requestor's tasks that ended within SchedulerConfig.QuotaWindow,
+//     plus the time its running tasks have used so far. Once reached, job submissions are
+//     rejected and no more of the requestor's tasks are started until enough usage ages out.
+type RequestorQuota struct {
+	MaxRunningTasks int
+	MaxQueuedTasks  int
+	MaxNodeHours    float64
+}
+
+// Returned by ScheduleJob when a job can't be accepted until the requestor's usage drops,
+// RetryAfter is our best estimate of when that will be.
+type QuotaExceededError struct {
+	Requestor  string
+	Quota      string
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("Exceeds %s quota for requestor %s, retry after %v", e.Quota, e.Requestor, e.RetryAfter)
+}
+
+// Returns the configured quota for the given requestor, or DefaultRequestorQuota if it has none.
+func (c *SchedulerConfig) quotaFor(requestor string) RequestorQuota {
+	if q, ok := c.RequestorQuotas[requestor]; ok {
+		return q
+	}
+	return c.DefaultRequestorQuota
+}
+
+type nodeUsage struct {
+	end time.Time
+	d   time.Duration
+}
+
+// Tracks the node time used by each requestor's tasks that ended within the quota window.
+// A task's whole duration counts towards the window it ended in.
+// Only accessed from the scheduler loop, so not safe for concurrent use.
+type quotaUsage struct {
+	window time.Duration
+	usage  map[string][]nodeUsage // ordered by end time.
+}
+
+func newQuotaUsage(window time.Duration) *quotaUsage {
+	if window <= 0 {
+		window = DefaultQuotaWindow
+	}
+	return &quotaUsage{window: window, usage: map[string][]nodeUsage{}}
+}
+
+func (q *quotaUsage) record(requestor string, end time.Time, d time.Duration) {
+	q.usage[requestor] = append(q.usage[requestor], nodeUsage{end, d})
+}
+
+// Drops usage that has aged out of the window.
+func (q *quotaUsage) expire(now time.Time) {
+	for r, us := range q.usage {
+		i := 0
+		for i < len(us) && now.Sub(us[i].end) >= q.window {
+			i++
+		}
+		if i == len(us) {
+			delete(q.usage, r)
+		} else {
+			q.usage[r] = us[i:]
+		}
+	}
+}
+
+// Returns the node time used by the requestor's tasks that ended within the window.
+func (q *quotaUsage) used(requestor string) time.Duration {
+	total := time.Duration(0)
+	for _, u := range q.usage[requestor] {
+		total += u.d
+	}
+	return total
+}
+
+// Returns the average duration of the requestor's tasks that ended within the window, or zero.
+func (q *quotaUsage) averageDuration(requestor string) time.Duration {
+	us := q.usage[requestor]
+	if len(us) == 0 {
+		return 0
+	}
+	return q.used(requestor) / time.Duration(len(us))
+}
+
+// Returns how long until the requestor's ended task usage, plus the given usage of running tasks,
+// drops below limit as ended tasks age out of the window. Running tasks only age out a full window
+// after they end, so if they alone reach the limit we return the window.
+func (q *quotaUsage) untilBelow(requestor string, running, limit time.Duration, now time.Time) time.Duration {
+	total := q.used(requestor) + running
+	if total < limit {
+		return 0
+	}
+	if running >= limit {
+		return q.window
+	}
+	for _, u := range q.usage[requestor] {
+		total -= u.d
+		if total < limit {
+			return u.end.Add(q.window).Sub(now)
+		}
+	}
+	return q.window
+}
+
+// The current usage of a requestor's in progress jobs.
+type requestorLoad struct {
+	running     int
+	queued      int
+	runningTime time.Duration
+}
+
+func getRequestorLoad(jobs []*jobState, now time.Time) requestorLoad {
+	l := requestorLoad{}
+	for _, js := range jobs {
+		l.running += js.TasksRunning
+		l.queued += len(js.Tasks) - js.TasksCompleted - js.TasksRunning
+		for _, t := range js.Tasks {
+			if t.Status == sched.InProgress && !t.TimeStarted.IsZero() {
+				l.runningTime += now.Sub(t.TimeStarted)
+			}
+		}
+	}
+	return l
+}
+
+func nodeHours(hours float64) time.Duration {
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// Checks whether a new job fits within its requestor's quota, returning a *QuotaExceededError if it doesn't.
+// MaxRunningTasks isn't checked here since it only throttles scheduling.
+func (s *statefulScheduler) checkQuota(jobDef *sched.JobDefinition) error {
+	q := s.config.quotaFor(jobDef.Requestor)
+	if q.MaxQueuedTasks <= 0 && q.MaxNodeHours <= 0 {
+		return nil
+	}
+	now := time.Now()
+	s.quotaUsage.expire(now)
+	load := getRequestorLoad(s.requestorMap[jobDef.Requestor], now)
+
+	if q.MaxQueuedTasks > 0 && load.queued+len(jobDef.Tasks) > q.MaxQueuedTasks {
+		if len(jobDef.Tasks) > q.MaxQueuedTasks {
+			return fmt.Errorf("Job has more tasks than the max queued tasks quota for requestor %s (%d > %d)",
+				jobDef.Requestor, len(jobDef.Tasks), q.MaxQueuedTasks)
+		}
+		// Estimate how long the requestor's running tasks take to work through the excess.
+		retryAfter := DefaultQuotaRetryAfter
+		if avg := s.quotaUsage.averageDuration(jobDef.Requestor); avg > 0 && load.running > 0 {
+			excess := load.queued + len(jobDef.Tasks) - q.MaxQueuedTasks
+			retryAfter = avg * time.Duration(excess) / time.Duration(load.running)
+		}
+		return &QuotaExceededError{Requestor: jobDef.Requestor, Quota: "max queued tasks", RetryAfter: retryAfter}
+	}
+
+	if q.MaxNodeHours > 0 {
+		limit := nodeHours(q.MaxNodeHours)
+		if retryAfter := s.quotaUsage.untilBelow(jobDef.Requestor, load.runningTime, limit, now); retryAfter > 0 {
+			return &QuotaExceededError{Requestor: jobDef.Requestor, Quota: "max node hours", RetryAfter: retryAfter}
+		}
+	}
+	return nil
+}
+
+// Returns the number of additional tasks that can be started for each requestor that is limited by its quota.
+// Requestors that aren't in the returned map are unlimited.
+func (s *statefulScheduler) getTaskLimits() map[string]int {
+	now := time.Now()
+	s.quotaUsage.expire(now)
+	limits := map[string]int{}
+	for requestor, jobs := range s.requestorMap {
+		q := s.config.quotaFor(requestor)
+		if q.MaxRunningTasks <= 0 && q.MaxNodeHours <= 0 {
+			continue
+		}
+		load := getRequestorLoad(jobs, now)
+		if q.MaxNodeHours > 0 && s.quotaUsage.untilBelow(requestor, load.runningTime, nodeHours(q.MaxNodeHours), now) > 0 {
+			limits[requestor] = 0
+		} else if q.MaxRunningTasks > 0 {
+			limits[requestor] = max(0, q.MaxRunningTasks-load.running)
+		}
+	}
+	return limits
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+func Test_QuotaUsage(t *testing.T) {
+	now := time.Now()
+	q := newQuotaUsage(time.Hour)
+	q.record("batch", now.Add(-90*time.Minute), 30*time.Minute)
+	q.record("batch", now.Add(-40*time.Minute), 20*time.Minute)
+	q.record("batch", now.Add(-10*time.Minute), 10*time.Minute)
+
+	q.expire(now)
+	if used := q.used("batch"); used != 30*time.Minute {
+		t.Fatalf("Expected usage older than the window to expire, got %v", used)
+	}
+	if avg := q.averageDuration("batch"); avg != 15*time.Minute {
+		t.Errorf("Expected average of 15m, got %v", avg)
+	}
+
+	// Under the limit.
+	if d := q.untilBelow("batch", 0, 31*time.Minute, now); d != 0 {
+		t.Errorf("Expected no wait when under the limit, got %v", d)
+	}
+	// The first task ages out in 20m, taking usage from 35m to 15m.
+	if d := q.untilBelow("batch", 5*time.Minute, 30*time.Minute, now); d != 20*time.Minute {
+		t.Errorf("Expected to wait 20m, got %v", d)
+	}
+	// Both tasks have to age out, taking usage from 35m to 5m.
+	if d := q.untilBelow("batch", 5*time.Minute, 10*time.Minute, now); d != 50*time.Minute {
+		t.Errorf("Expected to wait 50m, got %v", d)
+	}
+	// Running tasks alone exceed the limit.
+	if d := q.untilBelow("batch", time.Hour, time.Hour, now); d != time.Hour {
+		t.Errorf("Expected to wait the whole window, got %v", d)
+	}
+}
+
+func Test_StatefulScheduler_QueuedTasksQuota(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	s.config.RequestorQuotas = map[string]RequestorQuota{"batch": RequestorQuota{MaxQueuedTasks: 10}}
+	s.requestorMap["batch"] = []*jobState{makeFairShareJob("job1", "batch", sched.P0, 10, 2)}
+
+	jobDef := sched.GenJobDef(5)
+	jobDef.Requestor = "batch"
+	err := s.checkQuota(&jobDef)
+	qe, ok := err.(*QuotaExceededError)
+	if !ok || qe.RetryAfter != DefaultQuotaRetryAfter {
+		t.Fatalf("Expected QuotaExceededError with the default retry, got %v", err)
+	}
+
+	// With history, the 3 excess tasks take 1.5 task durations to drain with 2 running.
+	s.quotaUsage.record("batch", time.Now(), time.Minute)
+	if err := s.checkQuota(&jobDef); err.(*QuotaExceededError).RetryAfter != 90*time.Second {
+		t.Errorf("Expected to retry after 90s, got %v", err)
+	}
+
+	// A job that could never fit isn't retryable.
+	jobDef = sched.GenJobDef(11)
+	jobDef.Requestor = "batch"
+	if err := s.checkQuota(&jobDef); err == nil {
+		t.Errorf("Expected an error")
+	} else if _, ok := err.(*QuotaExceededError); ok {
+		t.Errorf("Expected a non-retryable error, got %v", err)
+	}
+
+	// Other requestors are unaffected.
+	jobDef.Requestor = "interactive"
+	if err := s.checkQuota(&jobDef); err != nil {
+		t.Errorf("Expected no quota for other requestors, got %v", err)
+	}
+}
+
+func Test_StatefulScheduler_NodeHoursQuota(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	s.config.DefaultRequestorQuota = RequestorQuota{MaxNodeHours: 1}
+	s.quotaUsage.record("batch", time.Now().Add(-time.Hour), 2*time.Hour)
+
+	jobDef := sched.GenJobDef(1)
+	jobDef.Requestor = "batch"
+	errCh := make(chan error)
+	go func() {
+		_, err := s.ScheduleJob(jobDef)
+		errCh <- err
+	}()
+	var err error
+	for done := false; !done; {
+		select {
+		case err = <-errCh:
+			done = true
+		default:
+			s.step()
+		}
+	}
+	qe, ok := err.(*QuotaExceededError)
+	if !ok {
+		t.Fatalf("Expected QuotaExceededError, got %v", err)
+	}
+	if qe.RetryAfter <= 22*time.Hour || qe.RetryAfter > 23*time.Hour {
+		t.Errorf("Expected to retry once the usage ages out of the window in 23h, got %v", qe.RetryAfter)
+	}
+	if limits := s.getTaskLimits(); len(limits) != 0 {
+		t.Errorf("Expected no limits for requestors without jobs, got %v", limits)
+	}
+}
+
+func Test_StatefulScheduler_TaskLimits(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	s.config.RequestorQuotas = map[string]RequestorQuota{
+		"batch":  RequestorQuota{MaxRunningTasks: 3},
+		"hourly": RequestorQuota{MaxNodeHours: 1},
+	}
+	s.requestorMap["batch"] = []*jobState{makeFairShareJob("job1", "batch", sched.P0, 10, 2)}
+	s.requestorMap["hourly"] = []*jobState{makeFairShareJob("job2", "hourly", sched.P0, 10, 0)}
+	s.requestorMap["interactive"] = []*jobState{makeFairShareJob("job3", "interactive", sched.P0, 10, 0)}
+	s.quotaUsage.record("hourly", time.Now(), time.Hour)
+
+	limits := s.getTaskLimits()
+	if len(limits) != 2 || limits["batch"] != 1 || limits["hourly"] != 0 {
+		t.Errorf("Expected batch limited to 1 more task and hourly throttled, got %v", limits)
+	}
+}
+
+func Test_TaskAssignments_TaskLimits(t *testing.T) {
+	jobs := []*jobState{
+		makeFairShareJob("big", "batch", sched.P2, 100, 0),
+		makeFairShareJob("throttled", "hourly", sched.P2, 100, 0),
+		makeFairShareJob("small", "interactive", sched.P0, 100, 0),
+	}
+	req := map[string][]*jobState{}
+	for _, js := range jobs {
+		req[js.Job.Def.Requestor] = append(req[js.Job.Def.Requestor], js)
+	}
+	nodes := []string{}
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, fmt.Sprintf("node%d", i))
+	}
+	testCluster := makeTestCluster(nodes...)
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+	config := &SchedulerConfig{SoftMaxSchedulableTasks: 10}
+
+	limits := map[string]int{"batch": 3, "hourly": 0}
+	assignments, _ := getTaskAssignments(cs, jobs, req, config, nil, limits, nil)
+	tasks := []*taskState{}
+	for _, a := range assignments {
+		tasks = append(tasks, a.task)
+	}
+	// The nodes the higher priority jobs can't use because of their quota go to the lower priority job.
+	counts := countByRequestor(jobs, tasks)
+	if len(tasks) != 10 || counts["batch"] != 3 || counts["hourly"] != 0 || counts["interactive"] != 7 {
+		t.Errorf("Expected 3 tasks for batch and 7 for interactive, got %v", counts)
+	}
+	if limits["batch"] != 3 {
+		t.Errorf("Expected the given limits to be left unmodified, got %v", limits)
+	}
+}
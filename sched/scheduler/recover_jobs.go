@@ -60,8 +60,9 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 				log.Infof("INFO: Rescheduling Saga %v", sagaId)
 				// reschedule saga
 				addJobCh <- jobAddedMsg{
-					job:  job,
-					saga: activeSaga,
+					job:       job,
+					saga:      activeSaga,
+					recovered: true,
 				}
 			}
 
@@ -4,6 +4,8 @@ package scheduler
 //go:generate mockgen -source=scheduler.go -package=scheduler -destination=scheduler_mock.go
 
 import (
+	"time"
+
 	"github.com/twitter/scoot/sched"
 )
 
@@ -11,4 +13,21 @@ type Scheduler interface {
 	ScheduleJob(jobDef sched.JobDefinition) (string, error)
 
 	KillJob(jobId string) error
+
+	// Waits up to timeout for the job to change after version and returns what changed,
+	// or nil if the job isn't in progress in this scheduler.
+	WatchJob(jobId string, version int64, timeout time.Duration) (*JobUpdate, error)
+
+	// Returns the in progress jobs matching the filter, along with the total number of matches
+	// before the filter's offset and limit are applied.
+	ListJobs(filter JobFilter) ([]JobSummary, int, error)
+
+	// Marks the given nodes as draining so they accept no new tasks, and returns the status of all draining nodes.
+	DrainNodes(nodeIds []string) ([]NodeDrainStatus, error)
+
+	// Returns the given nodes to rotation, and returns the status of the nodes that are still draining.
+	UndrainNodes(nodeIds []string) ([]NodeDrainStatus, error)
+
+	// Returns the status of all draining nodes.
+	DrainStatus() ([]NodeDrainStatus, error)
 }
@@ -6,6 +6,7 @@ package scheduler
 import (
 	gomock "github.com/golang/mock/gomock"
 	sched "github.com/twitter/scoot/sched"
+	time "time"
 )
 
 // Mock of Scheduler interface
@@ -49,3 +50,59 @@ func (_m *MockScheduler) KillJob(jobId string) error {
 func (_mr *_MockSchedulerRecorder) KillJob(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "KillJob", arg0)
 }
+
+func (_m *MockScheduler) WatchJob(jobId string, version int64, timeout time.Duration) (*JobUpdate, error) {
+	ret := _m.ctrl.Call(_m, "WatchJob", jobId, version, timeout)
+	ret0, _ := ret[0].(*JobUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSchedulerRecorder) WatchJob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "WatchJob", arg0, arg1, arg2)
+}
+
+func (_m *MockScheduler) ListJobs(filter JobFilter) ([]JobSummary, int, error) {
+	ret := _m.ctrl.Call(_m, "ListJobs", filter)
+	ret0, _ := ret[0].([]JobSummary)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockSchedulerRecorder) ListJobs(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListJobs", arg0)
+}
+
+func (_m *MockScheduler) DrainNodes(nodeIds []string) ([]NodeDrainStatus, error) {
+	ret := _m.ctrl.Call(_m, "DrainNodes", nodeIds)
+	ret0, _ := ret[0].([]NodeDrainStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSchedulerRecorder) DrainNodes(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DrainNodes", arg0)
+}
+
+func (_m *MockScheduler) UndrainNodes(nodeIds []string) ([]NodeDrainStatus, error) {
+	ret := _m.ctrl.Call(_m, "UndrainNodes", nodeIds)
+	ret0, _ := ret[0].([]NodeDrainStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSchedulerRecorder) UndrainNodes(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UndrainNodes", arg0)
+}
+
+func (_m *MockScheduler) DrainStatus() ([]NodeDrainStatus, error) {
+	ret := _m.ctrl.Call(_m, "DrainStatus")
+	ret0, _ := ret[0].([]NodeDrainStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSchedulerRecorder) DrainStatus() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DrainStatus")
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/actioncache"
+	"github.com/twitter/scoot/sched/taskdurations"
 	"github.com/twitter/scoot/workerapi"
 )
 
@@ -58,6 +60,10 @@ const DefaultSoftMaxSchedulableTasks = 1000
 // The max job priority we respect (higher priority is untested and disabled)
 const MaxPriority = sched.P2
 
+// Error reported for the tasks of a job that was killed because a newer job was submitted with the same
+// Requestor and Basis. Formatted with the id of the newer job.
+const SupersededError = "Superseded by job %s"
+
 // Increase the NodeScaleFactor by a percentage defined by 1 + (Priority * NodeScaleAdjustment)
 // Note: the use case here is to hit an SLA for each job priority, and this is a coarse way to do so.
 var NodeScaleAdjustment = .75
@@ -80,6 +86,29 @@ var NodeScaleAdjustment = .75
 //     how long to sleep between runner req retries.
 // ReadyFnBackoff -
 //     how long to wait between runner status queries to determine [init] status.
+// ActionCacheSize -
+//     the number of successful task results to remember, so that identical tasks
+//     (same argv, env vars and snapshot) can be completed without running them.
+//     Zero disables the action cache.
+// TaskDurationsFile -
+//     file to persist the recent durations of each task to, so that the longest tasks
+//     of a job are still started first after a restart. Empty keeps them in memory only.
+// DrainingNodesFile -
+//     file to persist the ids of draining nodes to, so that they're still draining
+//     after a restart. Empty keeps them in memory only.
+// FairShare -
+//     if true, nodes that aren't taken by priority 3 jobs are shared across requestors
+//     in proportion to their weights instead of by job priority. See getFairShareTasks.
+// RequestorWeights -
+//     the fair share weight of each requestor, DefaultRequestorWeight if unlisted or not positive.
+// RequestorQuotas -
+//     the resource quota of each requestor, see RequestorQuota.
+// DefaultRequestorQuota -
+//     the quota of requestors that aren't listed in RequestorQuotas. Zero values are unlimited.
+// QuotaWindow -
+//     the rolling window over which node hours are limited, DefaultQuotaWindow if zero.
+// Preemption -
+//     limits which lower priority tasks priority 3 jobs can preempt, see PreemptionPolicy.
 
 type SchedulerConfig struct {
 	MaxRetriesPerTask       int
@@ -93,6 +122,15 @@ type SchedulerConfig struct {
 	MaxRequestors           int
 	MaxJobsPerRequestor     int
 	SoftMaxSchedulableTasks int
+	ActionCacheSize         int
+	TaskDurationsFile       string
+	DrainingNodesFile       string
+	FairShare               bool
+	RequestorWeights        map[string]float64
+	RequestorQuotas         map[string]RequestorQuota
+	DefaultRequestorQuota   RequestorQuota
+	QuotaWindow             time.Duration
+	Preemption              PreemptionPolicy
 }
 
 // Used to calculate how many tasks a job can run without adversely affecting other jobs.
@@ -103,17 +141,6 @@ func (s *SchedulerConfig) GetNodeScaleFactor(numNodes int, p sched.Priority) flo
 	return sf * (1 + (float32(p) * float32(NodeScaleAdjustment)))
 }
 
-// Used to keep a running average of duration for a specific task.
-type averageDuration struct {
-	count    int64
-	duration time.Duration
-}
-
-func (ad averageDuration) update(d time.Duration) {
-	ad.count++
-	ad.duration = ad.duration + time.Duration(int64(d-ad.duration)/ad.count)
-}
-
 type RunnerFactory func(node cluster.Node) runner.Service
 
 // Scheduler that keeps track of the state of running tasks & the cluster
@@ -134,12 +161,18 @@ type statefulScheduler struct {
 	checkJobCh    chan jobCheckMsg
 	addJobCh      chan jobAddedMsg
 	killJobCh     chan jobKillRequest
+	drainCh       chan drainRequest
 
 	// Scheduler State
 	clusterState   *clusterState
-	inProgressJobs []*jobState                // ordered list of inprogress jobId to job.
-	requestorMap   map[string][]*jobState     // map of requestor to all its jobs. Default requestor="" is ok.
-	taskDurations  map[string]averageDuration // map of taskId to averageDuration (note: we unconditionally dereference this).
+	inProgressJobs []*jobState             // ordered list of inprogress jobId to job.
+	requestorMap   map[string][]*jobState  // map of requestor to all its jobs. Default requestor="" is ok.
+	taskDurations  taskdurations.Store     // recent durations of each task, used to start the longest tasks first.
+	actionCache    actioncache.ActionCache // results of previous successful tasks, nil if disabled.
+	watcher        *jobWatcher             // status of in progress jobs for WatchJob, safe for concurrent use.
+
+	fairShareDeficits map[string]float64 // map of requestor to its fair share deficit, carried across rounds.
+	quotaUsage        *quotaUsage        // node time used by each requestor within the quota window.
 
 	// stats
 	stat stats.StatsReceiver
@@ -190,7 +223,7 @@ func NewStatefulScheduler(
 	stat stats.StatsReceiver,
 ) *statefulScheduler {
 
-	nodeReadyFn := func(node cluster.Node) (bool, time.Duration) {
+	nodeReadyFn := func(node cluster.Node) (bool, runner.Capacity, time.Duration) {
 		run := rf(node)
 		st, svc, err := run.StatusAll()
 		if err != nil || !svc.Initialized {
@@ -200,9 +233,9 @@ func NewStatefulScheduler(
 						"node": node,
 						"err":  svc.Error,
 					}).Info("received service err during init of new node")
-				return false, 0
+				return false, runner.Capacity{}, 0
 			}
-			return false, config.ReadyFnBackoff
+			return false, runner.Capacity{}, config.ReadyFnBackoff
 		}
 		for _, s := range st {
 			log.WithFields(
@@ -221,7 +254,8 @@ func NewStatefulScheduler(
 				}).Info("Aborting existing run on new node")
 			run.Abort(s.RunID)
 		}
-		return true, 0
+		// Workers that predate multiple runs don't advertise their capacity, and run a single task anywhere.
+		return true, svc.Capacity, 0
 	}
 	if config.ReadyFnBackoff == 0 {
 		nodeReadyFn = nil
@@ -251,12 +285,31 @@ func NewStatefulScheduler(
 		checkJobCh:    make(chan jobCheckMsg, 1),
 		addJobCh:      make(chan jobAddedMsg, 1),
 		killJobCh:     make(chan jobKillRequest, 1), // TODO - what should this value be?
+		drainCh:       make(chan drainRequest, 1),
 
 		clusterState:   newClusterState(initialCluster, clusterUpdates, nodeReadyFn, stat),
 		inProgressJobs: make([]*jobState, 0),
 		requestorMap:   make(map[string][]*jobState),
-		taskDurations:  make(map[string]averageDuration),
+		taskDurations:  newTaskDurations(config.TaskDurationsFile),
+		watcher:        newJobWatcher(),
 		stat:           stat,
+
+		fairShareDeficits: make(map[string]float64),
+		quotaUsage:        newQuotaUsage(config.QuotaWindow),
+	}
+	if config.DrainingNodesFile != "" {
+		if ids, err := loadDrainingNodes(config.DrainingNodesFile); err != nil {
+			log.WithFields(
+				log.Fields{
+					"path": config.DrainingNodesFile,
+					"err":  err,
+				}).Error("Failed to load draining nodes")
+		} else if len(ids) > 0 {
+			sched.clusterState.setDraining(ids, true)
+		}
+	}
+	if config.ActionCacheSize > 0 {
+		sched.actionCache = actioncache.NewMemoryActionCache(config.ActionCacheSize)
 	}
 
 	if !config.DebugMode {
@@ -277,14 +330,32 @@ func NewStatefulScheduler(
 	return sched
 }
 
+// Creates the task duration store, persisted to path if it's set. If the durations there can't be
+// loaded they're left untouched and durations are kept in memory only.
+func newTaskDurations(path string) taskdurations.Store {
+	if path != "" {
+		store, err := taskdurations.NewFileStore(path, 0, 0, taskdurations.DefaultFlushInterval)
+		if err == nil {
+			return store
+		}
+		log.WithFields(
+			log.Fields{
+				"path": path,
+				"err":  err,
+			}).Error("Failed to load task durations, they will not be persisted")
+	}
+	return taskdurations.NewMemoryStore(0, 0)
+}
+
 type jobCheckMsg struct {
 	jobDef   *sched.JobDefinition
 	resultCh chan error
 }
 
 type jobAddedMsg struct {
-	job  *sched.Job
-	saga *saga.Saga
+	job       *sched.Job
+	saga      *saga.Saga
+	recovered bool // true if the job was recovered from the saga log rather than newly submitted.
 }
 
 func (s *statefulScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, error) {
@@ -398,6 +469,7 @@ func (s *statefulScheduler) step() {
 	s.addJobs()
 	s.clusterState.updateCluster()
 	s.asyncRunner.ProcessMessages()
+	s.skipTasksWithFailedDependencies()
 
 	// TODO: make processUpdates on scheduler state wait until an update
 	// has been received
@@ -406,6 +478,7 @@ func (s *statefulScheduler) step() {
 
 	s.checkForCompletedJobs()
 	s.killJobs()
+	s.processDrainRequests()
 	s.scheduleTasks()
 
 	remaining := 0
@@ -424,7 +497,7 @@ func (s *statefulScheduler) step() {
 
 // Checks if any new jobs have been scheduled since the last loop and adds
 // them to the scheduler state
-// TODO(jschiller): kill current jobs which share the same Requestor and Basis as these new jobs.
+// Newly submitted jobs supersede any current jobs which share the same Requestor and Basis.
 // TODO(jschiller): kill current tasks that share the same Requestor and Tag as these new tasks.
 func (s *statefulScheduler) addJobs() {
 checkLoop:
@@ -438,6 +511,8 @@ checkLoop:
 				err = fmt.Errorf("Exceeds max jobs per requestor: %s (%d)", checkJobMsg.jobDef.Requestor, s.config.MaxJobsPerRequestor)
 			} else if checkJobMsg.jobDef.Priority < sched.P0 || checkJobMsg.jobDef.Priority > sched.P3 {
 				err = fmt.Errorf("Invalid priority %d, must be between 0-3 inclusive", checkJobMsg.jobDef.Priority)
+			} else if err = s.checkQuota(checkJobMsg.jobDef); err != nil {
+				s.stat.Counter(stats.SchedQuotaRejectedJobsCounter).Inc(1)
 			} else {
 				seenTasks := map[string]bool{}
 				for _, t := range checkJobMsg.jobDef.Tasks {
@@ -447,6 +522,9 @@ checkLoop:
 					}
 					seenTasks[t.TaskID] = true
 				}
+				if err == nil {
+					err = sched.ValidateDependencies(checkJobMsg.jobDef.Tasks)
+				}
 			}
 			checkJobMsg.resultCh <- err
 		default:
@@ -492,6 +570,8 @@ addLoop:
 			}
 
 			js := newJobState(newJobMsg.job, newJobMsg.saga, s.taskDurations)
+			js.watcher = s.watcher
+			s.watcher.addJob(js)
 			s.inProgressJobs = append(s.inProgressJobs, js)
 
 			// TODO(jschiller): associate related tasks, i.e. task retries that decorate the taskId?
@@ -509,6 +589,11 @@ addLoop:
 					"numTasks": len(newJobMsg.job.Def.Tasks),
 					"tag":      newJobMsg.job.Def.Tag,
 				}).Info("Created new job")
+
+			if !newJobMsg.recovered {
+				s.supersedeJobs(js)
+			}
+			s.completeCachedTasks(js)
 		default:
 			break addLoop
 		}
@@ -587,6 +672,7 @@ func (s *statefulScheduler) checkForCompletedJobs() {
 							}).Info("Job completed and logged")
 						// This job is fully processed remove from InProgressJobs
 						s.deleteJob(j.Job.Id)
+						s.watcher.jobCompleted(j.Job.Id)
 					} else {
 						// set the jobState flag to false, will retry logging
 						// EndSaga message on next scheduler loop
@@ -604,10 +690,127 @@ func (s *statefulScheduler) checkForCompletedJobs() {
 	}
 }
 
+// Completes tasks whose results are already in the action cache, without dispatching them to a worker.
+// The cached result is logged to the saga as if the task had run. If logging fails the task is left
+// unscheduled and will be run normally. Tasks with SkipCacheLookup set are always run.
+func (s *statefulScheduler) completeCachedTasks(js *jobState) {
+	if s.actionCache == nil {
+		return
+	}
+	for _, task := range js.getUnScheduledTasks() {
+		if task.Def.SkipCacheLookup {
+			continue
+		}
+		result, err := s.actionCache.Get(actioncache.CommandKey(&task.Def.Command))
+		if err != nil {
+			log.WithFields(
+				log.Fields{
+					"jobID":  task.JobId,
+					"taskID": task.TaskId,
+					"err":    err,
+				}).Info("Failed to read action cache, task will be run")
+			continue
+		}
+		if result == nil {
+			s.stat.Counter(stats.SchedActionCacheMissCounter).Inc(1)
+			continue
+		}
+		s.stat.Counter(stats.SchedActionCacheHitCounter).Inc(1)
+
+		st := runner.CompleteStatus(runner.RunID("cached"), result.SnapshotID, result.ExitCode,
+			tags.LogTags{JobID: task.JobId, TaskID: task.TaskId, Tag: js.Job.Def.Tag})
+		if err := s.logTaskWithoutRunning(js, task.TaskId, st); err != nil {
+			log.WithFields(
+				log.Fields{
+					"jobID":  task.JobId,
+					"taskID": task.TaskId,
+					"err":    err,
+				}).Info("Failed to log cached task result, task will be run")
+			continue
+		}
+
+		log.WithFields(
+			log.Fields{
+				"jobID":      task.JobId,
+				"taskID":     task.TaskId,
+				"snapshotID": result.SnapshotID,
+				"tag":        js.Job.Def.Tag,
+			}).Info("Completed task from action cache")
+		s.stat.Counter(stats.SchedCompletedTaskCounter).Inc(1)
+		js.taskCompleted(task.TaskId, false, &st)
+	}
+}
+
+// Skips tasks that can never run because one of their dependencies completed unsuccessfully.
+// Skipped tasks are completed with a failed status, which in turn skips the tasks that depend on them.
+// If logging a skipped task fails it is left unscheduled, and will be skipped on a later pass.
+func (s *statefulScheduler) skipTasksWithFailedDependencies() {
+	for _, js := range s.inProgressJobs {
+		if !js.hasDependencies || js.JobKilled {
+			continue
+		}
+		for skipped := true; skipped; {
+			skipped = false
+			for _, task := range js.Tasks {
+				if task.Status != sched.NotStarted {
+					continue
+				}
+				dep := js.failedDependency(task)
+				if dep == "" {
+					continue
+				}
+				st := runner.FailedStatus("", fmt.Errorf("Skipped, dependency %s did not succeed", dep),
+					tags.LogTags{JobID: task.JobId, TaskID: task.TaskId, Tag: js.Job.Def.Tag})
+				if err := s.logTaskWithoutRunning(js, task.TaskId, st); err != nil {
+					log.WithFields(
+						log.Fields{
+							"jobID":  task.JobId,
+							"taskID": task.TaskId,
+							"err":    err,
+						}).Info("Failed to log skipped task")
+					continue
+				}
+				log.WithFields(
+					log.Fields{
+						"jobID":      task.JobId,
+						"taskID":     task.TaskId,
+						"dependency": dep,
+						"tag":        js.Job.Def.Tag,
+					}).Info("Skipped task with failed dependency")
+				s.stat.Counter(stats.SchedSkippedTaskCounter).Inc(1)
+				js.taskCompleted(task.TaskId, false, &st)
+				skipped = true
+			}
+		}
+	}
+}
+
+// Logs the start and end of a task that is completed by the scheduler itself rather than run on a worker.
+func (s *statefulScheduler) logTaskWithoutRunning(js *jobState, taskId string, st runner.RunStatus) error {
+	statusAsBytes, err := workerapi.SerializeProcessStatus(st)
+	if err != nil {
+		s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1)
+		return err
+	}
+	if err := js.Saga.StartTask(taskId, nil); err != nil {
+		return err
+	}
+	return js.Saga.EndTask(taskId, statusAsBytes)
+}
+
 // figures out which tasks to schedule next and on which worker and then runs them
 func (s *statefulScheduler) scheduleTasks() {
 	// Calculate a list of Tasks to Node Assignments & start running all those jobs
-	taskAssignments, nodeGroups := getTaskAssignments(s.clusterState, s.inProgressJobs, s.requestorMap, s.config, s.stat)
+	taskLimits := s.getTaskLimits()
+	throttled := 0
+	for _, limit := range taskLimits {
+		if limit == 0 {
+			throttled++
+		}
+	}
+	s.stat.Gauge(stats.SchedQuotaThrottledRequestorsGauge).Update(int64(throttled))
+	taskAssignments, nodeGroups := getTaskAssignments(
+		s.clusterState, s.inProgressJobs, s.requestorMap, s.config, s.fairShareDeficits, taskLimits, s.stat)
 	if taskAssignments != nil {
 		s.clusterState.nodeGroups = nodeGroups
 	}
@@ -639,11 +842,11 @@ func (s *statefulScheduler) scheduleTasks() {
 			log.Info(msg)
 			// Update jobState and clusterState here instead of in the async handler below.
 			s.getJob(rt.JobId).errorRunningTask(rt.TaskId, errors.New(msg), preempted)
-			s.clusterState.taskCompleted(nodeSt.node.Id(), flaky)
+			s.clusterState.taskCompleted(nodeSt.node.Id(), rt.JobId, rt.TaskId, flaky)
 		}
 
 		// Mark Task as Started in the cluster
-		s.clusterState.taskScheduled(nodeSt.node.Id(), jobID, taskID, taskDef.SnapshotID)
+		s.clusterState.taskScheduled(nodeSt.node.Id(), jobID, taskID, taskDef.SnapshotID, taskDef.Resources)
 		log.WithFields(
 			log.Fields{
 				"jobID":  jobID,
@@ -669,8 +872,9 @@ func (s *statefulScheduler) scheduleTasks() {
 				Tag:    tag,
 			},
 
-			task:   taskDef,
-			nodeSt: nodeSt,
+			task:        taskDef,
+			nodeSt:      nodeSt,
+			actionCache: s.actionCache,
 
 			abortCh:      make(chan bool, 1),
 			queryAbortCh: make(chan interface{}, 1),
@@ -685,23 +889,24 @@ func (s *statefulScheduler) scheduleTasks() {
 			tRunner.run,
 			func(err error) {
 				defer rs.Release()
+				// Charge the node time to the requestor's quota, whatever the outcome.
+				s.quotaUsage.record(jobState.Job.Def.Requestor, time.Now(), time.Now().Sub(tRunner.startTime))
 				// Update the average duration for this task so, for new jobs, we can schedule the likely long running tasks first.
 				if err == nil || err.(*taskError).st.State == runner.TIMEDOUT ||
 					(err.(*taskError).st.State == runner.COMPLETE && err.(*taskError).st.ExitCode == 0) {
-					s.taskDurations[taskID].update(time.Now().Sub(tRunner.startTime))
+					s.recordTaskDuration(taskdurations.Key{TaskID: taskID, JobType: jobState.Job.Def.JobType},
+						time.Now().Sub(tRunner.startTime))
 				}
 
-				// Tasks from the same job or related jobs will never preempt each other,
-				//  so we only need to check jobId to determine preemption.
-				if nodeSt.runningJob != jobID {
+				// If this task is no longer running on the node then it was preempted and has already been handled.
+				if !nodeSt.isRunning(jobID, taskID) {
 					log.WithFields(
 						log.Fields{
-							"node":        nodeSt.node,
-							"jobID":       jobID,
-							"taskID":      taskID,
-							"runningJob":  nodeSt.runningJob,
-							"runningTask": nodeSt.runningTask,
-							"tag":         tag,
+							"node":    nodeSt.node,
+							"jobID":   jobID,
+							"taskID":  taskID,
+							"running": nodeSt.numRunning(),
+							"tag":     tag,
 						}).Info("Task preempted")
 					return
 				}
@@ -764,7 +969,8 @@ func (s *statefulScheduler) scheduleTasks() {
 							"command": strings.Join(taskDef.Argv, " "),
 							"tag":     tag,
 						}).Info("Ending task.")
-					jobState.taskCompleted(taskID, true)
+					st := tRunner.finalStatus
+					jobState.taskCompleted(taskID, true, &st)
 				}
 
 				// update cluster state that this node is now free and if we consider the runner to be flaky.
@@ -776,7 +982,7 @@ func (s *statefulScheduler) scheduleTasks() {
 						"flaky":  flaky,
 						"tag":    tag,
 					}).Info("Freeing node, removed job.")
-				s.clusterState.taskCompleted(nodeSt.node.Id(), flaky)
+				s.clusterState.taskCompleted(nodeSt.node.Id(), jobID, taskID, flaky)
 
 				total := 0
 				completed := 0
@@ -807,6 +1013,20 @@ func (s *statefulScheduler) scheduleTasks() {
 	}
 }
 
+// Waits up to timeout for the job to change after the given version and returns the changes,
+// served from the scheduler's own state. Returns nil if the job isn't in progress here, either
+// because it finished or because it hasn't been added yet, in which case the saga log has its status.
+func (s *statefulScheduler) WatchJob(jobId string, version int64, timeout time.Duration) (*JobUpdate, error) {
+	return s.watcher.watch(jobId, version, timeout), nil
+}
+
+// Lists the jobs in progress in this scheduler, including those that haven't had any tasks scheduled yet.
+// Served from the job watcher so callers don't have to wait on the scheduler loop.
+func (s *statefulScheduler) ListJobs(filter JobFilter) ([]JobSummary, int, error) {
+	jobs, total := s.watcher.list(filter)
+	return jobs, total, nil
+}
+
 /*
 Put the kill request on channel that is processed by the main
 scheduler loop, and wait for the response
@@ -860,25 +1080,7 @@ func (s *statefulScheduler) killJobs() {
 
 	// kill the jobs with valid ids
 	for _, req := range validKillRequests {
-		jobState := s.getJob(req.jobId)
-		inProgress, notStarted := 0, 0
-		for _, task := range jobState.Tasks {
-			if task.Status == sched.InProgress {
-				task.TaskRunner.Abort(true)
-				inProgress++
-			} else if task.Status == sched.NotStarted {
-				st := runner.AbortStatus("", tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId})
-				statusAsBytes, err := workerapi.SerializeProcessStatus(st)
-				if err != nil {
-					s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
-				}
-				//TODO - is this the correct counter?
-				s.stat.Counter(stats.SchedCompletedTaskCounter).Inc(1)
-				jobState.Saga.EndTask(task.TaskId, statusAsBytes)
-				jobState.taskCompleted(task.TaskId, false)
-				notStarted++
-			}
-		}
+		notStarted, inProgress := s.abortJob(s.getJob(req.jobId), "")
 		log.WithFields(
 			log.Fields{
 				"jobID":           req.jobId,
@@ -890,3 +1092,77 @@ func (s *statefulScheduler) killJobs() {
 		req.responseCh <- nil
 	}
 }
+
+// Adds the duration of a finished task to its history and updates the task duration stats.
+func (s *statefulScheduler) recordTaskDuration(key taskdurations.Key, d time.Duration) {
+	if err := s.taskDurations.Record(key, d); err != nil {
+		log.WithFields(
+			log.Fields{
+				"taskID":  key.TaskID,
+				"jobType": key.JobType,
+				"err":     err,
+			}).Info("Failed to record task duration")
+		return
+	}
+	s.stat.Counter(stats.SchedTaskDurationsRecordedCounter).Inc(1)
+	s.stat.Gauge(stats.SchedTaskDurationsTrackedGauge).Update(int64(s.taskDurations.Len()))
+}
+
+// Aborts the in progress tasks of a job and completes its not started tasks with an abort status,
+// whose error is set to reason. The caller is responsible for marking the job as killed.
+// Returns the number of not started and in progress tasks that were aborted.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) abortJob(jobState *jobState, reason string) (notStarted int, inProgress int) {
+	for _, task := range jobState.Tasks {
+		if task.Status == sched.InProgress {
+			task.TaskRunner.AbortWithReason(true, reason)
+			inProgress++
+		} else if task.Status == sched.NotStarted {
+			st := runner.AbortStatus("", tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId})
+			st.Error = reason
+			statusAsBytes, err := workerapi.SerializeProcessStatus(st)
+			if err != nil {
+				s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
+			}
+			//TODO - is this the correct counter?
+			s.stat.Counter(stats.SchedCompletedTaskCounter).Inc(1)
+			jobState.Saga.EndTask(task.TaskId, statusAsBytes)
+			jobState.taskCompleted(task.TaskId, false, &st)
+			notStarted++
+		}
+	}
+	return notStarted, inProgress
+}
+
+// Kills the current jobs of the new job's requestor that have the same basis, since only the latest job
+// for a basis is wanted. Their tasks are aborted with SupersededError so the reason shows up in their status.
+// Jobs without a basis are never superseded.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) supersedeJobs(newJob *jobState) {
+	basis := newJob.Job.Def.Basis
+	if basis == "" {
+		return
+	}
+	for _, js := range s.requestorMap[newJob.Job.Def.Requestor] {
+		if js == newJob || js.JobKilled || js.Job.Def.Basis != basis {
+			continue
+		}
+		js.JobKilled = true
+		js.SupersededBy = newJob.Job.Id
+		s.watcher.jobSuperseded(js.Job.Id, js.SupersededBy)
+		notStarted, inProgress := s.abortJob(js, fmt.Sprintf(SupersededError, newJob.Job.Id))
+		s.stat.Counter(stats.SchedSupersededJobsCounter).Inc(1)
+		log.WithFields(
+			log.Fields{
+				"jobID":           js.Job.Id,
+				"supersededBy":    newJob.Job.Id,
+				"requestor":       js.Job.Def.Requestor,
+				"basis":           basis,
+				"tasksNotStarted": notStarted,
+				"tasksInProgress": inProgress,
+				"tag":             js.Job.Def.Tag,
+			}).Info("Superseded job")
+	}
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/actioncache"
 	"github.com/twitter/scoot/sched/worker/workers"
 	"github.com/twitter/scoot/snapshot/snapshots"
 )
@@ -343,7 +345,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	}
 
 	// verify scheduler state updated appropriately
-	if s.clusterState.nodes["node1"].runningTask != taskId {
+	if !s.clusterState.nodes["node1"].isRunning(jobId, taskId) {
 		t.Errorf("Expected %v to be scheduled on node1.  nodestate: %+v", taskId, s.clusterState.nodes["node1"])
 	}
 
@@ -353,7 +355,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	}
 
 	// verify state changed appropriately
-	if s.clusterState.nodes["node1"].runningTask != noTask {
+	if s.clusterState.nodes["node1"].numRunning() != 0 {
 		t.Errorf("Expected node1 to not have any running tasks")
 	}
 
@@ -373,6 +375,221 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 
 }
 
+// verifies that a job identical to a previously successful job is completed from the action cache
+func Test_StatefulScheduler_ActionCache(t *testing.T) {
+	jobDef := sched.GenJobDef(2)
+	deps := getDefaultSchedDeps()
+	deps.config.ActionCacheSize = 10
+	s := makeStatefulSchedulerDeps(deps)
+
+	scheduleJob := func() string {
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		jobId, err := s.ScheduleJob(jobDef)
+		if err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		return jobId
+	}
+
+	// run the first job to completion, populating the cache
+	jobId := scheduleJob()
+	s.step()
+	for s.getJob(jobId).getJobStatus() != sched.Completed {
+		s.step()
+	}
+	for len(s.inProgressJobs) > 0 {
+		s.step()
+	}
+
+	// the identical job completes when it is added, without running any tasks
+	jobId = scheduleJob()
+	s.step()
+	js := s.getJob(jobId)
+	if js == nil || js.getJobStatus() != sched.Completed || js.TasksRunning != 0 {
+		t.Fatalf("Expected job %v to be completed from the action cache, got %+v", jobId, js)
+	}
+
+	if !stats.StatsOk("", deps.statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedActionCacheMissCounter: {Checker: stats.Int64EqTest, Value: 2},
+			stats.SchedActionCacheHitCounter:  {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+
+	for len(s.inProgressJobs) > 0 {
+		s.step()
+	}
+}
+
+// verifies that tasks with DoNotCache aren't stored in the action cache, and tasks with SkipCacheLookup are run
+// even though the action cache has their results
+func Test_StatefulScheduler_ActionCacheFlags(t *testing.T) {
+	jobDef := sched.GenJobDef(2)
+	deps := getDefaultSchedDeps()
+	deps.config.ActionCacheSize = 10
+	s := makeStatefulSchedulerDeps(deps)
+
+	runJob := func(def sched.JobDefinition) {
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		jobId, err := s.ScheduleJob(def)
+		if err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		s.step()
+		for s.getJob(jobId).getJobStatus() != sched.Completed {
+			s.step()
+		}
+		for len(s.inProgressJobs) > 0 {
+			s.step()
+		}
+	}
+	isCached := func(task sched.TaskDefinition) bool {
+		result, err := s.actionCache.Get(actioncache.CommandKey(&task.Command))
+		return err == nil && result != nil
+	}
+
+	// only the task without DoNotCache is stored
+	def := jobDef
+	def.Tasks = append([]sched.TaskDefinition{}, jobDef.Tasks...)
+	def.Tasks[0].DoNotCache = true
+	runJob(def)
+	if isCached(jobDef.Tasks[0]) || !isCached(jobDef.Tasks[1]) {
+		t.Fatalf("Expected only %s to be cached", jobDef.Tasks[1].TaskID)
+	}
+
+	// both tasks are run, not completed from the cache
+	def.Tasks = append([]sched.TaskDefinition{}, jobDef.Tasks...)
+	def.Tasks[0].SkipCacheLookup = true
+	def.Tasks[1].SkipCacheLookup = true
+	runJob(def)
+	if !stats.StatsOk("", deps.statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedActionCacheMissCounter: {Checker: stats.Int64EqTest, Value: 2},
+			stats.SchedActionCacheHitCounter:  {Checker: stats.Int64EqTest, Value: nil},
+			stats.SchedCompletedTaskCounter:   {Checker: stats.Int64EqTest, Value: 4},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+	if !isCached(jobDef.Tasks[0]) {
+		t.Fatalf("Expected %s to be cached once run without DoNotCache", jobDef.Tasks[0].TaskID)
+	}
+}
+
+// verifies that tasks are only run once their dependencies have completed
+func Test_StatefulScheduler_TaskDependencies(t *testing.T) {
+	jobDef := sched.GenJobDef(2)
+	a, b := jobDef.Tasks[0].TaskID, jobDef.Tasks[1].TaskID
+	jobDef.Tasks[1].Dependencies = []string{a}
+	jobDef.Tasks[1].SnapshotFromTask = a
+
+	s := makeDefaultStatefulScheduler()
+	go func() {
+		checkJobMsg := <-s.checkJobCh
+		checkJobMsg.resultCh <- nil
+	}()
+	jobId, _ := s.ScheduleJob(jobDef)
+	s.step()
+
+	js := s.getJob(jobId)
+	if js.getTask(a).Status != sched.InProgress || js.getTask(b).Status != sched.NotStarted {
+		t.Fatalf("Expected only %s to be started, got %s: %s, %s: %s",
+			a, a, js.getTask(a).Status, b, js.getTask(b).Status)
+	}
+
+	for js.getTask(a).Status != sched.Completed {
+		s.step()
+	}
+	for js.getTask(b).Status == sched.NotStarted {
+		s.step()
+	}
+	if js.getTask(b).Def.SnapshotID != js.getTask(a).Result.SnapshotID {
+		t.Errorf("Expected %s to run against the output snapshot of %s, got %s",
+			b, a, js.getTask(b).Def.SnapshotID)
+	}
+
+	for len(s.inProgressJobs) > 0 {
+		s.step()
+	}
+}
+
+// verifies that tasks depending on a failed task are skipped without running
+func Test_StatefulScheduler_TaskDependencyFailed(t *testing.T) {
+	jobDef := sched.GenJobDef(3)
+	a, b, c := jobDef.Tasks[0].TaskID, jobDef.Tasks[1].TaskID, jobDef.Tasks[2].TaskID
+	jobDef.Tasks[1].Dependencies = []string{a}
+	jobDef.Tasks[2].Dependencies = []string{b}
+
+	deps := getDefaultSchedDeps()
+	// create a runner factory that returns a runner that returns an error
+	deps.rf = func(cluster.Node) runner.Service {
+		chaos := runners.NewChaosRunner(nil)
+		chaos.SetError(fmt.Errorf("starting error"))
+		return chaos
+	}
+	s := makeStatefulSchedulerDeps(deps)
+	go func() {
+		checkJobMsg := <-s.checkJobCh
+		checkJobMsg.resultCh <- nil
+	}()
+	jobId, _ := s.ScheduleJob(jobDef)
+
+	for len(s.inProgressJobs) == 0 || s.getJob(jobId).getJobStatus() != sched.Completed {
+		s.step()
+	}
+
+	js := s.getJob(jobId)
+	for _, id := range []string{b, c} {
+		task := js.getTask(id)
+		if task.NumTimesTried != 0 || task.Result == nil || !strings.HasPrefix(task.Result.Error, "Skipped") {
+			t.Errorf("Expected %s to be skipped, got %+v", id, task)
+		}
+	}
+	if !stats.StatsOk("", deps.statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedSkippedTaskCounter: {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+
+	for len(s.inProgressJobs) > 0 {
+		s.step()
+	}
+}
+
+// verifies that jobs with invalid task dependencies are rejected
+func Test_StatefulScheduler_InvalidTaskDependencies(t *testing.T) {
+	jobDef := sched.GenJobDef(2)
+	jobDef.Tasks[0].Dependencies = []string{jobDef.Tasks[1].TaskID}
+	jobDef.Tasks[1].Dependencies = []string{jobDef.Tasks[0].TaskID}
+
+	s := makeDefaultStatefulScheduler()
+	errCh := make(chan error)
+	go func() {
+		_, err := s.ScheduleJob(jobDef)
+		errCh <- err
+	}()
+
+	// advance scheduler until the job request has been checked
+	for {
+		select {
+		case err := <-errCh:
+			if err == nil {
+				t.Errorf("Expected job with a dependency cycle to be rejected")
+			}
+			return
+		default:
+			s.step()
+		}
+	}
+}
+
 func Test_StatefulScheduler_KillStartedJob(t *testing.T) {
 	sc := sagalogs.MakeInMemorySagaCoordinator()
 	s, _, _ := initializeServices(sc, false)
@@ -427,7 +644,7 @@ func Test_StatefulScheduler_KillFinishedJob(t *testing.T) {
 
 	// verify state changed appropriately
 	for i := 0; i < 5; i++ {
-		if s.clusterState.nodes[cluster.NodeId(fmt.Sprintf("node%d", i+1))].runningTask != noTask {
+		if s.clusterState.nodes[cluster.NodeId(fmt.Sprintf("node%d", i+1))].numRunning() != 0 {
 			t.Errorf("Expected nodes to not have any running tasks")
 		}
 	}
@@ -518,6 +735,133 @@ func Test_StatefulScheduler_KillNotStartedJob(t *testing.T) {
 	sendKillRequest(jobId1, s)
 }
 
+func Test_StatefulScheduler_SupersedeJob(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+	s, _, _ := initializeServices(sc, false)
+
+	scheduleJob := func(numTasks int, basis string) string {
+		jobDef := sched.GenJobDef(numTasks)
+		jobDef.Requestor = "ci"
+		jobDef.Basis = basis
+		for i := range jobDef.Tasks {
+			jobDef.Tasks[i].Argv = []string{"pause"}
+		}
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		jobId, err := s.ScheduleJob(jobDef)
+		if err != nil {
+			t.Fatalf("Unexpected error scheduling job: %v", err)
+		}
+		s.step()
+		return jobId
+	}
+
+	// Start one task of the old job, leaving the rest of its tasks waiting for a node.
+	oldJobId := scheduleJob(6, "rev1")
+	otherJobId := scheduleJob(1, "other")
+	for s.getJob(oldJobId).TasksRunning == 0 {
+		s.step()
+	}
+
+	newJobId := scheduleJob(1, "rev1")
+	oldJob := s.getJob(oldJobId)
+	if !oldJob.JobKilled || oldJob.SupersededBy != newJobId {
+		t.Fatalf("Expected old job to be superseded by %s, got killed:%v supersededBy:%s", newJobId, oldJob.JobKilled, oldJob.SupersededBy)
+	}
+	if update, _ := s.WatchJob(oldJobId, 0, 0); update == nil || update.SupersededBy != newJobId {
+		t.Fatalf("Expected the old job's update to be superseded by %s, got %+v", newJobId, update)
+	}
+	for oldJob.getJobStatus() != sched.Completed {
+		s.step()
+	}
+	expected := fmt.Sprintf(SupersededError, newJobId)
+	for _, task := range oldJob.Tasks {
+		if task.Result == nil || task.Result.State != runner.ABORTED || !strings.HasPrefix(task.Result.Error, expected) {
+			t.Errorf("Expected task %s to be aborted with error %q, got %+v", task.TaskId, expected, task.Result)
+		}
+	}
+
+	// Jobs with a different basis are unaffected.
+	if s.getJob(otherJobId).JobKilled || s.getJob(newJobId).JobKilled {
+		t.Errorf("Expected only the old job to be killed")
+	}
+
+	// cleanup
+	sendKillRequest(otherJobId, s)
+	sendKillRequest(newJobId, s)
+}
+
+func Test_StatefulScheduler_DrainNodes(t *testing.T) {
+	tmp, _ := temp.NewTempDir("", "stateful_scheduler_test")
+	deps, _ := getDepsWithPausingWorker()
+	deps.config.DrainingNodesFile = filepath.Join(tmp.Dir, "draining.json")
+	s := makeStatefulSchedulerDeps(deps)
+
+	respCh := make(chan error)
+	go func() {
+		_, err := s.DrainNodes([]string{"node1", "node2", "node3", "node4"})
+		respCh <- err
+	}()
+	if err := waitForResponse(respCh, s); err != nil {
+		t.Fatalf("Expected no error from DrainNodes, got %v", err)
+	}
+
+	jobId, taskIds, _ := putJobInScheduler(2, s, true)
+	s.step()
+	for i := 0; i < 10; i++ {
+		s.step()
+	}
+	job := s.getJob(jobId)
+	if job.TasksRunning != 1 {
+		t.Fatalf("Expected a single task running on the only undrained node, got %d", job.TasksRunning)
+	}
+	for _, id := range taskIds {
+		if task := job.getTask(id); task.Status == sched.InProgress && task.TaskRunner.nodeSt.node.Id() != "node5" {
+			t.Fatalf("Expected task %s to run on node5, got %s", id, task.TaskRunner.nodeSt.node.Id())
+		}
+	}
+
+	// Draining should survive a restart.
+	deps, _ = getDepsWithPausingWorker()
+	deps.config.DrainingNodesFile = filepath.Join(tmp.Dir, "draining.json")
+	s = makeStatefulSchedulerDeps(deps)
+	if st := s.clusterState.drainStatus(); len(st) != 4 || !st[0].Drained || st[0].NodeId != "node1" {
+		t.Fatalf("Expected 4 drained nodes after restart, got %+v", st)
+	}
+
+	go func() {
+		_, err := s.UndrainNodes([]string{"node1", "node2", "node3", "node4"})
+		respCh <- err
+	}()
+	if err := waitForResponse(respCh, s); err != nil {
+		t.Fatalf("Expected no error from UndrainNodes, got %v", err)
+	}
+	if s.clusterState.numSlots() != 5 || len(s.clusterState.drainStatus()) != 0 {
+		t.Fatalf("Expected all nodes back in rotation, got %d slots", s.clusterState.numSlots())
+	}
+}
+
+func Test_StatefulScheduler_DrainNodesSaveFailure(t *testing.T) {
+	tmp, _ := temp.NewTempDir("", "stateful_scheduler_test")
+	deps, _ := getDepsWithPausingWorker()
+	deps.config.DrainingNodesFile = filepath.Join(tmp.Dir, "missing", "draining.json")
+	s := makeStatefulSchedulerDeps(deps)
+
+	respCh := make(chan error)
+	go func() {
+		_, err := s.DrainNodes([]string{"node1"})
+		respCh <- err
+	}()
+	if err := waitForResponse(respCh, s); err == nil {
+		t.Fatal("Expected an error from DrainNodes when the draining nodes can't be saved")
+	}
+	if st := s.clusterState.drainStatus(); len(st) != 0 || s.clusterState.numSlots() != 5 {
+		t.Fatalf("Expected no nodes to be drained, got %+v", st)
+	}
+}
+
 func Test_StatefulScheduler_NodeScaleFactor(t *testing.T) {
 	NodeScaleAdjustment = .5 // Setting this global setting explicitly for consistency.
 	s := &SchedulerConfig{SoftMaxSchedulableTasks: 1000}
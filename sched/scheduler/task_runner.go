@@ -11,6 +11,7 @@ import (
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/actioncache"
 	"github.com/twitter/scoot/workerapi"
 )
 
@@ -32,11 +33,15 @@ type taskRunner struct {
 	runnerRetryInterval   time.Duration // How long to sleep between runner req retries.
 
 	tags.LogTags
-	task   sched.TaskDefinition
-	nodeSt *nodeState
+	task        sched.TaskDefinition
+	nodeSt      *nodeState
+	actionCache actioncache.ActionCache // if non-nil, successful results are stored here.
+
+	finalStatus runner.RunStatus // The status the run ended with, set before run() returns.
 
 	abortCh      chan bool        // Primary channel to check for aborts
 	queryAbortCh chan interface{} // Secondary channel to pass to blocking query.
+	abortReason  string           // Error reported in the abort status, written before signaling abortCh.
 
 	startTime time.Time
 }
@@ -133,6 +138,7 @@ func (r *taskRunner) run() error {
 			"tag":        taskErr.st.Tag,
 			"err":        taskErr,
 		}).Info("End task")
+	r.finalStatus = taskErr.st
 	if !shouldLog {
 		if taskErr != nil {
 			r.stat.Counter(stats.SchedFailedTaskCounter).Inc(1)
@@ -144,6 +150,7 @@ func (r *taskRunner) run() error {
 	taskErr.sagaErr = err
 	if taskErr.sagaErr == nil && taskErr.runnerErr == nil && taskErr.resultErr == nil {
 		r.stat.Counter(stats.SchedCompletedTaskCounter).Inc(1)
+		r.cacheResult(taskErr.st)
 		return nil
 	} else {
 		r.stat.Counter(stats.SchedFailedTaskCounter).Inc(1)
@@ -178,7 +185,7 @@ func (r *taskRunner) runAndWait() (runner.RunStatus, bool, error) {
 	for {
 		// was a job kill request received before we could start the run?
 		if aborted, endTask := r.abortRequested(); aborted {
-			st = runner.AbortStatus(id, tags.LogTags{JobID: r.JobID, TaskID: r.TaskID})
+			st = r.abortStatus(id)
 			log.WithFields(
 				log.Fields{
 					"jobID":  r.JobID,
@@ -196,7 +203,7 @@ func (r *taskRunner) runAndWait() (runner.RunStatus, bool, error) {
 			if err == nil { // we should have a status with runId, abort the run
 				r.runner.Abort(st.RunID)
 			}
-			st = runner.AbortStatus(id, tags.LogTags{JobID: r.JobID, TaskID: r.TaskID})
+			st = r.abortStatus(id)
 			log.WithFields(
 				log.Fields{
 					"jobID":  r.JobID,
@@ -294,7 +301,7 @@ func (r *taskRunner) queryWithTimeout(id runner.RunID, endTime time.Time, includ
 	sts, _, err := r.runner.Query(q, w)
 
 	if aborted, endTask := r.abortRequested(); aborted {
-		return runner.AbortStatus(id, tags.LogTags{JobID: r.JobID, TaskID: r.TaskID}), endTask, nil
+		return r.abortStatus(id), endTask, nil
 	}
 	if err != nil {
 		return runner.RunStatus{}, false, err
@@ -342,6 +349,23 @@ func (r *taskRunner) logTaskStatus(st *runner.RunStatus, msgType saga.SagaMessag
 	return err
 }
 
+// Store a successful result in the action cache so identical tasks can reuse it, unless the task opted out.
+func (r *taskRunner) cacheResult(st runner.RunStatus) {
+	if r.actionCache == nil || r.task.DoNotCache || !actioncache.IsCacheable(st) {
+		return
+	}
+	result := actioncache.Result{SnapshotID: st.SnapshotID, ExitCode: st.ExitCode}
+	if err := r.actionCache.Put(actioncache.CommandKey(&r.task.Command), result); err != nil {
+		log.WithFields(
+			log.Fields{
+				"jobID":  r.JobID,
+				"taskID": r.TaskID,
+				"err":    err,
+				"tag":    r.Tag,
+			}).Info("Failed to store task result in action cache")
+	}
+}
+
 func (r *taskRunner) abortRequested() (aborted bool, endTask bool) {
 	select {
 	case endTask := <-r.abortCh:
@@ -360,6 +384,18 @@ func (r *taskRunner) abortRequested() (aborted bool, endTask bool) {
 }
 
 func (r *taskRunner) Abort(endTask bool) {
+	r.AbortWithReason(endTask, "")
+}
+
+// Like Abort, but the given reason is reported as the error of the resulting abort status.
+func (r *taskRunner) AbortWithReason(endTask bool, reason string) {
+	r.abortReason = reason
 	r.abortCh <- endTask
 	r.queryAbortCh <- nil
 }
+
+func (r *taskRunner) abortStatus(id runner.RunID) runner.RunStatus {
+	st := runner.AbortStatus(id, tags.LogTags{JobID: r.JobID, TaskID: r.TaskID})
+	st.Error = r.abortReason
+	return st
+}
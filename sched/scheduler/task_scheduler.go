@@ -3,10 +3,13 @@ package scheduler
 import (
 	"math"
 	"sort"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/sched"
 )
 
@@ -30,8 +33,16 @@ func (k KillableTasks) Less(i, j int) bool { return k[i].TimeStarted.After(k[j].
 //
 // Does best effort scheduling which tries to assign tasks to nodes already primed for similar tasks.
 // Not all tasks are guaranteed to be scheduled.
+//
+// If config.FairShare is set, nodes left over after priority=3 jobs are split across requestors by weight,
+// see getFairShareTasks. The exception to this being a pure fn is that the fair share deficits, which carry
+// across rounds, are updated in place. deficits may be nil if they needn't be carried.
+//
+// taskLimits caps the number of tasks assigned to each requestor it lists, to enforce quotas.
+// Requestors missing from taskLimits are unlimited, and taskLimits itself may be nil.
 func getTaskAssignments(cs *clusterState, jobs []*jobState,
-	requestors map[string][]*jobState, config *SchedulerConfig, stat stats.StatsReceiver) (
+	requestors map[string][]*jobState, config *SchedulerConfig, deficits map[string]float64,
+	taskLimits map[string]int, stat stats.StatsReceiver) (
 	[]taskAssignment, map[string]*nodeGroup,
 ) {
 	if stat == nil {
@@ -54,6 +65,18 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 		return nil, nil
 	}
 
+	// Copy taskLimits so we can deduct from it as tasks are assigned, and map jobs to their requestor and priority.
+	limits := map[string]int{}
+	for requestor, limit := range taskLimits {
+		limits[requestor] = limit
+	}
+	requestorOf := map[string]string{}
+	priorityOf := map[string]sched.Priority{}
+	for _, j := range jobs {
+		requestorOf[j.Job.Id] = j.Job.Def.Requestor
+		priorityOf[j.Job.Id] = j.Job.Def.Priority
+	}
+
 	// Create a copy of cs.nodeGroups to modify based on new scheduling.
 	clusterSnapshotIds := []string{}
 	nodeGroups := map[string]*nodeGroup{}
@@ -68,16 +91,12 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 		clusterSnapshotIds = append(clusterSnapshotIds, snapId)
 	}
 
-	// Sort jobs by priority and count running tasks.
-	// TOOD(jschiller): don't kill tasks that will result in more than some threshold of work being discarded.
+	// Sort jobs by priority.
 	//
-	// An array indexed by priority. The value is the total number of running tasks for jobs of the given priority.
-	numKillableTasks := []int{0, 0, 0, 0}
 	// An array indexed by priority. The value is the subset of jobs in fifo order for the given priority.
 	priorityJobs := [][]*jobState{[]*jobState{}, []*jobState{}, []*jobState{}, []*jobState{}}
 	for _, job := range jobs {
 		p := int(job.Job.Def.Priority)
-		numKillableTasks[p] += job.TasksRunning
 		priorityJobs[p] = append(priorityJobs[p], job)
 	}
 	stat.Gauge(stats.SchedPriority0JobsGauge).Update(int64(len(priorityJobs[sched.P0])))
@@ -86,21 +105,26 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	stat.Gauge(stats.SchedPriority3JobsGauge).Update(int64(len(priorityJobs[sched.P3])))
 
 	// List killable tasks first by ascending priority and within that, by ascending execution duration.
+	// Running tasks are only killable if config.Preemption allows it.
 	//
 	// An array of in-progress *tasksState ordered by kill preference. Omits priority=3 since nothing should kill those tasks.
 	killableTasks := KillableTasks{}
+	// An array indexed by priority. The value is the total number of killable tasks for jobs of the given priority.
+	numKillableTasks := []int{0, 0, 0, 0}
+	now := time.Now()
+	numProtected := 0
 	for p := range []sched.Priority{sched.P0, sched.P1, sched.P2} {
 		ts := KillableTasks{}
 		for _, j := range priorityJobs[p] {
-			for _, t := range j.Tasks {
-				if t.Status == sched.InProgress {
-					ts = append(ts, t)
-				}
-			}
+			preemptible := config.Preemption.getPreemptibleTasks(j, now)
+			numProtected += j.TasksRunning - len(preemptible)
+			ts = append(ts, preemptible...)
 		}
 		sort.Sort(ts)
 		killableTasks = append(killableTasks, ts...)
+		numKillableTasks[p] = len(ts)
 	}
+	stat.Gauge(stats.SchedPreemptionProtectedTasksGauge).Update(int64(numProtected))
 
 	// Assign each job the minimum number of nodes until free nodes, and killable nodes if allowed, are exhausted.
 	// Priority3 jobs consume all free idle+killable nodes and starve jobs of a lower priority
@@ -109,7 +133,7 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	// Priority0 jobs consume all remaining idle nodes up to a limit.
 	//
 	var tasks []*taskState
-	// The number of healthy nodes we can assign before killing tasks on other nodes.
+	// The number of free slots on healthy nodes we can assign before killing tasks on other nodes.
 	numFree := cs.numFree()
 	// A map[requestor]map[tag]bool{} that makes sure we process all tags for a given requestor once as a batch.
 	tagsSeen := map[string]map[string]bool{}
@@ -125,6 +149,9 @@ func getTaskAssignments(cs *clusterState, jobs []*jobState,
 	remainingRequired := [][][]*taskState{[][]*taskState{}, [][]*taskState{}, [][]*taskState{}, [][]*taskState{}}
 Loop:
 	for _, p := range []sched.Priority{sched.P3, sched.P2, sched.P1, sched.P0} {
+		if config.FairShare && p != sched.P3 {
+			break
+		}
 		for _, job := range priorityJobs[p] {
 			// The number of available nodes for this priority is the remaining free nodes plus allowed killable nodes.
 			numAvailNodes := numFree + numKillableCounter[p]
@@ -157,7 +184,7 @@ Loop:
 					numRunning += j.TasksRunning
 					// Prepend tasks to handle the likely desire for immediate retries for failed tasks in a previous job.
 					// Hardcoded for now as this is the way scheduler is currently invoked by customers.
-					unsched = append(j.getUnScheduledTasks(), unsched...)
+					unsched = append(placeableTasks(j.getUnScheduledTasks(), cs.canEverRun), unsched...)
 					// Stop checking for unscheduled tasks if they exceed available nodes (we'll cap it below).
 					if len(unsched) >= numAvailNodes {
 						break
@@ -171,7 +198,7 @@ Loop:
 
 			// How many of the requested tasks can we assign based on the max healthy task load for our cluster.
 			// (the schedulable count is the minimum number of nodes appropriate for the current set of tasks).
-			numScaledTasks := ceil(float32(numTasks) * config.GetNodeScaleFactor(len(cs.nodes), p))
+			numScaledTasks := ceil(float32(numTasks) * config.GetNodeScaleFactor(cs.numSlots(), p))
 			numSchedulable := 0
 			numDesiredUnmet := 0
 			if p == sched.P3 {
@@ -185,6 +212,11 @@ Loop:
 				numDesiredUnmet = max(0, numDesired-numRunning-numAvailNodes)
 				numSchedulable = min(numAvailNodes, max(0, numDesired-numRunning))
 			}
+			// Don't exceed the requestor's quota, the rest of the tasks remain for the second pass below.
+			if limit, ok := limits[def.Requestor]; ok {
+				numSchedulable = min(numSchedulable, limit)
+				limits[def.Requestor] -= numSchedulable
+			}
 
 			if numSchedulable > 0 {
 				log.WithFields(
@@ -231,6 +263,18 @@ Loop:
 		}
 	}
 
+	// In fair share mode, the free nodes are distributed across requestors by weight rather than by priority.
+	// This runs even if there are no free nodes so that deficits accumulate while the cluster is busy.
+	// This leaves nothing in remainingRequired or remainingOptional for the priority based distribution below.
+	if config.FairShare {
+		if deficits == nil {
+			deficits = map[string]float64{}
+		}
+		fairShareTasks := getFairShareTasks(jobs, tasks, numFree, config.RequestorWeights, deficits, limits, cs.canEverRun)
+		numFree -= len(fairShareTasks)
+		tasks = append(tasks, fairShareTasks...)
+	}
+
 	// If there are still free nodes, priority=3 jobs have been satisfied already.
 	// Distribute a minimum of 75% free to priority=2, 20% to priority=1 and 5% to priority=0
 	// TODO(jschiller) percentages should be configurable.
@@ -254,6 +298,17 @@ LoopRemaining:
 				taskList := &(*taskLists)[i]
 				// Noting that we use ceil() above, we may use less quota than assigned if it's unavailable or unneeded.
 				nTasks := min(numFree, nodeQuota, len(*taskList))
+				// Drop jobs whose requestor has used up its quota.
+				requestor := requestorOf[(*taskList)[0].JobId]
+				if limit, ok := limits[requestor]; ok {
+					if limit == 0 {
+						*taskLists = append((*taskLists)[:i], (*taskLists)[i+1:]...)
+						i--
+						continue
+					}
+					nTasks = min(nTasks, limit)
+					limits[requestor] -= nTasks
+				}
 				if nTasks > 0 {
 					// Move the given number of tasks from remaining to the list of tasks that will be assigned nodes.
 					log.WithFields(
@@ -287,7 +342,12 @@ LoopRemaining:
 	// - New untouched node (or node whose last task used an empty snapshotId)
 	// - A random free node from the idle pools of nodes associated with other snapshotIds.
 	// - A node from the next killable task candidate.
-	assignments := assign(cs, tasks, killableTasks, nodeGroups, append([]string{""}, clusterSnapshotIds...), stat)
+	// Within a pool, nodes with the fewest free slots are preferred so tasks are packed onto nodes.
+	// Only priority=3 tasks can kill other tasks, the rest wait for a free node that can run them.
+	canKill := func(task *taskState) bool {
+		return priorityOf[task.JobId] == sched.P3
+	}
+	assignments := assign(cs, tasks, killableTasks, canKill, nodeGroups, append([]string{""}, clusterSnapshotIds...), stat)
 	if len(assignments) == len(tasks) {
 		log.WithFields(
 			log.Fields{
@@ -307,39 +367,98 @@ LoopRemaining:
 	return assignments, nodeGroups
 }
 
+// Helper fn, returns the given tasks less those for which canRun returns false, or all of them if canRun is nil.
+func placeableTasks(tasks []*taskState, canRun func(*taskState) bool) []*taskState {
+	if canRun == nil {
+		return tasks
+	}
+	placeable := tasks[:0:0]
+	for _, task := range tasks {
+		if canRun(task) {
+			placeable = append(placeable, task)
+		}
+	}
+	return placeable
+}
+
 // Helper fn, appends to 'assignments' and updates nodeGroups.
-// Should successfully assign all given tasks if caller invokes this with self-consistent params.
+// Should successfully assign all given tasks if caller invokes this with self-consistent params,
+// except for tasks whose resources or node selector no free or killable node satisfies, which are skipped.
+// Tasks for which canKill is false are skipped rather than take a killable node.
+// A node stays idle in nodeGroups until tasks have been assigned to all of its free slots.
 func assign(
 	cs *clusterState,
 	tasks []*taskState,
 	killableTasks KillableTasks,
+	canKill func(*taskState) bool,
 	nodeGroups map[string]*nodeGroup,
 	snapIds []string,
 	stat stats.StatsReceiver,
 ) (assignments []taskAssignment) {
+	// The number of tasks assigned to each node's free slots in this round.
+	assigned := map[cluster.NodeId]int{}
+	freeSlots := func(ns *nodeState) int {
+		return ns.numFreeSlots() - assigned[ns.node.Id()]
+	}
+	// The resources of tasks assigned to each node in this round, less those of the tasks they preempt.
+	pending := map[cluster.NodeId]runner.Resources{}
+	canRun := func(ns *nodeState, task *taskState, preempted *taskState) bool {
+		res := pending[ns.node.Id()].Add(task.Def.Resources)
+		if preempted != nil {
+			res = res.Sub(preempted.Def.Resources)
+		}
+		return ns.canRun(task.Def.NodeSelector, res)
+	}
 	for _, task := range tasks {
 		var snapshotId string
 		var nodeSt *nodeState
 		var wasRunning *taskState
-	SnapshotsLoop:
 		for _, snapId := range append([]string{task.Def.SnapshotID}, snapIds...) {
 			if groups, ok := nodeGroups[snapId]; ok {
 				for _, ns := range groups.idle {
-					if ns.suspended() {
+					if ns.suspended() || freeSlots(ns) <= 0 || !canRun(ns, task, nil) {
 						continue
 					}
-					snapshotId = snapId
-					nodeSt = ns
-					break SnapshotsLoop
+					// Prefer the node with the fewest free slots, stopping early if we can't pack any tighter.
+					if nodeSt == nil || freeSlots(ns) < freeSlots(nodeSt) {
+						snapshotId = snapId
+						nodeSt = ns
+					}
+					if freeSlots(nodeSt) == 1 {
+						break
+					}
 				}
 			}
+			if nodeSt != nil {
+				break
+			}
 		}
-		// Could not find any more free nodes, take one from killable nodes.
+		// Could not find any more free nodes, take one from the first killable task whose node can run this task,
+		// if this task may kill one. The killed task's slot is reused, so the node's free slots are unchanged.
 		if nodeSt == nil {
-			wasRunning = killableTasks[0]
+			if canKill(task) {
+				for i, kt := range killableTasks {
+					if ns, ok := cs.nodes[kt.TaskRunner.nodeSt.node.Id()]; ok && canRun(ns, task, kt) {
+						wasRunning = kt
+						nodeSt = ns
+						killableTasks = append(killableTasks[:i:i], killableTasks[i+1:]...)
+						break
+					}
+				}
+			}
+			if nodeSt == nil {
+				stat.Counter(stats.SchedUnplaceableTasksCounter).Inc(1)
+				log.WithFields(
+					log.Fields{
+						"jobID":        task.JobId,
+						"taskID":       task.TaskId,
+						"tag":          task.Def.Tag,
+						"resources":    task.Def.Resources,
+						"nodeSelector": task.Def.NodeSelector,
+					}).Info("No free or killable node satisfies the task's requirements, skipping")
+				continue
+			}
 			snapshotId = wasRunning.Def.SnapshotID
-			nodeSt = cs.nodes[wasRunning.TaskRunner.nodeSt.node.Id()]
-			killableTasks = killableTasks[1:]
 
 			stat.Counter(stats.SchedPreemptedTasksCounter).Inc(1)
 			log.WithFields(
@@ -358,8 +477,16 @@ func assign(
 			nodeGroups[snapshotId] = newNodeGroup()
 		}
 		nodeId := nodeSt.node.Id()
-		nodeGroups[snapshotId].busy[nodeId] = nodeSt
-		delete(nodeGroups[snapshotId].idle, nodeId)
+		if wasRunning == nil {
+			assigned[nodeId]++
+		} else {
+			pending[nodeId] = pending[nodeId].Sub(wasRunning.Def.Resources)
+		}
+		pending[nodeId] = pending[nodeId].Add(task.Def.Resources)
+		if freeSlots(nodeSt) <= 0 {
+			nodeGroups[snapshotId].busy[nodeId] = nodeSt
+			delete(nodeGroups[snapshotId].idle, nodeId)
+		}
 		log.WithFields(
 			log.Fields{
 				"jobID":          task.JobId,
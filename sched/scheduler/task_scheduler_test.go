@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -25,7 +26,7 @@ func Test_TaskAssignment_NoNodesAvailable(t *testing.T) {
 	// create a test cluster with no nodes
 	testCluster := makeTestCluster()
 	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
-	assignments, _ := getTaskAssignments(cs, []*jobState{js}, nil, nil, nil)
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, nil, nil, nil, nil, nil)
 
 	if len(assignments) != 0 {
 		t.Errorf("Assignments on a cluster with no nodes should not return any assignments")
@@ -36,7 +37,7 @@ func Test_TaskAssignment_NoTasks(t *testing.T) {
 	// create a test cluster with no nodes
 	testCluster := makeTestCluster("node1", "node2", "node3", "node4", "node5")
 	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
-	assignments, _ := getTaskAssignments(cs, []*jobState{}, nil, nil, nil)
+	assignments, _ := getTaskAssignments(cs, []*jobState{}, nil, nil, nil, nil, nil)
 
 	if len(assignments) != 0 {
 		t.Errorf("Assignments on a cluster with no nodes should not return any assignments")
@@ -57,7 +58,7 @@ func Test_TaskAssignments_TasksScheduled(t *testing.T) {
 	testCluster := makeTestCluster("node1", "node2", "node3", "node4", "node5")
 	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
 	unScheduledTasks := js.getUnScheduledTasks()
-	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, stats.NilStatsReceiver())
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, nil, nil, stats.NilStatsReceiver())
 
 	if len(assignments) != min(len(unScheduledTasks), len(testCluster.nodes)) {
 		t.Errorf(`Expected as many tasks as possible to be scheduled: NumScheduled %v, 
@@ -80,7 +81,7 @@ func Test_TaskAssignment_Affinity(t *testing.T) {
 	}
 	js := &jobState{Job: &sched.Job{}, Tasks: tasks}
 	req := map[string][]*jobState{"": []*jobState{js}}
-	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, nil)
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, nil, nil, nil)
 	if len(assignments) != 3 {
 		t.Errorf("Expected first three tasks to be assigned, got %v", len(assignments))
 	}
@@ -89,11 +90,11 @@ func Test_TaskAssignment_Affinity(t *testing.T) {
 	taskNodes := map[string]cluster.NodeId{}
 	for _, as := range assignments {
 		taskNodes[as.task.TaskId] = as.nodeSt.node.Id()
-		cs.taskScheduled(as.nodeSt.node.Id(), "job1", as.task.TaskId, as.task.Def.SnapshotID)
+		cs.taskScheduled(as.nodeSt.node.Id(), "job1", as.task.TaskId, as.task.Def.SnapshotID, as.task.Def.Resources)
 		js.taskStarted(as.task.TaskId, &taskRunner{})
 		if as.task.TaskId != "task1" {
-			cs.taskCompleted(as.nodeSt.node.Id(), false)
-			js.taskCompleted(as.task.TaskId, true)
+			cs.taskCompleted(as.nodeSt.node.Id(), "job1", as.task.TaskId, false)
+			js.taskCompleted(as.task.TaskId, true, nil)
 		}
 	}
 
@@ -101,7 +102,7 @@ func Test_TaskAssignment_Affinity(t *testing.T) {
 	cs.update([]cluster.NodeUpdate{
 		cluster.NodeUpdate{UpdateType: cluster.NodeAdded, Id: "node4", Node: cluster.NewIdNode("node4")},
 	})
-	assignments, _ = getTaskAssignments(cs, []*jobState{js}, req, nil, nil)
+	assignments, _ = getTaskAssignments(cs, []*jobState{js}, req, nil, nil, nil, nil)
 	for _, as := range assignments {
 		if as.task.TaskId == "task4" {
 			if as.nodeSt.node.Id() != taskNodes["task2"] {
@@ -115,6 +116,95 @@ func Test_TaskAssignment_Affinity(t *testing.T) {
 	}
 }
 
+// Multi-slot nodes are filled before moving on to the next node, and stay idle while they have free slots.
+func Test_TaskAssignment_MultiSlotNodes(t *testing.T) {
+	testCluster := makeTestCluster("node1", "node2")
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+	for _, ns := range cs.nodes {
+		ns.slots = 2
+	}
+	tasks := []*taskState{
+		&taskState{TaskId: "task1", Def: sched.TaskDefinition{Command: runner.Command{SnapshotID: "snapA"}}},
+		&taskState{TaskId: "task2", Def: sched.TaskDefinition{Command: runner.Command{SnapshotID: "snapA"}}},
+		&taskState{TaskId: "task3", Def: sched.TaskDefinition{Command: runner.Command{SnapshotID: "snapA"}}},
+		&taskState{TaskId: "task4", Def: sched.TaskDefinition{Command: runner.Command{SnapshotID: "snapA"}}},
+		&taskState{TaskId: "task5", Def: sched.TaskDefinition{Command: runner.Command{SnapshotID: "snapA"}}},
+	}
+	js := &jobState{Job: &sched.Job{}, Tasks: tasks}
+	req := map[string][]*jobState{"": []*jobState{js}}
+
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, nil, nil, nil)
+	if len(assignments) != 4 {
+		t.Fatalf("Expected four tasks to be assigned to two nodes with two slots each, got %v", len(assignments))
+	}
+	perNode := map[cluster.NodeId]int{}
+	for _, as := range assignments {
+		perNode[as.nodeSt.node.Id()]++
+	}
+	if perNode["node1"] != 2 || perNode["node2"] != 2 {
+		t.Fatalf("Expected two tasks per node, got %v", perNode)
+	}
+
+	// Run one task on each node, both nodes still have a free slot and should be idle.
+	cs.taskScheduled("node1", "job1", "task1", "snapA", runner.Resources{})
+	cs.taskScheduled("node2", "job1", "task2", "snapA", runner.Resources{})
+	if cs.numFree() != 2 || len(cs.nodeGroups["snapA"].idle) != 2 {
+		t.Fatalf("Expected two free slots on two idle nodes, got %v, %v", cs.numFree(), cs.nodeGroups["snapA"])
+	}
+
+	// Fill node1, it should now be busy.
+	cs.taskScheduled("node1", "job1", "task3", "snapA", runner.Resources{})
+	if cs.numFree() != 1 || len(cs.nodeGroups["snapA"].busy) != 1 || cs.nodeGroups["snapA"].busy["node1"] == nil {
+		t.Fatalf("Expected node1 to be busy, got %v", cs.nodeGroups["snapA"])
+	}
+
+	// Completing one of node1's tasks frees a slot and makes it idle again.
+	cs.taskCompleted("node1", "job1", "task1", false)
+	if !cs.nodes["node1"].isRunning("job1", "task3") || cs.nodes["node1"].isRunning("job1", "task1") {
+		t.Fatalf("Expected only task3 running on node1, got %v", cs.nodes["node1"].running)
+	}
+	if cs.numFree() != 2 || cs.nodeGroups["snapA"].idle["node1"] == nil {
+		t.Fatalf("Expected node1 to be idle, got %v", cs.nodeGroups["snapA"])
+	}
+}
+
+// Tasks are only assigned to nodes with matching labels and enough resources left, others are skipped.
+func Test_TaskAssignment_Constraints(t *testing.T) {
+	testCluster := makeTestCluster("small", "big")
+	cs := newClusterState(testCluster.nodes, testCluster.ch, nil, stats.NilStatsReceiver())
+	cs.nodes["small"].slots = 2
+	cs.nodes["small"].capacity = runner.Capacity{Slots: 2, MemoryBytes: 8 << 30, Labels: map[string]string{"os": "linux"}}
+	cs.nodes["big"].slots = 2
+	cs.nodes["big"].capacity = runner.Capacity{Slots: 2, MemoryBytes: 64 << 30, Labels: map[string]string{"os": "linux", "has": "docker"}}
+
+	def := func(mem int64, selector map[string]string) sched.TaskDefinition {
+		return sched.TaskDefinition{
+			Command:      runner.Command{SnapshotID: "snapA"},
+			Resources:    runner.Resources{MemoryBytes: mem},
+			NodeSelector: selector,
+		}
+	}
+	tasks := []*taskState{
+		&taskState{TaskId: "task1", Def: def(30<<30, nil)},
+		&taskState{TaskId: "task2", Def: def(30<<30, nil)},
+		&taskState{TaskId: "task3", Def: def(30<<30, nil)},
+		&taskState{TaskId: "task4", Def: def(0, map[string]string{"os": "windows"})},
+		&taskState{TaskId: "task5", Def: def(1<<30, map[string]string{"os": "linux"})},
+	}
+	js := &jobState{Job: &sched.Job{}, Tasks: tasks}
+	req := map[string][]*jobState{"": []*jobState{js}}
+
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, nil, nil, stats.NilStatsReceiver())
+	taskNodes := map[string]cluster.NodeId{}
+	for _, as := range assignments {
+		taskNodes[as.task.TaskId] = as.nodeSt.node.Id()
+	}
+	expected := map[string]cluster.NodeId{"task1": "big", "task2": "big", "task5": "small"}
+	if !reflect.DeepEqual(taskNodes, expected) {
+		t.Fatalf("Expected %v, got %v", expected, taskNodes)
+	}
+}
+
 // We want to see three tasks with TagX scheduled first, followed by one TagY, then the final TagX
 func Test_TaskAssignments_RequestorBatching(t *testing.T) {
 	js := []*jobState{
@@ -161,7 +251,7 @@ func Test_TaskAssignments_RequestorBatching(t *testing.T) {
 		SoftMaxSchedulableTasks: 10, // We want numTasks*GetNodeScaleFactor()==3 to define a specific order for scheduling.
 	}
 
-	assignments, _ := getTaskAssignments(cs, js, req, config, nil)
+	assignments, _ := getTaskAssignments(cs, js, req, config, nil, nil, nil)
 	if len(assignments) != 5 {
 		t.Errorf("Expected all five tasks to be assigned, got %v", len(assignments))
 	}
@@ -227,7 +317,7 @@ func Test_TaskAssignments_PrioritySimple(t *testing.T) {
 
 	req := map[string][]*jobState{"": js}
 
-	assignments, _ := getTaskAssignments(cs, js, req, nil, nil)
+	assignments, _ := getTaskAssignments(cs, js, req, nil, nil, nil, nil)
 	if len(assignments) != 4 {
 		t.Errorf("Expected four tasks to be assigned, got %v", len(assignments))
 	}
@@ -295,7 +385,7 @@ func Test_TaskAssignments_PriorityStages(t *testing.T) {
 	}
 
 	// Check for all 10 P3 tasks
-	assignments, _ := getTaskAssignments(cs, js, req, config, nil)
+	assignments, _ := getTaskAssignments(cs, js, req, config, nil, nil, nil)
 	if len(assignments) != 10 {
 		t.Fatalf("Expected ten tasks to be assigned, got %v", len(assignments))
 	}
@@ -305,14 +395,14 @@ func Test_TaskAssignments_PriorityStages(t *testing.T) {
 		}
 		for _, j := range js {
 			if j.Job.Id == assignment.task.JobId {
-				j.taskCompleted(assignment.task.TaskId, true)
+				j.taskCompleted(assignment.task.TaskId, true, nil)
 			}
 		}
 	}
 
 	// Check for 5 P2, 3 P1, and 2 P0 tasks
 	NodeScaleAdjustment = 0 //Reset this global setting to simplify testing here.
-	assignments, _ = getTaskAssignments(cs, js, req, config, nil)
+	assignments, _ = getTaskAssignments(cs, js, req, config, nil, nil, nil)
 	if len(assignments) != 10 {
 		t.Fatalf("Expected ten tasks to be assigned, got %v", len(assignments))
 	}
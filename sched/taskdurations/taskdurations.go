@@ -0,0 +1,306 @@
+// Package taskdurations records how long tasks take to run, so the scheduler can start the
+// likely longest tasks of a job first. Durations can be persisted to a file to survive restarts.
+package taskdurations
+
+import (
+	"container/list"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Number of recent durations kept per task, which the average is computed from.
+const DefaultWindowSize = 20
+
+// Number of tasks to keep durations for, the least recently used tasks are forgotten first.
+const DefaultMaxTasks = 100000
+
+// How often a file backed Store writes out new durations.
+const DefaultFlushInterval = 30 * time.Second
+
+// Identifies a task across jobs. Tasks with the same ID in jobs of different types are tracked separately.
+type Key struct {
+	TaskID  string
+	JobType string
+}
+
+// Summary of the recent durations of a task
+type Stats struct {
+	Count   int64         // Number of durations ever recorded, including those that have left the window.
+	Average time.Duration // Moving average over the window.
+}
+
+// Store keeps a window of recent durations for each task.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Returns the Stats for the key, or nil if no duration was recorded for it.
+	Get(key Key) (*Stats, error)
+
+	// Adds a duration for the key, dropping the oldest duration in its window if it's full.
+	Record(key Key, d time.Duration) error
+
+	// Returns the number of tasks with recorded durations.
+	Len() int
+
+	// Persists any durations recorded since the last flush. A no-op for in-memory Stores.
+	Flush() error
+
+	// Stops flushing in the background and flushes once more. A no-op for in-memory Stores.
+	Close() error
+}
+
+// In-memory Store that forgets the least recently used task once it holds maxTasks
+type memoryStore struct {
+	windowSize int
+	maxTasks   int
+	tasks      map[Key]*list.Element
+	lru        *list.List
+	mu         sync.Mutex
+}
+
+type history struct {
+	key     Key
+	count   int64
+	samples []time.Duration // Oldest first.
+}
+
+// Creates an in-memory Store. Zero values use DefaultWindowSize and DefaultMaxTasks.
+func NewMemoryStore(windowSize, maxTasks int) Store {
+	return newMemoryStore(windowSize, maxTasks)
+}
+
+func newMemoryStore(windowSize, maxTasks int) *memoryStore {
+	if windowSize <= 0 {
+		windowSize = DefaultWindowSize
+	}
+	if maxTasks <= 0 {
+		maxTasks = DefaultMaxTasks
+	}
+	return &memoryStore{
+		windowSize: windowSize,
+		maxTasks:   maxTasks,
+		tasks:      make(map[Key]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (s *memoryStore) Get(key Key) (*Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.tasks[key]
+	if !ok {
+		return nil, nil
+	}
+	s.lru.MoveToFront(e)
+	return e.Value.(*history).stats(), nil
+}
+
+func (s *memoryStore) Record(key Key, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(&history{key: key}).record(d, s.windowSize)
+	return nil
+}
+
+func (s *memoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+func (s *memoryStore) Flush() error {
+	return nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// Returns the existing history for h.key, or adds h as the most recently used history.
+// Caller must hold mu.
+func (s *memoryStore) add(h *history) *history {
+	if e, ok := s.tasks[h.key]; ok {
+		s.lru.MoveToFront(e)
+		return e.Value.(*history)
+	}
+	s.tasks[h.key] = s.lru.PushFront(h)
+	for s.lru.Len() > s.maxTasks {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.tasks, oldest.Value.(*history).key)
+	}
+	return h
+}
+
+func (h *history) record(d time.Duration, windowSize int) {
+	h.count++
+	h.samples = append(h.samples, d)
+	if len(h.samples) > windowSize {
+		h.samples = append([]time.Duration{}, h.samples[len(h.samples)-windowSize:]...)
+	}
+}
+
+func (h *history) stats() *Stats {
+	var sum time.Duration
+	for _, d := range h.samples {
+		sum += d
+	}
+	return &Stats{Count: h.count, Average: sum / time.Duration(len(h.samples))}
+}
+
+// Store that keeps durations in memory and periodically writes them to a file,
+// from which they are loaded when the Store is created.
+type fileStore struct {
+	*memoryStore
+	path string
+
+	flushMu sync.Mutex // Serializes writes to path.
+	dirty   bool       // Guarded by memoryStore.mu.
+
+	doneCh   chan struct{}
+	doneOnce sync.Once
+	flushWg  sync.WaitGroup
+}
+
+// On disk representation of a fileStore, tasks are ordered from least to most recently used.
+type fileContents struct {
+	Tasks []fileTask
+}
+
+type fileTask struct {
+	TaskID  string
+	JobType string
+	Count   int64
+	Samples []time.Duration
+}
+
+// Creates a Store persisted to path, loading any durations already there. Zero values use
+// DefaultWindowSize and DefaultMaxTasks. If flushInterval is positive, new durations are written
+// out that often in the background until the Store is closed, otherwise only when Flush is called.
+// Returns an error if path exists but can't be loaded.
+func NewFileStore(path string, windowSize, maxTasks int, flushInterval time.Duration) (Store, error) {
+	s := &fileStore{memoryStore: newMemoryStore(windowSize, maxTasks), path: path, doneCh: make(chan struct{})}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if flushInterval > 0 {
+		s.flushWg.Add(1)
+		go s.flushLoop(flushInterval)
+	}
+	return s, nil
+}
+
+// Flushes every interval until the Store is closed.
+func (s *fileStore) flushLoop(interval time.Duration) {
+	defer s.flushWg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				log.WithFields(
+					log.Fields{
+						"path": s.path,
+						"err":  err,
+					}).Info("Failed to write task durations")
+			}
+		case <-s.doneCh:
+			return
+		}
+	}
+}
+
+func (s *fileStore) Close() error {
+	s.doneOnce.Do(func() { close(s.doneCh) })
+	s.flushWg.Wait()
+	return s.Flush()
+}
+
+func (s *fileStore) Record(key Key, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(&history{key: key}).record(d, s.windowSize)
+	s.dirty = true
+	return nil
+}
+
+// Writes the durations to a temporary file which then replaces path, so a crash never leaves a partial file.
+func (s *fileStore) Flush() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	contents := fileContents{Tasks: make([]fileTask, 0, s.lru.Len())}
+	for e := s.lru.Back(); e != nil; e = e.Prev() {
+		h := e.Value.(*history)
+		samples := append([]time.Duration{}, h.samples...)
+		contents.Tasks = append(contents.Tasks, fileTask{TaskID: h.key.TaskID, JobType: h.key.JobType, Count: h.count, Samples: samples})
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	err := s.write(contents)
+	if err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *fileStore) write(contents fileContents) error {
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *fileStore) load() error {
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var contents fileContents
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range contents.Tasks {
+		if len(t.Samples) == 0 {
+			continue
+		}
+		samples := t.Samples
+		if len(samples) > s.windowSize {
+			samples = samples[len(samples)-s.windowSize:]
+		}
+		s.add(&history{key: Key{TaskID: t.TaskID, JobType: t.JobType}, count: t.Count, samples: samples})
+	}
+	return nil
+}
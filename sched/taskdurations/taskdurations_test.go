@@ -0,0 +1,112 @@
+package taskdurations
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreStats(t *testing.T) {
+	s := NewMemoryStore(4, 0)
+	key := Key{TaskID: "task", JobType: "test"}
+
+	if st, err := s.Get(key); err != nil || st != nil {
+		t.Fatalf("Expected no stats for unknown task, got %v %v", st, err)
+	}
+
+	for _, d := range []time.Duration{100, 1, 2, 3, 10} {
+		s.Record(key, d*time.Second)
+	}
+	// The first duration has left the window.
+	st, err := s.Get(key)
+	if err != nil || st == nil {
+		t.Fatalf("Expected stats, got %v %v", st, err)
+	}
+	if st.Count != 5 || st.Average != 4*time.Second {
+		t.Errorf("Unexpected stats %+v", st)
+	}
+
+	// Durations are tracked separately per job type.
+	if st, _ := s.Get(Key{TaskID: "task", JobType: "other"}); st != nil {
+		t.Errorf("Expected no stats for other job type, got %+v", st)
+	}
+}
+
+func TestMemoryStoreEviction(t *testing.T) {
+	s := NewMemoryStore(0, 2)
+	s.Record(Key{TaskID: "a"}, time.Second)
+	s.Record(Key{TaskID: "b"}, time.Second)
+	s.Get(Key{TaskID: "a"})
+	s.Record(Key{TaskID: "c"}, time.Second)
+
+	if s.Len() != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", s.Len())
+	}
+	if st, _ := s.Get(Key{TaskID: "b"}); st != nil {
+		t.Errorf("Expected least recently used task to be evicted")
+	}
+	if st, _ := s.Get(Key{TaskID: "a"}); st == nil {
+		t.Errorf("Expected recently used task to be kept")
+	}
+}
+
+func TestFileStoreRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "taskdurations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "durations.json")
+
+	s, err := NewFileStore(path, 2, 0, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error creating store: %v", err)
+	}
+	key := Key{TaskID: "task", JobType: "test"}
+	for _, d := range []time.Duration{1, 2, 4} {
+		s.Record(key, d*time.Second)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Unexpected error flushing: %v", err)
+	}
+
+	restarted, err := NewFileStore(path, 2, 0, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error loading store: %v", err)
+	}
+	st, _ := restarted.Get(key)
+	if st == nil || st.Count != 3 || st.Average != 3*time.Second {
+		t.Fatalf("Expected durations to survive a restart, got %+v", st)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path, 0, 0, 0); err == nil {
+		t.Errorf("Expected error loading corrupt file")
+	}
+}
+
+func TestFileStoreClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "taskdurations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "durations.json")
+
+	s, err := NewFileStore(path, 0, 0, time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error creating store: %v", err)
+	}
+	s.Record(Key{TaskID: "task"}, time.Second)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Unexpected error closing: %v", err)
+	}
+	// Close must stop the flush loop, and write out what hasn't been flushed yet.
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected durations to be written on close, got %v", err)
+	}
+}
@@ -98,6 +98,145 @@ func (c *CloudScootClient) GetStatus(jobId string) (r *scoot.JobStatus, err erro
 	return jobStatus, err
 }
 
+// WatchJob API. Waits up to timeoutMs for the job to change after version, and returns
+// a JobStatus with only the tasks that changed and the version to pass in next time.
+func (c *CloudScootClient) WatchJob(jobId string, version int64, timeoutMs int32) (r *scoot.JobStatus, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobStatus, err := c.client.WatchJob(jobId, version, timeoutMs)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return jobStatus, err
+}
+
+// ListJobs API. Returns the jobs in progress in the scheduler that match the request.
+func (c *CloudScootClient) ListJobs(req *scoot.ListJobsRequest) (r *scoot.ListJobsResponse, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.ListJobs(req)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return resp, err
+}
+
+// Heartbeat API. Registers or refreshes a worker with the scheduler.
+func (c *CloudScootClient) Heartbeat(hb *scoot.WorkerHeartbeat) (err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.client.Heartbeat(hb)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return err
+}
+
+// DrainNodes API. Marks the nodes as draining and returns the status of all draining nodes.
+func (c *CloudScootClient) DrainNodes(nodeIds []string) (r *scoot.DrainStatusResponse, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.DrainNodes(nodeIds)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return resp, err
+}
+
+// UndrainNodes API. Returns the nodes to rotation and returns the status of the nodes still draining.
+func (c *CloudScootClient) UndrainNodes(nodeIds []string) (r *scoot.DrainStatusResponse, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.UndrainNodes(nodeIds)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return resp, err
+}
+
+// GetDrainStatus API. Returns the status of all draining nodes.
+func (c *CloudScootClient) GetDrainStatus() (r *scoot.DrainStatusResponse, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.GetDrainStatus()
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return resp, err
+}
+
 // Close any open Transport associated with this ScootClient
 func (c *CloudScootClient) Close() error {
 	if c.client != nil {
@@ -46,6 +46,10 @@ func NewSimpleCLIClient(d dialer.Dialer) (CLIClient, error) {
 	c.addCmd(&smokeTestCmd{})
 	c.addCmd(&watchJobCmd{})
 	c.addCmd(&killJobCmd{})
+	c.addCmd(&listJobsCmd{})
+	c.addCmd(&drainNodesCmd{})
+	c.addCmd(&undrainNodesCmd{})
+	c.addCmd(&drainStatusCmd{})
 
 	return c, nil
 }
@@ -0,0 +1,117 @@
+package client
+
+/**
+implements the command line entries for draining nodes, returning them to rotation, and listing draining nodes
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type drainNodesCmd struct {
+	printAsJson bool
+}
+
+func (c *drainNodesCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "drain_nodes",
+		Short: "Stop scheduling new tasks on the given nodes, letting their current tasks finish",
+	}
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out drain status as JSON")
+	return r
+}
+
+func (c *drainNodesCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Draining Scoot Nodes", args)
+
+	if len(args) == 0 {
+		return errors.New("at least one node id must be provided")
+	}
+	resp, err := cl.scootClient.DrainNodes(args)
+	return printDrainStatus(resp, err, c.printAsJson)
+}
+
+type undrainNodesCmd struct {
+	printAsJson bool
+}
+
+func (c *undrainNodesCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "undrain_nodes",
+		Short: "Return the given draining nodes to rotation",
+	}
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out drain status as JSON")
+	return r
+}
+
+func (c *undrainNodesCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Undraining Scoot Nodes", args)
+
+	if len(args) == 0 {
+		return errors.New("at least one node id must be provided")
+	}
+	resp, err := cl.scootClient.UndrainNodes(args)
+	return printDrainStatus(resp, err, c.printAsJson)
+}
+
+type drainStatusCmd struct {
+	printAsJson bool
+}
+
+func (c *drainStatusCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "drain_status",
+		Short: "List draining nodes and whether they've drained",
+	}
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out drain status as JSON")
+	return r
+}
+
+func (c *drainStatusCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Getting Scoot Drain Status", args)
+
+	resp, err := cl.scootClient.GetDrainStatus()
+	return printDrainStatus(resp, err, c.printAsJson)
+}
+
+func printDrainStatus(resp *scoot.DrainStatusResponse, err error, printAsJson bool) error {
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error draining nodes: %v", err.Error())
+		}
+	}
+
+	if printAsJson {
+		asJson, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("Error converting drain status to JSON: %v", err.Error())
+		}
+		log.Infof("%s\n", asJson)
+		fmt.Printf("%s\n", asJson) // must also go to stdout in case caller looking in stdout for the results
+		return nil
+	}
+
+	log.Infof("%d draining nodes", len(resp.Nodes))
+	fmt.Printf("%d draining nodes\n", len(resp.Nodes))
+	for _, node := range resp.Nodes {
+		state := "draining"
+		if node.Drained {
+			state = "drained"
+		}
+		line := fmt.Sprintf("%s %s running=%d inCluster=%t", node.NodeId, state, node.GetNumRunning(), node.GetInCluster())
+		log.Info(line)
+		fmt.Println(line) // must also go to stdout in case caller looking in stdout for the results
+	}
+	return nil
+}
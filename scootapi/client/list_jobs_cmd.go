@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type listJobsCmd struct {
+	requestor   string
+	tag         string
+	basis       string
+	priority    int
+	status      string
+	offset      int
+	limit       int
+	printAsJson bool
+}
+
+func (c *listJobsCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "list_jobs",
+		Short: "ListJobs in progress in the scheduler",
+	}
+	r.Flags().StringVar(&c.requestor, "requestor", "", "Only list jobs from this requestor")
+	r.Flags().StringVar(&c.tag, "tag", "", "Only list jobs with this tag")
+	r.Flags().StringVar(&c.basis, "basis", "", "Only list jobs with this basis")
+	r.Flags().IntVar(&c.priority, "priority", -1, "Only list jobs with this priority, if not negative")
+	r.Flags().StringVar(&c.status, "status", "", "Only list jobs with this status, e.g. NOT_STARTED or IN_PROGRESS")
+	r.Flags().IntVar(&c.offset, "offset", 0, "Number of matching jobs to skip")
+	r.Flags().IntVar(&c.limit, "limit", 0, "Maximum number of jobs to list, or 0 for the server default")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out jobs as JSON")
+	return r
+}
+
+func (c *listJobsCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Listing Scoot Jobs", args)
+
+	req := scoot.NewListJobsRequest()
+	if c.requestor != "" {
+		req.Requestor = &c.requestor
+	}
+	if c.tag != "" {
+		req.Tag = &c.tag
+	}
+	if c.basis != "" {
+		req.Basis = &c.basis
+	}
+	if c.priority >= 0 {
+		priority := int32(c.priority)
+		req.Priority = &priority
+	}
+	if c.status != "" {
+		status, err := scoot.StatusFromString(strings.ToUpper(c.status))
+		if err != nil {
+			return err
+		}
+		req.Status = &status
+	}
+	offset, limit := int32(c.offset), int32(c.limit)
+	req.Offset = &offset
+	if limit != 0 {
+		req.Limit = &limit
+	}
+
+	resp, err := cl.scootClient.ListJobs(req)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		default:
+			return fmt.Errorf("Error listing jobs: %v", err.Error())
+		}
+	}
+
+	if c.printAsJson {
+		asJson, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("Error converting jobs to JSON: %v", err.Error())
+		}
+		log.Infof("%s\n", asJson)
+		fmt.Printf("%s\n", asJson) // must also go to stdout in case caller looking in stdout for the results
+		return nil
+	}
+
+	log.Infof("Listing %d of %d jobs", len(resp.Jobs), resp.GetTotal())
+	fmt.Printf("Listing %d of %d jobs\n", len(resp.Jobs), resp.GetTotal())
+	for _, job := range resp.Jobs {
+		line := fmt.Sprintf("%s %v requestor=%q tag=%q basis=%q priority=%d submitted=%s tasks=%d running=%d completed=%d",
+			job.ID, job.Status, job.GetRequestor(), job.GetTag(), job.GetBasis(), job.GetPriority(),
+			time.Unix(0, job.GetSubmitTimeMs()*int64(time.Millisecond)).Format(time.RFC3339),
+			job.GetNumTasks(), job.GetNumRunning(), job.GetNumCompleted())
+		log.Info(line)
+		fmt.Println(line) // must also go to stdout in case caller looking in stdout for the results
+	}
+	return nil
+}
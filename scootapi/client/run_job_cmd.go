@@ -19,6 +19,10 @@ type runJobCmd struct {
 	snapshotId  string
 	jobFilePath string
 	tag         string
+	envVars     []string
+	sandbox     bool
+	image       string
+	outputs     []string
 }
 
 func (c *runJobCmd) registerFlags() *cobra.Command {
@@ -30,6 +34,10 @@ func (c *runJobCmd) registerFlags() *cobra.Command {
 	r.Flags().StringVar(&c.snapshotId, "snapshot_id", "", "Repo checkout id: <master-sha> OR <backend>-<kind>(-<additional information>)+")
 	r.Flags().StringVar(&c.jobFilePath, "job_def", "", "JSON file to read jobs from. Error if snapshot_id flag is also provided.")
 	r.Flags().StringVar(&c.tag, "tag", "", "Tag can be specified by requestor in order to more easily trace a job through logs")
+	r.Flags().StringArrayVar(&c.envVars, "env", nil, "Environment variable to set for the command as key=value, may be repeated. Ignored with job_def.")
+	r.Flags().BoolVar(&c.sandbox, "sandbox", false, "Run the command in a sandbox without network access, only able to write to its checkout. Ignored with job_def.")
+	r.Flags().StringVar(&c.image, "image", "", "Run the command sandboxed in this image from the workers' image directory, ex: go.tar:1.8, with its checkout at /scoot/checkout. Ignored with job_def.")
+	r.Flags().StringArrayVar(&c.outputs, "output", nil, "Checkout path or glob to copy into the result snapshot, ex: reports/*.xml, may be repeated. Ignored with job_def.")
 	return r
 }
 
@@ -46,10 +54,24 @@ type CLIJobDef struct {
 }
 
 type TaskDef struct {
-	Args       []string
-	SnapshotID string
-	TimeoutMs  int32
-	TaskID     string
+	Args             []string
+	SnapshotID       string
+	TimeoutMs        int32
+	TaskID           string
+	EnvVars          map[string]string
+	Sandbox          bool
+	Image            string
+	Outputs          []string
+	Dependencies     []string
+	SnapshotFromTask string
+	Resources        TaskResources
+	NodeSelector     map[string]string
+}
+
+type TaskResources struct {
+	CPUs        int32
+	MemoryBytes int64
+	DiskBytes   int64
 }
 
 func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
@@ -72,6 +94,23 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 		taskId := "task1"
 		task.Command = scoot.NewCommand()
 		task.Command.Argv = args
+		if len(c.envVars) > 0 {
+			task.Command.EnvVars = map[string]string{}
+			for _, kv := range c.envVars {
+				parts := strings.SplitN(kv, "=", 2)
+				if len(parts) != 2 || parts[0] == "" {
+					return fmt.Errorf("Invalid env %q, expected key=value", kv)
+				}
+				task.Command.EnvVars[parts[0]] = parts[1]
+			}
+		}
+		if c.sandbox {
+			task.Command.Sandbox = &c.sandbox
+		}
+		if c.image != "" {
+			task.Command.Image = &c.image
+		}
+		task.Command.Outputs = c.outputs
 		task.SnapshotId = &c.snapshotId
 		task.TaskId = &taskId
 		jobDef.Tasks = []*scoot.TaskDefinition{task}
@@ -104,8 +143,28 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 			taskDef := scoot.NewTaskDefinition()
 			taskDef.Command = scoot.NewCommand()
 			taskDef.Command.Argv = jt.Args
+			taskDef.Command.EnvVars = jt.EnvVars
+			if jt.Sandbox {
+				taskDef.Command.Sandbox = &jt.Sandbox
+			}
+			if jt.Image != "" {
+				taskDef.Command.Image = &jt.Image
+			}
+			taskDef.Command.Outputs = jt.Outputs
 			taskDef.SnapshotId = &jt.SnapshotID
 			taskDef.TaskId = &jt.TaskID
+			taskDef.Dependencies = jt.Dependencies
+			if jt.SnapshotFromTask != "" {
+				taskDef.SnapshotFromTask = &jt.SnapshotFromTask
+			}
+			if jt.Resources != (TaskResources{}) {
+				taskDef.Resources = &scoot.Resources{
+					Cpus:        &jt.Resources.CPUs,
+					MemoryBytes: &jt.Resources.MemoryBytes,
+					DiskBytes:   &jt.Resources.DiskBytes,
+				}
+			}
+			taskDef.NodeSelector = jt.NodeSelector
 			jobDef.Tasks = append(jobDef.Tasks, taskDef)
 			if jt.TimeoutMs > 0 {
 				taskDef.TimeoutMs = &jt.TimeoutMs
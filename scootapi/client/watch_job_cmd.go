@@ -12,6 +12,7 @@ import (
 
 const (
 	jobStatusSleepSeconds time.Duration = 3 * time.Second
+	jobWatchTimeout       time.Duration = 20 * time.Second
 )
 
 type watchJobCmd struct {
@@ -37,17 +38,26 @@ func (c *watchJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string
 
 	jobId := args[0]
 
+	// Long-poll for changes, printing only the tasks that changed since the last response.
+	var version int64
 	for {
-		jobStatus, err := GetAndPrintStatus(jobId, cl.scootClient)
+		status, err := cl.scootClient.WatchJob(jobId, version, int32(jobWatchTimeout/time.Millisecond))
 		if err != nil {
-			return err
+			return convertStatusErr(err)
+		}
+		if len(status.TaskStatus) > 0 {
+			PrintJobStatus(status)
 		}
 
-		if *jobStatus == scoot.Status_COMPLETED || *jobStatus == scoot.Status_ROLLED_BACK {
+		if status.Status == scoot.Status_COMPLETED || status.Status == scoot.Status_ROLLED_BACK {
 			return nil
 		}
 
-		time.Sleep(jobStatusSleepSeconds)
+		// Statuses read from the saga log don't have a version and return immediately, so avoid spinning on them.
+		if !status.IsSetVersion() {
+			time.Sleep(jobStatusSleepSeconds)
+		}
+		version = status.GetVersion()
 	}
 
 }
@@ -56,12 +66,7 @@ func GetAndPrintStatus(jobId string, thriftClient scoot.CloudScoot) (*scoot.Stat
 
 	status, err := thriftClient.GetStatus(jobId)
 	if err != nil {
-		switch err := err.(type) {
-		case *scoot.InvalidRequest:
-			return nil, fmt.Errorf("Invalid Request: %v", err.GetMessage())
-		case *scoot.ScootServerError:
-			return nil, fmt.Errorf("Error getting status: %v", err.Error())
-		}
+		return nil, convertStatusErr(err)
 	}
 	PrintJobStatus(status)
 
@@ -69,6 +74,16 @@ func GetAndPrintStatus(jobId string, thriftClient scoot.CloudScoot) (*scoot.Stat
 
 }
 
+func convertStatusErr(err error) error {
+	switch err := err.(type) {
+	case *scoot.InvalidRequest:
+		return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+	case *scoot.ScootServerError:
+		return fmt.Errorf("Error getting status: %v", err.Error())
+	}
+	return err
+}
+
 func PrintJobStatus(jobStatus *scoot.JobStatus) {
 	log.Infof("Job id: %s\n", jobStatus.ID)
 	log.Infof("Job status: %s\n", jobStatus.Status.String())
@@ -23,6 +23,12 @@ func Usage() {
 	fmt.Fprintln(os.Stderr, "  JobId RunJob(JobDefinition job)")
 	fmt.Fprintln(os.Stderr, "  JobStatus GetStatus(string jobId)")
 	fmt.Fprintln(os.Stderr, "  JobStatus KillJob(string jobId)")
+	fmt.Fprintln(os.Stderr, "  JobStatus WatchJob(string jobId, i64 version, i32 timeoutMs)")
+	fmt.Fprintln(os.Stderr, "  ListJobsResponse ListJobs(ListJobsRequest request)")
+	fmt.Fprintln(os.Stderr, "  void Heartbeat(WorkerHeartbeat heartbeat)")
+	fmt.Fprintln(os.Stderr, "  DrainStatusResponse DrainNodes(list<string> nodeIds)")
+	fmt.Fprintln(os.Stderr, "  DrainStatusResponse UndrainNodes(list<string> nodeIds)")
+	fmt.Fprintln(os.Stderr, "  DrainStatusResponse GetDrainStatus()")
 	fmt.Fprintln(os.Stderr)
 	os.Exit(0)
 }
@@ -122,19 +128,19 @@ func main() {
 			fmt.Fprintln(os.Stderr, "RunJob requires 1 args")
 			flag.Usage()
 		}
-		arg14 := flag.Arg(1)
-		mbTrans15 := thrift.NewTMemoryBufferLen(len(arg14))
-		defer mbTrans15.Close()
-		_, err16 := mbTrans15.WriteString(arg14)
-		if err16 != nil {
+		arg39 := flag.Arg(1)
+		mbTrans40 := thrift.NewTMemoryBufferLen(len(arg39))
+		defer mbTrans40.Close()
+		_, err41 := mbTrans40.WriteString(arg39)
+		if err41 != nil {
 			Usage()
 			return
 		}
-		factory17 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt18 := factory17.GetProtocol(mbTrans15)
+		factory42 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt43 := factory42.GetProtocol(mbTrans40)
 		argvalue0 := scoot.NewJobDefinition()
-		err19 := argvalue0.Read(jsProt18)
-		if err19 != nil {
+		err44 := argvalue0.Read(jsProt43)
+		if err44 != nil {
 			Usage()
 			return
 		}
@@ -162,6 +168,153 @@ func main() {
 		fmt.Print(client.KillJob(value0))
 		fmt.Print("\n")
 		break
+	case "WatchJob":
+		if flag.NArg()-1 != 3 {
+			fmt.Fprintln(os.Stderr, "WatchJob requires 3 args")
+			flag.Usage()
+		}
+		argvalue0 := flag.Arg(1)
+		value0 := argvalue0
+		argvalue1, err45 := (strconv.ParseInt(flag.Arg(2), 10, 64))
+		if err45 != nil {
+			Usage()
+			return
+		}
+		value1 := argvalue1
+		tmp2, err46 := (strconv.Atoi(flag.Arg(3)))
+		if err46 != nil {
+			Usage()
+			return
+		}
+		argvalue2 := int32(tmp2)
+		value2 := argvalue2
+		fmt.Print(client.WatchJob(value0, value1, value2))
+		fmt.Print("\n")
+		break
+	case "ListJobs":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "ListJobs requires 1 args")
+			flag.Usage()
+		}
+		arg47 := flag.Arg(1)
+		mbTrans48 := thrift.NewTMemoryBufferLen(len(arg47))
+		defer mbTrans48.Close()
+		_, err49 := mbTrans48.WriteString(arg47)
+		if err49 != nil {
+			Usage()
+			return
+		}
+		factory50 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt51 := factory50.GetProtocol(mbTrans48)
+		argvalue0 := scoot.NewListJobsRequest()
+		err52 := argvalue0.Read(jsProt51)
+		if err52 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.ListJobs(value0))
+		fmt.Print("\n")
+		break
+	case "Heartbeat":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Heartbeat requires 1 args")
+			flag.Usage()
+		}
+		arg53 := flag.Arg(1)
+		mbTrans54 := thrift.NewTMemoryBufferLen(len(arg53))
+		defer mbTrans54.Close()
+		_, err55 := mbTrans54.WriteString(arg53)
+		if err55 != nil {
+			Usage()
+			return
+		}
+		factory56 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt57 := factory56.GetProtocol(mbTrans54)
+		argvalue0 := scoot.NewWorkerHeartbeat()
+		err58 := argvalue0.Read(jsProt57)
+		if err58 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Heartbeat(value0))
+		fmt.Print("\n")
+		break
+	case "DrainNodes":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "DrainNodes requires 1 args")
+			flag.Usage()
+		}
+		arg59 := flag.Arg(1)
+		mbTrans60 := thrift.NewTMemoryBufferLen(len(arg59))
+		defer mbTrans60.Close()
+		_, err61 := mbTrans60.WriteString(arg59)
+		if err61 != nil {
+			Usage()
+			return
+		}
+		factory62 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt63 := factory62.GetProtocol(mbTrans60)
+		_, size0, err64 := jsProt63.ReadListBegin()
+		if err64 != nil {
+			Usage()
+			return
+		}
+		argvalue0 := make([]string, 0, size0)
+		for i := 0; i < size0; i++ {
+			elem, err65 := jsProt63.ReadString()
+			if err65 != nil {
+				Usage()
+				return
+			}
+			argvalue0 = append(argvalue0, elem)
+		}
+		value0 := argvalue0
+		fmt.Print(client.DrainNodes(value0))
+		fmt.Print("\n")
+		break
+	case "UndrainNodes":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "UndrainNodes requires 1 args")
+			flag.Usage()
+		}
+		arg66 := flag.Arg(1)
+		mbTrans67 := thrift.NewTMemoryBufferLen(len(arg66))
+		defer mbTrans67.Close()
+		_, err68 := mbTrans67.WriteString(arg66)
+		if err68 != nil {
+			Usage()
+			return
+		}
+		factory69 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt70 := factory69.GetProtocol(mbTrans67)
+		_, size0, err71 := jsProt70.ReadListBegin()
+		if err71 != nil {
+			Usage()
+			return
+		}
+		argvalue0 := make([]string, 0, size0)
+		for i := 0; i < size0; i++ {
+			elem, err72 := jsProt70.ReadString()
+			if err72 != nil {
+				Usage()
+				return
+			}
+			argvalue0 = append(argvalue0, elem)
+		}
+		value0 := argvalue0
+		fmt.Print(client.UndrainNodes(value0))
+		fmt.Print("\n")
+		break
+	case "GetDrainStatus":
+		if flag.NArg()-1 != 0 {
+			fmt.Fprintln(os.Stderr, "GetDrainStatus requires 0 args")
+			flag.Usage()
+		}
+		fmt.Print(client.GetDrainStatus())
+		fmt.Print("\n")
+		break
 	case "":
 		Usage()
 		break
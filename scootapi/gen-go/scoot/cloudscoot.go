@@ -24,6 +24,24 @@ type CloudScoot interface {
 	// Parameters:
 	//  - JobId
 	KillJob(jobId string) (r *JobStatus, err error)
+	// Parameters:
+	//  - JobId
+	//  - Version
+	//  - TimeoutMs
+	WatchJob(jobId string, version int64, timeoutMs int32) (r *JobStatus, err error)
+	// Parameters:
+	//  - Request
+	ListJobs(request *ListJobsRequest) (r *ListJobsResponse, err error)
+	// Parameters:
+	//  - Heartbeat
+	Heartbeat(heartbeat *WorkerHeartbeat) (err error)
+	// Parameters:
+	//  - NodeIds
+	DrainNodes(nodeIds []string) (r *DrainStatusResponse, err error)
+	// Parameters:
+	//  - NodeIds
+	UndrainNodes(nodeIds []string) (r *DrainStatusResponse, err error)
+	GetDrainStatus() (r *DrainStatusResponse, err error)
 }
 
 type CloudScootClient struct {
@@ -102,16 +120,16 @@ func (p *CloudScootClient) recvRunJob() (value *JobId, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error6 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error7 error
-		error7, err = error6.Read(iprot)
+		error17 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error18 error
+		error18, err = error17.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error7
+		err = error18
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -186,16 +204,16 @@ func (p *CloudScootClient) recvGetStatus() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error9 error
-		error9, err = error8.Read(iprot)
+		error19 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error20 error
+		error20, err = error19.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error9
+		err = error20
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -270,16 +288,16 @@ func (p *CloudScootClient) recvKillJob() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error11 error
-		error11, err = error10.Read(iprot)
+		error21 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error22 error
+		error22, err = error21.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error11
+		err = error22
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -304,242 +322,2705 @@ func (p *CloudScootClient) recvKillJob() (value *JobStatus, err error) {
 	return
 }
 
-type CloudScootProcessor struct {
-	processorMap map[string]thrift.TProcessorFunction
-	handler      CloudScoot
-}
-
-func (p *CloudScootProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-	p.processorMap[key] = processor
-}
-
-func (p *CloudScootProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-	processor, ok = p.processorMap[key]
-	return processor, ok
-}
-
-func (p *CloudScootProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-	return p.processorMap
+// Parameters:
+//  - JobId
+//  - Version
+//  - TimeoutMs
+func (p *CloudScootClient) WatchJob(jobId string, version int64, timeoutMs int32) (r *JobStatus, err error) {
+	if err = p.sendWatchJob(jobId, version, timeoutMs); err != nil {
+		return
+	}
+	return p.recvWatchJob()
 }
 
-func NewCloudScootProcessor(handler CloudScoot) *CloudScootProcessor {
-
-	self12 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self12.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
-	self12.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
-	self12.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
-	return self12
+func (p *CloudScootClient) sendWatchJob(jobId string, version int64, timeoutMs int32) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("WatchJob", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootWatchJobArgs{
+		JobId:     jobId,
+		Version:   version,
+		TimeoutMs: timeoutMs,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
 }
 
-func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	name, _, seqId, err := iprot.ReadMessageBegin()
+func (p *CloudScootClient) recvWatchJob() (value *JobStatus, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
-		return false, err
+		return
 	}
-	if processor, ok := p.GetProcessorFunction(name); ok {
-		return processor.Process(seqId, iprot, oprot)
+	if method != "WatchJob" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "WatchJob failed: wrong method name")
+		return
 	}
-	iprot.Skip(thrift.STRUCT)
-	iprot.ReadMessageEnd()
-	x13 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
-	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x13.Write(oprot)
-	oprot.WriteMessageEnd()
-	oprot.Flush()
-	return false, x13
-
-}
-
-type cloudScootProcessorRunJob struct {
-	handler CloudScoot
-}
-
-func (p *cloudScootProcessorRunJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootRunJobArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "WatchJob failed: out of sequence response")
+		return
 	}
-
-	iprot.ReadMessageEnd()
-	result := CloudScootRunJobResult{}
-	var retval *JobId
-	var err2 error
-	if retval, err2 = p.handler.RunJob(args.Job); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *CanNotScheduleNow:
-			result.Cnsn = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RunJob: "+err2.Error())
-			oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+	if mTypeId == thrift.EXCEPTION {
+		error23 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error24 error
+		error24, err = error23.Read(iprot)
+		if err != nil {
+			return
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("RunJob", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error24
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "WatchJob failed: invalid message type")
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	result := CloudScootWatchJobResult{}
+	if err = result.Read(iprot); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
 	}
-	if err != nil {
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
 		return
 	}
-	return true, err
-}
-
-type cloudScootProcessorGetStatus struct {
-	handler CloudScoot
+	value = result.GetSuccess()
+	return
 }
 
-func (p *cloudScootProcessorGetStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootGetStatusArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+// Parameters:
+//  - Request
+func (p *CloudScootClient) ListJobs(request *ListJobsRequest) (r *ListJobsResponse, err error) {
+	if err = p.sendListJobs(request); err != nil {
+		return
 	}
+	return p.recvListJobs()
+}
 
-	iprot.ReadMessageEnd()
-	result := CloudScootGetStatusResult{}
-	var retval *JobStatus
-	var err2 error
-	if retval, err2 = p.handler.GetStatus(args.JobId); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *ScootServerError:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetStatus: "+err2.Error())
-			oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("GetStatus", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+func (p *CloudScootClient) sendListJobs(request *ListJobsRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("ListJobs", thrift.CALL, p.SeqId); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	args := CloudScootListJobsArgs{
+		Request: request,
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err = args.Write(oprot); err != nil {
+		return
 	}
-	if err != nil {
+	if err = oprot.WriteMessageEnd(); err != nil {
 		return
 	}
-	return true, err
-}
-
-type cloudScootProcessorKillJob struct {
-	handler CloudScoot
+	return oprot.Flush()
 }
 
-func (p *cloudScootProcessorKillJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := CloudScootKillJobArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *CloudScootClient) recvListJobs() (value *ListJobsResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
 	}
-
-	iprot.ReadMessageEnd()
-	result := CloudScootKillJobResult{}
-	var retval *JobStatus
-	var err2 error
-	if retval, err2 = p.handler.KillJob(args.JobId); err2 != nil {
-		switch v := err2.(type) {
-		case *InvalidRequest:
-			result.Ir = v
-		case *ScootServerError:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing KillJob: "+err2.Error())
-			oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("KillJob", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if method != "ListJobs" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListJobs failed: wrong method name")
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListJobs failed: out of sequence response")
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if mTypeId == thrift.EXCEPTION {
+		error25 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error26 error
+		error26, err = error25.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error26
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListJobs failed: invalid message type")
+		return
 	}
-	if err != nil {
+	result := CloudScootListJobsResult{}
+	if err = result.Read(iprot); err != nil {
 		return
 	}
-	return true, err
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Heartbeat
+func (p *CloudScootClient) Heartbeat(heartbeat *WorkerHeartbeat) (err error) {
+	if err = p.sendHeartbeat(heartbeat); err != nil {
+		return
+	}
+	return p.recvHeartbeat()
+}
+
+func (p *CloudScootClient) sendHeartbeat(heartbeat *WorkerHeartbeat) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Heartbeat", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootHeartbeatArgs{
+		Heartbeat: heartbeat,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvHeartbeat() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Heartbeat" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Heartbeat failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Heartbeat failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error27 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error28 error
+		error28, err = error27.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error28
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Heartbeat failed: invalid message type")
+		return
+	}
+	result := CloudScootHeartbeatResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	}
+	return
+}
+
+// Parameters:
+//  - NodeIds
+func (p *CloudScootClient) DrainNodes(nodeIds []string) (r *DrainStatusResponse, err error) {
+	if err = p.sendDrainNodes(nodeIds); err != nil {
+		return
+	}
+	return p.recvDrainNodes()
+}
+
+func (p *CloudScootClient) sendDrainNodes(nodeIds []string) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("DrainNodes", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootDrainNodesArgs{
+		NodeIds: nodeIds,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvDrainNodes() (value *DrainStatusResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "DrainNodes" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "DrainNodes failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "DrainNodes failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error29 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error30 error
+		error30, err = error29.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error30
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "DrainNodes failed: invalid message type")
+		return
+	}
+	result := CloudScootDrainNodesResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - NodeIds
+func (p *CloudScootClient) UndrainNodes(nodeIds []string) (r *DrainStatusResponse, err error) {
+	if err = p.sendUndrainNodes(nodeIds); err != nil {
+		return
+	}
+	return p.recvUndrainNodes()
+}
+
+func (p *CloudScootClient) sendUndrainNodes(nodeIds []string) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("UndrainNodes", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootUndrainNodesArgs{
+		NodeIds: nodeIds,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvUndrainNodes() (value *DrainStatusResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "UndrainNodes" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "UndrainNodes failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "UndrainNodes failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error31 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error32 error
+		error32, err = error31.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error32
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "UndrainNodes failed: invalid message type")
+		return
+	}
+	result := CloudScootUndrainNodesResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+func (p *CloudScootClient) GetDrainStatus() (r *DrainStatusResponse, err error) {
+	if err = p.sendGetDrainStatus(); err != nil {
+		return
+	}
+	return p.recvGetDrainStatus()
+}
+
+func (p *CloudScootClient) sendGetDrainStatus() (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetDrainStatus", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetDrainStatusArgs{}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetDrainStatus() (value *DrainStatusResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetDrainStatus" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetDrainStatus failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetDrainStatus failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error33 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error34 error
+		error34, err = error33.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error34
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetDrainStatus failed: invalid message type")
+		return
+	}
+	result := CloudScootGetDrainStatusResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+type CloudScootProcessor struct {
+	processorMap map[string]thrift.TProcessorFunction
+	handler      CloudScoot
+}
+
+func (p *CloudScootProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+	p.processorMap[key] = processor
+}
+
+func (p *CloudScootProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+	processor, ok = p.processorMap[key]
+	return processor, ok
+}
+
+func (p *CloudScootProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+	return p.processorMap
+}
+
+func NewCloudScootProcessor(handler CloudScoot) *CloudScootProcessor {
+
+	self35 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self35.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
+	self35.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
+	self35.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
+	self35.processorMap["WatchJob"] = &cloudScootProcessorWatchJob{handler: handler}
+	self35.processorMap["ListJobs"] = &cloudScootProcessorListJobs{handler: handler}
+	self35.processorMap["Heartbeat"] = &cloudScootProcessorHeartbeat{handler: handler}
+	self35.processorMap["DrainNodes"] = &cloudScootProcessorDrainNodes{handler: handler}
+	self35.processorMap["UndrainNodes"] = &cloudScootProcessorUndrainNodes{handler: handler}
+	self35.processorMap["GetDrainStatus"] = &cloudScootProcessorGetDrainStatus{handler: handler}
+	return self35
+}
+
+func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	name, _, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return false, err
+	}
+	if processor, ok := p.GetProcessorFunction(name); ok {
+		return processor.Process(seqId, iprot, oprot)
+	}
+	iprot.Skip(thrift.STRUCT)
+	iprot.ReadMessageEnd()
+	x36 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+	x36.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return false, x36
+
+}
+
+type cloudScootProcessorRunJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorRunJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootRunJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootRunJobResult{}
+	var retval *JobId
+	var err2 error
+	if retval, err2 = p.handler.RunJob(args.Job); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *CanNotScheduleNow:
+			result.Cnsn = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RunJob: "+err2.Error())
+			oprot.WriteMessageBegin("RunJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("RunJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetStatus struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetStatusArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetStatusResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.GetStatus(args.JobId); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetStatus: "+err2.Error())
+			oprot.WriteMessageBegin("GetStatus", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetStatus", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorKillJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorKillJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootKillJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootKillJobResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.KillJob(args.JobId); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing KillJob: "+err2.Error())
+			oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("KillJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorWatchJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorWatchJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootWatchJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("WatchJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootWatchJobResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.WatchJob(args.JobId, args.Version, args.TimeoutMs); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing WatchJob: "+err2.Error())
+			oprot.WriteMessageBegin("WatchJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("WatchJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorListJobs struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorListJobs) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootListJobsArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("ListJobs", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootListJobsResult{}
+	var retval *ListJobsResponse
+	var err2 error
+	if retval, err2 = p.handler.ListJobs(args.Request); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListJobs: "+err2.Error())
+			oprot.WriteMessageBegin("ListJobs", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("ListJobs", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorHeartbeat struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorHeartbeat) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootHeartbeatArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Heartbeat", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootHeartbeatResult{}
+	var err2 error
+	if err2 = p.handler.Heartbeat(args.Heartbeat); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Heartbeat: "+err2.Error())
+			oprot.WriteMessageBegin("Heartbeat", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("Heartbeat", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorDrainNodes struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorDrainNodes) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootDrainNodesArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("DrainNodes", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootDrainNodesResult{}
+	var retval *DrainStatusResponse
+	var err2 error
+	if retval, err2 = p.handler.DrainNodes(args.NodeIds); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DrainNodes: "+err2.Error())
+			oprot.WriteMessageBegin("DrainNodes", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("DrainNodes", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorUndrainNodes struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorUndrainNodes) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootUndrainNodesArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("UndrainNodes", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootUndrainNodesResult{}
+	var retval *DrainStatusResponse
+	var err2 error
+	if retval, err2 = p.handler.UndrainNodes(args.NodeIds); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UndrainNodes: "+err2.Error())
+			oprot.WriteMessageBegin("UndrainNodes", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("UndrainNodes", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorGetDrainStatus struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetDrainStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetDrainStatusArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetDrainStatus", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetDrainStatusResult{}
+	var retval *DrainStatusResponse
+	var err2 error
+	if retval, err2 = p.handler.GetDrainStatus(); err2 != nil {
+		switch v := err2.(type) {
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetDrainStatus: "+err2.Error())
+			oprot.WriteMessageBegin("GetDrainStatus", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetDrainStatus", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - Job
+type CloudScootRunJobArgs struct {
+	Job *JobDefinition `thrift:"job,1" json:"job"`
+}
+
+func NewCloudScootRunJobArgs() *CloudScootRunJobArgs {
+	return &CloudScootRunJobArgs{}
+}
+
+var CloudScootRunJobArgs_Job_DEFAULT *JobDefinition
+
+func (p *CloudScootRunJobArgs) GetJob() *JobDefinition {
+	if !p.IsSetJob() {
+		return CloudScootRunJobArgs_Job_DEFAULT
+	}
+	return p.Job
+}
+func (p *CloudScootRunJobArgs) IsSetJob() bool {
+	return p.Job != nil
+}
+
+func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) readField1(iprot thrift.TProtocol) error {
+	p.Job = &JobDefinition{}
+	if err := p.Job.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+	}
+	if err := p.Job.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootRunJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRunJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Cnsn
+type CloudScootRunJobResult struct {
+	Success *JobId             `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest    `thrift:"ir,1" json:"ir,omitempty"`
+	Cnsn    *CanNotScheduleNow `thrift:"cnsn,2" json:"cnsn,omitempty"`
+}
+
+func NewCloudScootRunJobResult() *CloudScootRunJobResult {
+	return &CloudScootRunJobResult{}
+}
+
+var CloudScootRunJobResult_Success_DEFAULT *JobId
+
+func (p *CloudScootRunJobResult) GetSuccess() *JobId {
+	if !p.IsSetSuccess() {
+		return CloudScootRunJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootRunJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootRunJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootRunJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootRunJobResult_Cnsn_DEFAULT *CanNotScheduleNow
+
+func (p *CloudScootRunJobResult) GetCnsn() *CanNotScheduleNow {
+	if !p.IsSetCnsn() {
+		return CloudScootRunJobResult_Cnsn_DEFAULT
+	}
+	return p.Cnsn
+}
+func (p *CloudScootRunJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootRunJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootRunJobResult) IsSetCnsn() bool {
+	return p.Cnsn != nil
+}
+
+func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobId{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Cnsn = &CanNotScheduleNow{}
+	if err := p.Cnsn.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cnsn), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCnsn() {
+		if err := oprot.WriteFieldBegin("cnsn", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cnsn: ", p), err)
+		}
+		if err := p.Cnsn.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cnsn), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cnsn: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRunJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+type CloudScootGetStatusArgs struct {
+	JobId string `thrift:"jobId,1" json:"jobId"`
+}
+
+func NewCloudScootGetStatusArgs() *CloudScootGetStatusArgs {
+	return &CloudScootGetStatusArgs{}
+}
+
+func (p *CloudScootGetStatusArgs) GetJobId() string {
+	return p.JobId
+}
+func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetStatus_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetStatusArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootGetStatusResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootGetStatusResult() *CloudScootGetStatusResult {
+	return &CloudScootGetStatusResult{}
+}
+
+var CloudScootGetStatusResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootGetStatusResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootGetStatusResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootGetStatusResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootGetStatusResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootGetStatusResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootGetStatusResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootGetStatusResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootGetStatusResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootGetStatusResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootGetStatusResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootGetStatusResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetStatus_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetStatusResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+type CloudScootKillJobArgs struct {
+	JobId string `thrift:"jobId,1" json:"jobId"`
+}
+
+func NewCloudScootKillJobArgs() *CloudScootKillJobArgs {
+	return &CloudScootKillJobArgs{}
+}
+
+func (p *CloudScootKillJobArgs) GetJobId() string {
+	return p.JobId
+}
+func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("KillJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootKillJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootKillJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootKillJobResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootKillJobResult() *CloudScootKillJobResult {
+	return &CloudScootKillJobResult{}
+}
+
+var CloudScootKillJobResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootKillJobResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootKillJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootKillJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootKillJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootKillJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootKillJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootKillJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootKillJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootKillJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootKillJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootKillJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("KillJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootKillJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootKillJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootKillJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootKillJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+//  - Version
+//  - TimeoutMs
+type CloudScootWatchJobArgs struct {
+	JobId     string `thrift:"jobId,1" json:"jobId"`
+	Version   int64  `thrift:"version,2" json:"version"`
+	TimeoutMs int32  `thrift:"timeoutMs,3" json:"timeoutMs"`
+}
+
+func NewCloudScootWatchJobArgs() *CloudScootWatchJobArgs {
+	return &CloudScootWatchJobArgs{}
+}
+
+func (p *CloudScootWatchJobArgs) GetJobId() string {
+	return p.JobId
+}
+
+func (p *CloudScootWatchJobArgs) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *CloudScootWatchJobArgs) GetTimeoutMs() int32 {
+	return p.TimeoutMs
+}
+func (p *CloudScootWatchJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Version = v
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TimeoutMs = v
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WatchJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobArgs) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("version", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:version: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Version)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.version (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:version: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobArgs) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:timeoutMs: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.TimeoutMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:timeoutMs: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootWatchJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootWatchJobResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootWatchJobResult() *CloudScootWatchJobResult {
+	return &CloudScootWatchJobResult{}
+}
+
+var CloudScootWatchJobResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootWatchJobResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootWatchJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootWatchJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootWatchJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootWatchJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootWatchJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootWatchJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootWatchJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootWatchJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootWatchJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootWatchJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootWatchJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WatchJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootWatchJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type CloudScootListJobsArgs struct {
+	Request *ListJobsRequest `thrift:"request,1" json:"request"`
+}
+
+func NewCloudScootListJobsArgs() *CloudScootListJobsArgs {
+	return &CloudScootListJobsArgs{}
+}
+
+var CloudScootListJobsArgs_Request_DEFAULT *ListJobsRequest
+
+func (p *CloudScootListJobsArgs) GetRequest() *ListJobsRequest {
+	if !p.IsSetRequest() {
+		return CloudScootListJobsArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *CloudScootListJobsArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *CloudScootListJobsArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootListJobsArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &ListJobsRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *CloudScootListJobsArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobs_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootListJobsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootListJobsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootListJobsArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+type CloudScootListJobsResult struct {
+	Success *ListJobsResponse `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+}
+
+func NewCloudScootListJobsResult() *CloudScootListJobsResult {
+	return &CloudScootListJobsResult{}
+}
+
+var CloudScootListJobsResult_Success_DEFAULT *ListJobsResponse
+
+func (p *CloudScootListJobsResult) GetSuccess() *ListJobsResponse {
+	if !p.IsSetSuccess() {
+		return CloudScootListJobsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootListJobsResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootListJobsResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootListJobsResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+func (p *CloudScootListJobsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootListJobsResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootListJobsResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *CloudScootListJobsResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &ListJobsResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootListJobsResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootListJobsResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobs_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootListJobsResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootListJobsResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootListJobsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootListJobsResult(%+v)", *p)
+}
 
 // Attributes:
-//  - Job
-type CloudScootRunJobArgs struct {
-	Job *JobDefinition `thrift:"job,1" json:"job"`
+//  - Heartbeat
+type CloudScootHeartbeatArgs struct {
+	Heartbeat *WorkerHeartbeat `thrift:"heartbeat,1" json:"heartbeat"`
 }
 
-func NewCloudScootRunJobArgs() *CloudScootRunJobArgs {
-	return &CloudScootRunJobArgs{}
+func NewCloudScootHeartbeatArgs() *CloudScootHeartbeatArgs {
+	return &CloudScootHeartbeatArgs{}
 }
 
-var CloudScootRunJobArgs_Job_DEFAULT *JobDefinition
+var CloudScootHeartbeatArgs_Heartbeat_DEFAULT *WorkerHeartbeat
 
-func (p *CloudScootRunJobArgs) GetJob() *JobDefinition {
-	if !p.IsSetJob() {
-		return CloudScootRunJobArgs_Job_DEFAULT
+func (p *CloudScootHeartbeatArgs) GetHeartbeat() *WorkerHeartbeat {
+	if !p.IsSetHeartbeat() {
+		return CloudScootHeartbeatArgs_Heartbeat_DEFAULT
 	}
-	return p.Job
+	return p.Heartbeat
 }
-func (p *CloudScootRunJobArgs) IsSetJob() bool {
-	return p.Job != nil
+func (p *CloudScootHeartbeatArgs) IsSetHeartbeat() bool {
+	return p.Heartbeat != nil
 }
 
-func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootHeartbeatArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootHeartbeatArgs) readField1(iprot thrift.TProtocol) error {
+	p.Heartbeat = &WorkerHeartbeat{}
+	if err := p.Heartbeat.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Heartbeat), err)
+	}
+	return nil
+}
+
+func (p *CloudScootHeartbeatArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Heartbeat_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootHeartbeatArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("heartbeat", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:heartbeat: ", p), err)
+	}
+	if err := p.Heartbeat.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Heartbeat), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:heartbeat: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootHeartbeatArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootHeartbeatArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Ir
+type CloudScootHeartbeatResult struct {
+	Ir *InvalidRequest `thrift:"ir,1" json:"ir,omitempty"`
+}
+
+func NewCloudScootHeartbeatResult() *CloudScootHeartbeatResult {
+	return &CloudScootHeartbeatResult{}
+}
+
+var CloudScootHeartbeatResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootHeartbeatResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootHeartbeatResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+func (p *CloudScootHeartbeatResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootHeartbeatResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootHeartbeatResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootHeartbeatResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Heartbeat_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootHeartbeatResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootHeartbeatResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootHeartbeatResult(%+v)", *p)
+}
+
+// Attributes:
+//  - NodeIds
+type CloudScootDrainNodesArgs struct {
+	NodeIds []string `thrift:"nodeIds,1" json:"nodeIds"`
+}
+
+func NewCloudScootDrainNodesArgs() *CloudScootDrainNodesArgs {
+	return &CloudScootDrainNodesArgs{}
+}
+
+func (p *CloudScootDrainNodesArgs) GetNodeIds() []string {
+	return p.NodeIds
+}
+func (p *CloudScootDrainNodesArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -572,16 +3053,30 @@ func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) readField1(iprot thrift.TProtocol) error {
-	p.Job = &JobDefinition{}
-	if err := p.Job.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+func (p *CloudScootDrainNodesArgs) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.NodeIds = tSlice
+	for i := 0; i < size; i++ {
+		var _elem37 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem37 = v
+		}
+		p.NodeIds = append(p.NodeIds, _elem37)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunJob_args"); err != nil {
+func (p *CloudScootDrainNodesArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DrainNodes_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -596,79 +3091,87 @@ func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+func (p *CloudScootDrainNodesArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nodeIds", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nodeIds: ", p), err)
 	}
-	if err := p.Job.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.NodeIds)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodeIds {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nodeIds: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootRunJobArgs) String() string {
+func (p *CloudScootDrainNodesArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootRunJobArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootDrainNodesArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
-//  - Cnsn
-type CloudScootRunJobResult struct {
-	Success *JobId             `thrift:"success,0" json:"success,omitempty"`
-	Ir      *InvalidRequest    `thrift:"ir,1" json:"ir,omitempty"`
-	Cnsn    *CanNotScheduleNow `thrift:"cnsn,2" json:"cnsn,omitempty"`
+//  - Err
+type CloudScootDrainNodesResult struct {
+	Success *DrainStatusResponse `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest      `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError    `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootRunJobResult() *CloudScootRunJobResult {
-	return &CloudScootRunJobResult{}
+func NewCloudScootDrainNodesResult() *CloudScootDrainNodesResult {
+	return &CloudScootDrainNodesResult{}
 }
 
-var CloudScootRunJobResult_Success_DEFAULT *JobId
+var CloudScootDrainNodesResult_Success_DEFAULT *DrainStatusResponse
 
-func (p *CloudScootRunJobResult) GetSuccess() *JobId {
+func (p *CloudScootDrainNodesResult) GetSuccess() *DrainStatusResponse {
 	if !p.IsSetSuccess() {
-		return CloudScootRunJobResult_Success_DEFAULT
+		return CloudScootDrainNodesResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootRunJobResult_Ir_DEFAULT *InvalidRequest
+var CloudScootDrainNodesResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootRunJobResult) GetIr() *InvalidRequest {
+func (p *CloudScootDrainNodesResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootRunJobResult_Ir_DEFAULT
+		return CloudScootDrainNodesResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootRunJobResult_Cnsn_DEFAULT *CanNotScheduleNow
+var CloudScootDrainNodesResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootRunJobResult) GetCnsn() *CanNotScheduleNow {
-	if !p.IsSetCnsn() {
-		return CloudScootRunJobResult_Cnsn_DEFAULT
+func (p *CloudScootDrainNodesResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootDrainNodesResult_Err_DEFAULT
 	}
-	return p.Cnsn
+	return p.Err
 }
-func (p *CloudScootRunJobResult) IsSetSuccess() bool {
+func (p *CloudScootDrainNodesResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootRunJobResult) IsSetIr() bool {
+func (p *CloudScootDrainNodesResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootRunJobResult) IsSetCnsn() bool {
-	return p.Cnsn != nil
+func (p *CloudScootDrainNodesResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootDrainNodesResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -709,15 +3212,15 @@ func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobId{}
+func (p *CloudScootDrainNodesResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DrainStatusResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootDrainNodesResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -725,16 +3228,16 @@ func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField2(iprot thrift.TProtocol) error {
-	p.Cnsn = &CanNotScheduleNow{}
-	if err := p.Cnsn.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cnsn), err)
+func (p *CloudScootDrainNodesResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunJob_result"); err != nil {
+func (p *CloudScootDrainNodesResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DrainNodes_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -755,7 +3258,7 @@ func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootDrainNodesResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -770,7 +3273,7 @@ func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootDrainNodesResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -785,42 +3288,42 @@ func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *CloudScootRunJobResult) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetCnsn() {
-		if err := oprot.WriteFieldBegin("cnsn", thrift.STRUCT, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cnsn: ", p), err)
+func (p *CloudScootDrainNodesResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
 		}
-		if err := p.Cnsn.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cnsn), err)
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cnsn: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *CloudScootRunJobResult) String() string {
+func (p *CloudScootDrainNodesResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootRunJobResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootDrainNodesResult(%+v)", *p)
 }
 
 // Attributes:
-//  - JobId
-type CloudScootGetStatusArgs struct {
-	JobId string `thrift:"jobId,1" json:"jobId"`
+//  - NodeIds
+type CloudScootUndrainNodesArgs struct {
+	NodeIds []string `thrift:"nodeIds,1" json:"nodeIds"`
 }
 
-func NewCloudScootGetStatusArgs() *CloudScootGetStatusArgs {
-	return &CloudScootGetStatusArgs{}
+func NewCloudScootUndrainNodesArgs() *CloudScootUndrainNodesArgs {
+	return &CloudScootUndrainNodesArgs{}
 }
 
-func (p *CloudScootGetStatusArgs) GetJobId() string {
-	return p.JobId
+func (p *CloudScootUndrainNodesArgs) GetNodeIds() []string {
+	return p.NodeIds
 }
-func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootUndrainNodesArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -853,17 +3356,30 @@ func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.JobId = v
+func (p *CloudScootUndrainNodesArgs) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.NodeIds = tSlice
+	for i := 0; i < size; i++ {
+		var _elem38 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem38 = v
+		}
+		p.NodeIds = append(p.NodeIds, _elem38)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetStatus_args"); err != nil {
+func (p *CloudScootUndrainNodesArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("UndrainNodes_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -878,79 +3394,87 @@ func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+func (p *CloudScootUndrainNodesArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nodeIds", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nodeIds: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.JobId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.NodeIds)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodeIds {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nodeIds: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootGetStatusArgs) String() string {
+func (p *CloudScootUndrainNodesArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetStatusArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootUndrainNodesArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
 //  - Err
-type CloudScootGetStatusResult struct {
-	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
-	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
-	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+type CloudScootUndrainNodesResult struct {
+	Success *DrainStatusResponse `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest      `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError    `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootGetStatusResult() *CloudScootGetStatusResult {
-	return &CloudScootGetStatusResult{}
+func NewCloudScootUndrainNodesResult() *CloudScootUndrainNodesResult {
+	return &CloudScootUndrainNodesResult{}
 }
 
-var CloudScootGetStatusResult_Success_DEFAULT *JobStatus
+var CloudScootUndrainNodesResult_Success_DEFAULT *DrainStatusResponse
 
-func (p *CloudScootGetStatusResult) GetSuccess() *JobStatus {
+func (p *CloudScootUndrainNodesResult) GetSuccess() *DrainStatusResponse {
 	if !p.IsSetSuccess() {
-		return CloudScootGetStatusResult_Success_DEFAULT
+		return CloudScootUndrainNodesResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootGetStatusResult_Ir_DEFAULT *InvalidRequest
+var CloudScootUndrainNodesResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootGetStatusResult) GetIr() *InvalidRequest {
+func (p *CloudScootUndrainNodesResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootGetStatusResult_Ir_DEFAULT
+		return CloudScootUndrainNodesResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootGetStatusResult_Err_DEFAULT *ScootServerError
+var CloudScootUndrainNodesResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootGetStatusResult) GetErr() *ScootServerError {
+func (p *CloudScootUndrainNodesResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootGetStatusResult_Err_DEFAULT
+		return CloudScootUndrainNodesResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootGetStatusResult) IsSetSuccess() bool {
+func (p *CloudScootUndrainNodesResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootGetStatusResult) IsSetIr() bool {
+func (p *CloudScootUndrainNodesResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootGetStatusResult) IsSetErr() bool {
+func (p *CloudScootUndrainNodesResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootUndrainNodesResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -991,15 +3515,15 @@ func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobStatus{}
+func (p *CloudScootUndrainNodesResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DrainStatusResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootUndrainNodesResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -1007,7 +3531,7 @@ func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootUndrainNodesResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -1015,8 +3539,8 @@ func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetStatus_result"); err != nil {
+func (p *CloudScootUndrainNodesResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("UndrainNodes_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1037,7 +3561,7 @@ func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootUndrainNodesResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1052,7 +3576,7 @@ func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootUndrainNodesResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -1067,7 +3591,7 @@ func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootUndrainNodesResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -1082,27 +3606,21 @@ func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) String() string {
+func (p *CloudScootUndrainNodesResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetStatusResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootUndrainNodesResult(%+v)", *p)
 }
 
-// Attributes:
-//  - JobId
-type CloudScootKillJobArgs struct {
-	JobId string `thrift:"jobId,1" json:"jobId"`
+type CloudScootGetDrainStatusArgs struct {
 }
 
-func NewCloudScootKillJobArgs() *CloudScootKillJobArgs {
-	return &CloudScootKillJobArgs{}
+func NewCloudScootGetDrainStatusArgs() *CloudScootGetDrainStatusArgs {
+	return &CloudScootGetDrainStatusArgs{}
 }
 
-func (p *CloudScootKillJobArgs) GetJobId() string {
-	return p.JobId
-}
-func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetDrainStatusArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1115,15 +3633,8 @@ func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		switch fieldId {
-		case 1:
-			if err := p.readField1(iprot); err != nil {
-				return err
-			}
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -1135,22 +3646,10 @@ func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.JobId = v
-	}
-	return nil
-}
-
-func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("KillJob_args"); err != nil {
+func (p *CloudScootGetDrainStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetDrainStatus_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err := p.writeField1(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1160,79 +3659,51 @@ func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.JobId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
-	}
-	return err
-}
-
-func (p *CloudScootKillJobArgs) String() string {
+func (p *CloudScootGetDrainStatusArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootKillJobArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetDrainStatusArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-//  - Ir
 //  - Err
-type CloudScootKillJobResult struct {
-	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
-	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
-	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+type CloudScootGetDrainStatusResult struct {
+	Success *DrainStatusResponse `thrift:"success,0" json:"success,omitempty"`
+	Err     *ScootServerError    `thrift:"err,1" json:"err,omitempty"`
 }
 
-func NewCloudScootKillJobResult() *CloudScootKillJobResult {
-	return &CloudScootKillJobResult{}
+func NewCloudScootGetDrainStatusResult() *CloudScootGetDrainStatusResult {
+	return &CloudScootGetDrainStatusResult{}
 }
 
-var CloudScootKillJobResult_Success_DEFAULT *JobStatus
+var CloudScootGetDrainStatusResult_Success_DEFAULT *DrainStatusResponse
 
-func (p *CloudScootKillJobResult) GetSuccess() *JobStatus {
+func (p *CloudScootGetDrainStatusResult) GetSuccess() *DrainStatusResponse {
 	if !p.IsSetSuccess() {
-		return CloudScootKillJobResult_Success_DEFAULT
+		return CloudScootGetDrainStatusResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootKillJobResult_Ir_DEFAULT *InvalidRequest
-
-func (p *CloudScootKillJobResult) GetIr() *InvalidRequest {
-	if !p.IsSetIr() {
-		return CloudScootKillJobResult_Ir_DEFAULT
-	}
-	return p.Ir
-}
-
-var CloudScootKillJobResult_Err_DEFAULT *ScootServerError
+var CloudScootGetDrainStatusResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootKillJobResult) GetErr() *ScootServerError {
+func (p *CloudScootGetDrainStatusResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootKillJobResult_Err_DEFAULT
+		return CloudScootGetDrainStatusResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootKillJobResult) IsSetSuccess() bool {
+func (p *CloudScootGetDrainStatusResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootKillJobResult) IsSetIr() bool {
-	return p.Ir != nil
-}
-
-func (p *CloudScootKillJobResult) IsSetErr() bool {
+func (p *CloudScootGetDrainStatusResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootGetDrainStatusResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1254,10 +3725,6 @@ func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-		case 2:
-			if err := p.readField2(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1273,23 +3740,15 @@ func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobStatus{}
+func (p *CloudScootGetDrainStatusResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DrainStatusResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootKillJobResult) readField1(iprot thrift.TProtocol) error {
-	p.Ir = &InvalidRequest{}
-	if err := p.Ir.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
-	}
-	return nil
-}
-
-func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootGetDrainStatusResult) readField1(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -1297,8 +3756,8 @@ func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("KillJob_result"); err != nil {
+func (p *CloudScootGetDrainStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetDrainStatus_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1307,9 +3766,6 @@ func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1319,7 +3775,7 @@ func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetDrainStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1334,39 +3790,24 @@ func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *CloudScootKillJobResult) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetIr() {
-		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
-		}
-		if err := p.Ir.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *CloudScootKillJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootGetDrainStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
-		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
 		}
 		if err := p.Err.Write(oprot); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *CloudScootKillJobResult) String() string {
+func (p *CloudScootGetDrainStatusResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootKillJobResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootGetDrainStatusResult(%+v)", *p)
 }
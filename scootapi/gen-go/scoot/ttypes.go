@@ -1013,8 +1013,16 @@ func (p *RunStatus) String() string {
 
 // Attributes:
 //  - Argv
+//  - EnvVars
+//  - Sandbox
+//  - Image
+//  - Outputs
 type Command struct {
-	Argv []string `thrift:"argv,1" json:"argv"`
+	Argv    []string          `thrift:"argv,1" json:"argv"`
+	EnvVars map[string]string `thrift:"envVars,2" json:"envVars,omitempty"`
+	Sandbox *bool             `thrift:"sandbox,3" json:"sandbox,omitempty"`
+	Image   *string           `thrift:"image,4" json:"image,omitempty"`
+	Outputs []string          `thrift:"outputs,5" json:"outputs,omitempty"`
 }
 
 func NewCommand() *Command {
@@ -1024,6 +1032,52 @@ func NewCommand() *Command {
 func (p *Command) GetArgv() []string {
 	return p.Argv
 }
+
+var Command_EnvVars_DEFAULT map[string]string
+
+func (p *Command) GetEnvVars() map[string]string {
+	return p.EnvVars
+}
+
+var Command_Sandbox_DEFAULT bool
+
+func (p *Command) GetSandbox() bool {
+	if !p.IsSetSandbox() {
+		return Command_Sandbox_DEFAULT
+	}
+	return *p.Sandbox
+}
+
+var Command_Image_DEFAULT string
+
+func (p *Command) GetImage() string {
+	if !p.IsSetImage() {
+		return Command_Image_DEFAULT
+	}
+	return *p.Image
+}
+
+var Command_Outputs_DEFAULT []string
+
+func (p *Command) GetOutputs() []string {
+	return p.Outputs
+}
+func (p *Command) IsSetEnvVars() bool {
+	return p.EnvVars != nil
+}
+
+func (p *Command) IsSetSandbox() bool {
+	return p.Sandbox != nil
+}
+
+func (p *Command) IsSetImage() bool {
+	return p.Image != nil
+}
+
+func (p *Command) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
 func (p *Command) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1042,6 +1096,22 @@ func (p *Command) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1079,6 +1149,74 @@ func (p *Command) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *Command) readField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.EnvVars = tMap
+	for i := 0; i < size; i++ {
+		var _key1 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key1 = v
+		}
+		var _val2 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val2 = v
+		}
+		p.EnvVars[_key1] = _val2
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Command) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Sandbox = &v
+	}
+	return nil
+}
+
+func (p *Command) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Image = &v
+	}
+	return nil
+}
+
+func (p *Command) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Outputs = tSlice
+	for i := 0; i < size; i++ {
+		var _elem3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem3 = v
+		}
+		p.Outputs = append(p.Outputs, _elem3)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
 func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("Command"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1086,6 +1224,18 @@ func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1116,6 +1266,85 @@ func (p *Command) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *Command) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEnvVars() {
+		if err := oprot.WriteFieldBegin("envVars", thrift.MAP, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:envVars: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.EnvVars)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.EnvVars {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:envVars: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSandbox() {
+		if err := oprot.WriteFieldBegin("sandbox", thrift.BOOL, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sandbox: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Sandbox)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.sandbox (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sandbox: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetImage() {
+		if err := oprot.WriteFieldBegin("image", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:image: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Image)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.image (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:image: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:outputs: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Outputs {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *Command) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1124,79 +1353,62 @@ func (p *Command) String() string {
 }
 
 // Attributes:
-//  - Command
-//  - SnapshotId
-//  - TaskId
-//  - TimeoutMs
-type TaskDefinition struct {
-	Command    *Command `thrift:"command,1,required" json:"command"`
-	SnapshotId *string  `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
-	TaskId     *string  `thrift:"taskId,3" json:"taskId,omitempty"`
-	TimeoutMs  *int32   `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
-}
-
-func NewTaskDefinition() *TaskDefinition {
-	return &TaskDefinition{}
+//  - Cpus
+//  - MemoryBytes
+//  - DiskBytes
+type Resources struct {
+	Cpus        *int32 `thrift:"cpus,1" json:"cpus,omitempty"`
+	MemoryBytes *int64 `thrift:"memoryBytes,2" json:"memoryBytes,omitempty"`
+	DiskBytes   *int64 `thrift:"diskBytes,3" json:"diskBytes,omitempty"`
 }
 
-var TaskDefinition_Command_DEFAULT *Command
-
-func (p *TaskDefinition) GetCommand() *Command {
-	if !p.IsSetCommand() {
-		return TaskDefinition_Command_DEFAULT
-	}
-	return p.Command
+func NewResources() *Resources {
+	return &Resources{}
 }
 
-var TaskDefinition_SnapshotId_DEFAULT string
+var Resources_Cpus_DEFAULT int32
 
-func (p *TaskDefinition) GetSnapshotId() string {
-	if !p.IsSetSnapshotId() {
-		return TaskDefinition_SnapshotId_DEFAULT
+func (p *Resources) GetCpus() int32 {
+	if !p.IsSetCpus() {
+		return Resources_Cpus_DEFAULT
 	}
-	return *p.SnapshotId
+	return *p.Cpus
 }
 
-var TaskDefinition_TaskId_DEFAULT string
+var Resources_MemoryBytes_DEFAULT int64
 
-func (p *TaskDefinition) GetTaskId() string {
-	if !p.IsSetTaskId() {
-		return TaskDefinition_TaskId_DEFAULT
+func (p *Resources) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return Resources_MemoryBytes_DEFAULT
 	}
-	return *p.TaskId
+	return *p.MemoryBytes
 }
 
-var TaskDefinition_TimeoutMs_DEFAULT int32
+var Resources_DiskBytes_DEFAULT int64
 
-func (p *TaskDefinition) GetTimeoutMs() int32 {
-	if !p.IsSetTimeoutMs() {
-		return TaskDefinition_TimeoutMs_DEFAULT
+func (p *Resources) GetDiskBytes() int64 {
+	if !p.IsSetDiskBytes() {
+		return Resources_DiskBytes_DEFAULT
 	}
-	return *p.TimeoutMs
-}
-func (p *TaskDefinition) IsSetCommand() bool {
-	return p.Command != nil
+	return *p.DiskBytes
 }
-
-func (p *TaskDefinition) IsSetSnapshotId() bool {
-	return p.SnapshotId != nil
+func (p *Resources) IsSetCpus() bool {
+	return p.Cpus != nil
 }
 
-func (p *TaskDefinition) IsSetTaskId() bool {
-	return p.TaskId != nil
+func (p *Resources) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
 }
 
-func (p *TaskDefinition) IsSetTimeoutMs() bool {
-	return p.TimeoutMs != nil
+func (p *Resources) IsSetDiskBytes() bool {
+	return p.DiskBytes != nil
 }
 
-func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
+func (p *Resources) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetCommand bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -1210,7 +1422,6 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetCommand = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
@@ -1219,10 +1430,6 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField3(iprot); err != nil {
 				return err
 			}
-		case 4:
-			if err := p.readField4(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1235,49 +1442,38 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetCommand {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Command is not set"))
-	}
 	return nil
 }
 
-func (p *TaskDefinition) readField1(iprot thrift.TProtocol) error {
-	p.Command = &Command{}
-	if err := p.Command.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Command), err)
+func (p *Resources) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Cpus = &v
 	}
 	return nil
 }
 
-func (p *TaskDefinition) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *Resources) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.SnapshotId = &v
+		p.MemoryBytes = &v
 	}
 	return nil
 }
 
-func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *Resources) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.TaskId = &v
-	}
-	return nil
-}
-
-func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
-	} else {
-		p.TimeoutMs = &v
+		p.DiskBytes = &v
 	}
 	return nil
 }
 
-func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
+func (p *Resources) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Resources"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1289,9 +1485,6 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField3(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField4(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1301,195 +1494,185 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *TaskDefinition) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("command", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:command: ", p), err)
-	}
-	if err := p.Command.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Command), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:command: ", p), err)
+func (p *Resources) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpus() {
+		if err := oprot.WriteFieldBegin("cpus", thrift.I32, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cpus: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Cpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpus (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cpus: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *TaskDefinition) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSnapshotId() {
-		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:snapshotId: ", p), err)
+func (p *Resources) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:memoryBytes: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (2) field write error: ", p), err)
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (2) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:snapshotId: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:memoryBytes: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTaskId() {
-		if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:taskId: ", p), err)
+func (p *Resources) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDiskBytes() {
+		if err := oprot.WriteFieldBegin("diskBytes", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:diskBytes: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.TaskId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.taskId (3) field write error: ", p), err)
+		if err := oprot.WriteI64(int64(*p.DiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.diskBytes (3) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:taskId: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:diskBytes: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTimeoutMs() {
-		if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:timeoutMs: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(*p.TimeoutMs)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (4) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:timeoutMs: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *TaskDefinition) String() string {
+func (p *Resources) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TaskDefinition(%+v)", *p)
+	return fmt.Sprintf("Resources(%+v)", *p)
 }
 
 // Attributes:
-//  - Tasks
-//  - DEPRECATEDJobType
-//  - DefaultTaskTimeoutMs
-//  - Priority
-//  - Tag
-//  - Basis
-//  - Requestor
-//  - JobType
-type JobDefinition struct {
-	Tasks                []*TaskDefinition `thrift:"tasks,1,required" json:"tasks"`
-	DEPRECATEDJobType    *JobType          `thrift:"DEPRECATED_jobType,2" json:"DEPRECATED_jobType,omitempty"`
-	DefaultTaskTimeoutMs *int32            `thrift:"defaultTaskTimeoutMs,3" json:"defaultTaskTimeoutMs,omitempty"`
-	Priority             *int32            `thrift:"priority,4" json:"priority,omitempty"`
-	Tag                  *string           `thrift:"tag,5" json:"tag,omitempty"`
-	Basis                *string           `thrift:"basis,6" json:"basis,omitempty"`
-	Requestor            *string           `thrift:"requestor,7" json:"requestor,omitempty"`
-	JobType              *string           `thrift:"jobType,8" json:"jobType,omitempty"`
+//  - Command
+//  - SnapshotId
+//  - TaskId
+//  - TimeoutMs
+//  - Dependencies
+//  - SnapshotFromTask
+//  - Resources
+//  - NodeSelector
+type TaskDefinition struct {
+	Command          *Command          `thrift:"command,1,required" json:"command"`
+	SnapshotId       *string           `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
+	TaskId           *string           `thrift:"taskId,3" json:"taskId,omitempty"`
+	TimeoutMs        *int32            `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	Dependencies     []string          `thrift:"dependencies,5" json:"dependencies,omitempty"`
+	SnapshotFromTask *string           `thrift:"snapshotFromTask,6" json:"snapshotFromTask,omitempty"`
+	Resources        *Resources        `thrift:"resources,7" json:"resources,omitempty"`
+	NodeSelector     map[string]string `thrift:"nodeSelector,8" json:"nodeSelector,omitempty"`
 }
 
-func NewJobDefinition() *JobDefinition {
-	return &JobDefinition{}
+func NewTaskDefinition() *TaskDefinition {
+	return &TaskDefinition{}
 }
 
-func (p *JobDefinition) GetTasks() []*TaskDefinition {
-	return p.Tasks
+var TaskDefinition_Command_DEFAULT *Command
+
+func (p *TaskDefinition) GetCommand() *Command {
+	if !p.IsSetCommand() {
+		return TaskDefinition_Command_DEFAULT
+	}
+	return p.Command
 }
 
-var JobDefinition_DEPRECATEDJobType_DEFAULT JobType
+var TaskDefinition_SnapshotId_DEFAULT string
 
-func (p *JobDefinition) GetDEPRECATEDJobType() JobType {
-	if !p.IsSetDEPRECATEDJobType() {
-		return JobDefinition_DEPRECATEDJobType_DEFAULT
+func (p *TaskDefinition) GetSnapshotId() string {
+	if !p.IsSetSnapshotId() {
+		return TaskDefinition_SnapshotId_DEFAULT
 	}
-	return *p.DEPRECATEDJobType
+	return *p.SnapshotId
 }
 
-var JobDefinition_DefaultTaskTimeoutMs_DEFAULT int32
+var TaskDefinition_TaskId_DEFAULT string
 
-func (p *JobDefinition) GetDefaultTaskTimeoutMs() int32 {
-	if !p.IsSetDefaultTaskTimeoutMs() {
-		return JobDefinition_DefaultTaskTimeoutMs_DEFAULT
+func (p *TaskDefinition) GetTaskId() string {
+	if !p.IsSetTaskId() {
+		return TaskDefinition_TaskId_DEFAULT
 	}
-	return *p.DefaultTaskTimeoutMs
+	return *p.TaskId
 }
 
-var JobDefinition_Priority_DEFAULT int32
+var TaskDefinition_TimeoutMs_DEFAULT int32
 
-func (p *JobDefinition) GetPriority() int32 {
-	if !p.IsSetPriority() {
-		return JobDefinition_Priority_DEFAULT
+func (p *TaskDefinition) GetTimeoutMs() int32 {
+	if !p.IsSetTimeoutMs() {
+		return TaskDefinition_TimeoutMs_DEFAULT
 	}
-	return *p.Priority
+	return *p.TimeoutMs
 }
 
-var JobDefinition_Tag_DEFAULT string
+var TaskDefinition_Dependencies_DEFAULT []string
 
-func (p *JobDefinition) GetTag() string {
-	if !p.IsSetTag() {
-		return JobDefinition_Tag_DEFAULT
-	}
-	return *p.Tag
+func (p *TaskDefinition) GetDependencies() []string {
+	return p.Dependencies
 }
 
-var JobDefinition_Basis_DEFAULT string
+var TaskDefinition_SnapshotFromTask_DEFAULT string
 
-func (p *JobDefinition) GetBasis() string {
-	if !p.IsSetBasis() {
-		return JobDefinition_Basis_DEFAULT
+func (p *TaskDefinition) GetSnapshotFromTask() string {
+	if !p.IsSetSnapshotFromTask() {
+		return TaskDefinition_SnapshotFromTask_DEFAULT
 	}
-	return *p.Basis
+	return *p.SnapshotFromTask
 }
 
-var JobDefinition_Requestor_DEFAULT string
+var TaskDefinition_Resources_DEFAULT *Resources
 
-func (p *JobDefinition) GetRequestor() string {
-	if !p.IsSetRequestor() {
-		return JobDefinition_Requestor_DEFAULT
+func (p *TaskDefinition) GetResources() *Resources {
+	if !p.IsSetResources() {
+		return TaskDefinition_Resources_DEFAULT
 	}
-	return *p.Requestor
+	return p.Resources
 }
 
-var JobDefinition_JobType_DEFAULT string
+var TaskDefinition_NodeSelector_DEFAULT map[string]string
 
-func (p *JobDefinition) GetJobType() string {
-	if !p.IsSetJobType() {
-		return JobDefinition_JobType_DEFAULT
-	}
-	return *p.JobType
+func (p *TaskDefinition) GetNodeSelector() map[string]string {
+	return p.NodeSelector
 }
-func (p *JobDefinition) IsSetDEPRECATEDJobType() bool {
-	return p.DEPRECATEDJobType != nil
+func (p *TaskDefinition) IsSetCommand() bool {
+	return p.Command != nil
 }
 
-func (p *JobDefinition) IsSetDefaultTaskTimeoutMs() bool {
-	return p.DefaultTaskTimeoutMs != nil
+func (p *TaskDefinition) IsSetSnapshotId() bool {
+	return p.SnapshotId != nil
 }
 
-func (p *JobDefinition) IsSetPriority() bool {
-	return p.Priority != nil
+func (p *TaskDefinition) IsSetTaskId() bool {
+	return p.TaskId != nil
 }
 
-func (p *JobDefinition) IsSetTag() bool {
-	return p.Tag != nil
+func (p *TaskDefinition) IsSetTimeoutMs() bool {
+	return p.TimeoutMs != nil
 }
 
-func (p *JobDefinition) IsSetBasis() bool {
-	return p.Basis != nil
+func (p *TaskDefinition) IsSetDependencies() bool {
+	return p.Dependencies != nil
 }
 
-func (p *JobDefinition) IsSetRequestor() bool {
-	return p.Requestor != nil
+func (p *TaskDefinition) IsSetSnapshotFromTask() bool {
+	return p.SnapshotFromTask != nil
 }
 
-func (p *JobDefinition) IsSetJobType() bool {
-	return p.JobType != nil
+func (p *TaskDefinition) IsSetResources() bool {
+	return p.Resources != nil
 }
 
-func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
+func (p *TaskDefinition) IsSetNodeSelector() bool {
+	return p.NodeSelector != nil
+}
+
+func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetTasks bool = false
+	var issetCommand bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -1504,7 +1687,7 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetTasks = true
+			issetCommand = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
@@ -1545,98 +1728,116 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetTasks {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
+	if !issetCommand {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Command is not set"))
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*TaskDefinition, 0, size)
-	p.Tasks = tSlice
-	for i := 0; i < size; i++ {
-		_elem1 := &TaskDefinition{}
-		if err := _elem1.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem1), err)
-		}
-		p.Tasks = append(p.Tasks, _elem1)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+func (p *TaskDefinition) readField1(iprot thrift.TProtocol) error {
+	p.Command = &Command{}
+	if err := p.Command.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Command), err)
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *TaskDefinition) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		temp := JobType(v)
-		p.DEPRECATEDJobType = &temp
+		p.SnapshotId = &v
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.DefaultTaskTimeoutMs = &v
+		p.TaskId = &v
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField4(iprot thrift.TProtocol) error {
+func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 4: ", err)
 	} else {
-		p.Priority = &v
+		p.TimeoutMs = &v
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField5(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 5: ", err)
-	} else {
-		p.Tag = &v
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Dependencies = tSlice
+	for i := 0; i < size; i++ {
+		var _elem4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem4 = v
+		}
+		p.Dependencies = append(p.Dependencies, _elem4)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField6(iprot thrift.TProtocol) error {
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 6: ", err)
 	} else {
-		p.Basis = &v
+		p.SnapshotFromTask = &v
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField7(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 7: ", err)
-	} else {
-		p.Requestor = &v
+func (p *TaskDefinition) readField7(iprot thrift.TProtocol) error {
+	p.Resources = &Resources{}
+	if err := p.Resources.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Resources), err)
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField8(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 8: ", err)
-	} else {
-		p.JobType = &v
+func (p *TaskDefinition) readField8(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.NodeSelector = tMap
+	for i := 0; i < size; i++ {
+		var _key5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key5 = v
+		}
+		var _val6 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val6 = v
+		}
+		p.NodeSelector[_key5] = _val6
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
 	}
 	return nil
 }
 
-func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
+func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1672,158 +1873,274 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobDefinition) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("tasks", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tasks: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tasks)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Tasks {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
+func (p *TaskDefinition) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("command", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:command: ", p), err)
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
+	if err := p.Command.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Command), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tasks: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:command: ", p), err)
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDEPRECATEDJobType() {
-		if err := oprot.WriteFieldBegin("DEPRECATED_jobType", thrift.I32, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:DEPRECATED_jobType: ", p), err)
+func (p *TaskDefinition) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotId() {
+		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:snapshotId: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(*p.DEPRECATEDJobType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.DEPRECATED_jobType (2) field write error: ", p), err)
+		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (2) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:DEPRECATED_jobType: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:snapshotId: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDefaultTaskTimeoutMs() {
-		if err := oprot.WriteFieldBegin("defaultTaskTimeoutMs", thrift.I32, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:defaultTaskTimeoutMs: ", p), err)
+func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskId() {
+		if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:taskId: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(*p.DefaultTaskTimeoutMs)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.defaultTaskTimeoutMs (3) field write error: ", p), err)
+		if err := oprot.WriteString(string(*p.TaskId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.taskId (3) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:defaultTaskTimeoutMs: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:taskId: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetPriority() {
-		if err := oprot.WriteFieldBegin("priority", thrift.I32, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:priority: ", p), err)
+func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTimeoutMs() {
+		if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:timeoutMs: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.priority (4) field write error: ", p), err)
+		if err := oprot.WriteI32(int32(*p.TimeoutMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (4) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:priority: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:timeoutMs: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTag() {
-		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:tag: ", p), err)
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDependencies() {
+		if err := oprot.WriteFieldBegin("dependencies", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:dependencies: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.Tag)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.tag (5) field write error: ", p), err)
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Dependencies)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Dependencies {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:tag: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:dependencies: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetBasis() {
-		if err := oprot.WriteFieldBegin("basis", thrift.STRING, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:basis: ", p), err)
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotFromTask() {
+		if err := oprot.WriteFieldBegin("snapshotFromTask", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:snapshotFromTask: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.Basis)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.basis (6) field write error: ", p), err)
+		if err := oprot.WriteString(string(*p.SnapshotFromTask)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotFromTask (6) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:basis: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:snapshotFromTask: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRequestor() {
-		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:requestor: ", p), err)
+func (p *TaskDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetResources() {
+		if err := oprot.WriteFieldBegin("resources", thrift.STRUCT, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:resources: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.requestor (7) field write error: ", p), err)
+		if err := p.Resources.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Resources), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:requestor: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:resources: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField8(oprot thrift.TProtocol) (err error) {
-	if p.IsSetJobType() {
-		if err := oprot.WriteFieldBegin("jobType", thrift.STRING, 8); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:jobType: ", p), err)
+func (p *TaskDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNodeSelector() {
+		if err := oprot.WriteFieldBegin("nodeSelector", thrift.MAP, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:nodeSelector: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.JobType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.jobType (8) field write error: ", p), err)
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.NodeSelector)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.NodeSelector {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:jobType: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:nodeSelector: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobDefinition) String() string {
+func (p *TaskDefinition) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("JobDefinition(%+v)", *p)
+	return fmt.Sprintf("TaskDefinition(%+v)", *p)
 }
 
 // Attributes:
-//  - ID
-type JobId struct {
-	ID string `thrift:"id,1,required" json:"id"`
+//  - Tasks
+//  - DEPRECATEDJobType
+//  - DefaultTaskTimeoutMs
+//  - Priority
+//  - Tag
+//  - Basis
+//  - Requestor
+//  - JobType
+type JobDefinition struct {
+	Tasks                []*TaskDefinition `thrift:"tasks,1,required" json:"tasks"`
+	DEPRECATEDJobType    *JobType          `thrift:"DEPRECATED_jobType,2" json:"DEPRECATED_jobType,omitempty"`
+	DefaultTaskTimeoutMs *int32            `thrift:"defaultTaskTimeoutMs,3" json:"defaultTaskTimeoutMs,omitempty"`
+	Priority             *int32            `thrift:"priority,4" json:"priority,omitempty"`
+	Tag                  *string           `thrift:"tag,5" json:"tag,omitempty"`
+	Basis                *string           `thrift:"basis,6" json:"basis,omitempty"`
+	Requestor            *string           `thrift:"requestor,7" json:"requestor,omitempty"`
+	JobType              *string           `thrift:"jobType,8" json:"jobType,omitempty"`
 }
 
-func NewJobId() *JobId {
-	return &JobId{}
+func NewJobDefinition() *JobDefinition {
+	return &JobDefinition{}
 }
 
-func (p *JobId) GetID() string {
-	return p.ID
+func (p *JobDefinition) GetTasks() []*TaskDefinition {
+	return p.Tasks
 }
-func (p *JobId) Read(iprot thrift.TProtocol) error {
+
+var JobDefinition_DEPRECATEDJobType_DEFAULT JobType
+
+func (p *JobDefinition) GetDEPRECATEDJobType() JobType {
+	if !p.IsSetDEPRECATEDJobType() {
+		return JobDefinition_DEPRECATEDJobType_DEFAULT
+	}
+	return *p.DEPRECATEDJobType
+}
+
+var JobDefinition_DefaultTaskTimeoutMs_DEFAULT int32
+
+func (p *JobDefinition) GetDefaultTaskTimeoutMs() int32 {
+	if !p.IsSetDefaultTaskTimeoutMs() {
+		return JobDefinition_DefaultTaskTimeoutMs_DEFAULT
+	}
+	return *p.DefaultTaskTimeoutMs
+}
+
+var JobDefinition_Priority_DEFAULT int32
+
+func (p *JobDefinition) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return JobDefinition_Priority_DEFAULT
+	}
+	return *p.Priority
+}
+
+var JobDefinition_Tag_DEFAULT string
+
+func (p *JobDefinition) GetTag() string {
+	if !p.IsSetTag() {
+		return JobDefinition_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var JobDefinition_Basis_DEFAULT string
+
+func (p *JobDefinition) GetBasis() string {
+	if !p.IsSetBasis() {
+		return JobDefinition_Basis_DEFAULT
+	}
+	return *p.Basis
+}
+
+var JobDefinition_Requestor_DEFAULT string
+
+func (p *JobDefinition) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return JobDefinition_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var JobDefinition_JobType_DEFAULT string
+
+func (p *JobDefinition) GetJobType() string {
+	if !p.IsSetJobType() {
+		return JobDefinition_JobType_DEFAULT
+	}
+	return *p.JobType
+}
+func (p *JobDefinition) IsSetDEPRECATEDJobType() bool {
+	return p.DEPRECATEDJobType != nil
+}
+
+func (p *JobDefinition) IsSetDefaultTaskTimeoutMs() bool {
+	return p.DefaultTaskTimeoutMs != nil
+}
+
+func (p *JobDefinition) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *JobDefinition) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *JobDefinition) IsSetBasis() bool {
+	return p.Basis != nil
+}
+
+func (p *JobDefinition) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *JobDefinition) IsSetJobType() bool {
+	return p.JobType != nil
+}
+
+func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetID bool = false
+	var issetTasks bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -1838,7 +2155,35 @@ func (p *JobId) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetID = true
+			issetTasks = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1851,27 +2196,2033 @@ func (p *JobId) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	if !issetTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
 	}
 	return nil
 }
 
-func (p *JobId) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.ID = v
+func (p *JobDefinition) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*TaskDefinition, 0, size)
+	p.Tasks = tSlice
+	for i := 0; i < size; i++ {
+		_elem7 := &TaskDefinition{}
+		if err := _elem7.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem7), err)
+		}
+		p.Tasks = append(p.Tasks, _elem7)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *JobId) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobId"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := JobType(v)
+		p.DEPRECATEDJobType = &temp
 	}
-	if err := p.writeField1(oprot); err != nil {
-		return err
+	return nil
+}
+
+func (p *JobDefinition) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.DefaultTaskTimeoutMs = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Priority = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Basis = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.JobType = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobDefinition) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tasks", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tasks: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tasks)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Tasks {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tasks: ", p), err)
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDEPRECATEDJobType() {
+		if err := oprot.WriteFieldBegin("DEPRECATED_jobType", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:DEPRECATED_jobType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.DEPRECATEDJobType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.DEPRECATED_jobType (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:DEPRECATED_jobType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDefaultTaskTimeoutMs() {
+		if err := oprot.WriteFieldBegin("defaultTaskTimeoutMs", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:defaultTaskTimeoutMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.DefaultTaskTimeoutMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.defaultTaskTimeoutMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:defaultTaskTimeoutMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:priority: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:priority: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBasis() {
+		if err := oprot.WriteFieldBegin("basis", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:basis: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Basis)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.basis (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:basis: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetJobType() {
+		if err := oprot.WriteFieldBegin("jobType", thrift.STRING, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:jobType: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.JobType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.jobType (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:jobType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobDefinition(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+type JobId struct {
+	ID string `thrift:"id,1,required" json:"id"`
+}
+
+func NewJobId() *JobId {
+	return &JobId{}
+}
+
+func (p *JobId) GetID() string {
+	return p.ID
+}
+func (p *JobId) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	return nil
+}
+
+func (p *JobId) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *JobId) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobId"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobId) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *JobId) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobId(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Status
+//  - TaskStatus
+//  - TaskData
+//  - Version
+//  - SupersededBy
+type JobStatus struct {
+	ID           string                `thrift:"id,1,required" json:"id"`
+	Status       Status                `thrift:"status,2,required" json:"status"`
+	TaskStatus   map[string]Status     `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
+	TaskData     map[string]*RunStatus `thrift:"taskData,4" json:"taskData,omitempty"`
+	Version      *int64                `thrift:"version,5" json:"version,omitempty"`
+	SupersededBy *string               `thrift:"supersededBy,6" json:"supersededBy,omitempty"`
+}
+
+func NewJobStatus() *JobStatus {
+	return &JobStatus{}
+}
+
+func (p *JobStatus) GetID() string {
+	return p.ID
+}
+
+func (p *JobStatus) GetStatus() Status {
+	return p.Status
+}
+
+var JobStatus_TaskStatus_DEFAULT map[string]Status
+
+func (p *JobStatus) GetTaskStatus() map[string]Status {
+	return p.TaskStatus
+}
+
+var JobStatus_TaskData_DEFAULT map[string]*RunStatus
+
+func (p *JobStatus) GetTaskData() map[string]*RunStatus {
+	return p.TaskData
+}
+
+var JobStatus_Version_DEFAULT int64
+
+func (p *JobStatus) GetVersion() int64 {
+	if !p.IsSetVersion() {
+		return JobStatus_Version_DEFAULT
+	}
+	return *p.Version
+}
+
+var JobStatus_SupersededBy_DEFAULT string
+
+func (p *JobStatus) GetSupersededBy() string {
+	if !p.IsSetSupersededBy() {
+		return JobStatus_SupersededBy_DEFAULT
+	}
+	return *p.SupersededBy
+}
+func (p *JobStatus) IsSetTaskStatus() bool {
+	return p.TaskStatus != nil
+}
+
+func (p *JobStatus) IsSetTaskData() bool {
+	return p.TaskData != nil
+}
+
+func (p *JobStatus) IsSetVersion() bool {
+	return p.Version != nil
+}
+
+func (p *JobStatus) IsSetSupersededBy() bool {
+	return p.SupersededBy != nil
+}
+
+func (p *JobStatus) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetStatus bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	return nil
+}
+
+func (p *JobStatus) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *JobStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *JobStatus) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]Status, size)
+	p.TaskStatus = tMap
+	for i := 0; i < size; i++ {
+		var _key8 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key8 = v
+		}
+		var _val9 Status
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := Status(v)
+			_val9 = temp
+		}
+		p.TaskStatus[_key8] = _val9
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*RunStatus, size)
+	p.TaskData = tMap
+	for i := 0; i < size; i++ {
+		var _key10 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key10 = v
+		}
+		_val11 := &RunStatus{}
+		if err := _val11.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val11), err)
+		}
+		p.TaskData[_key10] = _val11
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Version = &v
+	}
+	return nil
+}
+
+func (p *JobStatus) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.SupersededBy = &v
+	}
+	return nil
+}
+
+func (p *JobStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *JobStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *JobStatus) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskStatus() {
+		if err := oprot.WriteFieldBegin("taskStatus", thrift.MAP, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:taskStatus: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.I32, len(p.TaskStatus)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskStatus {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:taskStatus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskData() {
+		if err := oprot.WriteFieldBegin("taskData", thrift.MAP, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:taskData: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.TaskData)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskData {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:taskData: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetVersion() {
+		if err := oprot.WriteFieldBegin("version", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:version: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Version)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.version (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:version: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSupersededBy() {
+		if err := oprot.WriteFieldBegin("supersededBy", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:supersededBy: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SupersededBy)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.supersededBy (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:supersededBy: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobStatus(%+v)", *p)
+}
+
+// Attributes:
+//  - Requestor
+//  - Tag
+//  - Basis
+//  - Priority
+//  - Status
+//  - Offset
+//  - Limit
+type ListJobsRequest struct {
+	Requestor *string `thrift:"requestor,1" json:"requestor,omitempty"`
+	Tag       *string `thrift:"tag,2" json:"tag,omitempty"`
+	Basis     *string `thrift:"basis,3" json:"basis,omitempty"`
+	Priority  *int32  `thrift:"priority,4" json:"priority,omitempty"`
+	Status    *Status `thrift:"status,5" json:"status,omitempty"`
+	Offset    *int32  `thrift:"offset,6" json:"offset,omitempty"`
+	Limit     *int32  `thrift:"limit,7" json:"limit,omitempty"`
+}
+
+func NewListJobsRequest() *ListJobsRequest {
+	return &ListJobsRequest{}
+}
+
+var ListJobsRequest_Requestor_DEFAULT string
+
+func (p *ListJobsRequest) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return ListJobsRequest_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var ListJobsRequest_Tag_DEFAULT string
+
+func (p *ListJobsRequest) GetTag() string {
+	if !p.IsSetTag() {
+		return ListJobsRequest_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var ListJobsRequest_Basis_DEFAULT string
+
+func (p *ListJobsRequest) GetBasis() string {
+	if !p.IsSetBasis() {
+		return ListJobsRequest_Basis_DEFAULT
+	}
+	return *p.Basis
+}
+
+var ListJobsRequest_Priority_DEFAULT int32
+
+func (p *ListJobsRequest) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return ListJobsRequest_Priority_DEFAULT
+	}
+	return *p.Priority
+}
+
+var ListJobsRequest_Status_DEFAULT Status
+
+func (p *ListJobsRequest) GetStatus() Status {
+	if !p.IsSetStatus() {
+		return ListJobsRequest_Status_DEFAULT
+	}
+	return *p.Status
+}
+
+var ListJobsRequest_Offset_DEFAULT int32
+
+func (p *ListJobsRequest) GetOffset() int32 {
+	if !p.IsSetOffset() {
+		return ListJobsRequest_Offset_DEFAULT
+	}
+	return *p.Offset
+}
+
+var ListJobsRequest_Limit_DEFAULT int32
+
+func (p *ListJobsRequest) GetLimit() int32 {
+	if !p.IsSetLimit() {
+		return ListJobsRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *ListJobsRequest) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *ListJobsRequest) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *ListJobsRequest) IsSetBasis() bool {
+	return p.Basis != nil
+}
+
+func (p *ListJobsRequest) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *ListJobsRequest) IsSetStatus() bool {
+	return p.Status != nil
+}
+
+func (p *ListJobsRequest) IsSetOffset() bool {
+	return p.Offset != nil
+}
+
+func (p *ListJobsRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *ListJobsRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Basis = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Priority = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = &temp
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Offset = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobsRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBasis() {
+		if err := oprot.WriteFieldBegin("basis", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:basis: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Basis)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.basis (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:basis: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:priority: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:priority: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStatus() {
+		if err := oprot.WriteFieldBegin("status", thrift.I32, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:status: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Status)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.status (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:status: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOffset() {
+		if err := oprot.WriteFieldBegin("offset", thrift.I32, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:offset: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Offset)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.offset (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:offset: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:limit: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:limit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListJobsRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Status
+//  - Requestor
+//  - Tag
+//  - Basis
+//  - Priority
+//  - SubmitTimeMs
+//  - NumTasks
+//  - NumRunning
+//  - NumCompleted
+type JobSummary struct {
+	ID           string  `thrift:"id,1,required" json:"id"`
+	Status       Status  `thrift:"status,2,required" json:"status"`
+	Requestor    *string `thrift:"requestor,3" json:"requestor,omitempty"`
+	Tag          *string `thrift:"tag,4" json:"tag,omitempty"`
+	Basis        *string `thrift:"basis,5" json:"basis,omitempty"`
+	Priority     *int32  `thrift:"priority,6" json:"priority,omitempty"`
+	SubmitTimeMs *int64  `thrift:"submitTimeMs,7" json:"submitTimeMs,omitempty"`
+	NumTasks     *int32  `thrift:"numTasks,8" json:"numTasks,omitempty"`
+	NumRunning   *int32  `thrift:"numRunning,9" json:"numRunning,omitempty"`
+	NumCompleted *int32  `thrift:"numCompleted,10" json:"numCompleted,omitempty"`
+}
+
+func NewJobSummary() *JobSummary {
+	return &JobSummary{}
+}
+
+func (p *JobSummary) GetID() string {
+	return p.ID
+}
+
+func (p *JobSummary) GetStatus() Status {
+	return p.Status
+}
+
+var JobSummary_Requestor_DEFAULT string
+
+func (p *JobSummary) GetRequestor() string {
+	if !p.IsSetRequestor() {
+		return JobSummary_Requestor_DEFAULT
+	}
+	return *p.Requestor
+}
+
+var JobSummary_Tag_DEFAULT string
+
+func (p *JobSummary) GetTag() string {
+	if !p.IsSetTag() {
+		return JobSummary_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var JobSummary_Basis_DEFAULT string
+
+func (p *JobSummary) GetBasis() string {
+	if !p.IsSetBasis() {
+		return JobSummary_Basis_DEFAULT
+	}
+	return *p.Basis
+}
+
+var JobSummary_Priority_DEFAULT int32
+
+func (p *JobSummary) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return JobSummary_Priority_DEFAULT
+	}
+	return *p.Priority
+}
+
+var JobSummary_SubmitTimeMs_DEFAULT int64
+
+func (p *JobSummary) GetSubmitTimeMs() int64 {
+	if !p.IsSetSubmitTimeMs() {
+		return JobSummary_SubmitTimeMs_DEFAULT
+	}
+	return *p.SubmitTimeMs
+}
+
+var JobSummary_NumTasks_DEFAULT int32
+
+func (p *JobSummary) GetNumTasks() int32 {
+	if !p.IsSetNumTasks() {
+		return JobSummary_NumTasks_DEFAULT
+	}
+	return *p.NumTasks
+}
+
+var JobSummary_NumRunning_DEFAULT int32
+
+func (p *JobSummary) GetNumRunning() int32 {
+	if !p.IsSetNumRunning() {
+		return JobSummary_NumRunning_DEFAULT
+	}
+	return *p.NumRunning
+}
+
+var JobSummary_NumCompleted_DEFAULT int32
+
+func (p *JobSummary) GetNumCompleted() int32 {
+	if !p.IsSetNumCompleted() {
+		return JobSummary_NumCompleted_DEFAULT
+	}
+	return *p.NumCompleted
+}
+func (p *JobSummary) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *JobSummary) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *JobSummary) IsSetBasis() bool {
+	return p.Basis != nil
+}
+
+func (p *JobSummary) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *JobSummary) IsSetSubmitTimeMs() bool {
+	return p.SubmitTimeMs != nil
+}
+
+func (p *JobSummary) IsSetNumTasks() bool {
+	return p.NumTasks != nil
+}
+
+func (p *JobSummary) IsSetNumRunning() bool {
+	return p.NumRunning != nil
+}
+
+func (p *JobSummary) IsSetNumCompleted() bool {
+	return p.NumCompleted != nil
+}
+
+func (p *JobSummary) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+	var issetStatus bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	return nil
+}
+
+func (p *JobSummary) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *JobSummary) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Requestor = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Basis = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Priority = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.SubmitTimeMs = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.NumTasks = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.NumRunning = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.NumCompleted = &v
+	}
+	return nil
+}
+
+func (p *JobSummary) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobSummary"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobSummary) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *JobSummary) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *JobSummary) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequestor() {
+		if err := oprot.WriteFieldBegin("requestor", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:requestor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Requestor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requestor (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:requestor: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBasis() {
+		if err := oprot.WriteFieldBegin("basis", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:basis: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Basis)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.basis (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:basis: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:priority: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:priority: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubmitTimeMs() {
+		if err := oprot.WriteFieldBegin("submitTimeMs", thrift.I64, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:submitTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SubmitTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.submitTimeMs (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:submitTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNumTasks() {
+		if err := oprot.WriteFieldBegin("numTasks", thrift.I32, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:numTasks: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.NumTasks)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.numTasks (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:numTasks: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNumRunning() {
+		if err := oprot.WriteFieldBegin("numRunning", thrift.I32, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:numRunning: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.NumRunning)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.numRunning (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:numRunning: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNumCompleted() {
+		if err := oprot.WriteFieldBegin("numCompleted", thrift.I32, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:numCompleted: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.NumCompleted)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.numCompleted (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:numCompleted: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobSummary(%+v)", *p)
+}
+
+// Attributes:
+//  - Jobs
+//  - Total
+type ListJobsResponse struct {
+	Jobs  []*JobSummary `thrift:"jobs,1,required" json:"jobs"`
+	Total *int32        `thrift:"total,2" json:"total,omitempty"`
+}
+
+func NewListJobsResponse() *ListJobsResponse {
+	return &ListJobsResponse{}
+}
+
+func (p *ListJobsResponse) GetJobs() []*JobSummary {
+	return p.Jobs
+}
+
+var ListJobsResponse_Total_DEFAULT int32
+
+func (p *ListJobsResponse) GetTotal() int32 {
+	if !p.IsSetTotal() {
+		return ListJobsResponse_Total_DEFAULT
+	}
+	return *p.Total
+}
+func (p *ListJobsResponse) IsSetTotal() bool {
+	return p.Total != nil
+}
+
+func (p *ListJobsResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetJobs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetJobs = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetJobs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Jobs is not set"))
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*JobSummary, 0, size)
+	p.Jobs = tSlice
+	for i := 0; i < size; i++ {
+		_elem12 := &JobSummary{}
+		if err := _elem12.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem12), err)
+		}
+		p.Jobs = append(p.Jobs, _elem12)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Total = &v
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobsResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobs", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobs: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Jobs)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Jobs {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobs: ", p), err)
+	}
+	return err
+}
+
+func (p *ListJobsResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTotal() {
+		if err := oprot.WriteFieldBegin("total", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:total: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Total)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.total (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:total: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *ListJobsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListJobsResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - NodeId
+//  - Version
+//  - Slots
+//  - NumRunning
+//  - Labels
+//  - SnapshotIds
+type WorkerHeartbeat struct {
+	NodeId      string            `thrift:"nodeId,1,required" json:"nodeId"`
+	Version     *string           `thrift:"version,2" json:"version,omitempty"`
+	Slots       *int32            `thrift:"slots,3" json:"slots,omitempty"`
+	NumRunning  *int32            `thrift:"numRunning,4" json:"numRunning,omitempty"`
+	Labels      map[string]string `thrift:"labels,5" json:"labels,omitempty"`
+	SnapshotIds []string          `thrift:"snapshotIds,6" json:"snapshotIds,omitempty"`
+}
+
+func NewWorkerHeartbeat() *WorkerHeartbeat {
+	return &WorkerHeartbeat{}
+}
+
+func (p *WorkerHeartbeat) GetNodeId() string {
+	return p.NodeId
+}
+
+var WorkerHeartbeat_Version_DEFAULT string
+
+func (p *WorkerHeartbeat) GetVersion() string {
+	if !p.IsSetVersion() {
+		return WorkerHeartbeat_Version_DEFAULT
+	}
+	return *p.Version
+}
+
+var WorkerHeartbeat_Slots_DEFAULT int32
+
+func (p *WorkerHeartbeat) GetSlots() int32 {
+	if !p.IsSetSlots() {
+		return WorkerHeartbeat_Slots_DEFAULT
+	}
+	return *p.Slots
+}
+
+var WorkerHeartbeat_NumRunning_DEFAULT int32
+
+func (p *WorkerHeartbeat) GetNumRunning() int32 {
+	if !p.IsSetNumRunning() {
+		return WorkerHeartbeat_NumRunning_DEFAULT
+	}
+	return *p.NumRunning
+}
+
+var WorkerHeartbeat_Labels_DEFAULT map[string]string
+
+func (p *WorkerHeartbeat) GetLabels() map[string]string {
+	return p.Labels
+}
+
+var WorkerHeartbeat_SnapshotIds_DEFAULT []string
+
+func (p *WorkerHeartbeat) GetSnapshotIds() []string {
+	return p.SnapshotIds
+}
+func (p *WorkerHeartbeat) IsSetVersion() bool {
+	return p.Version != nil
+}
+
+func (p *WorkerHeartbeat) IsSetSlots() bool {
+	return p.Slots != nil
+}
+
+func (p *WorkerHeartbeat) IsSetNumRunning() bool {
+	return p.NumRunning != nil
+}
+
+func (p *WorkerHeartbeat) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
+func (p *WorkerHeartbeat) IsSetSnapshotIds() bool {
+	return p.SnapshotIds != nil
+}
+
+func (p *WorkerHeartbeat) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetNodeId bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetNodeId = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetNodeId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NodeId is not set"))
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NodeId = v
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Version = &v
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Slots = &v
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.NumRunning = &v
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) readField5(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key13 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key13 = v
+		}
+		var _val14 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val14 = v
+		}
+		p.Labels[_key13] = _val14
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) readField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.SnapshotIds = tSlice
+	for i := 0; i < size; i++ {
+		var _elem15 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem15 = v
+		}
+		p.SnapshotIds = append(p.SnapshotIds, _elem15)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *WorkerHeartbeat) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WorkerHeartbeat"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -1879,79 +4230,179 @@ func (p *JobId) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructEnd(); err != nil {
 		return thrift.PrependError("write struct stop error: ", err)
 	}
-	return nil
+	return nil
+}
+
+func (p *WorkerHeartbeat) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nodeId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nodeId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.NodeId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nodeId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nodeId: ", p), err)
+	}
+	return err
+}
+
+func (p *WorkerHeartbeat) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetVersion() {
+		if err := oprot.WriteFieldBegin("version", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:version: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Version)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.version (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:version: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerHeartbeat) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSlots() {
+		if err := oprot.WriteFieldBegin("slots", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:slots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Slots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.slots (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:slots: ", p), err)
+		}
+	}
+	return err
 }
 
-func (p *JobId) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+func (p *WorkerHeartbeat) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNumRunning() {
+		if err := oprot.WriteFieldBegin("numRunning", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:numRunning: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.NumRunning)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.numRunning (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:numRunning: ", p), err)
+		}
 	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	return err
+}
+
+func (p *WorkerHeartbeat) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:labels: ", p), err)
+		}
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	return err
+}
+
+func (p *WorkerHeartbeat) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotIds() {
+		if err := oprot.WriteFieldBegin("snapshotIds", thrift.LIST, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:snapshotIds: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.SnapshotIds)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.SnapshotIds {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:snapshotIds: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *JobId) String() string {
+func (p *WorkerHeartbeat) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("JobId(%+v)", *p)
+	return fmt.Sprintf("WorkerHeartbeat(%+v)", *p)
 }
 
 // Attributes:
-//  - ID
-//  - Status
-//  - TaskStatus
-//  - TaskData
-type JobStatus struct {
-	ID         string                `thrift:"id,1,required" json:"id"`
-	Status     Status                `thrift:"status,2,required" json:"status"`
-	TaskStatus map[string]Status     `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
-	TaskData   map[string]*RunStatus `thrift:"taskData,4" json:"taskData,omitempty"`
+//  - NodeId
+//  - Drained
+//  - NumRunning
+//  - InCluster
+type NodeDrainStatus struct {
+	NodeId     string `thrift:"nodeId,1,required" json:"nodeId"`
+	Drained    bool   `thrift:"drained,2,required" json:"drained"`
+	NumRunning *int32 `thrift:"numRunning,3" json:"numRunning,omitempty"`
+	InCluster  *bool  `thrift:"inCluster,4" json:"inCluster,omitempty"`
 }
 
-func NewJobStatus() *JobStatus {
-	return &JobStatus{}
+func NewNodeDrainStatus() *NodeDrainStatus {
+	return &NodeDrainStatus{}
 }
 
-func (p *JobStatus) GetID() string {
-	return p.ID
+func (p *NodeDrainStatus) GetNodeId() string {
+	return p.NodeId
 }
 
-func (p *JobStatus) GetStatus() Status {
-	return p.Status
+func (p *NodeDrainStatus) GetDrained() bool {
+	return p.Drained
 }
 
-var JobStatus_TaskStatus_DEFAULT map[string]Status
+var NodeDrainStatus_NumRunning_DEFAULT int32
 
-func (p *JobStatus) GetTaskStatus() map[string]Status {
-	return p.TaskStatus
+func (p *NodeDrainStatus) GetNumRunning() int32 {
+	if !p.IsSetNumRunning() {
+		return NodeDrainStatus_NumRunning_DEFAULT
+	}
+	return *p.NumRunning
 }
 
-var JobStatus_TaskData_DEFAULT map[string]*RunStatus
+var NodeDrainStatus_InCluster_DEFAULT bool
 
-func (p *JobStatus) GetTaskData() map[string]*RunStatus {
-	return p.TaskData
+func (p *NodeDrainStatus) GetInCluster() bool {
+	if !p.IsSetInCluster() {
+		return NodeDrainStatus_InCluster_DEFAULT
+	}
+	return *p.InCluster
 }
-func (p *JobStatus) IsSetTaskStatus() bool {
-	return p.TaskStatus != nil
+func (p *NodeDrainStatus) IsSetNumRunning() bool {
+	return p.NumRunning != nil
 }
 
-func (p *JobStatus) IsSetTaskData() bool {
-	return p.TaskData != nil
+func (p *NodeDrainStatus) IsSetInCluster() bool {
+	return p.InCluster != nil
 }
 
-func (p *JobStatus) Read(iprot thrift.TProtocol) error {
+func (p *NodeDrainStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetID bool = false
-	var issetStatus bool = false
+	var issetNodeId bool = false
+	var issetDrained bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -1966,12 +4417,12 @@ func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetID = true
+			issetNodeId = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-			issetStatus = true
+			issetDrained = true
 		case 3:
 			if err := p.readField3(iprot); err != nil {
 				return err
@@ -1992,91 +4443,53 @@ func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	if !issetNodeId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NodeId is not set"))
 	}
-	if !issetStatus {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	if !issetDrained {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Drained is not set"))
 	}
 	return nil
 }
 
-func (p *JobStatus) readField1(iprot thrift.TProtocol) error {
+func (p *NodeDrainStatus) readField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.ID = v
+		p.NodeId = v
 	}
 	return nil
 }
 
-func (p *JobStatus) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *NodeDrainStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		temp := Status(v)
-		p.Status = temp
+		p.Drained = v
 	}
 	return nil
 }
 
-func (p *JobStatus) readField3(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
-	}
-	tMap := make(map[string]Status, size)
-	p.TaskStatus = tMap
-	for i := 0; i < size; i++ {
-		var _key2 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key2 = v
-		}
-		var _val3 Status
-		if v, err := iprot.ReadI32(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			temp := Status(v)
-			_val3 = temp
-		}
-		p.TaskStatus[_key2] = _val3
-	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+func (p *NodeDrainStatus) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.NumRunning = &v
 	}
 	return nil
 }
 
-func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
-	}
-	tMap := make(map[string]*RunStatus, size)
-	p.TaskData = tMap
-	for i := 0; i < size; i++ {
-		var _key4 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key4 = v
-		}
-		_val5 := &RunStatus{}
-		if err := _val5.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val5), err)
-		}
-		p.TaskData[_key4] = _val5
-	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+func (p *NodeDrainStatus) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.InCluster = &v
 	}
 	return nil
 }
 
-func (p *JobStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobStatus"); err != nil {
+func (p *NodeDrainStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeDrainStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2100,87 +4513,182 @@ func (p *JobStatus) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+func (p *NodeDrainStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nodeId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nodeId: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.NodeId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nodeId (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nodeId: ", p), err)
 	}
 	return err
 }
 
-func (p *JobStatus) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+func (p *NodeDrainStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("drained", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:drained: ", p), err)
 	}
-	if err := oprot.WriteI32(int32(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.Drained)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.drained (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:drained: ", p), err)
 	}
 	return err
 }
 
-func (p *JobStatus) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTaskStatus() {
-		if err := oprot.WriteFieldBegin("taskStatus", thrift.MAP, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:taskStatus: ", p), err)
+func (p *NodeDrainStatus) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNumRunning() {
+		if err := oprot.WriteFieldBegin("numRunning", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:numRunning: ", p), err)
 		}
-		if err := oprot.WriteMapBegin(thrift.STRING, thrift.I32, len(p.TaskStatus)); err != nil {
-			return thrift.PrependError("error writing map begin: ", err)
+		if err := oprot.WriteI32(int32(*p.NumRunning)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.numRunning (3) field write error: ", p), err)
 		}
-		for k, v := range p.TaskStatus {
-			if err := oprot.WriteString(string(k)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
-			if err := oprot.WriteI32(int32(v)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:numRunning: ", p), err)
 		}
-		if err := oprot.WriteMapEnd(); err != nil {
-			return thrift.PrependError("error writing map end: ", err)
+	}
+	return err
+}
+
+func (p *NodeDrainStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetInCluster() {
+		if err := oprot.WriteFieldBegin("inCluster", thrift.BOOL, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:inCluster: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.InCluster)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.inCluster (4) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:taskStatus: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:inCluster: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobStatus) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTaskData() {
-		if err := oprot.WriteFieldBegin("taskData", thrift.MAP, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:taskData: ", p), err)
+func (p *NodeDrainStatus) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeDrainStatus(%+v)", *p)
+}
+
+// Attributes:
+//  - Nodes
+type DrainStatusResponse struct {
+	Nodes []*NodeDrainStatus `thrift:"nodes,1,required" json:"nodes"`
+}
+
+func NewDrainStatusResponse() *DrainStatusResponse {
+	return &DrainStatusResponse{}
+}
+
+func (p *DrainStatusResponse) GetNodes() []*NodeDrainStatus {
+	return p.Nodes
+}
+func (p *DrainStatusResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetNodes bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
 		}
-		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.TaskData)); err != nil {
-			return thrift.PrependError("error writing map begin: ", err)
+		if fieldTypeId == thrift.STOP {
+			break
 		}
-		for k, v := range p.TaskData {
-			if err := oprot.WriteString(string(k)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
 			}
-			if err := v.Write(oprot); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			issetNodes = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
 			}
 		}
-		if err := oprot.WriteMapEnd(); err != nil {
-			return thrift.PrependError("error writing map end: ", err)
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:taskData: ", p), err)
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetNodes {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Nodes is not set"))
+	}
+	return nil
+}
+
+func (p *DrainStatusResponse) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeDrainStatus, 0, size)
+	p.Nodes = tSlice
+	for i := 0; i < size; i++ {
+		_elem16 := &NodeDrainStatus{}
+		if err := _elem16.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem16), err)
+		}
+		p.Nodes = append(p.Nodes, _elem16)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *DrainStatusResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DrainStatusResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DrainStatusResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nodes", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nodes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Nodes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Nodes {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
 	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nodes: ", p), err)
+	}
 	return err
 }
 
-func (p *JobStatus) String() string {
+func (p *DrainStatusResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("JobStatus(%+v)", *p)
+	return fmt.Sprintf("DrainStatusResponse(%+v)", *p)
 }
+
@@ -2,6 +2,7 @@ exception InvalidRequest {
   1: optional string message
 }
 
+# The job can't be accepted now, e.g. because its requestor exceeded a quota, but may be after retryAfterMs.
 exception CanNotScheduleNow {
   1: optional i64 retryAfterMs
 }
@@ -44,6 +45,25 @@ struct RunStatus {
 
 struct Command {
   1: list<string> argv
+  # Environment variables set for the command, overriding the worker's defaults and inherited variables.
+  2: optional map<string, string> envVars
+  # Run the command in a sandbox, without network access and only able to write to its checkout and any tmpfs
+  # the worker provides at /tmp. Workers may sandbox every command regardless.
+  3: optional bool sandbox
+  # Run the command sandboxed in this OCI image from the worker's image directory, ex: "go.tar:1.8", with
+  # its checkout mounted at /scoot/checkout. Fails on workers without images.
+  4: optional string image
+  # Files and directories to copy from the checkout into the task's result snapshot, as paths relative to
+  # the checkout that may be globs, ex: "reports/*.xml". If the command exits zero, paths that aren't globs
+  # must exist or the task fails. Workers limit the total size of a task's outputs.
+  5: optional list<string> outputs
+}
+
+# Resources a task needs from the node it runs on. Unset or zero values are no requirement.
+struct Resources {
+  1: optional i32 cpus
+  2: optional i64 memoryBytes
+  3: optional i64 diskBytes
 }
 
 struct TaskDefinition {
@@ -52,6 +72,15 @@ struct TaskDefinition {
   # TaskId should generally be unique, otherwise previous tasks with the same Requestor and Tag will be stomped.
   3: optional string taskId
   4: optional i32 timeoutMs
+  # TaskIds of other tasks in the same job that must complete successfully before this task is run.
+  # If any of them fail, this task is skipped.
+  5: optional list<string> dependencies
+  # TaskId of one of the dependencies whose output snapshot replaces this task's snapshotId.
+  6: optional string snapshotFromTask
+  # The task is only placed on a node with these resources left over from its other running tasks.
+  7: optional Resources resources
+  # Labels a node must have to run this task, ex: {"os": "linux-x86", "has": "docker"}.
+  8: optional map<string, string> nodeSelector
 }
 
 struct JobDefinition {
@@ -64,6 +93,9 @@ struct JobDefinition {
   # Set to JobId by default.
   5: optional string tag
   # Basis is used to replace an ancestor of this job (keep only the latest job for a basis).
+  # Submitting a job kills any current job with the same requestor and basis; the unfinished tasks of
+  # the killed job end with an ABORTED status whose error is "Superseded by job <id>", and its JobStatus
+  # has supersededBy set to <id>.
   6: optional string basis
   # Requestor is used for rate limiting. If unfilled, limit is applied to a no-name pool.
   7: optional string requestor
@@ -100,6 +132,66 @@ struct JobStatus {
   2: required Status status
   3: optional map<string, Status> taskStatus
   4: optional map<string, RunStatus> taskData
+  # Set by WatchJob, pass it to the next WatchJob call to get only the changes made after this status.
+  5: optional i64 version
+  # Id of the newer job with the same requestor and basis that killed this job, if it was superseded.
+  6: optional string supersededBy
+}
+
+# Unset fields match any job. Offset and limit page through the matching jobs, which are ordered by submit time.
+struct ListJobsRequest {
+  1: optional string requestor
+  2: optional string tag
+  3: optional string basis
+  4: optional i32 priority
+  5: optional Status status
+  6: optional i32 offset
+  7: optional i32 limit
+}
+
+struct JobSummary {
+  1: required string id
+  2: required Status status
+  3: optional string requestor
+  4: optional string tag
+  5: optional string basis
+  6: optional i32 priority
+  7: optional i64 submitTimeMs
+  8: optional i32 numTasks
+  9: optional i32 numRunning
+  10: optional i32 numCompleted
+}
+
+struct ListJobsResponse {
+  1: required list<JobSummary> jobs
+  # Number of jobs matching the request, ignoring offset and limit.
+  2: optional i32 total
+}
+
+# Sent periodically by workers to register with the scheduler. A worker is considered lost once its heartbeats stop.
+struct WorkerHeartbeat {
+  # The worker's thrift address, 'host:port', used as its node id.
+  1: required string nodeId
+  2: optional string version
+  3: optional i32 slots
+  4: optional i32 numRunning
+  5: optional map<string, string> labels
+  # Snapshots the worker checked out recently, most recent first, which it likely still has cached.
+  6: optional list<string> snapshotIds
+}
+
+# A node marked as draining accepts no new tasks, it's drained once its running tasks have finished.
+struct NodeDrainStatus {
+  1: required string nodeId
+  2: required bool drained
+  3: optional i32 numRunning
+  # False if the node isn't currently in the cluster, it will start out draining if it joins.
+  4: optional bool inCluster
+}
+
+struct DrainStatusResponse {
+  # All nodes that are draining, ordered by node id.
+  1: required list<NodeDrainStatus> nodes
 }
 
 service CloudScoot {
@@ -115,4 +207,32 @@ service CloudScoot {
     1: InvalidRequest ir
     2: ScootServerError err
     )
+  # Waits up to timeoutMs for the job to change after the given version (0 for the full status), and returns
+  # the job status with only the tasks that changed since then. If nothing changed, no tasks are returned.
+  JobStatus WatchJob(1: string jobId, 2: i64 version, 3: i32 timeoutMs) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  # Lists the jobs known to the scheduler, i.e. those that are queued or in progress.
+  ListJobsResponse ListJobs(1: ListJobsRequest request) throws (
+    1: InvalidRequest ir
+  )
+  # Registers the worker if it's new, and refreshes it otherwise. Only available if the scheduler's cluster
+  # is configured to track workers by heartbeat.
+  void Heartbeat(1: WorkerHeartbeat heartbeat) throws (
+    1: InvalidRequest ir
+  )
+  # Marks the given nodes as draining so they accept no new tasks. Draining persists across scheduler restarts.
+  DrainStatusResponse DrainNodes(1: list<string> nodeIds) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  # Returns the given nodes to rotation.
+  DrainStatusResponse UndrainNodes(1: list<string> nodeIds) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
+  DrainStatusResponse GetDrainStatus() throws (
+    1: ScootServerError err
+  )
 }
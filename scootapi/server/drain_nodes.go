@@ -0,0 +1,63 @@
+package server
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the DrainNodes API
+func DrainNodes(nodeIds []string, s scheduler.Scheduler) (*scoot.DrainStatusResponse, error) {
+	if err := validateNodeIds(nodeIds); err != nil {
+		return nil, err
+	}
+	return drainStatusToThrift(s.DrainNodes(nodeIds))
+}
+
+// Implementation of the UndrainNodes API
+func UndrainNodes(nodeIds []string, s scheduler.Scheduler) (*scoot.DrainStatusResponse, error) {
+	if err := validateNodeIds(nodeIds); err != nil {
+		return nil, err
+	}
+	return drainStatusToThrift(s.UndrainNodes(nodeIds))
+}
+
+// Implementation of the GetDrainStatus API
+func GetDrainStatus(s scheduler.Scheduler) (*scoot.DrainStatusResponse, error) {
+	return drainStatusToThrift(s.DrainStatus())
+}
+
+func validateNodeIds(nodeIds []string) error {
+	if len(nodeIds) == 0 {
+		msg := "At least one node id is required"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	for _, id := range nodeIds {
+		if id == "" {
+			msg := "Node ids must not be empty"
+			return &scoot.InvalidRequest{Message: &msg}
+		}
+	}
+	return nil
+}
+
+// Translates the scheduler's drain status to thrift, or its error to a ScootServerError.
+func drainStatusToThrift(statuses []scheduler.NodeDrainStatus, err error) (*scoot.DrainStatusResponse, error) {
+	if err != nil {
+		log.Errorf("Error changing or reading drain status: %v", err)
+		return nil, scoot.NewScootServerError()
+	}
+	resp := scoot.NewDrainStatusResponse()
+	resp.Nodes = make([]*scoot.NodeDrainStatus, 0, len(statuses))
+	for _, st := range statuses {
+		numRunning, inCluster := int32(st.NumRunning), st.InCluster
+		resp.Nodes = append(resp.Nodes, &scoot.NodeDrainStatus{
+			NodeId:     st.NodeId,
+			Drained:    st.Drained,
+			NumRunning: &numRunning,
+			InCluster:  &inCluster,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_DrainNodes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := scheduler.NewMockScheduler(mockCtrl)
+
+	statuses := []scheduler.NodeDrainStatus{
+		{NodeId: "node1", NumRunning: 2, InCluster: true},
+		{NodeId: "node2", Drained: true},
+	}
+	s.EXPECT().DrainNodes([]string{"node1", "node2"}).Return(statuses, nil)
+	resp, err := DrainNodes([]string{"node1", "node2"}, s)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, instead got %v", err)
+	}
+	if len(resp.Nodes) != 2 {
+		t.Fatalf("Expected 2 nodes, got %+v", resp)
+	}
+	if n := resp.Nodes[0]; n.NodeId != "node1" || n.Drained || n.GetNumRunning() != 2 || !n.GetInCluster() {
+		t.Errorf("Unexpected drain status %+v", n)
+	}
+	if n := resp.Nodes[1]; n.NodeId != "node2" || !n.Drained || n.GetNumRunning() != 0 || n.GetInCluster() {
+		t.Errorf("Unexpected drain status %+v", n)
+	}
+
+	for _, ids := range [][]string{nil, {"node1", ""}} {
+		if _, err := DrainNodes(ids, s); err == nil {
+			t.Fatalf("Expected error for node ids %v", ids)
+		} else if _, ok := err.(*scoot.InvalidRequest); !ok {
+			t.Fatalf("Expected InvalidRequest, got %v", err)
+		}
+	}
+
+	s.EXPECT().UndrainNodes([]string{"node1"}).Return(nil, errors.New("disk full"))
+	if _, err := UndrainNodes([]string{"node1"}, s); err == nil {
+		t.Fatal("Expected error from scheduler")
+	} else if _, ok := err.(*scoot.ScootServerError); !ok {
+		t.Fatalf("Expected ScootServerError, got %v", err)
+	}
+
+	s.EXPECT().DrainStatus().Return([]scheduler.NodeDrainStatus{}, nil)
+	if resp, err := GetDrainStatus(s); err != nil || len(resp.Nodes) != 0 {
+		t.Fatalf("Expected no draining nodes, got %+v, %v", resp, err)
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/cloud/cluster/registry"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the Heartbeat API. Heartbeats are rejected, and logged as errors,
+// unless the scheduler's cluster is configured to track workers by heartbeat.
+func Heartbeat(hb *scoot.WorkerHeartbeat, r *registry.Registry) error {
+	if r == nil {
+		msg := `scheduler cluster isn't configured to track workers by heartbeat, use the "heartbeat" Cluster config`
+		log.Errorf("Rejecting heartbeat from %s: %s", hb.GetNodeId(), msg)
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	if hb == nil || hb.NodeId == "" {
+		msg := "heartbeat must have a nodeId"
+		return &scoot.InvalidRequest{Message: &msg}
+	}
+	r.Heartbeat(thriftHeartbeatToDomain(hb))
+	return nil
+}
+
+func thriftHeartbeatToDomain(hb *scoot.WorkerHeartbeat) registry.Heartbeat {
+	return registry.Heartbeat{
+		NodeId:      cluster.NodeId(hb.NodeId),
+		Version:     hb.GetVersion(),
+		Slots:       int(hb.GetSlots()),
+		NumRunning:  int(hb.GetNumRunning()),
+		Labels:      hb.GetLabels(),
+		SnapshotIDs: hb.GetSnapshotIds(),
+	}
+}
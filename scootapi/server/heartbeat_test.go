@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/cloud/cluster/registry"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_Heartbeat(t *testing.T) {
+	hb := scoot.NewWorkerHeartbeat()
+	hb.NodeId = "host1:9091"
+	if err := Heartbeat(hb, nil); err == nil {
+		t.Fatalf("Expected error without a registry")
+	}
+
+	r := registry.NewRegistry(time.Minute, nil, stats.NilStatsReceiver())
+	cl := cluster.NewCluster(nil, r.Updates())
+	if err := Heartbeat(scoot.NewWorkerHeartbeat(), r); err == nil {
+		t.Fatalf("Expected error for a heartbeat without a nodeId")
+	}
+
+	slots := int32(4)
+	hb.Slots = &slots
+	hb.Labels = map[string]string{"os": "linux"}
+	if err := Heartbeat(hb, r); err != nil {
+		t.Fatalf("Heartbeat returned err: %v", err)
+	}
+	if members := cl.Members(); len(members) != 1 || members[0].Id() != "host1:9091" {
+		t.Fatalf("Expected the worker to be registered, got %v", members)
+	}
+	if hbs := r.Workers(); len(hbs) != 1 || hbs[0].Slots != 4 || hbs[0].Labels["os"] != "linux" {
+		t.Fatalf("Expected the worker's heartbeat to be recorded, got %v", hbs)
+	}
+}
@@ -1,9 +1,12 @@
 package server
 
 import (
+	"fmt"
+
 	"github.com/twitter/scoot/common/thrifthelpers"
 	s "github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
@@ -71,6 +74,9 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 				taskStatus = scoot.Status_COMPLETED
 				if thriftJobStatus, err := workerRunStatusToScootRunStatus(sagaState.GetEndTaskData(id)); err == nil {
 					js.TaskData[id] = thriftJobStatus
+					if supersededBy := getSupersededBy(thriftJobStatus); supersededBy != "" {
+						js.SupersededBy = &supersededBy
+					}
 				}
 			} else if sagaState.IsTaskStarted(id) {
 				taskStatus = scoot.Status_IN_PROGRESS
@@ -106,6 +112,19 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 	return js
 }
 
+// The saga log doesn't record why a job was killed, but the tasks of a superseded job are aborted
+// with SupersededError, which names the job that superseded it.
+func getSupersededBy(rs *scoot.RunStatus) string {
+	if rs == nil || rs.Status != scoot.RunStatusState_ABORTED || rs.Error == nil {
+		return ""
+	}
+	var jobId string
+	if n, _ := fmt.Sscanf(*rs.Error, scheduler.SupersededError, &jobId); n != 1 {
+		return ""
+	}
+	return jobId
+}
+
 // this is a thrift to thrift structure translation.  We are doing this because we get invalid
 // import statements in the generated code when we use thrift import statements (this issue is supposed
 // to be fixed in thrift 10.0
@@ -117,6 +136,10 @@ func workerRunStatusToScootRunStatus(resultsFromSaga []byte) (*scoot.RunStatus,
 	workerRunStatus := worker.RunStatus{}
 	thrifthelpers.JsonDeserialize(&workerRunStatus, resultsFromSaga)
 
+	return workerToScootRunStatus(&workerRunStatus)
+}
+
+func workerToScootRunStatus(workerRunStatus *worker.RunStatus) (*scoot.RunStatus, error) {
 	status, err := scoot.RunStatusStateFromString(workerRunStatus.Status.String())
 	if err != nil {
 		return nil, err
@@ -1,15 +1,18 @@
 package server
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
+	"github.com/twitter/scoot/common/log/tags"
 	"github.com/twitter/scoot/common/thrifthelpers"
 	"github.com/twitter/scoot/runner"
 	s "github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/workerapi"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
@@ -229,3 +232,34 @@ func TestRunStatusRoundTrip(t *testing.T) {
 		t.Fatalf("runStatus.OutUri: %v (expected %v)", *runStatus.OutUri, stdoutRef)
 	}
 }
+
+func TestSupersededJobStatus(t *testing.T) {
+	sagaCoord := s.MakeSagaCoordinator(sagalogs.MakeInMemorySagaLog())
+	saga, err := sagaCoord.MakeSaga("old", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := runner.AbortStatus("", tags.LogTags{JobID: "old", TaskID: "t"})
+	st.Error = fmt.Sprintf(scheduler.SupersededError, "new")
+	statusAsBytes, err := workerapi.SerializeProcessStatus(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saga.StartTask("t", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = saga.EndTask("t", statusAsBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	jobStatus, err := GetJobStatus("old", sagaCoord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobStatus.GetSupersededBy() != "new" {
+		t.Fatalf("Expected job to be superseded by new, got %v", jobStatus)
+	}
+	if jobStatus, _ = GetJobStatus("other", sagaCoord); jobStatus.IsSetSupersededBy() {
+		t.Fatalf("Expected supersededBy to be unset, got %v", jobStatus)
+	}
+}
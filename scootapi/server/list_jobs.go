@@ -0,0 +1,101 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Number of jobs returned by ListJobs when the request doesn't set a limit.
+const DefaultListJobsLimit = 100
+
+// Implementation of the ListJobs API
+func ListJobs(req *scoot.ListJobsRequest, s scheduler.Scheduler) (*scoot.ListJobsResponse, error) {
+	filter, err := thriftListJobsRequestToFilter(req)
+	if err != nil {
+		msg := err.Error()
+		return nil, &scoot.InvalidRequest{Message: &msg}
+	}
+
+	jobs, total, err := s.ListJobs(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := scoot.NewListJobsResponse()
+	resp.Jobs = make([]*scoot.JobSummary, 0, len(jobs))
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, jobSummaryToThrift(job))
+	}
+	t := int32(total)
+	resp.Total = &t
+	return resp, nil
+}
+
+// Translates a thrift list jobs request to a scheduler filter, using the default limit if unset
+func thriftListJobsRequestToFilter(req *scoot.ListJobsRequest) (scheduler.JobFilter, error) {
+	filter := scheduler.JobFilter{Limit: DefaultListJobsLimit}
+	if req == nil {
+		return filter, nil
+	}
+	if req.GetOffset() < 0 || req.GetLimit() < 0 {
+		return filter, fmt.Errorf("Offset and limit must not be negative, got %d and %d", req.GetOffset(), req.GetLimit())
+	}
+
+	filter.Requestor = req.GetRequestor()
+	filter.Tag = req.GetTag()
+	filter.Basis = req.GetBasis()
+	filter.Offset = int(req.GetOffset())
+	if req.IsSetLimit() && req.GetLimit() > 0 {
+		filter.Limit = int(req.GetLimit())
+	}
+	if req.IsSetPriority() {
+		p := sched.Priority(req.GetPriority())
+		filter.Priority = &p
+	}
+	if req.IsSetStatus() {
+		st, err := thriftStatusToScoot(req.GetStatus())
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &st
+	}
+	return filter, nil
+}
+
+func thriftStatusToScoot(st scoot.Status) (sched.Status, error) {
+	switch st {
+	case scoot.Status_NOT_STARTED:
+		return sched.NotStarted, nil
+	case scoot.Status_IN_PROGRESS:
+		return sched.InProgress, nil
+	case scoot.Status_COMPLETED:
+		return sched.Completed, nil
+	case scoot.Status_ROLLING_BACK:
+		return sched.RollingBack, nil
+	case scoot.Status_ROLLED_BACK:
+		return sched.RolledBack, nil
+	}
+	return sched.NotStarted, fmt.Errorf("Unknown status %v", st)
+}
+
+func jobSummaryToThrift(job scheduler.JobSummary) *scoot.JobSummary {
+	requestor, tag, basis := job.Requestor, job.Tag, job.Basis
+	priority := int32(job.Priority)
+	submitTimeMs := job.SubmitTime.UnixNano() / 1e6
+	numTasks, numRunning, numCompleted := int32(job.NumTasks), int32(job.NumRunning), int32(job.NumCompleted)
+	return &scoot.JobSummary{
+		ID:           job.JobId,
+		Status:       convertStatus(job.Status),
+		Requestor:    &requestor,
+		Tag:          &tag,
+		Basis:        &basis,
+		Priority:     &priority,
+		SubmitTimeMs: &submitTimeMs,
+		NumTasks:     &numTasks,
+		NumRunning:   &numRunning,
+		NumCompleted: &numCompleted,
+	}
+}
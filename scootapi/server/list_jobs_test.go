@@ -0,0 +1,48 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_ListJobs(t *testing.T) {
+	s := scheduler.NewMockScheduler(mockCtrl)
+
+	submitted := time.Unix(100, 0)
+	jobs := []scheduler.JobSummary{
+		{JobId: "1", Requestor: "bob", Tag: "tag", Status: sched.InProgress, SubmitTime: submitted, NumTasks: 3, NumRunning: 1},
+	}
+	priority := sched.Priority(1)
+	status := sched.InProgress
+	s.EXPECT().ListJobs(scheduler.JobFilter{
+		Requestor: "bob", Priority: &priority, Status: &status, Offset: 2, Limit: DefaultListJobsLimit,
+	}).Return(jobs, 3, nil)
+
+	req := scoot.NewListJobsRequest()
+	requestor, p, st, offset := "bob", int32(1), scoot.Status_IN_PROGRESS, int32(2)
+	req.Requestor, req.Priority, req.Status, req.Offset = &requestor, &p, &st, &offset
+	resp, err := ListJobs(req, s)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, instead got %v", err)
+	}
+	if resp.GetTotal() != 3 || len(resp.Jobs) != 1 {
+		t.Fatalf("Expected 1 of 3 jobs, got %+v", resp)
+	}
+	if job := resp.Jobs[0]; job.ID != "1" || job.Status != scoot.Status_IN_PROGRESS || job.GetTag() != "tag" ||
+		job.GetSubmitTimeMs() != 100000 || job.GetNumTasks() != 3 || job.GetNumRunning() != 1 {
+		t.Errorf("Unexpected job summary %+v", job)
+	}
+
+	limit := int32(-1)
+	req = scoot.NewListJobsRequest()
+	req.Limit = &limit
+	if _, err := ListJobs(req, s); err == nil {
+		t.Fatal("Expected error for negative limit")
+	} else if _, ok := err.(*scoot.InvalidRequest); !ok {
+		t.Fatalf("Expected InvalidRequest, got %v", err)
+	}
+}
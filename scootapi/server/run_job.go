@@ -2,16 +2,18 @@ package server
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 )
 
 // Implementation of the RunJob API
-func runJob(scheduler scheduler.Scheduler, def *scoot.JobDefinition, stat stats.StatsReceiver) (*scoot.JobId, error) {
+func runJob(s scheduler.Scheduler, def *scoot.JobDefinition, stat stats.StatsReceiver) (*scoot.JobId, error) {
 
 	jobDef, err := thriftJobToScoot(def)
 	// TODO: change to return scoot.NewInvalidRequest()
@@ -24,10 +26,14 @@ func runJob(scheduler scheduler.Scheduler, def *scoot.JobDefinition, stat stats.
 		return nil, err
 	}
 
-	id, err := scheduler.ScheduleJob(jobDef)
+	id, err := s.ScheduleJob(jobDef)
 
-	if err != nil {
-		return nil, err //TODO: use or delete scoot.NewCanNotScheduleNow()
+	if qe, ok := err.(*scheduler.QuotaExceededError); ok {
+		stat.Counter(stats.SchedServerRunJobQuotaExceededCounter).Inc(1)
+		retryAfterMs := int64(qe.RetryAfter / time.Millisecond)
+		return nil, &scoot.CanNotScheduleNow{RetryAfterMs: &retryAfterMs}
+	} else if err != nil {
+		return nil, err
 	}
 
 	return &scoot.JobId{ID: id}, nil
@@ -49,6 +55,10 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 			return result, fmt.Errorf("nil command")
 		}
 		task.Command.Argv = t.Command.Argv
+		task.Command.EnvVars = t.Command.EnvVars
+		task.Command.Sandbox = t.Command.GetSandbox()
+		task.Command.Image = t.Command.GetImage()
+		task.Command.Outputs = t.Command.GetOutputs()
 		if t.SnapshotId != nil {
 			task.SnapshotID = *t.SnapshotId
 		}
@@ -61,6 +71,16 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 			return result, fmt.Errorf("nil taskId")
 		}
 		task.TaskID = *t.TaskId
+		task.Dependencies = t.Dependencies
+		task.SnapshotFromTask = t.GetSnapshotFromTask()
+		if t.Resources != nil {
+			task.Resources = runner.Resources{
+				CPUs:        int(t.Resources.GetCpus()),
+				MemoryBytes: t.Resources.GetMemoryBytes(),
+				DiskBytes:   t.Resources.GetDiskBytes(),
+			}
+		}
+		task.NodeSelector = t.NodeSelector
 
 		result.Tasks = append(result.Tasks, task)
 	}
@@ -75,7 +95,7 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 		result.JobType = *def.JobType
 	}
 	if def.Requestor != nil {
-		result.Requestor = *def.JobType
+		result.Requestor = *def.Requestor
 	}
 	if def.Priority != nil {
 		result.Priority = sched.Priority(*def.Priority)
@@ -96,6 +116,17 @@ func validateJob(job sched.JobDefinition) error {
 		if len(task.Command.Argv) == 0 {
 			return NewInvalidJobRequest("invalid task.Command.Argv. Must have at least one argument; was empty")
 		}
+		for k := range task.Command.EnvVars {
+			if k == "" || strings.ContainsAny(k, "=\x00") {
+				return NewInvalidJobRequest(fmt.Sprintf("invalid task.Command.EnvVars. Invalid variable name %q", k))
+			}
+		}
+		if res := task.Resources; res.CPUs < 0 || res.MemoryBytes < 0 || res.DiskBytes < 0 {
+			return NewInvalidJobRequest(fmt.Sprintf("invalid task.Resources. Must not be negative; was %+v", res))
+		}
+	}
+	if err := sched.ValidateDependencies(job.Tasks); err != nil {
+		return NewInvalidJobRequest(fmt.Sprintf("invalid task dependencies. %v", err))
 	}
 	return nil
 }
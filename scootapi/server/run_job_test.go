@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/twitter/scoot/common/stats"
@@ -80,6 +81,25 @@ func Test_RunJob_NoCommand(t *testing.T) {
 	}
 }
 
+// Tasks with environment variables that can't be set should return InvalidJobRequest error
+func Test_RunJob_InvalidEnvVar(t *testing.T) {
+	for _, name := range []string{"", "A=B"} {
+		jobDef := scoot.NewJobDefinition()
+		task := testhelpers.GenTask(testhelpers.NewRand(), "1", "")
+		task.Command.EnvVars = map[string]string{name: "value"}
+		jobDef.Tasks = []*scoot.TaskDefinition{task}
+		jobId, err := runJob(CreateSchedulerMock(t), jobDef, stats.NilStatsReceiver())
+
+		if !IsInvalidJobRequest(err) {
+			t.Errorf("expected error to be InvalidJobRequest for env var %q not %v", name, reflect.TypeOf(err))
+		}
+
+		if jobId != nil {
+			t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+		}
+	}
+}
+
 func Test_RunJob_ValidJob(t *testing.T) {
 	jobDef := testhelpers.GenJobDefinition(testhelpers.NewRand(), -1, "")
 
@@ -113,3 +133,24 @@ func Test_RunJob_SchedulerError(t *testing.T) {
 		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
 	}
 }
+
+func Test_RunJob_QuotaExceeded(t *testing.T) {
+	jobDef := testhelpers.GenJobDefinition(testhelpers.NewRand(), -1, "")
+
+	s := CreateSchedulerMock(t)
+	s.EXPECT().ScheduleJob(gomock.Any()).Return("",
+		&scheduler.QuotaExceededError{Requestor: "bob", Quota: "max queued tasks", RetryAfter: 90 * time.Second})
+
+	jobId, err := runJob(s, jobDef, stats.NilStatsReceiver())
+
+	cnsn, ok := err.(*scoot.CanNotScheduleNow)
+	if !ok {
+		t.Fatalf("expected error to be CanNotScheduleNow not %v", reflect.TypeOf(err))
+	}
+	if cnsn.GetRetryAfterMs() != 90000 {
+		t.Errorf("expected retryAfterMs to be 90000 not %d", cnsn.GetRetryAfterMs())
+	}
+	if jobId != nil {
+		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+	}
+}
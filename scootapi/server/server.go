@@ -2,6 +2,7 @@ package server
 
 import (
 	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/twitter/scoot/cloud/cluster/registry"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched/scheduler"
@@ -9,9 +10,14 @@ import (
 )
 
 // Creates and returns a new server Handler, which combines the scheduler,
-// saga coordinator and stats receivers.
-func NewHandler(scheduler scheduler.Scheduler, sc saga.SagaCoordinator, stat stats.StatsReceiver) scoot.CloudScoot {
-	handler := &Handler{scheduler: scheduler, sagaCoord: sc, stat: stat}
+// saga coordinator, worker registry and stats receivers.
+// The registry is nil unless the cluster is configured to track workers by heartbeat.
+func NewHandler(
+	scheduler scheduler.Scheduler,
+	sc saga.SagaCoordinator,
+	reg *registry.Registry,
+	stat stats.StatsReceiver) scoot.CloudScoot {
+	handler := &Handler{scheduler: scheduler, sagaCoord: sc, registry: reg, stat: stat}
 	go stats.StartUptimeReporting(stat, stats.SchedUptime_ms, stats.SchedServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	return handler
 }
@@ -26,10 +32,11 @@ func MakeServer(handler scoot.CloudScoot,
 		transport, transportFactory, protocolFactory)
 }
 
-// Wrapping type that combines a scheduler, saga coordinator and stat receiver into a server
+// Wrapping type that combines a scheduler, saga coordinator, worker registry and stat receiver into a server
 type Handler struct {
 	scheduler scheduler.Scheduler
 	sagaCoord saga.SagaCoordinator
+	registry  *registry.Registry
 	stat      stats.StatsReceiver
 }
 
@@ -53,3 +60,38 @@ func (h *Handler) KillJob(jobId string) (*scoot.JobStatus, error) {
 	h.stat.Counter(stats.SchedServerJobKillCounter).Inc(1)
 	return KillJob(jobId, h.scheduler, h.sagaCoord)
 }
+
+// Implements WatchJob Cloud Scoot API
+func (h *Handler) WatchJob(jobId string, version int64, timeoutMs int32) (*scoot.JobStatus, error) {
+	h.stat.Counter(stats.SchedServerWatchJobCounter).Inc(1)
+	return WatchJob(jobId, version, timeoutMs, h.scheduler, h.sagaCoord, h.stat)
+}
+
+// Implements ListJobs Cloud Scoot API
+func (h *Handler) ListJobs(req *scoot.ListJobsRequest) (*scoot.ListJobsResponse, error) {
+	h.stat.Counter(stats.SchedServerListJobsCounter).Inc(1)
+	return ListJobs(req, h.scheduler)
+}
+
+// Implements Heartbeat Cloud Scoot API
+func (h *Handler) Heartbeat(hb *scoot.WorkerHeartbeat) error {
+	h.stat.Counter(stats.SchedServerHeartbeatCounter).Inc(1)
+	return Heartbeat(hb, h.registry)
+}
+
+// Implements DrainNodes Cloud Scoot API
+func (h *Handler) DrainNodes(nodeIds []string) (*scoot.DrainStatusResponse, error) {
+	h.stat.Counter(stats.SchedServerDrainNodesCounter).Inc(1)
+	return DrainNodes(nodeIds, h.scheduler)
+}
+
+// Implements UndrainNodes Cloud Scoot API
+func (h *Handler) UndrainNodes(nodeIds []string) (*scoot.DrainStatusResponse, error) {
+	h.stat.Counter(stats.SchedServerDrainNodesCounter).Inc(1)
+	return UndrainNodes(nodeIds, h.scheduler)
+}
+
+// Implements GetDrainStatus Cloud Scoot API
+func (h *Handler) GetDrainStatus() (*scoot.DrainStatusResponse, error) {
+	return GetDrainStatus(h.scheduler)
+}
@@ -26,7 +26,7 @@ func Test_RequestCounters(t *testing.T) {
 
 	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
 
-	handler := NewHandler(s, sc, statsReceiver)
+	handler := NewHandler(s, sc, nil, statsReceiver)
 
 	domainJobDef := sched.GenJobDef(1)
 	domainJobDef.Tasks[0].Argv = []string{}
@@ -10,16 +10,21 @@ import (
 	"github.com/twitter/scoot/bazel/execution"
 	bazel "github.com/twitter/scoot/bazel/server"
 	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/cloud/cluster/registry"
 	"github.com/twitter/scoot/common/endpoints"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/config/jsonconfig"
 	"github.com/twitter/scoot/config/scootconfig"
 	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	snap "github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/bundlestore"
+	"github.com/twitter/scoot/snapshot/git/gitdb"
 )
 
 type servers struct {
@@ -38,6 +43,9 @@ func makeServers(
 // Creates an MagicBag and a JsonSchema for this server and returns them
 func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 	bag := ice.NewMagicBag()
+	// The Execution API ingests Bazel input roots and reads results with a GitDB whose snapshots
+	// are shared with workers through the bundlestore.
+	bag.InstallModule(gitdb.Module())
 	bag.PutMany(
 		func() (thrift.TServerTransport, error) { return thrift.NewTServerSocket(scootapi.DefaultSched_Thrift) },
 
@@ -51,18 +59,25 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 
 		func(
 			cl *cluster.Cluster,
+			slog saga.SagaLog,
 			sc saga.SagaCoordinator,
 			rf func(cluster.Node) runner.Service,
 			config scheduler.SchedulerConfig,
 			stat stats.StatsReceiver) scheduler.Scheduler {
+			waitForSagaLogLeadership(slog)
 			return scheduler.NewStatefulSchedulerFromCluster(cl, sc, rf, config, stat)
 		},
 
+		// Overridden by cluster configs that track workers by heartbeat, see ClusterHeartbeatConfig.
+		// Without one heartbeats fail with an InvalidRequest, a Registry here would go unread by the cluster.
+		func() *registry.Registry { return nil },
+
 		func(
 			s scheduler.Scheduler,
 			sc saga.SagaCoordinator,
+			reg *registry.Registry,
 			stat stats.StatsReceiver) scoot.CloudScoot {
-			return NewHandler(s, sc, stat)
+			return NewHandler(s, sc, reg, stat)
 		},
 
 		func(
@@ -93,8 +108,26 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 			return net.Listen("tcp", scootapi.DefaultSched_GRPC)
 		},
 
-		func(l net.Listener) bazel.GRPCServer {
-			return execution.NewExecutionServer(l)
+		func(tmp *temp.TempDir) (bundlestore.Store, error) {
+			_, addr, err := scootapi.GetScootapiAddr()
+			if err != nil {
+				return nil, err
+			}
+			if addr != "" {
+				return bundlestore.MakeHTTPStore(scootapi.APIAddrToBundlestoreURI(addr)), nil
+			}
+			return bundlestore.MakeFileStoreInEnvOrTemp(tmp)
+		},
+
+		func(
+			l net.Listener,
+			s scheduler.Scheduler,
+			sc saga.SagaCoordinator,
+			store bundlestore.Store,
+			db snap.DB,
+			tmp *temp.TempDir,
+			stat stats.StatsReceiver) bazel.GRPCServer {
+			return execution.NewExecutionServer(l, s, sc, store, db, tmp, stat)
 		},
 	)
 
@@ -102,11 +135,17 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 		"SagaLog": {
 			"memory": &scootconfig.InMemorySagaLogConfig{},
 			"file":   &scootconfig.FileSagaLogConfig{},
+			"bolt":   &scootconfig.BoltSagaLogConfig{},
+			"raft":   &scootconfig.RaftSagaLogConfig{},
 			"":       &scootconfig.InMemorySagaLogConfig{},
 		},
 		"Cluster": {
-			"memory": &scootconfig.ClusterMemoryConfig{},
-			"local":  &scootconfig.ClusterLocalConfig{},
+			"memory":    &scootconfig.ClusterMemoryConfig{},
+			"local":     &scootconfig.ClusterLocalConfig{},
+			"file":      &scootconfig.ClusterFileConfig{},
+			"dns":       &scootconfig.ClusterDNSConfig{},
+			"http":      &scootconfig.ClusterEndpointConfig{},
+			"heartbeat": &scootconfig.ClusterHeartbeatConfig{},
 			"": &scootconfig.ClusterMemoryConfig{
 				Type:  "memory",
 				Count: 10,
@@ -130,6 +169,26 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 	return bag, schema
 }
 
+// Blocks until this scheduler leads the saga log if it's replicated, so a standby scheduler
+// only starts scheduling, and recovering jobs, once it takes over from a failed leader.
+// Exits if leadership is lost later on, since another scheduler has taken over the log.
+func waitForSagaLogLeadership(slog saga.SagaLog) {
+	rlog, ok := slog.(saga.ReplicatedSagaLog)
+	if !ok {
+		return
+	}
+	log.Info("Waiting to become the saga log leader")
+	lostCh, err := rlog.WaitForLeadership()
+	if err != nil {
+		log.Fatal("Error waiting to become the saga log leader: ", err)
+	}
+	log.Info("Became the saga log leader, starting the scheduler")
+	go func() {
+		<-lostCh
+		log.Fatal("Lost saga log leadership, exiting so the new leader owns all jobs")
+	}()
+}
+
 // Starts the Server based on the MagicBag and config schema provided
 // this method blocks until the server completes running or an error occurs.
 func RunServer(bag *ice.MagicBag, schema jsonconfig.Schema, config []byte) {
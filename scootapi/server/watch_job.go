@@ -0,0 +1,89 @@
+package server
+
+import (
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/workerapi"
+)
+
+// Upper bound on how long a single WatchJob request is held open.
+const MaxWatchJobTimeout = 30 * time.Second
+
+/**
+Wait for the job identified by jobId to change after the given version, and return a job status
+containing only the tasks that changed, along with the version to pass in next time.
+Jobs that aren't in progress in the scheduler (finished, unknown, or not yet added) fall back to
+the full status from the saga log without waiting.
+*/
+func WatchJob(
+	jobId string,
+	version int64,
+	timeoutMs int32,
+	s scheduler.Scheduler,
+	sc saga.SagaCoordinator,
+	stat stats.StatsReceiver,
+) (*scoot.JobStatus, error) {
+	if jobId == "" {
+		return nil, scoot.NewInvalidRequest()
+	}
+	timeout := time.Duration(timeoutMs) * time.Millisecond
+	if timeout < 0 {
+		timeout = 0
+	} else if timeout > MaxWatchJobTimeout {
+		timeout = MaxWatchJobTimeout
+	}
+
+	update, err := s.WatchJob(jobId, version, timeout)
+	if err != nil {
+		return nil, scoot.NewScootServerError()
+	}
+	if update == nil {
+		stat.Counter(stats.SchedServerWatchJobFallbackCounter).Inc(1)
+		return GetJobStatus(jobId, sc)
+	}
+	return convertJobUpdateToJobStatus(update), nil
+}
+
+// Converts the changes in a JobUpdate to a corresponding JobStatus
+func convertJobUpdateToJobStatus(update *scheduler.JobUpdate) *scoot.JobStatus {
+	js := scoot.NewJobStatus()
+	js.ID = update.JobId
+	js.Status = convertStatus(update.Status)
+	js.TaskStatus = make(map[string]scoot.Status)
+	js.TaskData = make(map[string]*scoot.RunStatus)
+	version := update.Version
+	js.Version = &version
+	if update.SupersededBy != "" {
+		js.SupersededBy = &update.SupersededBy
+	}
+
+	for id, task := range update.Tasks {
+		js.TaskStatus[id] = convertStatus(task.Status)
+		if task.Result != nil {
+			if runStatus, err := workerToScootRunStatus(workerapi.DomainRunStatusToThrift(*task.Result)); err == nil {
+				js.TaskData[id] = runStatus
+			}
+		}
+	}
+	return js
+}
+
+func convertStatus(st sched.Status) scoot.Status {
+	switch st {
+	case sched.InProgress:
+		return scoot.Status_IN_PROGRESS
+	case sched.Completed:
+		return scoot.Status_COMPLETED
+	case sched.RollingBack:
+		return scoot.Status_ROLLING_BACK
+	case sched.RolledBack:
+		return scoot.Status_ROLLED_BACK
+	default:
+		return scoot.Status_NOT_STARTED
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_WatchJob(t *testing.T) {
+	sc := makeMockSagaCoordinator(t)
+	s := scheduler.NewMockScheduler(mockCtrl)
+	stat := stats.NilStatsReceiver()
+
+	st := runner.CompleteStatus("run", "snap", 0, tags.LogTags{JobID: "2", TaskID: "task1"})
+	update := &scheduler.JobUpdate{
+		JobId:   "2",
+		Version: 5,
+		Status:  sched.InProgress,
+		Tasks: map[string]scheduler.TaskUpdate{
+			"task1": {Status: sched.Completed, Result: &st},
+			"task2": {Status: sched.InProgress},
+		},
+		SupersededBy: "3",
+	}
+	s.EXPECT().WatchJob("2", int64(3), MaxWatchJobTimeout).Return(update, nil)
+	s.EXPECT().WatchJob("1", int64(0), time.Second).Return(nil, nil)
+
+	// Changes are served from the scheduler, with the timeout capped.
+	js, err := WatchJob("2", 3, int32(time.Hour/time.Millisecond), s, sc, stat)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, instead got %v", err)
+	}
+	if js.Status != scoot.Status_IN_PROGRESS || js.GetVersion() != 5 || len(js.TaskStatus) != 2 {
+		t.Fatalf("Expected in progress status at version 5 with 2 tasks, got %+v", js)
+	}
+	if js.GetSupersededBy() != "3" {
+		t.Errorf("Expected job to be superseded by 3, got %v", js.SupersededBy)
+	}
+	if js.TaskStatus["task1"] != scoot.Status_COMPLETED || js.TaskData["task1"].GetSnapshotId() != "snap" {
+		t.Errorf("Expected task1 to be completed with snapshot, got %v %v", js.TaskStatus["task1"], js.TaskData["task1"])
+	}
+	if _, ok := js.TaskData["task2"]; ok || js.TaskStatus["task2"] != scoot.Status_IN_PROGRESS {
+		t.Errorf("Expected task2 to be in progress without data, got %v", js.TaskStatus["task2"])
+	}
+
+	// Jobs the scheduler doesn't have fall back to the saga log.
+	js, err = WatchJob("1", 0, 1000, s, sc, stat)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, instead got %v", err)
+	}
+	if js.Status != scoot.Status_COMPLETED || js.IsSetVersion() || js.IsSetSupersededBy() {
+		t.Fatalf("Expected completed status from saga log without a version, got %+v", js)
+	}
+
+	if _, err := WatchJob("", 0, 0, s, sc, stat); err == nil {
+		t.Fatal("Expected error for empty job id")
+	}
+}
@@ -144,5 +144,10 @@ func (s *Server) checkBundleName(name string) error {
 	if ok, _ := regexp.MatchString(bundleRE, name); ok {
 		return nil
 	}
-	return fmt.Errorf("Error with bundleName, expected %q, got: %s", bundleRE, name)
+	// Blobs, ActionResults, ingested input roots and job results stored for the Bazel APIs are named by their sha256 digest and size
+	blobRE := "^bz-[a-f0-9]{64}-[0-9]+.(bin|actionresult|snapshot|jobresult)"
+	if ok, _ := regexp.MatchString(blobRE, name); ok {
+		return nil
+	}
+	return fmt.Errorf("Error with bundleName, expected %q or %q, got: %s", bundleRE, blobRE, name)
 }
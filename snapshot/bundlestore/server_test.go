@@ -311,3 +311,29 @@ func TestRetry(t *testing.T) {
 		t.Fatalf("Expected 3 tries, got: %d", server.counter)
 	}
 }
+
+func TestCheckBundleName(t *testing.T) {
+	s := &Server{}
+	sha256 := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	for _, name := range []string{
+		"bs-0000000000000000000000000000000000000001.bundle",
+		"bz-" + sha256 + "-0.bin",
+		"bz-" + sha256 + "-123.actionresult",
+		"bz-" + sha256 + "-456.snapshot",
+		"bz-" + sha256 + "-36.jobresult",
+	} {
+		if err := s.checkBundleName(name); err != nil {
+			t.Errorf("Expected %s to be a legal name, got: %v", name, err)
+		}
+	}
+	for _, name := range []string{
+		"foo",
+		"bz-" + sha256 + ".snapshot",
+		"bz-" + sha256[:40] + "-1.snapshot",
+		"bz-" + sha256 + "-1.tar",
+	} {
+		if err := s.checkBundleName(name); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/twitter/scoot/bazel/cas"
 	bazel "github.com/twitter/scoot/bazel/server"
 	"github.com/twitter/scoot/common/endpoints"
 	"github.com/twitter/scoot/config/jsonconfig"
@@ -62,10 +61,6 @@ func Defaults() *ice.MagicBag {
 		func() (net.Listener, error) {
 			return net.Listen("tcp", scootapi.DefaultApiBundlestore_GRPC)
 		},
-
-		func(l net.Listener) bazel.GRPCServer {
-			return cas.NewCASServer(l)
-		},
 	)
 	return bag
 }
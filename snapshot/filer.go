@@ -1,8 +1,13 @@
 package snapshot
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
+
+	"github.com/twitter/scoot/os/temp"
 )
 
 // A Snapshot is a low-level interface offering per-file access to data in a Snapshot.
@@ -62,13 +67,15 @@ type Updater interface {
 	UpdateInterval() time.Duration
 }
 
+// Creates a Filer backed by db, staging the sources of IngestMap under tmp.
 //TODO: this is temporary until we finalize snapshot.DB and gitDB.
-func NewDBAdapter(db DB) Filer {
-	return &dbAdapter{db: db}
+func NewDBAdapter(db DB, tmp *temp.TempDir) Filer {
+	return &dbAdapter{db: db, tmp: tmp}
 }
 
 type dbAdapter struct {
-	db DB
+	db  DB
+	tmp *temp.TempDir
 }
 
 func (dba *dbAdapter) Checkout(id string) (Checkout, error) {
@@ -97,8 +104,36 @@ func (dba *dbAdapter) Ingest(path string) (id string, err error) {
 	}
 }
 
+// Copies each src into a staging dir, then ingests that. A src dir's contents are copied into its dest dir,
+// and a src file is copied to its dest path.
 func (dba *dbAdapter) IngestMap(srcToDest map[string]string) (id string, err error) {
-	panic("not implemented")
+	stagingDir, err := dba.tmp.TempDir("ingest-")
+	if err != nil {
+		return "", err
+	}
+	staging := stagingDir.Dir
+	defer os.RemoveAll(staging)
+	for src, dest := range srcToDest {
+		absDest := filepath.Join(staging, dest)
+		fi, err := os.Stat(src)
+		if err != nil {
+			return "", err
+		}
+		if fi.IsDir() {
+			// Copy the contents of src rather than the dir itself.
+			src += "/."
+			err = os.MkdirAll(absDest, os.ModePerm)
+		} else {
+			err = os.MkdirAll(filepath.Dir(absDest), os.ModePerm)
+		}
+		if err != nil {
+			return "", err
+		}
+		if out, err := exec.Command("cp", "-r", src, absDest).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("Couldn't copy %s: %v %s", src, err, out)
+		}
+	}
+	return dba.Ingest(staging)
 }
 
 func (dba *dbAdapter) Update() error {
@@ -30,14 +30,6 @@ func (db *DB) readFileAll(id snap.ID, path string) (string, error) {
 
 // checkout creates a checkout of id.
 func (db *DB) checkout(id snap.ID) (path string, err error) {
-	defer func() {
-		// If we're returning our repo dir, we need to keep the work tree locked, otherwise, we can unlock it.
-		// Note: we defer this to capture the various places 'path' is returned.
-		if path != db.dataRepo.Dir() {
-			db.workTreeLock.Unlock()
-		}
-	}()
-
 	v, err := db.parseID(id)
 	if err != nil {
 		return "", err
@@ -52,9 +44,15 @@ func (db *DB) checkout(id snap.ID) (path string, err error) {
 		// For FSSnapshots, we make a "bare checkout".
 		return db.checkoutFSSnapshot(v.SHA())
 	case KindGitCommitSnapshot:
-		// For GitCommitSnapshot's, we use dataRepo's work tree.
+		// For GitCommitSnapshot's, we use dataRepo's work tree if it's free.
+		// Otherwise another run is using it, so we make a bare checkout of the commit's tree.
+		if db.workTreeInUse {
+			log.Infof("Work tree in use, making a bare checkout for id=%s", id)
+			return db.checkoutFSSnapshot(v.SHA())
+		}
 		if id == db.currentSnapID {
 			log.Infof("Using cached checkout for id=%s", id)
+			db.workTreeInUse = true
 			return db.dataRepo.Dir(), nil
 		}
 		path, err := db.checkoutGitCommitSnapshot(v.SHA())
@@ -62,6 +60,7 @@ func (db *DB) checkout(id snap.ID) (path string, err error) {
 			db.currentSnapID = ""
 		} else {
 			db.currentSnapID = id
+			db.workTreeInUse = true
 		}
 		return path, err
 	default:
@@ -70,6 +69,7 @@ func (db *DB) checkout(id snap.ID) (path string, err error) {
 }
 
 // checkoutFSSnapshot creates a new dir with a new index and checks out exactly that tree.
+// The sha may also be a commit, in which case its tree is checked out.
 func (db *DB) checkoutFSSnapshot(sha string) (path string, err error) {
 	// we don't need the work tree
 	indexDir, err := db.tmp.TempDir("git-index")
@@ -103,7 +103,8 @@ func (db *DB) checkoutFSSnapshot(sha string) (path string, err error) {
 }
 
 // checkoutGitCommitSnapshot checks out a commit into our work tree.
-// We could use multiple work trees, except our internal git doesn't yet have work-tree support.
+// We could use multiple work trees, except our internal git doesn't yet have work-tree support,
+// so concurrent checkouts fall back to checkoutFSSnapshot.
 // TODO(dbentley): migrate to work-trees.
 func (db *DB) checkoutGitCommitSnapshot(sha string) (path string, err error) {
 	cmds := [][]string{
@@ -128,7 +129,7 @@ func (db *DB) checkoutGitCommitSnapshot(sha string) (path string, err error) {
 
 func (db *DB) releaseCheckout(path string) error {
 	if path == db.dataRepo.Dir() {
-		db.workTreeLock.Unlock()
+		db.workTreeInUse = false
 		return nil
 	}
 
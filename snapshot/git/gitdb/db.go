@@ -2,7 +2,6 @@ package gitdb
 
 import (
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/twitter/scoot/common/stats"
@@ -128,9 +127,6 @@ type DB struct {
 	InitDoneCh chan error
 	err        error
 
-	// must hold workTreeLock before sending a checkoutReq to reqCh
-	workTreeLock sync.Mutex
-
 	// All data below here should be accessed only by the loop() goroutine
 	dataRepo   *repo.Repository
 	updater    RepoUpdater
@@ -144,6 +140,9 @@ type DB struct {
 
 	// TODO: reusing git checkout if its snap.ID matches the request - make this configurable at runtime...
 	currentSnapID snap.ID
+	// True while dataRepo's work tree is checked out and not yet released,
+	// concurrent checkouts of git commits then get a bare checkout instead.
+	workTreeInUse bool
 
 	stat stats.StatsReceiver
 }
@@ -350,7 +349,6 @@ func (db *DB) Checkout(id snap.ID) (path string, err error) {
 	if <-db.initDoneCh; db.err != nil {
 		return "", db.err
 	}
-	db.workTreeLock.Lock()
 	resultCh := make(chan stringAndError)
 	db.reqCh <- checkoutReq{id: id, resultCh: resultCh}
 	result := <-resultCh
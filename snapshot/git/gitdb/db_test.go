@@ -161,6 +161,54 @@ func TestStream(t *testing.T) {
 
 }
 
+func TestConcurrentCommitCheckouts(t *testing.T) {
+	commit1ID, err := commitText(fixture.external, "concurrent_first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit2ID, err := commitText(fixture.external, "concurrent_second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id1, err := fixture.simpleDB.IngestGitCommit(fixture.external, commit1ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, err := fixture.simpleDB.IngestGitCommit(fixture.external, commit2ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first checkout gets the work tree, the second can't wait for it to be released.
+	co1, err := fixture.simpleDB.Checkout(id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	co2, err := fixture.simpleDB.Checkout(id2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if co1 == co2 {
+		t.Fatalf("Expected concurrent checkouts in separate dirs, got %v", co1)
+	}
+	if err := assertFileContents(co1, "file.txt", "concurrent_first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := assertFileContents(co2, "file.txt", "concurrent_second"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fixture.simpleDB.ReleaseCheckout(co2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(co2); !os.IsNotExist(err) {
+		t.Fatalf("Expected the separate checkout to be removed on release, got %v", err)
+	}
+	if err := fixture.simpleDB.ReleaseCheckout(co1); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestInitUpdate(t *testing.T) {
 	// This test doesn't use our fixture DBs because it has such specific git setup
 	// Our git repos are:
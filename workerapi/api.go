@@ -19,6 +19,7 @@ type WorkerStatus struct {
 	Runs        []runner.RunStatus
 	Initialized bool
 	Error       string
+	runner.Capacity
 }
 
 func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
@@ -26,7 +27,14 @@ func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
 	for _, r := range thrift.Runs {
 		runs = append(runs, ThriftRunStatusToDomain(r))
 	}
-	return WorkerStatus{runs, thrift.Initialized, thrift.Error}
+	capacity := runner.Capacity{
+		Slots:       int(thrift.GetSlots()),
+		CPUs:        int(thrift.GetCpus()),
+		MemoryBytes: thrift.GetMemoryBytes(),
+		DiskBytes:   thrift.GetDiskBytes(),
+		Labels:      thrift.GetLabels(),
+	}
+	return WorkerStatus{runs, thrift.Initialized, thrift.Error, capacity}
 }
 
 func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
@@ -34,12 +42,36 @@ func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
 	thrift.Runs = make([]*worker.RunStatus, 0)
 	for _, r := range domain.Runs {
 		thrift.Runs = append(thrift.Runs, DomainRunStatusToThrift(r))
-		thrift.Initialized = domain.Initialized
-		thrift.Error = domain.Error
 	}
+	thrift.Initialized = domain.Initialized
+	thrift.Error = domain.Error
+	DomainCapacityToThrift(domain.Capacity, thrift)
 	return thrift
 }
 
+// Sets the optional capacity fields of a WorkerStatus, leaving unknown values unset.
+func DomainCapacityToThrift(domain runner.Capacity, thrift *worker.WorkerStatus) {
+	if domain.Slots != 0 {
+		s := int32(domain.Slots)
+		thrift.Slots = &s
+	}
+	if domain.CPUs != 0 {
+		c := int32(domain.CPUs)
+		thrift.Cpus = &c
+	}
+	if domain.MemoryBytes != 0 {
+		m := domain.MemoryBytes
+		thrift.MemoryBytes = &m
+	}
+	if domain.DiskBytes != 0 {
+		d := domain.DiskBytes
+		thrift.DiskBytes = &d
+	}
+	if len(domain.Labels) != 0 {
+		thrift.Labels = domain.Labels
+	}
+}
+
 func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	argv := make([]string, 0)
 	env := make(map[string]string)
@@ -74,6 +106,9 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 		EnvVars:    env,
 		Timeout:    timeout,
 		SnapshotID: snapshotID,
+		Sandbox:    thrift.GetSandbox(),
+		Image:      thrift.GetImage(),
+		Outputs:    thrift.GetOutputs(),
 		LogTags: tags.LogTags{
 			JobID:  jobID,
 			TaskID: taskID,
@@ -96,6 +131,15 @@ func DomainRunCommandToThrift(domain *runner.Command) *worker.RunCommand {
 	thrift.TaskId = &taskID
 	tag := domain.Tag
 	thrift.Tag = &tag
+	if domain.Sandbox {
+		sandbox := domain.Sandbox
+		thrift.Sandbox = &sandbox
+	}
+	if domain.Image != "" {
+		image := domain.Image
+		thrift.Image = &image
+	}
+	thrift.Outputs = domain.Outputs
 	return thrift
 }
 
@@ -144,6 +188,8 @@ func ThriftRunStatusToDomain(thrift *worker.RunStatus) runner.RunStatus {
 	if thrift.Tag != nil {
 		domain.Tag = *thrift.Tag
 	}
+	domain.PeakMemoryBytes = thrift.GetPeakMemoryBytes()
+	domain.CPUTime = time.Duration(thrift.GetCpuTimeMs()) * time.Millisecond
 	return domain
 }
 
@@ -177,6 +223,15 @@ func DomainRunStatusToThrift(domain runner.RunStatus) *worker.RunStatus {
 	thrift.JobId = helpers.CopyStringToPointer(domain.JobID)
 	thrift.TaskId = helpers.CopyStringToPointer(domain.TaskID)
 	thrift.Tag = helpers.CopyStringToPointer(domain.Tag)
+	// Usage is left unset when unknown, as it is by workers that don't measure it.
+	if domain.PeakMemoryBytes != 0 {
+		m := domain.PeakMemoryBytes
+		thrift.PeakMemoryBytes = &m
+	}
+	if domain.CPUTime != 0 {
+		c := int64(domain.CPUTime / time.Millisecond)
+		thrift.CpuTimeMs = &c
+	}
 	return thrift
 }
 
@@ -192,3 +247,16 @@ func SerializeProcessStatus(processStatus runner.RunStatus) ([]byte, error) {
 
 	return asBytes, err
 }
+
+func DeserializeProcessStatus(data []byte) (runner.RunStatus, error) {
+
+	runStatus := worker.NewRunStatus()
+
+	err := thrifthelpers.JsonDeserialize(runStatus, data)
+
+	if err != nil {
+		return runner.RunStatus{}, err
+	}
+
+	return ThriftRunStatusToDomain(runStatus), nil
+}
@@ -17,6 +17,14 @@ var nonzero = int32(12345)
 var emptystr = ""
 var nonemptystr = "abcdef"
 var deadbeefID = "snap-id-deadbeef"
+var slots = int32(4)
+var cpus = int32(8)
+var memoryBytes = int64(1 << 30)
+var diskBytes = int64(1 << 40)
+var peakMemoryBytes = int64(1 << 20)
+var cpuTimeMs = int64(1500)
+var sandbox = true
+var image = "go.tar:1.8"
 
 var cmdFromThrift = func(x interface{}) interface{} { return ThriftRunCommandToDomain(x.(*worker.RunCommand)) }
 var cmdToThrift = func(x interface{}) interface{} { return DomainRunCommandToThrift(x.(*runner.Command)) }
@@ -123,6 +131,69 @@ var tests = []struct {
 			Timeout: time.Duration(zero),
 		},
 	},
+	{
+		19,
+		cmdFromThrift,
+		cmdToThrift,
+		&worker.RunCommand{
+			Argv:       someCmd,
+			Env:        map[string]string{},
+			SnapshotId: &emptystr,
+			TimeoutMs:  &zero,
+			JobId:      &emptystr,
+			TaskId:     &emptystr,
+			Tag:        &emptystr,
+			Sandbox:    &sandbox,
+		},
+		&runner.Command{
+			Argv:    someCmd,
+			EnvVars: map[string]string{},
+			Timeout: time.Duration(zero),
+			Sandbox: true,
+		},
+	},
+	{
+		20,
+		cmdFromThrift,
+		cmdToThrift,
+		&worker.RunCommand{
+			Argv:       someCmd,
+			Env:        map[string]string{},
+			SnapshotId: &emptystr,
+			TimeoutMs:  &zero,
+			JobId:      &emptystr,
+			TaskId:     &emptystr,
+			Tag:        &emptystr,
+			Image:      &image,
+		},
+		&runner.Command{
+			Argv:    someCmd,
+			EnvVars: map[string]string{},
+			Timeout: time.Duration(zero),
+			Image:   image,
+		},
+	},
+	{
+		21,
+		cmdFromThrift,
+		cmdToThrift,
+		&worker.RunCommand{
+			Argv:       someCmd,
+			Env:        map[string]string{},
+			SnapshotId: &emptystr,
+			TimeoutMs:  &zero,
+			JobId:      &emptystr,
+			TaskId:     &emptystr,
+			Tag:        &emptystr,
+			Outputs:    []string{"out", "reports/*.xml"},
+		},
+		&runner.Command{
+			Argv:    someCmd,
+			EnvVars: map[string]string{},
+			Timeout: time.Duration(zero),
+			Outputs: []string{"out", "reports/*.xml"},
+		},
+	},
 
 	//RunStatus
 	{
@@ -379,6 +450,57 @@ var tests = []struct {
 			},
 		},
 	},
+	{
+		16,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs:        []*worker.RunStatus{},
+			Initialized: true,
+			Slots:       &slots,
+			Cpus:        &cpus,
+			MemoryBytes: &memoryBytes,
+		},
+		WorkerStatus{
+			Runs:        []runner.RunStatus{},
+			Initialized: true,
+			Capacity:    runner.Capacity{Slots: 4, CPUs: 8, MemoryBytes: 1 << 30},
+		},
+	},
+	{
+		17,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs:        []*worker.RunStatus{},
+			Initialized: true,
+			DiskBytes:   &diskBytes,
+			Labels:      map[string]string{"os": "linux-x86", "has": "docker"},
+		},
+		WorkerStatus{
+			Runs:        []runner.RunStatus{},
+			Initialized: true,
+			Capacity:    runner.Capacity{DiskBytes: 1 << 40, Labels: map[string]string{"os": "linux-x86", "has": "docker"}},
+		},
+	},
+	{
+		18,
+		rsFromThrift,
+		rsToThrift,
+		&worker.RunStatus{
+			Status:          worker.Status_COMPLETE,
+			RunId:           "id",
+			ExitCode:        &zero,
+			PeakMemoryBytes: &peakMemoryBytes,
+			CpuTimeMs:       &cpuTimeMs,
+		},
+		runner.RunStatus{
+			RunID:           "id",
+			State:           runner.COMPLETE,
+			PeakMemoryBytes: 1 << 20,
+			CPUTime:         1500 * time.Millisecond,
+		},
+	},
 }
 
 func TestTranslation(t *testing.T) {
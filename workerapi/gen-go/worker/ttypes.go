@@ -99,17 +99,21 @@ func (p *Status) UnmarshalText(text []byte) error {
 //  - JobId
 //  - TaskId
 //  - Tag
+//  - PeakMemoryBytes
+//  - CpuTimeMs
 type RunStatus struct {
-	Status     Status  `thrift:"status,1,required" json:"status"`
-	RunId      string  `thrift:"runId,2,required" json:"runId"`
-	OutUri     *string `thrift:"outUri,3" json:"outUri,omitempty"`
-	ErrUri     *string `thrift:"errUri,4" json:"errUri,omitempty"`
-	Error      *string `thrift:"error,5" json:"error,omitempty"`
-	ExitCode   *int32  `thrift:"exitCode,6" json:"exitCode,omitempty"`
-	SnapshotId *string `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
-	JobId      *string `thrift:"jobId,8" json:"jobId,omitempty"`
-	TaskId     *string `thrift:"taskId,9" json:"taskId,omitempty"`
-	Tag        *string `thrift:"tag,10" json:"tag,omitempty"`
+	Status          Status  `thrift:"status,1,required" json:"status"`
+	RunId           string  `thrift:"runId,2,required" json:"runId"`
+	OutUri          *string `thrift:"outUri,3" json:"outUri,omitempty"`
+	ErrUri          *string `thrift:"errUri,4" json:"errUri,omitempty"`
+	Error           *string `thrift:"error,5" json:"error,omitempty"`
+	ExitCode        *int32  `thrift:"exitCode,6" json:"exitCode,omitempty"`
+	SnapshotId      *string `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	JobId           *string `thrift:"jobId,8" json:"jobId,omitempty"`
+	TaskId          *string `thrift:"taskId,9" json:"taskId,omitempty"`
+	Tag             *string `thrift:"tag,10" json:"tag,omitempty"`
+	PeakMemoryBytes *int64  `thrift:"peakMemoryBytes,11" json:"peakMemoryBytes,omitempty"`
+	CpuTimeMs       *int64  `thrift:"cpuTimeMs,12" json:"cpuTimeMs,omitempty"`
 }
 
 func NewRunStatus() *RunStatus {
@@ -195,6 +199,24 @@ func (p *RunStatus) GetTag() string {
 	}
 	return *p.Tag
 }
+
+var RunStatus_PeakMemoryBytes_DEFAULT int64
+
+func (p *RunStatus) GetPeakMemoryBytes() int64 {
+	if !p.IsSetPeakMemoryBytes() {
+		return RunStatus_PeakMemoryBytes_DEFAULT
+	}
+	return *p.PeakMemoryBytes
+}
+
+var RunStatus_CpuTimeMs_DEFAULT int64
+
+func (p *RunStatus) GetCpuTimeMs() int64 {
+	if !p.IsSetCpuTimeMs() {
+		return RunStatus_CpuTimeMs_DEFAULT
+	}
+	return *p.CpuTimeMs
+}
 func (p *RunStatus) IsSetOutUri() bool {
 	return p.OutUri != nil
 }
@@ -227,6 +249,14 @@ func (p *RunStatus) IsSetTag() bool {
 	return p.Tag != nil
 }
 
+func (p *RunStatus) IsSetPeakMemoryBytes() bool {
+	return p.PeakMemoryBytes != nil
+}
+
+func (p *RunStatus) IsSetCpuTimeMs() bool {
+	return p.CpuTimeMs != nil
+}
+
 func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -286,6 +316,14 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField10(iprot); err != nil {
 				return err
 			}
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -398,6 +436,24 @@ func (p *RunStatus) readField10(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunStatus) readField11(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.PeakMemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.CpuTimeMs = &v
+	}
+	return nil
+}
+
 func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -432,6 +488,12 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField10(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -587,6 +649,36 @@ func (p *RunStatus) writeField10(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunStatus) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPeakMemoryBytes() {
+		if err := oprot.WriteFieldBegin("peakMemoryBytes", thrift.I64, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:peakMemoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.PeakMemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.peakMemoryBytes (11) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:peakMemoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpuTimeMs() {
+		if err := oprot.WriteFieldBegin("cpuTimeMs", thrift.I64, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:cpuTimeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.CpuTimeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpuTimeMs (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:cpuTimeMs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -598,10 +690,20 @@ func (p *RunStatus) String() string {
 //  - Runs
 //  - Initialized
 //  - Error
+//  - Slots
+//  - Cpus
+//  - MemoryBytes
+//  - DiskBytes
+//  - Labels
 type WorkerStatus struct {
-	Runs        []*RunStatus `thrift:"runs,1,required" json:"runs"`
-	Initialized bool         `thrift:"initialized,2,required" json:"initialized"`
-	Error       string       `thrift:"error,3,required" json:"error"`
+	Runs        []*RunStatus      `thrift:"runs,1,required" json:"runs"`
+	Initialized bool              `thrift:"initialized,2,required" json:"initialized"`
+	Error       string            `thrift:"error,3,required" json:"error"`
+	Slots       *int32            `thrift:"slots,4" json:"slots,omitempty"`
+	Cpus        *int32            `thrift:"cpus,5" json:"cpus,omitempty"`
+	MemoryBytes *int64            `thrift:"memoryBytes,6" json:"memoryBytes,omitempty"`
+	DiskBytes   *int64            `thrift:"diskBytes,7" json:"diskBytes,omitempty"`
+	Labels      map[string]string `thrift:"labels,8" json:"labels,omitempty"`
 }
 
 func NewWorkerStatus() *WorkerStatus {
@@ -619,6 +721,68 @@ func (p *WorkerStatus) GetInitialized() bool {
 func (p *WorkerStatus) GetError() string {
 	return p.Error
 }
+
+var WorkerStatus_Slots_DEFAULT int32
+
+func (p *WorkerStatus) GetSlots() int32 {
+	if !p.IsSetSlots() {
+		return WorkerStatus_Slots_DEFAULT
+	}
+	return *p.Slots
+}
+
+var WorkerStatus_Cpus_DEFAULT int32
+
+func (p *WorkerStatus) GetCpus() int32 {
+	if !p.IsSetCpus() {
+		return WorkerStatus_Cpus_DEFAULT
+	}
+	return *p.Cpus
+}
+
+var WorkerStatus_MemoryBytes_DEFAULT int64
+
+func (p *WorkerStatus) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return WorkerStatus_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var WorkerStatus_DiskBytes_DEFAULT int64
+
+func (p *WorkerStatus) GetDiskBytes() int64 {
+	if !p.IsSetDiskBytes() {
+		return WorkerStatus_DiskBytes_DEFAULT
+	}
+	return *p.DiskBytes
+}
+
+var WorkerStatus_Labels_DEFAULT map[string]string
+
+func (p *WorkerStatus) GetLabels() map[string]string {
+	return p.Labels
+}
+func (p *WorkerStatus) IsSetSlots() bool {
+	return p.Slots != nil
+}
+
+func (p *WorkerStatus) IsSetCpus() bool {
+	return p.Cpus != nil
+}
+
+func (p *WorkerStatus) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *WorkerStatus) IsSetDiskBytes() bool {
+	return p.DiskBytes != nil
+}
+
+func (p *WorkerStatus) IsSetLabels() bool {
+	return p.Labels != nil
+}
+
 func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -652,6 +816,26 @@ func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetError = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -714,6 +898,70 @@ func (p *WorkerStatus) readField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *WorkerStatus) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Slots = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Cpus = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.DiskBytes = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField8(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Labels = tMap
+	for i := 0; i < size; i++ {
+		var _key1 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key1 = v
+		}
+		var _val2 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val2 = v
+		}
+		p.Labels[_key1] = _val2
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
 func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("WorkerStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -727,6 +975,21 @@ func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField3(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -783,6 +1046,92 @@ func (p *WorkerStatus) writeField3(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *WorkerStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSlots() {
+		if err := oprot.WriteFieldBegin("slots", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:slots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Slots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.slots (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:slots: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpus() {
+		if err := oprot.WriteFieldBegin("cpus", thrift.I32, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:cpus: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Cpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpus (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:cpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDiskBytes() {
+		if err := oprot.WriteFieldBegin("diskBytes", thrift.I64, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:diskBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.DiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.diskBytes (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:diskBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLabels() {
+		if err := oprot.WriteFieldBegin("labels", thrift.MAP, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:labels: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Labels)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Labels {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:labels: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *WorkerStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -798,6 +1147,9 @@ func (p *WorkerStatus) String() string {
 //  - JobId
 //  - TaskId
 //  - Tag
+//  - Sandbox
+//  - Image
+//  - Outputs
 type RunCommand struct {
 	Argv       []string          `thrift:"argv,1,required" json:"argv"`
 	Env        map[string]string `thrift:"env,2" json:"env,omitempty"`
@@ -806,6 +1158,9 @@ type RunCommand struct {
 	JobId      *string           `thrift:"jobId,5" json:"jobId,omitempty"`
 	TaskId     *string           `thrift:"taskId,6" json:"taskId,omitempty"`
 	Tag        *string           `thrift:"tag,7" json:"tag,omitempty"`
+	Sandbox    *bool             `thrift:"sandbox,8" json:"sandbox,omitempty"`
+	Image      *string           `thrift:"image,9" json:"image,omitempty"`
+	Outputs    []string          `thrift:"outputs,10" json:"outputs,omitempty"`
 }
 
 func NewRunCommand() *RunCommand {
@@ -866,6 +1221,30 @@ func (p *RunCommand) GetTag() string {
 	}
 	return *p.Tag
 }
+
+var RunCommand_Sandbox_DEFAULT bool
+
+func (p *RunCommand) GetSandbox() bool {
+	if !p.IsSetSandbox() {
+		return RunCommand_Sandbox_DEFAULT
+	}
+	return *p.Sandbox
+}
+
+var RunCommand_Image_DEFAULT string
+
+func (p *RunCommand) GetImage() string {
+	if !p.IsSetImage() {
+		return RunCommand_Image_DEFAULT
+	}
+	return *p.Image
+}
+
+var RunCommand_Outputs_DEFAULT []string
+
+func (p *RunCommand) GetOutputs() []string {
+	return p.Outputs
+}
 func (p *RunCommand) IsSetEnv() bool {
 	return p.Env != nil
 }
@@ -890,6 +1269,18 @@ func (p *RunCommand) IsSetTag() bool {
 	return p.Tag != nil
 }
 
+func (p *RunCommand) IsSetSandbox() bool {
+	return p.Sandbox != nil
+}
+
+func (p *RunCommand) IsSetImage() bool {
+	return p.Image != nil
+}
+
+func (p *RunCommand) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
 func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -935,6 +1326,18 @@ func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 			if err := p.readField7(iprot); err != nil {
 				return err
 			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -961,13 +1364,13 @@ func (p *RunCommand) readField1(iprot thrift.TProtocol) error {
 	tSlice := make([]string, 0, size)
 	p.Argv = tSlice
 	for i := 0; i < size; i++ {
-		var _elem1 string
+		var _elem3 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_elem1 = v
+			_elem3 = v
 		}
-		p.Argv = append(p.Argv, _elem1)
+		p.Argv = append(p.Argv, _elem3)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -983,19 +1386,19 @@ func (p *RunCommand) readField2(iprot thrift.TProtocol) error {
 	tMap := make(map[string]string, size)
 	p.Env = tMap
 	for i := 0; i < size; i++ {
-		var _key2 string
+		var _key4 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key2 = v
+			_key4 = v
 		}
-		var _val3 string
+		var _val5 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_val3 = v
+			_val5 = v
 		}
-		p.Env[_key2] = _val3
+		p.Env[_key4] = _val5
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -1048,6 +1451,46 @@ func (p *RunCommand) readField7(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunCommand) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.Sandbox = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.Image = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField10(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Outputs = tSlice
+	for i := 0; i < size; i++ {
+		var _elem6 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem6 = v
+		}
+		p.Outputs = append(p.Outputs, _elem6)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
 func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunCommand"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1073,6 +1516,15 @@ func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField7(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1204,6 +1656,59 @@ func (p *RunCommand) writeField7(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunCommand) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSandbox() {
+		if err := oprot.WriteFieldBegin("sandbox", thrift.BOOL, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:sandbox: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Sandbox)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.sandbox (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:sandbox: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetImage() {
+		if err := oprot.WriteFieldBegin("image", thrift.STRING, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:image: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Image)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.image (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:image: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.LIST, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:outputs: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Outputs {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunCommand) String() string {
 	if p == nil {
 		return "<nil>"
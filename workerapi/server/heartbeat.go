@@ -0,0 +1,86 @@
+package server
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Reported to the scheduler in heartbeats, set at link time with:
+// -ldflags "-X github.com/twitter/scoot/workerapi/server.Version=<version>"
+var Version = "unknown"
+
+// Sends heartbeats to the scheduler, implemented by scootapi.CloudScootClient.
+type HeartbeatClient interface {
+	Heartbeat(hb *scoot.WorkerHeartbeat) error
+}
+
+// Heartbeater registers this worker with the scheduler and keeps it registered
+// by periodically sending heartbeats that carry the worker's current state.
+type Heartbeater struct {
+	nodeId   string
+	client   HeartbeatClient
+	run      runner.Service
+	labels   Labels
+	interval time.Duration
+	stat     stats.StatsReceiver
+}
+
+// Creates a Heartbeater which advertises this worker as nodeId, its thrift 'host:port'.
+// Labels override those reported by run, as in QueryWorker.
+func NewHeartbeater(
+	nodeId string,
+	client HeartbeatClient,
+	run runner.Service,
+	labels Labels,
+	interval time.Duration,
+	stat stats.StatsReceiver) *Heartbeater {
+	return &Heartbeater{nodeId, client, run, labels, interval, stat}
+}
+
+// Sends a heartbeat immediately and then every interval, forever.
+// Failures are logged and retried on the next interval.
+func (h *Heartbeater) Run() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		if err := h.client.Heartbeat(h.heartbeat()); err != nil {
+			h.stat.Counter(stats.WorkerHeartbeatFailures).Inc(1)
+			log.Errorf("Failed to send heartbeat to scheduler: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Describes this worker based on the runner's current status.
+func (h *Heartbeater) heartbeat() *scoot.WorkerHeartbeat {
+	hb := scoot.NewWorkerHeartbeat()
+	hb.NodeId = h.nodeId
+	hb.Version = &Version
+
+	st, svc, err := h.run.StatusAll()
+	if err != nil {
+		log.Errorf("Failed to get runner status for heartbeat: %v", err)
+		return hb
+	}
+	slots := int32(svc.Capacity.Slots)
+	hb.Slots = &slots
+	hb.Labels = svc.Capacity.Labels
+	if len(h.labels) > 0 {
+		hb.Labels = h.labels
+	}
+
+	numRunning := int32(0)
+	for _, status := range st {
+		if !status.State.IsDone() {
+			numRunning++
+		}
+	}
+	hb.NumRunning = &numRunning
+	hb.SnapshotIds = svc.SnapshotIDs
+	return hb
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/mocks"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type fakeHeartbeatClient struct {
+	heartbeats []*scoot.WorkerHeartbeat
+}
+
+func (c *fakeHeartbeatClient) Heartbeat(hb *scoot.WorkerHeartbeat) error {
+	c.heartbeats = append(c.heartbeats, hb)
+	return nil
+}
+
+func TestHeartbeat(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	run := mocks.NewMockService(mockCtrl)
+	run.EXPECT().StatusAll().Return(
+		[]runner.RunStatus{
+			runner.CompleteStatus("1", "snapB", 0, tags.LogTags{}),
+			runner.CompleteStatus("2", "snapA", 0, tags.LogTags{}),
+			runner.RunningStatus("3", "", "", tags.LogTags{}),
+		},
+		runner.ServiceStatus{
+			Initialized: true,
+			Capacity:    runner.Capacity{Slots: 2, Labels: map[string]string{"os": "linux"}},
+			SnapshotIDs: []string{"snapA", "snapB"},
+		},
+		nil)
+
+	client := &fakeHeartbeatClient{}
+	h := NewHeartbeater("host1:9091", client, run, Labels{"has": "docker"}, 0, stats.NilStatsReceiver())
+	hb := h.heartbeat()
+	if hb.NodeId != "host1:9091" || hb.GetVersion() != Version || hb.GetSlots() != 2 || hb.GetNumRunning() != 1 {
+		t.Fatalf("Unexpected heartbeat: %v", hb)
+	}
+	if !reflect.DeepEqual(hb.Labels, map[string]string{"has": "docker"}) {
+		t.Fatalf("Expected configured labels to override the runner's, got %v", hb.Labels)
+	}
+	if !reflect.DeepEqual(hb.SnapshotIds, []string{"snapA", "snapB"}) {
+		t.Fatalf("Expected the runner's checked out snapshots, got %v", hb.SnapshotIds)
+	}
+}
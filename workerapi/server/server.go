@@ -3,7 +3,9 @@
 package server
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,19 +37,40 @@ func MakeServer(
 // (avoid conflict with other injected integers)
 type StatsCollectInterval time.Duration
 
+// Labels describing this worker, advertised in its status to match against task node selectors.
+type Labels map[string]string
+
+// Parses comma separated key=value pairs, ex: "os=linux-x86,has=docker". An empty string yields no labels.
+func ParseLabels(s string) (Labels, error) {
+	labels := Labels{}
+	for _, kv := range strings.Split(s, ",") {
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid label %q, expected key=value", kv)
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
+
 type handler struct {
 	stat         stats.StatsReceiver
 	run          runner.Service
+	labels       Labels
 	timeLastRpc  time.Time
 	mu           sync.RWMutex
 	currentCmd   *runner.Command
 	currentRunID runner.RunID
 }
 
-// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver
-func NewHandler(stat stats.StatsReceiver, run runner.Service) worker.Worker {
+// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver.
+// Labels are added to the capacity the runner advertises and may be nil.
+func NewHandler(stat stats.StatsReceiver, run runner.Service, labels Labels) worker.Worker {
 	scopedStat := stat.Scope("handler")
-	h := &handler{stat: scopedStat, run: run, timeLastRpc: time.Now()}
+	h := &handler{stat: scopedStat, run: run, labels: labels, timeLastRpc: time.Now()}
 	stats.ReportServerRestart(scopedStat, stats.WorkerServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	go h.stats()
 	return h
@@ -128,6 +151,11 @@ func (h *handler) QueryWorker() (*worker.WorkerStatus, error) {
 		ws.Error = err.Error()
 	}
 	ws.Initialized = svc.Initialized
+	capacity := svc.Capacity
+	if len(h.labels) > 0 {
+		capacity.Labels = h.labels
+	}
+	domain.DomainCapacityToThrift(capacity, ws)
 
 	for _, status := range st {
 		if status.State.IsDone() {
@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"reflect"
 	"testing"
 	"time"
 
@@ -127,6 +128,22 @@ func TestFailedRunsStats(t *testing.T) {
 	}
 }
 
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels("os=linux-x86,has=docker,empty=")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(labels, Labels{"os": "linux-x86", "has": "docker", "empty": ""}) {
+		t.Errorf("Unexpected labels: %v", labels)
+	}
+	if labels, err := ParseLabels(""); err != nil || len(labels) != 0 {
+		t.Errorf("Expected no labels, got %v, %v", labels, err)
+	}
+	if _, err := ParseLabels("os"); err == nil {
+		t.Errorf("Expected an error for a label without a value")
+	}
+}
+
 func setupTestEnv(useErrorExec bool) (h *handler, initDoneCh chan error, statsRegistry stats.StatsRegistry, simExecer *execers.SimExecer) {
 
 	stats.StatReportIntvl = 100 * time.Millisecond
@@ -148,8 +165,8 @@ func setupTestEnv(useErrorExec bool) (h *handler, initDoneCh chan error, statsRe
 		func() execer.Execer {
 			return simExecer
 		},
-		func(db snapshot.DB) snapshot.Filer {
-			return snapshot.NewDBAdapter(db)
+		func(db snapshot.DB, tmp *temp.TempDir) snapshot.Filer {
+			return snapshot.NewDBAdapter(db, tmp)
 		},
 		runners.NewSingleRunner,
 		func() snapshot.InitDoneCh {
@@ -166,7 +183,7 @@ func setupTestEnv(useErrorExec bool) (h *handler, initDoneCh chan error, statsRe
 			return statsRec
 		},
 		func(stat stats.StatsReceiver, run runner.Service) worker.Worker {
-			return NewHandler(stat, run)
+			return NewHandler(stat, run, nil)
 		},
 	)
 	if useErrorExec {
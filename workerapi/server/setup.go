@@ -10,18 +10,20 @@ import (
 	"github.com/twitter/scoot/ice"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
-	"github.com/twitter/scoot/runner/execer/execers"
+	"github.com/twitter/scoot/runner/execer/oci"
 	osexec "github.com/twitter/scoot/runner/execer/os"
+	"github.com/twitter/scoot/runner/runners"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
 
 type servers struct {
-	thrift thrift.TServer
-	http   *endpoints.TwitterServer
+	thrift      thrift.TServer
+	http        *endpoints.TwitterServer
+	heartbeater *Heartbeater
 }
 
-func makeServers(thrift thrift.TServer, http *endpoints.TwitterServer) servers {
-	return servers{thrift, http}
+func makeServers(thrift thrift.TServer, http *endpoints.TwitterServer, heartbeater *Heartbeater) servers {
+	return servers{thrift, http, heartbeater}
 }
 
 // Module returns a module that supports serving Thrift and HTTP
@@ -43,11 +45,32 @@ func (m module) Install(b *ice.MagicBag) {
 		func() execer.Memory {
 			return 0
 		},
-		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
-			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
+		// Tasks inherit the worker's whole environment unless this is overridden.
+		func() execer.EnvPolicy {
+			return execer.EnvPolicy{}
 		},
-		func(stat stats.StatsReceiver, r runner.Service) worker.Worker {
-			return NewHandler(stat, r)
+		// Runs aren't isolated in cgroups unless this is overridden with a parent cgroup.
+		func() osexec.CgroupConfig {
+			return osexec.CgroupConfig{}
+		},
+		// Only commands that ask for a sandbox are sandboxed unless this is overridden.
+		func() osexec.SandboxConfig {
+			return osexec.SandboxConfig{}
+		},
+		// Commands can't run in images unless this is overridden with a store of them.
+		func() *oci.Store {
+			return nil
+		},
+		runners.NewExecer,
+		func() Labels {
+			return nil
+		},
+		// Workers only send heartbeats if this is overridden with a scheduler to send them to.
+		func() *Heartbeater {
+			return nil
+		},
+		func(stat stats.StatsReceiver, r runner.Service, labels Labels) worker.Worker {
+			return NewHandler(stat, r, labels)
 		},
 		func(
 			handler worker.Worker,
@@ -84,6 +107,10 @@ func RunServer(
 		log.Fatal("Error injecting servers", err)
 	}
 
+	if servers.heartbeater != nil {
+		go servers.heartbeater.Run()
+	}
+
 	errCh := make(chan error)
 	go func() {
 		errCh <- servers.http.Serve()
@@ -21,13 +21,20 @@ struct RunStatus {
   8: optional string jobId
   9: optional string taskId
   10: optional string tag
+  11: optional i64 peakMemoryBytes  # Most memory used at once by the run's processes, unset if unknown.
+  12: optional i64 cpuTimeMs        # User and system cpu time used by the run's processes, unset if unknown.
 }
 
-// TODO: add useful load information when it comes time to have multiple runs.
+// The capacity fields are unset by workers that predate multiple runs, which have a single slot.
 struct WorkerStatus {
   1: required list<RunStatus> runs  # All runs excepting what's been Erase()'d
   2: required bool initialized      # True if the worker has finished with any long-running init tasks.
   3: required string error          # Set when a general worker error unrelated to a specific run has occurred.
+  4: optional i32 slots             # Number of runs the worker can execute concurrently.
+  5: optional i32 cpus              # Number of cpus shared by the worker's runs.
+  6: optional i64 memoryBytes       # Memory shared by the worker's runs.
+  7: optional i64 diskBytes         # Disk available to the worker's runs.
+  8: optional map<string,string> labels  # Describe the worker for task node selectors, ex: os=linux-x86.
 }
 
 struct RunCommand {
@@ -38,6 +45,9 @@ struct RunCommand {
   5: optional string jobId
   6: optional string taskId
   7: optional string tag
+  8: optional bool sandbox            # Run isolated from the host's network and filesystem outside the checkout.
+  9: optional string image            # Run sandboxed in this image from the worker's image directory.
+  10: optional list<string> outputs   # Checkout paths or globs to copy into the result snapshot.
 }
 
 //TODO: add a method to kill the worker if we can articulate unrecoverable issues.